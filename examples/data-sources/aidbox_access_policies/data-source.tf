@@ -0,0 +1,11 @@
+data "aidbox_access_policies" "all" {}
+
+# Fails the apply when a policy allows all requests
+resource "terraform_data" "production_guard" {
+  lifecycle {
+    precondition {
+      condition     = alltrue([for policy in data.aidbox_access_policies.all.policies : policy.engine != "allow"])
+      error_message = "No access policy may allow all requests in production."
+    }
+  }
+}
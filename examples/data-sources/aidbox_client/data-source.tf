@@ -0,0 +1,17 @@
+# Client created by the box on startup, from the BOX_ROOT_CLIENT_ID setting
+data "aidbox_client" "root" {
+  id = "root"
+}
+
+resource "aidbox_webhook" "deployments" {
+  id     = "deployments"
+  url    = "https://ci.example.com/hooks/aidbox"
+  events = ["Client/update"]
+
+  lifecycle {
+    precondition {
+      condition     = !contains(data.aidbox_client.root.grant_types, "password")
+      error_message = "The root client must not allow the password grant."
+    }
+  }
+}
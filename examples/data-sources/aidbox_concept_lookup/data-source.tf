@@ -0,0 +1,9 @@
+# Fails the plan when the code is not defined by LOINC
+data "aidbox_concept_lookup" "heart_rate" {
+  system = "http://loinc.org"
+  code   = "8867-4"
+}
+
+output "heart_rate_display" {
+  value = data.aidbox_concept_lookup.heart_rate.display
+}
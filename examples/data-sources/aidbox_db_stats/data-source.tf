@@ -0,0 +1,9 @@
+data "aidbox_db_stats" "box" {}
+
+output "resource_counts" {
+  value = { for table in data.aidbox_db_stats.box.resource_types : table.resource_type => table.count }
+}
+
+output "database_size_mb" {
+  value = floor(data.aidbox_db_stats.box.total_size / 1048576)
+}
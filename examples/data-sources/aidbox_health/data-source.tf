@@ -0,0 +1,13 @@
+data "aidbox_health" "box" {}
+
+resource "aidbox_setting" "search_count" {
+  name  = "fhir.search.default-params.count"
+  value = "50"
+
+  lifecycle {
+    precondition {
+      condition     = data.aidbox_health.box.healthy
+      error_message = "The box is not healthy: ${jsonencode(data.aidbox_health.box.checks)}"
+    }
+  }
+}
@@ -0,0 +1,9 @@
+data "aidbox_ig_packages" "installed" {}
+
+# Only created when US Core is installed
+resource "aidbox_consent_policy" "us_core" {
+  count = contains(keys(data.aidbox_ig_packages.installed.versions), "hl7.fhir.us.core") ? 1 : 0
+
+  id             = "us-core-observations"
+  resource_types = ["Observation"]
+}
@@ -0,0 +1,11 @@
+data "aidbox_import_blocks" "box" {
+  resource_types = ["aidbox_webhook", "aidbox_notification_template"]
+}
+
+# Save the blocks to a file, such as with
+# terraform output -raw import_blocks > imports.tf, then generate the
+# configuration of the resources with
+# terraform plan -generate-config-out=generated.tf
+output "import_blocks" {
+  value = data.aidbox_import_blocks.box.hcl
+}
@@ -0,0 +1,12 @@
+data "aidbox_search_parameters" "patient" {
+  resource_type = "Patient"
+}
+
+locals {
+  patient_search_codes = [for search_parameter in data.aidbox_search_parameters.patient.search_parameters : search_parameter.code]
+}
+
+# Whether patients can be searched by medical record number
+output "mrn_search_defined" {
+  value = contains(local.patient_search_codes, "mrn")
+}
@@ -0,0 +1,9 @@
+data "aidbox_settings" "box" {}
+
+# Settings not left to the default of the box
+output "changed_settings" {
+  value = {
+    for name, value in data.aidbox_settings.box.values : name => value
+    if data.aidbox_settings.box.sources[name] != "default"
+  }
+}
@@ -0,0 +1,14 @@
+data "aidbox_users" "admins" {
+  role   = "admin"
+  active = true
+}
+
+# Administrators outside of the organization
+output "external_admins" {
+  value = [for user in data.aidbox_users.admins.users : user.email if !endswith(user.email, "@example.com")]
+}
+
+# Users of the partner organization
+data "aidbox_users" "partner" {
+  email_regex = "@partner\\.example\\.org$"
+}
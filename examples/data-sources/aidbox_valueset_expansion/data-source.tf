@@ -0,0 +1,7 @@
+data "aidbox_valueset_expansion" "gender" {
+  url = "http://hl7.org/fhir/ValueSet/administrative-gender"
+}
+
+output "gender_codes" {
+  value = [for coding in data.aidbox_valueset_expansion.gender.codes : coding.code]
+}
@@ -0,0 +1,14 @@
+ephemeral "aidbox_offline_license_payload" "example" {
+  license_id = aidbox_license.example.id
+}
+
+resource "kubernetes_secret_v1" "aidbox_license" {
+  metadata {
+    name = "aidbox-license"
+  }
+
+  data_wo = {
+    AIDBOX_LICENSE = ephemeral.aidbox_offline_license_payload.example.jwt
+  }
+  data_wo_revision = 1
+}
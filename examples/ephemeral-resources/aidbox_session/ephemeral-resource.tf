@@ -0,0 +1,7 @@
+ephemeral "aidbox_session" "admin" {}
+
+locals {
+  # Ephemeral values can only be used in other ephemeral contexts, such as
+  # provider configuration blocks and write-only arguments.
+  admin_authorization = "Bearer ${ephemeral.aidbox_session.admin.token}"
+}
@@ -0,0 +1,5 @@
+locals {
+  callback_headers = {
+    Authorization = provider::aidbox::basic_auth_header("webhook-client", var.webhook_client_secret)
+  }
+}
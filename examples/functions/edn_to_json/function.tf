@@ -0,0 +1,3 @@
+locals {
+  box_config = jsondecode(provider::aidbox::edn_to_json(file("${path.module}/zrc/box.edn")))
+}
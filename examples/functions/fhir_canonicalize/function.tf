@@ -0,0 +1,5 @@
+locals {
+  patients = [for file in fileset(path.module, "seed/*.json") : file("${path.module}/${file}")]
+
+  unique_patients = distinct([for patient in local.patients : provider::aidbox::fhir_canonicalize(patient)])
+}
@@ -0,0 +1,13 @@
+locals {
+  patient = jsonencode({
+    resourceType = "Patient"
+    telecom = [
+      { system = "phone", value = "555-0100" },
+      { system = "email", value = "jim@example.com" },
+    ]
+  })
+}
+
+output "email" {
+  value = one(provider::aidbox::fhirpath_extract(local.patient, "telecom.where(system = 'email').value"))
+}
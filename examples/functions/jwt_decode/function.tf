@@ -0,0 +1,3 @@
+output "license_expiration" {
+  value = provider::aidbox::jwt_decode(aidbox_license.example.jwt).exp
+}
@@ -0,0 +1,3 @@
+resource "kubernetes_manifest" "aidbox_license" {
+  manifest = jsondecode(provider::aidbox::k8s_license_secret("aidbox-license", "aidbox", aidbox_license.example.jwt))
+}
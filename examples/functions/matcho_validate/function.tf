@@ -0,0 +1,10 @@
+locals {
+  policy = jsonencode({
+    request-method = { "$enum" = ["get", "post"] }
+    user           = { data = { roles = { "$contains" = "admin" } } }
+  })
+}
+
+output "policy_errors" {
+  value = provider::aidbox::matcho_validate(local.policy)
+}
@@ -0,0 +1,6 @@
+output "scopes" {
+  value = join(" ", [
+    provider::aidbox::smart_scope("patient", "Observation", "rs"),
+    provider::aidbox::smart_scope("patient", "Patient", "r"),
+  ])
+}
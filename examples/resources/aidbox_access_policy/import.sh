@@ -0,0 +1 @@
+terraform import aidbox_access_policy.portal_patients portal-patients
@@ -0,0 +1,11 @@
+# The portal client reads and searches patients
+resource "aidbox_access_policy" "portal_patients" {
+  id          = "portal-patients"
+  description = "Read access to patients for the portal"
+  engine      = "matcho"
+  matcho = yamlencode({
+    uri            = "#/Patient.*"
+    request-method = { "$enum" = ["get"] }
+  })
+  links = ["Client/portal"]
+}
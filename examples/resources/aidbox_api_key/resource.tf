@@ -0,0 +1,22 @@
+resource "time_rotating" "lab_results" {
+  rotation_days = 90
+}
+
+# Rotated every 90 days, the old key being revoked once the new one exists
+resource "aidbox_api_key" "lab_results" {
+  name        = "lab-results"
+  description = "Pushes results from the laboratory information system"
+
+  rotation_trigger = {
+    rotation = time_rotating.lab_results.id
+  }
+
+  lifecycle {
+    create_before_destroy = true
+  }
+}
+
+output "lab_results_authorization" {
+  value     = provider::aidbox::basic_auth_header(aidbox_api_key.lab_results.id, aidbox_api_key.lab_results.key)
+  sensitive = true
+}
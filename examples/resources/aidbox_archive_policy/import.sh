@@ -0,0 +1 @@
+terraform import aidbox_archive_policy.audit_events audit-events
@@ -0,0 +1,12 @@
+resource "aidbox_archive_policy" "audit_events" {
+  id             = "audit-events"
+  resource_types = ["AuditEvent"]
+  older_than     = "8760h"
+  at             = "03:00"
+
+  storage {
+    backend    = "aws"
+    account_id = "archives"
+    bucket     = "box-archives"
+  }
+}
@@ -0,0 +1 @@
+terraform import aidbox_audit_config.example audit
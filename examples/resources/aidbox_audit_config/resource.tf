@@ -0,0 +1,5 @@
+resource "aidbox_audit_config" "example" {
+  fhir_audit_events  = true
+  excluded_endpoints = ["GET /health", "GET /metrics"]
+  retention          = "52560h" # 6 years
+}
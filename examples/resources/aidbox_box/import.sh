@@ -0,0 +1 @@
+terraform import aidbox_box.staging staging
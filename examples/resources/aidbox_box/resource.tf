@@ -0,0 +1,17 @@
+resource "aidbox_box" "staging" {
+  name         = "staging"
+  fhir_version = "4.0.1"
+  description  = "Staging environment"
+
+  env = {
+    BOX_SEARCH_FHIR__COMPARISONS = "true"
+  }
+}
+
+# Manage the configuration of the new box with another provider instance.
+provider "aidbox" {
+  alias             = "staging"
+  box_url           = aidbox_box.staging.box_url
+  box_client_id     = aidbox_box.staging.client_id
+  box_client_secret = aidbox_box.staging.client_secret
+}
@@ -0,0 +1 @@
+terraform import aidbox_box_user.jane staging/jane@example.com
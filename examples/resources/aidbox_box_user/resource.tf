@@ -0,0 +1,10 @@
+resource "aidbox_box" "staging" {
+  name         = "staging"
+  fhir_version = "4.0.1"
+}
+
+resource "aidbox_box_user" "jane" {
+  box_id = aidbox_box.staging.id
+  email  = "jane@example.com"
+  role   = "developer"
+}
@@ -0,0 +1 @@
+terraform import aidbox_consent_policy.research research
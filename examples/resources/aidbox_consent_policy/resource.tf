@@ -0,0 +1,13 @@
+# Patients opt in to sharing their results for research
+resource "aidbox_consent_policy" "research" {
+  id               = "research"
+  description      = "Access to results for research, for patients who consented to it"
+  resource_types   = ["Observation", "DiagnosticReport"]
+  default_decision = "deny"
+
+  template {
+    category = "http://loinc.org|57016-8"
+    actions  = ["access", "use"]
+    purposes = ["HRESCH"]
+  }
+}
@@ -0,0 +1 @@
+terraform import aidbox_db_settings.example db-settings
@@ -0,0 +1,5 @@
+resource "aidbox_db_settings" "example" {
+  sql_statement_timeout = "30s"
+  search_timeout        = "10s"
+  history               = true
+}
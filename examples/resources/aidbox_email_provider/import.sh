@@ -0,0 +1,2 @@
+# The email provider of a box is always imported with the ID "provider"
+terraform import aidbox_email_provider.example provider
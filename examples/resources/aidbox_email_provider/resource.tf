@@ -0,0 +1,15 @@
+resource "aidbox_email_provider" "example" {
+  from = "noreply@example.com"
+
+  smtp {
+    host     = "smtp.example.com"
+    port     = 587
+    username = "aidbox"
+    tls      = true
+
+    # The password is never stored in state with Terraform 1.11 and later.
+    # Increment the version to update it.
+    password_wo         = var.smtp_password
+    password_wo_version = 1
+  }
+}
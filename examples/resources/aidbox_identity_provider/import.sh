@@ -0,0 +1 @@
+terraform import aidbox_identity_provider.okta okta
@@ -0,0 +1,100 @@
+# Endpoints, scopes and claim mapping derived from the Okta organization
+resource "aidbox_identity_provider" "okta" {
+  id                       = "okta"
+  title                    = "Sign in with Okta"
+  client_id                = "0oa1b2c3d4e5f6g7h8i9"
+  client_secret_wo         = var.okta_client_secret
+  client_secret_wo_version = 1
+
+  okta {
+    domain                  = "example.okta.com"
+    authorization_server_id = "default"
+  }
+}
+
+# Endpoints, scopes and claim mapping derived from the Auth0 tenant
+resource "aidbox_identity_provider" "auth0" {
+  id            = "auth0"
+  title         = "Sign in with your hospital account"
+  client_id     = "a1b2c3d4e5f6g7h8i9j0"
+  client_secret = var.auth0_client_secret
+
+  auth0 {
+    domain     = "example.us.auth0.com"
+    connection = "hospital-ad"
+  }
+}
+
+# Endpoints, scopes and claim mapping derived from the Keycloak realm, with
+# realm roles granting Aidbox roles
+resource "aidbox_identity_provider" "keycloak" {
+  id            = "keycloak"
+  title         = "Sign in with Keycloak"
+  client_id     = "aidbox"
+  client_secret = var.keycloak_client_secret
+
+  keycloak {
+    base_url = "https://sso.example.com"
+    realm    = "clinic"
+
+    role_mapping {
+      realm_role = "physician"
+      role       = "practitioner"
+    }
+
+    role_mapping {
+      realm_role = "it-admin"
+      role       = "admin"
+    }
+  }
+}
+
+# Endpoints, scopes and claim mapping derived from the Entra ID tenant, with
+# sign in restricted to the members of groups
+resource "aidbox_identity_provider" "entra" {
+  id            = "entra"
+  title         = "Sign in with Microsoft"
+  client_id     = "6731de76-14a6-49ae-97bc-6eba6914391e"
+  client_secret = var.entra_client_secret
+
+  azure_ad {
+    tenant_id = "0b4f6c5d-2a1e-4c3b-8d7f-9e6a5b4c3d2e"
+    allowed_groups = [
+      "8f3c1c2e-5b7a-4a37-9d55-0c3d0f1b2a61",
+      "1d2e3f4a-5b6c-4d7e-8f90-a1b2c3d4e5f6",
+    ]
+
+    role_mapping {
+      group = "8f3c1c2e-5b7a-4a37-9d55-0c3d0f1b2a61"
+      role  = "admin"
+    }
+  }
+}
+
+# Endpoints, scopes and claim mapping derived from Google, with sign in
+# restricted to a Google Workspace domain
+resource "aidbox_identity_provider" "google" {
+  id            = "google"
+  title         = "Sign in with Google"
+  client_id     = "123456789012-abcdefghijklmnopqrstuvwxyz012345.apps.googleusercontent.com"
+  client_secret = var.google_client_secret
+
+  google {
+    hosted_domains = ["example.com"]
+  }
+}
+
+# Any other OpenID Connect provider
+resource "aidbox_identity_provider" "oidc" {
+  id                 = "oidc"
+  title              = "Sign in with SSO"
+  client_id          = "aidbox"
+  client_secret      = var.oidc_client_secret
+  authorize_endpoint = "https://sso.example.com/authorize"
+  token_endpoint     = "https://sso.example.com/token"
+  jwks_uri           = "https://sso.example.com/jwks.json"
+  scopes             = ["openid", "email"]
+  claim_mapping = {
+    email = "email"
+  }
+}
@@ -0,0 +1 @@
+terraform import aidbox_job.deidentification nightly-deidentification
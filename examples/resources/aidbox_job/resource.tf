@@ -0,0 +1,5 @@
+resource "aidbox_job" "deidentification" {
+  id     = "nightly-deidentification"
+  module = "aidbox.deidentification/run"
+  at     = "02:00"
+}
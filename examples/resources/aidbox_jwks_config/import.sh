@@ -0,0 +1 @@
+terraform import aidbox_jwks_config.auth0 auth0
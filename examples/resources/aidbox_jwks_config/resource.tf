@@ -0,0 +1,24 @@
+# Issuer publishing its signing keys
+resource "aidbox_jwks_config" "auth0" {
+  id        = "auth0"
+  issuer    = "https://example.us.auth0.com/"
+  audiences = ["https://box.example.com"]
+  jwks_uri  = "https://example.us.auth0.com/.well-known/jwks.json"
+}
+
+# Issuer with static keys, the 2025 key replacing the 2024 one
+resource "aidbox_jwks_config" "partner" {
+  id     = "partner"
+  issuer = "https://partner.example.org"
+
+  key {
+    kid        = "2024"
+    public_key = file("${path.module}/partner-2024.pem")
+  }
+
+  key {
+    kid        = "2025"
+    algorithm  = "ES256"
+    public_key = file("${path.module}/partner-2025.pem")
+  }
+}
@@ -0,0 +1 @@
+terraform import aidbox_ldap_identity_provider.example corporate-ad
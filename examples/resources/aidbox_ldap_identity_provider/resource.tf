@@ -0,0 +1,20 @@
+resource "aidbox_ldap_identity_provider" "example" {
+  id    = "corporate-ad"
+  title = "Corporate account"
+  url   = "ldaps://ad.example.com:636"
+
+  # The bind password is never stored in state. Increment the version to
+  # update it.
+  bind_dn                  = "CN=aidbox,OU=Service Accounts,DC=example,DC=com"
+  bind_password_wo         = var.ldap_bind_password
+  bind_password_wo_version = 1
+
+  user_search_base   = "OU=Staff,DC=example,DC=com"
+  user_search_filter = "(sAMAccountName={username})"
+
+  attribute_mapping = {
+    email             = "mail"
+    "name.givenName"  = "givenName"
+    "name.familyName" = "sn"
+  }
+}
@@ -0,0 +1 @@
+terraform import aidbox_notification_template.example reset-password
@@ -0,0 +1,5 @@
+resource "aidbox_notification_template" "example" {
+  id       = "reset-password"
+  subject  = "Reset your password"
+  template = file("${path.module}/templates/reset-password.html")
+}
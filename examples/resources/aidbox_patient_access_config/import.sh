@@ -0,0 +1 @@
+terraform import aidbox_patient_access_config.example patient-access
@@ -0,0 +1,10 @@
+resource "aidbox_patient_access_config" "example" {
+  compartment_definition = "http://hl7.org/fhir/CompartmentDefinition/patient"
+  resource_types = [
+    "AllergyIntolerance",
+    "Condition",
+    "Immunization",
+    "MedicationRequest",
+    "Observation",
+  ]
+}
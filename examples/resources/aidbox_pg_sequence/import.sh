@@ -0,0 +1 @@
+terraform import aidbox_pg_sequence.mrn mrn
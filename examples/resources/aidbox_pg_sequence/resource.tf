@@ -0,0 +1,5 @@
+resource "aidbox_pg_sequence" "mrn" {
+  id        = "mrn"
+  start     = 100000
+  increment = 1
+}
@@ -0,0 +1 @@
+terraform import aidbox_saml_identity_provider.adfs st-mary-adfs
@@ -0,0 +1,28 @@
+# Identity provider publishing its SAML metadata
+resource "aidbox_saml_identity_provider" "adfs" {
+  id           = "st-mary-adfs"
+  title        = "St. Mary's Hospital"
+  metadata_url = "https://adfs.stmary.example.org/FederationMetadata/2007-06/FederationMetadata.xml"
+
+  sp_entity_id = "https://box.example.com"
+  acs_url      = "https://box.example.com/auth/saml/acs"
+
+  attribute_mapping = {
+    email             = "http://schemas.xmlsoap.org/ws/2005/05/identity/claims/emailaddress"
+    "name.givenName"  = "http://schemas.xmlsoap.org/ws/2005/05/identity/claims/givenname"
+    "name.familyName" = "http://schemas.xmlsoap.org/ws/2005/05/identity/claims/surname"
+  }
+}
+
+# Identity provider configured with its signing certificate
+resource "aidbox_saml_identity_provider" "clinic" {
+  id          = "clinic-sso"
+  title       = "Clinic"
+  entity_id   = "https://sso.clinic.example.org/saml"
+  sso_url     = "https://sso.clinic.example.org/saml/sso"
+  certificate = file("${path.module}/clinic-sso.pem")
+
+  sp_entity_id   = "https://box.example.com"
+  acs_url        = "https://box.example.com/auth/saml/acs"
+  name_id_format = "urn:oasis:names:tc:SAML:2.0:nameid-format:persistent"
+}
@@ -0,0 +1 @@
+terraform import aidbox_scim_config.example scim
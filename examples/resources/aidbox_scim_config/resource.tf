@@ -0,0 +1,13 @@
+resource "aidbox_scim_config" "example" {
+  # The bearer token is never stored in state with Terraform 1.11 and later.
+  # Increment the version to update it.
+  bearer_token_wo         = var.scim_bearer_token
+  bearer_token_wo_version = 1
+
+  attribute_mapping = {
+    "userName"              = "email"
+    "name.givenName"        = "name.givenName"
+    "name.familyName"       = "name.familyName"
+    "emails[primary].value" = "email"
+  }
+}
@@ -0,0 +1 @@
+terraform import aidbox_security_labels_config.example security-labels
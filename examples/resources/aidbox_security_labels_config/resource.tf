@@ -0,0 +1,5 @@
+resource "aidbox_security_labels_config" "example" {
+  resource_types = ["Observation", "Condition", "DiagnosticReport"]
+  label_source   = "meta.security"
+  masking        = "redact"
+}
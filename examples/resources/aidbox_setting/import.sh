@@ -0,0 +1,4 @@
+terraform import aidbox_setting.search_count fhir.search.default-params.count
+
+# Scoped settings are imported with their scope and name
+terraform import aidbox_setting.org_search_count org-1/fhir.search.default-params.count
@@ -0,0 +1,11 @@
+resource "aidbox_setting" "search_count" {
+  name  = "fhir.search.default-params.count"
+  value = "50"
+}
+
+# Scoped to an organization of a multitenant box
+resource "aidbox_setting" "org_search_count" {
+  name  = "fhir.search.default-params.count"
+  scope = "org-1"
+  value = "20"
+}
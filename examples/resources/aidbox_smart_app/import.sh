@@ -0,0 +1 @@
+terraform import aidbox_smart_app.growth_chart growth-chart
@@ -0,0 +1,29 @@
+resource "aidbox_smart_app" "growth_chart" {
+  id           = "growth-chart"
+  name         = "Growth Chart"
+  description  = "Displays the growth charts of pediatric patients"
+  launch_uri   = "https://apps.example.com/growth-chart/launch"
+  redirect_uri = "https://apps.example.com/growth-chart/"
+  logo_url     = "https://apps.example.com/growth-chart/logo.png"
+  scopes = [
+    "launch",
+    "openid",
+    "fhirUser",
+    provider::aidbox::smart_scope("patient", "Patient", "rs"),
+    provider::aidbox::smart_scope("patient", "Observation", "rs"),
+  ]
+
+  # Makes the app a confidential client authenticating with a signed JWT
+  jwks_url = "https://apps.example.com/growth-chart/jwks.json"
+}
+
+# Backend service registering its public keys inline
+resource "aidbox_smart_app" "bulk_export" {
+  id                         = "bulk-export"
+  name                       = "Bulk Export"
+  launch_uri                 = "https://apps.example.com/bulk-export/launch"
+  redirect_uri               = "https://apps.example.com/bulk-export/"
+  scopes                     = ["system/*.rs"]
+  token_endpoint_auth_method = "private_key_jwt"
+  jwks                       = file("${path.module}/bulk-export-jwks.json")
+}
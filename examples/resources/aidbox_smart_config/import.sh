@@ -0,0 +1 @@
+terraform import aidbox_smart_config.example smart
@@ -0,0 +1,19 @@
+resource "aidbox_smart_config" "example" {
+  scopes_supported = [
+    "openid",
+    "fhirUser",
+    "launch",
+    "launch/patient",
+    provider::aidbox::smart_scope("patient", "*", "rs"),
+    provider::aidbox::smart_scope("user", "*", "cruds"),
+  ]
+  capabilities = [
+    "launch-ehr",
+    "launch-standalone",
+    "client-public",
+    "client-confidential-asymmetric",
+    "context-ehr-patient",
+    "context-standalone-patient",
+    "permission-v2",
+  ]
+}
@@ -0,0 +1,2 @@
+# The SMS provider of a box is always imported with the ID "sms-provider"
+terraform import aidbox_sms_provider.example sms-provider
@@ -0,0 +1,11 @@
+resource "aidbox_sms_provider" "example" {
+  twilio {
+    account_sid = "AC0123456789abcdef0123456789abcdef"
+    from        = "+15550100"
+
+    # The auth token is never stored in state with Terraform 1.11 and later.
+    # Increment the version to update it.
+    auth_token_wo         = var.twilio_auth_token
+    auth_token_wo_version = 1
+  }
+}
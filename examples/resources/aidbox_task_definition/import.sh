@@ -0,0 +1 @@
+terraform import aidbox_task_definition.send_email send-email
@@ -0,0 +1,14 @@
+resource "aidbox_task_definition" "send_email" {
+  id       = "send-email"
+  name     = "notifications/send-email"
+  executor = "notification-worker"
+
+  params_schema = jsonencode({
+    type     = "object"
+    required = ["to", "template"]
+    properties = {
+      to       = { type = "string" }
+      template = { type = "string" }
+    }
+  })
+}
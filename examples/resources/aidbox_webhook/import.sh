@@ -0,0 +1 @@
+terraform import aidbox_webhook.example user-created
@@ -0,0 +1,14 @@
+resource "aidbox_webhook" "example" {
+  id     = "user-created"
+  url    = "https://hooks.example.com/aidbox"
+  events = ["User/create"]
+
+  headers = {
+    Authorization = "Bearer ${var.hook_token}"
+  }
+
+  retry {
+    max_attempts = 5
+    interval     = "30s"
+  }
+}
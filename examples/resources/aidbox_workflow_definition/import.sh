@@ -0,0 +1 @@
+terraform import aidbox_workflow_definition.onboarding patient-onboarding
@@ -0,0 +1,24 @@
+resource "aidbox_workflow_definition" "onboarding" {
+  id = "patient-onboarding"
+
+  definition = jsonencode({
+    steps = {
+      welcome  = { task = "notifications/send-email" }
+      reminder = { task = "notifications/send-sms" }
+    }
+    transitions = {
+      welcome = "reminder"
+    }
+    retry = {
+      max-attempts = 3
+      interval     = "5m"
+    }
+  })
+}
+
+# Definitions kept as zen/EDN files can be converted with the edn_to_json
+# provider function.
+resource "aidbox_workflow_definition" "discharge" {
+  id         = "patient-discharge"
+  definition = provider::aidbox::edn_to_json(file("${path.module}/workflows/discharge.edn"))
+}
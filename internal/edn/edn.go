@@ -0,0 +1,446 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package edn decodes EDN documents, such as zen project files, into plain
+// JSON-compatible Go values.
+//
+// Keywords and symbols become strings without their leading colon, lists,
+// vectors and sets become slices (sets in a deterministic order), tagged
+// literals become their inner value and nil becomes nil. Numbers are returned
+// as json.Number to keep their precision.
+package edn
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Decode parses a single EDN form and returns its JSON-compatible value.
+func Decode(text string) (interface{}, error) {
+	d := &decoder{input: text, line: 1, column: 1}
+
+	if err := d.skipIgnored(); err != nil {
+		return nil, err
+	}
+	if d.eof() {
+		return nil, d.errorf("empty document")
+	}
+
+	value, err := d.readForm()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := d.skipIgnored(); err != nil {
+		return nil, err
+	}
+	if !d.eof() {
+		return nil, d.errorf("unexpected content after the first form")
+	}
+
+	return value, nil
+}
+
+// ToJSON converts an EDN document into compact JSON with sorted object keys.
+func ToJSON(text string) ([]byte, error) {
+	value, err := Decode(text)
+	if err != nil {
+		return nil, err
+	}
+	return marshal(value)
+}
+
+func marshal(value interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return nil, err
+	}
+	return bytes.TrimRight(buf.Bytes(), "\n"), nil
+}
+
+type decoder struct {
+	input  string
+	pos    int
+	line   int
+	column int
+}
+
+func (d *decoder) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("invalid EDN at line %d, column %d: %s", d.line, d.column, fmt.Sprintf(format, args...))
+}
+
+func (d *decoder) eof() bool {
+	return d.pos >= len(d.input)
+}
+
+func (d *decoder) peek() rune {
+	r, _ := utf8.DecodeRuneInString(d.input[d.pos:])
+	return r
+}
+
+func (d *decoder) peekAt(offset int) byte {
+	if d.pos+offset >= len(d.input) {
+		return 0
+	}
+	return d.input[d.pos+offset]
+}
+
+func (d *decoder) next() rune {
+	r, size := utf8.DecodeRuneInString(d.input[d.pos:])
+	d.pos += size
+	if r == '\n' {
+		d.line++
+		d.column = 1
+	} else {
+		d.column++
+	}
+	return r
+}
+
+// skipIgnored skips whitespace, commas, comments and discarded (#_) forms.
+func (d *decoder) skipIgnored() error {
+	for !d.eof() {
+		r := d.peek()
+		switch {
+		case unicode.IsSpace(r) || r == ',':
+			d.next()
+		case r == ';':
+			for !d.eof() && d.peek() != '\n' {
+				d.next()
+			}
+		case r == '#' && d.peekAt(1) == '_':
+			d.next()
+			d.next()
+			if err := d.skipIgnored(); err != nil {
+				return err
+			}
+			if d.eof() {
+				return d.errorf("missing form after #_")
+			}
+			if _, err := d.readForm(); err != nil {
+				return err
+			}
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+func (d *decoder) readForm() (interface{}, error) {
+	r := d.peek()
+
+	switch {
+	case r == '(':
+		d.next()
+		return d.readSequence(')')
+	case r == '[':
+		d.next()
+		return d.readSequence(']')
+	case r == '{':
+		d.next()
+		return d.readMap()
+	case r == '"':
+		return d.readString()
+	case r == '\\':
+		return d.readCharacter()
+	case r == ':':
+		d.next()
+		token := d.readToken()
+		if token == "" {
+			return nil, d.errorf("empty keyword")
+		}
+		return token, nil
+	case r == '^':
+		d.next()
+		if err := d.skipIgnored(); err != nil {
+			return nil, err
+		}
+		if _, err := d.readForm(); err != nil {
+			return nil, err
+		}
+		if err := d.skipIgnored(); err != nil {
+			return nil, err
+		}
+		if d.eof() {
+			return nil, d.errorf("missing form after metadata")
+		}
+		return d.readForm()
+	case r == '#':
+		return d.readDispatch()
+	case r == ')' || r == ']' || r == '}':
+		return nil, d.errorf("unexpected %q", r)
+	}
+
+	token := d.readToken()
+	if token == "" {
+		return nil, d.errorf("unexpected %q", r)
+	}
+	return parseAtom(token, d)
+}
+
+func (d *decoder) readDispatch() (interface{}, error) {
+	d.next()
+	if d.eof() {
+		return nil, d.errorf("unexpected end of input after #")
+	}
+
+	switch d.peek() {
+	case '{':
+		d.next()
+		items, err := d.readSequence('}')
+		if err != nil {
+			return nil, err
+		}
+		return sortSet(items)
+	case '"':
+		// Regular expressions are kept as their source string.
+		return d.readString()
+	}
+
+	tag := d.readToken()
+	if tag == "" {
+		return nil, d.errorf("invalid dispatch character %q", d.peek())
+	}
+	if err := d.skipIgnored(); err != nil {
+		return nil, err
+	}
+	if d.eof() {
+		return nil, d.errorf("missing value for tag #%s", tag)
+	}
+	return d.readForm()
+}
+
+func (d *decoder) readSequence(closing rune) ([]interface{}, error) {
+	items := []interface{}{}
+	for {
+		if err := d.skipIgnored(); err != nil {
+			return nil, err
+		}
+		if d.eof() {
+			return nil, d.errorf("unexpected end of input, expected %q", closing)
+		}
+		if d.peek() == closing {
+			d.next()
+			return items, nil
+		}
+		item, err := d.readForm()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+}
+
+func (d *decoder) readMap() (map[string]interface{}, error) {
+	line, column := d.line, d.column
+	items, err := d.readSequence('}')
+	if err != nil {
+		return nil, err
+	}
+	if len(items)%2 != 0 {
+		return nil, fmt.Errorf("invalid EDN at line %d, column %d: map literal must contain an even number of forms", line, column-1)
+	}
+
+	m := make(map[string]interface{}, len(items)/2)
+	for i := 0; i < len(items); i += 2 {
+		key, err := mapKey(items[i])
+		if err != nil {
+			return nil, err
+		}
+		m[key] = items[i+1]
+	}
+	return m, nil
+}
+
+func (d *decoder) readString() (string, error) {
+	d.next()
+
+	var sb strings.Builder
+	for !d.eof() {
+		r := d.next()
+		switch r {
+		case '"':
+			return sb.String(), nil
+		case '\\':
+			if d.eof() {
+				return "", d.errorf("unterminated string")
+			}
+			escaped := d.next()
+			switch escaped {
+			case 'n':
+				sb.WriteRune('\n')
+			case 't':
+				sb.WriteRune('\t')
+			case 'r':
+				sb.WriteRune('\r')
+			case 'b':
+				sb.WriteRune('\b')
+			case 'f':
+				sb.WriteRune('\f')
+			case 'u':
+				if d.pos+4 > len(d.input) {
+					return "", d.errorf("invalid unicode escape")
+				}
+				code, err := strconv.ParseUint(d.input[d.pos:d.pos+4], 16, 32)
+				if err != nil {
+					return "", d.errorf("invalid unicode escape")
+				}
+				for i := 0; i < 4; i++ {
+					d.next()
+				}
+				sb.WriteRune(rune(code))
+			default:
+				sb.WriteRune(escaped)
+			}
+		default:
+			sb.WriteRune(r)
+		}
+	}
+
+	return "", d.errorf("unterminated string")
+}
+
+func (d *decoder) readCharacter() (string, error) {
+	d.next()
+	if d.eof() {
+		return "", d.errorf("unexpected end of input after \\")
+	}
+
+	first := d.next()
+	rest := d.readToken()
+	if rest == "" {
+		return string(first), nil
+	}
+
+	name := string(first) + rest
+	switch name {
+	case "newline":
+		return "\n", nil
+	case "space":
+		return " ", nil
+	case "tab":
+		return "\t", nil
+	case "return":
+		return "\r", nil
+	}
+	if first == 'u' && len(rest) == 4 {
+		if code, err := strconv.ParseUint(rest, 16, 32); err == nil {
+			return string(rune(code)), nil
+		}
+	}
+	return "", d.errorf("unknown character literal \\%s", name)
+}
+
+// readToken reads a run of symbol constituent characters.
+func (d *decoder) readToken() string {
+	start := d.pos
+	for !d.eof() {
+		r := d.peek()
+		if unicode.IsSpace(r) || strings.ContainsRune(",;()[]{}\"\\^", r) {
+			break
+		}
+		d.next()
+	}
+	return d.input[start:d.pos]
+}
+
+func parseAtom(token string, d *decoder) (interface{}, error) {
+	switch token {
+	case "nil":
+		return nil, nil
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	}
+
+	first := token[0]
+	if first >= '0' && first <= '9' || (first == '+' || first == '-') && len(token) > 1 && token[1] >= '0' && token[1] <= '9' {
+		number, err := parseNumber(token)
+		if err != nil {
+			return nil, d.errorf("%s", err)
+		}
+		return number, nil
+	}
+
+	return token, nil
+}
+
+func parseNumber(token string) (json.Number, error) {
+	text := strings.TrimPrefix(token, "+")
+
+	if numerator, denominator, ok := strings.Cut(text, "/"); ok {
+		ratio, valid := new(big.Rat).SetString(numerator + "/" + denominator)
+		if !valid {
+			return "", fmt.Errorf("invalid number %q", token)
+		}
+		if ratio.IsInt() {
+			return json.Number(ratio.Num().String()), nil
+		}
+		f, _ := ratio.Float64()
+		return json.Number(strconv.FormatFloat(f, 'g', -1, 64)), nil
+	}
+
+	text = strings.TrimSuffix(strings.TrimSuffix(text, "N"), "M")
+	if _, err := strconv.ParseInt(text, 10, 64); err == nil {
+		return json.Number(text), nil
+	}
+	if _, ok := new(big.Int).SetString(text, 10); ok {
+		return json.Number(text), nil
+	}
+	if _, ok := new(big.Float).SetString(text); ok && !strings.ContainsAny(text, "xXpP_") {
+		return json.Number(text), nil
+	}
+	return "", fmt.Errorf("invalid number %q", token)
+}
+
+// mapKey converts a decoded map key into a JSON object key. Strings,
+// keywords and symbols are used as-is; other keys use their JSON encoding.
+func mapKey(key interface{}) (string, error) {
+	if s, ok := key.(string); ok {
+		return s, nil
+	}
+	encoded, err := marshal(key)
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
+
+// sortSet orders set members by their JSON encoding so that sets convert
+// deterministically regardless of how they were written.
+func sortSet(items []interface{}) ([]interface{}, error) {
+	keys := make([]string, len(items))
+	for i, item := range items {
+		encoded, err := marshal(item)
+		if err != nil {
+			return nil, err
+		}
+		keys[i] = string(encoded)
+	}
+
+	sort.Sort(byKey{items: items, keys: keys})
+	return items, nil
+}
+
+type byKey struct {
+	items []interface{}
+	keys  []string
+}
+
+func (b byKey) Len() int           { return len(b.items) }
+func (b byKey) Less(i, j int) bool { return b.keys[i] < b.keys[j] }
+func (b byKey) Swap(i, j int) {
+	b.items[i], b.items[j] = b.items[j], b.items[i]
+	b.keys[i], b.keys[j] = b.keys[j], b.keys[i]
+}
@@ -0,0 +1,95 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package edn
+
+import (
+	"testing"
+)
+
+func TestToJSON(t *testing.T) {
+	testCases := map[string]struct {
+		edn      string
+		expected string
+	}{
+		"scalars": {
+			edn:      `[nil true false 42 -7 +3 1.5 2.5M 10N 1/2 4/2 "a\"b\n" \c \space :kw :ns/kw sym]`,
+			expected: `[null,true,false,42,-7,3,1.5,2.5,10,0.5,2,"a\"b\n","c"," ","kw","ns/kw","sym"]`,
+		},
+		"zen-namespace": {
+			edn: `{ns myapp
+ import #{zen.fhir aidbox}
+ ; the box definition
+ box {:zen/tags #{aidbox/system}
+      :config {:zen/tags #{aidbox/config}, :port 8080}
+      #_#_:ignored true
+      :pattern #"^[a-z]+$"
+      :created #inst "2024-01-01T00:00:00Z"}}`,
+			expected: `{"box":{"config":{"port":8080,"zen/tags":["aidbox/config"]},"created":"2024-01-01T00:00:00Z","pattern":"^[a-z]+$","zen/tags":["aidbox/system"]},"import":["aidbox","zen.fhir"],"ns":"myapp"}`,
+		},
+		"non-string-keys": {
+			edn:      `{1 "one" [1 2] "pair" nil "nothing"}`,
+			expected: `{"1":"one","[1,2]":"pair","null":"nothing"}`,
+		},
+		"metadata": {
+			edn:      `^{:doc "ignored"} (a ^:private b)`,
+			expected: `["a","b"]`,
+		},
+		"html": {
+			edn:      `"<a & b>"`,
+			expected: `"<a & b>"`,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := ToJSON(testCase.edn)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if string(got) != testCase.expected {
+				t.Errorf("expected %s, got %s", testCase.expected, got)
+			}
+		})
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	testCases := map[string]string{
+		"":             "invalid EDN at line 1, column 1: empty document",
+		"{:a 1":        `invalid EDN at line 1, column 6: unexpected end of input, expected '}'`,
+		"{:a 1 :b}":    "invalid EDN at line 1, column 1: map literal must contain an even number of forms",
+		"[1 2]]":       "invalid EDN at line 1, column 6: unexpected content after the first form",
+		"\"abc":        "invalid EDN at line 1, column 5: unterminated string",
+		"{:a\n 12abc}": `invalid EDN at line 2, column 7: invalid number "12abc"`,
+		"\\unknown":    `invalid EDN at line 1, column 9: unknown character literal \unknown`,
+		":":            "invalid EDN at line 1, column 2: empty keyword",
+		"[1 #_]":       `invalid EDN at line 1, column 6: unexpected ']'`,
+	}
+
+	for text, expected := range testCases {
+		t.Run(text, func(t *testing.T) {
+			_, err := Decode(text)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if err.Error() != expected {
+				t.Errorf("expected %q, got %q", expected, err.Error())
+			}
+		})
+	}
+}
+
+func FuzzToJSON(f *testing.F) {
+	f.Add(`{:resourceType "Patient" :name [{:given ["John"]}]}`)
+	f.Add(`#{1 2 3}`)
+	f.Add(`{:a #inst "2024-01-01"}`)
+	f.Add(`[1.5M 2N -3]`)
+	f.Add(`{:unterminated`)
+	f.Add(`"\u00"`)
+
+	f.Fuzz(func(t *testing.T, text string) {
+		// Malformed EDN must produce an error rather than panic.
+		_, _ = ToJSON(text)
+	})
+}
@@ -0,0 +1,79 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fhir
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// ServerManagedMetaFields lists the meta elements assigned by the server on
+// every write. They are dropped when canonicalizing a resource.
+var ServerManagedMetaFields = []string{"versionId", "lastUpdated", "createdAt"}
+
+// Canonicalize returns the canonical JSON form of a FHIR document: object
+// keys are sorted, nulls and the empty objects or arrays left behind are
+// removed, and server-managed meta fields are dropped from every resource in
+// the document, including contained and bundled ones.
+func Canonicalize(document []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(document))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	if decoder.More() {
+		return nil, fmt.Errorf("invalid JSON: unexpected content after the top-level value")
+	}
+
+	value, _ = canonicalize(value)
+	if value == nil {
+		value = map[string]interface{}{}
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return nil, err
+	}
+	return bytes.TrimRight(buf.Bytes(), "\n"), nil
+}
+
+// canonicalize returns the cleaned value and whether it should be kept.
+func canonicalize(value interface{}) (interface{}, bool) {
+	switch v := value.(type) {
+	case nil:
+		return nil, false
+	case map[string]interface{}:
+		if _, isResource := v["resourceType"]; isResource {
+			if meta, ok := v["meta"].(map[string]interface{}); ok {
+				for _, field := range ServerManagedMetaFields {
+					delete(meta, field)
+				}
+			}
+		}
+		for key, item := range v {
+			cleaned, keep := canonicalize(item)
+			if keep {
+				v[key] = cleaned
+			} else {
+				delete(v, key)
+			}
+		}
+		return v, len(v) > 0
+	case []interface{}:
+		items := make([]interface{}, 0, len(v))
+		for _, item := range v {
+			if cleaned, keep := canonicalize(item); keep {
+				items = append(items, cleaned)
+			}
+		}
+		return items, len(items) > 0
+	default:
+		return v, true
+	}
+}
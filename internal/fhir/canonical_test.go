@@ -0,0 +1,76 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fhir
+
+import (
+	"testing"
+)
+
+func TestCanonicalize(t *testing.T) {
+	testCases := map[string]struct {
+		document string
+		expected string
+	}{
+		"sorted-keys": {
+			document: `{"resourceType": "Patient", "id": "pt-1", "active": true}`,
+			expected: `{"active":true,"id":"pt-1","resourceType":"Patient"}`,
+		},
+		"server-meta": {
+			document: `{"resourceType": "Patient", "meta": {"versionId": "3", "lastUpdated": "2024-01-01T00:00:00Z", "createdAt": "2024-01-01T00:00:00Z", "profile": ["http://example.org/p"]}}`,
+			expected: `{"meta":{"profile":["http://example.org/p"]},"resourceType":"Patient"}`,
+		},
+		"empty-meta": {
+			document: `{"resourceType": "Patient", "meta": {"versionId": "3"}}`,
+			expected: `{"resourceType":"Patient"}`,
+		},
+		"nulls": {
+			document: `{"resourceType": "Patient", "gender": null, "name": [null, {"family": null}, {"given": ["Jim", null]}]}`,
+			expected: `{"name":[{"given":["Jim"]}],"resourceType":"Patient"}`,
+		},
+		"contained": {
+			document: `{"resourceType": "Bundle", "entry": [{"resource": {"resourceType": "Patient", "meta": {"versionId": "1"}}}]}`,
+			expected: `{"entry":[{"resource":{"resourceType":"Patient"}}],"resourceType":"Bundle"}`,
+		},
+		"non-resource-meta": {
+			document: `{"extension": [{"valueMeta": {"versionId": "1"}}], "meta": {"versionId": "1"}}`,
+			expected: `{"extension":[{"valueMeta":{"versionId":"1"}}],"meta":{"versionId":"1"}}`,
+		},
+		"numbers": {
+			document: `{"value": 1.50, "big": 12345678901234567890}`,
+			expected: `{"big":12345678901234567890,"value":1.50}`,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := Canonicalize([]byte(testCase.document))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if string(got) != testCase.expected {
+				t.Errorf("expected %s, got %s", testCase.expected, got)
+			}
+		})
+	}
+}
+
+func TestCanonicalizeInvalid(t *testing.T) {
+	for _, document := range []string{`{"a":`, `{} {}`, ``} {
+		if _, err := Canonicalize([]byte(document)); err == nil {
+			t.Errorf("expected an error for %q", document)
+		}
+	}
+}
+
+func FuzzCanonicalize(f *testing.F) {
+	f.Add([]byte(`{"resourceType":"Patient","id":"pt-1","meta":{"versionId":"1","lastUpdated":"2024-01-01T00:00:00Z"},"name":[]}`))
+	f.Add([]byte(`{"resourceType":"Patient","extension":[{"url":"b"},{"url":"a"}]}`))
+	f.Add([]byte(`[1, 2, 3]`))
+	f.Add([]byte(`{"unterminated":`))
+
+	f.Fuzz(func(t *testing.T, document []byte) {
+		// Malformed documents must produce an error rather than panic.
+		_, _ = Canonicalize(document)
+	})
+}
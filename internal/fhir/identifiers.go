@@ -0,0 +1,74 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fhir
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+var (
+	idRegexp           = regexp.MustCompile(`^[A-Za-z0-9\-.]{1,64}$`)
+	resourceTypeRegexp = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*$`)
+)
+
+// ValidateID returns an error when id is not a valid FHIR resource id: 1 to
+// 64 letters, digits, dashes and dots.
+func ValidateID(id string) error {
+	if !idRegexp.MatchString(id) {
+		return fmt.Errorf("must be 1 to 64 letters, digits, '-' or '.'")
+	}
+	return nil
+}
+
+// ParseReference returns the resource type and the id of a relative literal
+// reference, such as `Client/portal`, optionally to a version of the
+// resource, such as `Client/portal/_history/2`. Aidbox resource types, such
+// as Client, are accepted as well as FHIR ones.
+func ParseReference(reference string) (string, string, error) {
+	parts := strings.Split(reference, "/")
+	if len(parts) != 2 && (len(parts) != 4 || parts[2] != "_history" || parts[3] == "") {
+		return "", "", fmt.Errorf("must be a resource type and an id separated by '/', such as Client/portal")
+	}
+
+	resourceType, id := parts[0], parts[1]
+	if !resourceTypeRegexp.MatchString(resourceType) {
+		return "", "", fmt.Errorf("resource type %q must start with an uppercase letter and only contain letters and digits", resourceType)
+	}
+	if err := ValidateID(id); err != nil {
+		return "", "", fmt.Errorf("id %q %s", id, err)
+	}
+	return resourceType, id, nil
+}
+
+// ValidateURI returns an error when uri is not an absolute URI, or, when
+// schemes are given, when its scheme is not one of them.
+func ValidateURI(uri string, schemes ...string) error {
+	if strings.TrimSpace(uri) != uri || strings.ContainsAny(uri, " \t\n") {
+		return fmt.Errorf("must not contain whitespace")
+	}
+
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return err
+	}
+	if parsed.Scheme == "" {
+		return fmt.Errorf("must be an absolute URI, with a scheme")
+	}
+	if parsed.Opaque == "" && parsed.Host == "" && (parsed.Scheme == "http" || parsed.Scheme == "https") {
+		return fmt.Errorf("must have a host")
+	}
+
+	if len(schemes) == 0 {
+		return nil
+	}
+	for _, scheme := range schemes {
+		if strings.EqualFold(parsed.Scheme, scheme) {
+			return nil
+		}
+	}
+	return fmt.Errorf("must be a %s URI", strings.Join(schemes, " or "))
+}
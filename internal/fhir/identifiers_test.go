@@ -0,0 +1,72 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fhir
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateID(t *testing.T) {
+	for id, valid := range map[string]bool{
+		"portal":                true,
+		"pt-1.v2":               true,
+		strings.Repeat("a", 64): true,
+		strings.Repeat("a", 65): false,
+		"":                      false,
+		"portal_app":            false,
+		"Client/portal":         false,
+		"café":                  false,
+	} {
+		if err := ValidateID(id); (err == nil) != valid {
+			t.Errorf("ValidateID(%q) = %v, expected valid %t", id, err, valid)
+		}
+	}
+}
+
+func TestParseReference(t *testing.T) {
+	for reference, expected := range map[string][2]string{
+		"Client/portal":           {"Client", "portal"},
+		"Patient/pt-1/_history/3": {"Patient", "pt-1"},
+		"portal":                  {},
+		"client/portal":           {},
+		"Client/":                 {},
+		"Client/portal/_history":  {},
+		"Client/portal/extra/1":   {},
+		"/Client/portal":          {},
+	} {
+		resourceType, id, err := ParseReference(reference)
+		if expected[0] == "" {
+			if err == nil {
+				t.Errorf("ParseReference(%q) = %q, %q, expected an error", reference, resourceType, id)
+			}
+			continue
+		}
+		if err != nil || resourceType != expected[0] || id != expected[1] {
+			t.Errorf("ParseReference(%q) = %q, %q, %v, expected %q, %q", reference, resourceType, id, err, expected[0], expected[1])
+		}
+	}
+}
+
+func TestValidateURI(t *testing.T) {
+	for _, testCase := range []struct {
+		uri     string
+		schemes []string
+		valid   bool
+	}{
+		{"https://box.example.com/fhir", nil, true},
+		{"urn:uuid:2f3c1a3e-7b0b-4e0c-9a1d-0d3c0f1b2a4e", nil, true},
+		{"http://hl7.org/fhir/StructureDefinition/Patient", []string{"http", "https"}, true},
+		{"ldaps://ldap.example.com:636", []string{"ldap", "ldaps"}, true},
+		{"https://box.example.com", []string{"ldap", "ldaps"}, false},
+		{"/fhir/Patient", nil, false},
+		{"https:///fhir", nil, false},
+		{" https://box.example.com", nil, false},
+		{"https://box.example.com/a b", nil, false},
+	} {
+		if err := ValidateURI(testCase.uri, testCase.schemes...); (err == nil) != testCase.valid {
+			t.Errorf("ValidateURI(%q, %q) = %v, expected valid %t", testCase.uri, testCase.schemes, err, testCase.valid)
+		}
+	}
+}
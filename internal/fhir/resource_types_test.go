@@ -0,0 +1,31 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fhir
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestResourceTypesSorted(t *testing.T) {
+	// IsResourceType relies on a binary search.
+	if !sort.StringsAreSorted(ResourceTypes) {
+		t.Fatal("ResourceTypes must be sorted")
+	}
+}
+
+func TestIsResourceType(t *testing.T) {
+	for name, expected := range map[string]bool{
+		"Patient":            true,
+		"VisionPrescription": true,
+		"Account":            true,
+		"patient":            false,
+		"Resource":           false,
+		"":                   false,
+	} {
+		if got := IsResourceType(name); got != expected {
+			t.Errorf("IsResourceType(%q) = %t, expected %t", name, got, expected)
+		}
+	}
+}
@@ -0,0 +1,471 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package fhirpath evaluates a small subset of FHIRPath against decoded JSON
+// resources: member navigation, indexing, first(), last() and where() with a
+// single equality test against a literal.
+package fhirpath
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Expression is a parsed FHIRPath expression.
+type Expression struct {
+	source string
+	steps  []step
+}
+
+type stepKind int
+
+const (
+	stepMember stepKind = iota
+	stepIndex
+	stepWhere
+	stepFirst
+	stepLast
+)
+
+type step struct {
+	kind  stepKind
+	name  string
+	index int
+	where *criteria
+}
+
+type criteria struct {
+	path    []step
+	negate  bool
+	literal interface{}
+}
+
+// String returns the source text of the expression.
+func (e *Expression) String() string {
+	return e.source
+}
+
+// Parse compiles a FHIRPath expression.
+func Parse(source string) (*Expression, error) {
+	p := &parser{lexer: newLexer(source)}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	steps, err := p.parsePath(false)
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.kind != tokenEOF {
+		return nil, p.errorf("unexpected %s", p.tok)
+	}
+
+	return &Expression{source: source, steps: steps}, nil
+}
+
+// Evaluate runs the expression against a resource decoded by encoding/json
+// and returns the resulting collection. A leading resource type matching the
+// resource's resourceType is skipped, as in FHIRPath.
+func (e *Expression) Evaluate(resource interface{}) ([]interface{}, error) {
+	steps := e.steps
+	if len(steps) > 0 && steps[0].kind == stepMember && isTypeName(steps[0].name) {
+		object, ok := resource.(map[string]interface{})
+		if !ok || object["resourceType"] != steps[0].name {
+			return []interface{}{}, nil
+		}
+		steps = steps[1:]
+	}
+
+	return evaluate(steps, []interface{}{resource})
+}
+
+func evaluate(steps []step, collection []interface{}) ([]interface{}, error) {
+	for _, s := range steps {
+		var next []interface{}
+
+		switch s.kind {
+		case stepMember:
+			for _, item := range collection {
+				object, ok := item.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				switch value := object[s.name].(type) {
+				case nil:
+				case []interface{}:
+					for _, elem := range value {
+						if elem != nil {
+							next = append(next, elem)
+						}
+					}
+				default:
+					next = append(next, value)
+				}
+			}
+		case stepIndex:
+			if s.index < len(collection) {
+				next = append(next, collection[s.index])
+			}
+		case stepFirst:
+			if len(collection) > 0 {
+				next = append(next, collection[0])
+			}
+		case stepLast:
+			if len(collection) > 0 {
+				next = append(next, collection[len(collection)-1])
+			}
+		case stepWhere:
+			for _, item := range collection {
+				matched, err := s.where.matches(item)
+				if err != nil {
+					return nil, err
+				}
+				if matched {
+					next = append(next, item)
+				}
+			}
+		}
+
+		collection = next
+	}
+
+	if collection == nil {
+		collection = []interface{}{}
+	}
+	return collection, nil
+}
+
+func (c *criteria) matches(item interface{}) (bool, error) {
+	values, err := evaluate(c.path, []interface{}{item})
+	if err != nil {
+		return false, err
+	}
+	if len(values) != 1 {
+		return false, nil
+	}
+	if equal(values[0], c.literal) {
+		return !c.negate, nil
+	}
+	return c.negate, nil
+}
+
+func equal(value, literal interface{}) bool {
+	switch l := literal.(type) {
+	case *big.Float:
+		var n *big.Float
+		switch v := value.(type) {
+		case json.Number:
+			n, _ = new(big.Float).SetString(v.String())
+		case float64:
+			n = big.NewFloat(v)
+		}
+		return n != nil && n.Cmp(l) == 0
+	default:
+		return value == literal
+	}
+}
+
+func isTypeName(name string) bool {
+	r := []rune(name)
+	return len(r) > 0 && unicode.IsUpper(r[0])
+}
+
+type parser struct {
+	lexer *lexer
+	tok   token
+}
+
+func (p *parser) advance() error {
+	tok, err := p.lexer.next()
+	if err != nil {
+		return err
+	}
+	p.tok = tok
+	return nil
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("invalid FHIRPath at offset %d: %s", p.tok.pos, fmt.Sprintf(format, args...))
+}
+
+func (p *parser) expect(kind tokenKind) error {
+	if p.tok.kind != kind {
+		return p.errorf("expected %s, got %s", kind, p.tok)
+	}
+	return p.advance()
+}
+
+// parsePath parses `segment ('.' segment)*`. Inside where() the path stops
+// at the comparison operator.
+func (p *parser) parsePath(nested bool) ([]step, error) {
+	var steps []step
+
+	for {
+		if p.tok.kind != tokenIdent {
+			return nil, p.errorf("expected identifier, got %s", p.tok)
+		}
+		name := p.tok.text
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+
+		if p.tok.kind == tokenLParen {
+			s, err := p.parseFunction(name, nested)
+			if err != nil {
+				return nil, err
+			}
+			steps = append(steps, s)
+		} else {
+			steps = append(steps, step{kind: stepMember, name: name})
+		}
+
+		for p.tok.kind == tokenLBracket {
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			if p.tok.kind != tokenNumber {
+				return nil, p.errorf("expected index, got %s", p.tok)
+			}
+			index, err := strconv.Atoi(p.tok.text)
+			if err != nil || index < 0 {
+				return nil, p.errorf("invalid index %s", p.tok.text)
+			}
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			if err := p.expect(tokenRBracket); err != nil {
+				return nil, err
+			}
+			steps = append(steps, step{kind: stepIndex, index: index})
+		}
+
+		if p.tok.kind != tokenDot {
+			return steps, nil
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (p *parser) parseFunction(name string, nested bool) (step, error) {
+	if err := p.expect(tokenLParen); err != nil {
+		return step{}, err
+	}
+
+	switch name {
+	case "first", "last":
+		if err := p.expect(tokenRParen); err != nil {
+			return step{}, err
+		}
+		if name == "first" {
+			return step{kind: stepFirst}, nil
+		}
+		return step{kind: stepLast}, nil
+	case "where":
+		if nested {
+			return step{}, p.errorf("nested where() is not supported")
+		}
+		path, err := p.parsePath(true)
+		if err != nil {
+			return step{}, err
+		}
+		c := &criteria{path: path}
+		switch p.tok.kind {
+		case tokenEqual:
+		case tokenNotEqual:
+			c.negate = true
+		default:
+			return step{}, p.errorf("expected = or != in where(), got %s", p.tok)
+		}
+		if err := p.advance(); err != nil {
+			return step{}, err
+		}
+		switch p.tok.kind {
+		case tokenString:
+			c.literal = p.tok.text
+		case tokenNumber:
+			n, _ := new(big.Float).SetString(p.tok.text)
+			c.literal = n
+		case tokenIdent:
+			switch p.tok.text {
+			case "true":
+				c.literal = true
+			case "false":
+				c.literal = false
+			default:
+				return step{}, p.errorf("where() must compare against a literal, got %s", p.tok)
+			}
+		default:
+			return step{}, p.errorf("where() must compare against a literal, got %s", p.tok)
+		}
+		if err := p.advance(); err != nil {
+			return step{}, err
+		}
+		if err := p.expect(tokenRParen); err != nil {
+			return step{}, err
+		}
+		return step{kind: stepWhere, where: c}, nil
+	default:
+		return step{}, p.errorf("unsupported function %s()", name)
+	}
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenString
+	tokenNumber
+	tokenDot
+	tokenLBracket
+	tokenRBracket
+	tokenLParen
+	tokenRParen
+	tokenEqual
+	tokenNotEqual
+)
+
+func (k tokenKind) String() string {
+	switch k {
+	case tokenEOF:
+		return "end of expression"
+	case tokenIdent:
+		return "identifier"
+	case tokenString:
+		return "string"
+	case tokenNumber:
+		return "number"
+	case tokenDot:
+		return "'.'"
+	case tokenLBracket:
+		return "'['"
+	case tokenRBracket:
+		return "']'"
+	case tokenLParen:
+		return "'('"
+	case tokenRParen:
+		return "')'"
+	case tokenEqual:
+		return "'='"
+	default:
+		return "'!='"
+	}
+}
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenIdent, tokenNumber:
+		return fmt.Sprintf("%s %q", t.kind, t.text)
+	default:
+		return t.kind.String()
+	}
+}
+
+type lexer struct {
+	input []rune
+	pos   int
+}
+
+func newLexer(source string) *lexer {
+	return &lexer{input: []rune(source)}
+}
+
+func (l *lexer) next() (token, error) {
+	for l.pos < len(l.input) && unicode.IsSpace(l.input[l.pos]) {
+		l.pos++
+	}
+	start := l.pos
+	if l.pos >= len(l.input) {
+		return token{kind: tokenEOF, pos: start}, nil
+	}
+
+	r := l.input[l.pos]
+	single := map[rune]tokenKind{
+		'.': tokenDot, '[': tokenLBracket, ']': tokenRBracket,
+		'(': tokenLParen, ')': tokenRParen, '=': tokenEqual,
+	}
+	if kind, ok := single[r]; ok {
+		l.pos++
+		return token{kind: kind, text: string(r), pos: start}, nil
+	}
+
+	switch {
+	case r == '!':
+		if l.pos+1 < len(l.input) && l.input[l.pos+1] == '=' {
+			l.pos += 2
+			return token{kind: tokenNotEqual, text: "!=", pos: start}, nil
+		}
+	case r == '\'':
+		return l.lexString()
+	case r == '`':
+		l.pos++
+		for l.pos < len(l.input) && l.input[l.pos] != '`' {
+			l.pos++
+		}
+		if l.pos >= len(l.input) {
+			return token{}, fmt.Errorf("invalid FHIRPath at offset %d: unterminated delimited identifier", start)
+		}
+		l.pos++
+		return token{kind: tokenIdent, text: string(l.input[start+1 : l.pos-1]), pos: start}, nil
+	case unicode.IsDigit(r):
+		for l.pos < len(l.input) && (unicode.IsDigit(l.input[l.pos]) || l.input[l.pos] == '.' && l.pos+1 < len(l.input) && unicode.IsDigit(l.input[l.pos+1])) {
+			l.pos++
+		}
+		return token{kind: tokenNumber, text: string(l.input[start:l.pos]), pos: start}, nil
+	case r == '_' || unicode.IsLetter(r):
+		for l.pos < len(l.input) && (l.input[l.pos] == '_' || unicode.IsLetter(l.input[l.pos]) || unicode.IsDigit(l.input[l.pos])) {
+			l.pos++
+		}
+		return token{kind: tokenIdent, text: string(l.input[start:l.pos]), pos: start}, nil
+	}
+
+	return token{}, fmt.Errorf("invalid FHIRPath at offset %d: unexpected character %q", start, r)
+}
+
+func (l *lexer) lexString() (token, error) {
+	start := l.pos
+	l.pos++
+
+	var sb strings.Builder
+	for l.pos < len(l.input) {
+		r := l.input[l.pos]
+		switch r {
+		case '\'':
+			l.pos++
+			return token{kind: tokenString, text: sb.String(), pos: start}, nil
+		case '\\':
+			if l.pos+1 >= len(l.input) {
+				return token{}, fmt.Errorf("invalid FHIRPath at offset %d: unterminated string", start)
+			}
+			escaped := l.input[l.pos+1]
+			switch escaped {
+			case 'n':
+				sb.WriteRune('\n')
+			case 't':
+				sb.WriteRune('\t')
+			case 'r':
+				sb.WriteRune('\r')
+			default:
+				sb.WriteRune(escaped)
+			}
+			l.pos += 2
+		default:
+			sb.WriteRune(r)
+			l.pos++
+		}
+	}
+
+	return token{}, fmt.Errorf("invalid FHIRPath at offset %d: unterminated string", start)
+}
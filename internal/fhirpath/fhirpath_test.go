@@ -0,0 +1,118 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fhirpath
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+const patient = `{
+  "resourceType": "Patient",
+  "id": "pt-1",
+  "active": true,
+  "name": [
+    {"use": "official", "family": "Chalmers", "given": ["Peter", "James"]},
+    {"use": "usual", "given": ["Jim"]}
+  ],
+  "telecom": [
+    {"system": "phone", "value": "555-0100", "rank": 2},
+    {"system": "email", "value": "jim@example.com", "rank": 1}
+  ]
+}`
+
+func TestEvaluate(t *testing.T) {
+	decoder := json.NewDecoder(bytes.NewReader([]byte(patient)))
+	decoder.UseNumber()
+	var resource interface{}
+	if err := decoder.Decode(&resource); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := map[string]string{
+		"id":                                        `[pt-1]`,
+		"Patient.id":                                `[pt-1]`,
+		"Observation.id":                            `[]`,
+		"name.given":                                `[Peter James Jim]`,
+		"name[0].given[1]":                          `[James]`,
+		"name.given.first()":                        `[Peter]`,
+		"name.given.last()":                         `[Jim]`,
+		"name.where(use = 'usual').given":           `[Jim]`,
+		"telecom.where(system != 'phone').value":    `[jim@example.com]`,
+		"telecom.where(rank = 2).value":             `[555-0100]`,
+		"Patient.where(active = true).id":           `[pt-1]`,
+		"name[5]":                                   `[]`,
+		"missing.path":                              `[]`,
+		"telecom.where(value = 'it\\'s').system":    `[]`,
+		"`telecom`.where(system = 'email').`value`": `[jim@example.com]`,
+	}
+
+	for expression, expected := range testCases {
+		t.Run(expression, func(t *testing.T) {
+			parsed, err := Parse(expression)
+			if err != nil {
+				t.Fatalf("unexpected parse error: %s", err)
+			}
+
+			result, err := parsed.Evaluate(resource)
+			if err != nil {
+				t.Fatalf("unexpected evaluation error: %s", err)
+			}
+
+			if got := fmt.Sprint(result); got != expected {
+				t.Errorf("expected %s, got %s", expected, got)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	testCases := map[string]string{
+		"name.":                       "invalid FHIRPath at offset 5: expected identifier, got end of expression",
+		"name[first]":                 `invalid FHIRPath at offset 5: expected index, got identifier "first"`,
+		"name.exists()":               "invalid FHIRPath at offset 12: unsupported function exists()",
+		"name.where(use)":             "invalid FHIRPath at offset 14: expected = or != in where(), got ')'",
+		"name.where(use = other)":     `invalid FHIRPath at offset 17: where() must compare against a literal, got identifier "other"`,
+		"name.where(use = 'official'": "invalid FHIRPath at offset 27: expected ')', got end of expression",
+		"name.where(use = 'official)": "invalid FHIRPath at offset 17: unterminated string",
+		"name + 1":                    "invalid FHIRPath at offset 5: unexpected character '+'",
+	}
+
+	for expression, expected := range testCases {
+		t.Run(expression, func(t *testing.T) {
+			_, err := Parse(expression)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if err.Error() != expected {
+				t.Errorf("expected %q, got %q", expected, err.Error())
+			}
+		})
+	}
+}
+
+func FuzzParseAndEvaluate(f *testing.F) {
+	resource := map[string]interface{}{
+		"resourceType": "Patient",
+		"name": []interface{}{
+			map[string]interface{}{"use": "official", "given": []interface{}{"John"}},
+		},
+	}
+
+	f.Add("Patient.name.given")
+	f.Add("name.where(use = 'official').given.first()")
+	f.Add("name[0].given.last()")
+	f.Add("name.where(use != ")
+	f.Add("name[-1]")
+
+	f.Fuzz(func(t *testing.T, source string) {
+		expression, err := Parse(source)
+		if err != nil {
+			return
+		}
+		_, _ = expression.Evaluate(resource)
+	})
+}
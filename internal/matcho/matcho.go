@@ -0,0 +1,150 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package matcho performs offline syntax checks of matcho patterns, the
+// pattern language used by Aidbox access policies.
+package matcho
+
+import (
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"sort"
+	"strings"
+)
+
+// Operators lists the matcho operators understood by Aidbox.
+var Operators = []string{"$contains", "$enum", "$one-of"}
+
+// Error describes a problem found at a specific location in a pattern.
+type Error struct {
+	Path    string
+	Message string
+}
+
+func (e Error) Error() string {
+	if e.Path == "" {
+		return e.Message
+	}
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+// Parse decodes a matcho document written as JSON or YAML.
+func Parse(text string) (interface{}, error) {
+	var pattern interface{}
+	if err := yaml.Unmarshal([]byte(text), &pattern); err != nil {
+		return nil, fmt.Errorf("invalid matcho document: %w", err)
+	}
+	return pattern, nil
+}
+
+// Validate checks a decoded matcho document and returns every problem found.
+// A document must be a map at the top level.
+func Validate(pattern interface{}) []Error {
+	if _, ok := asMap(pattern); !ok {
+		return []Error{{Message: fmt.Sprintf("matcho document must be an object, got %s", describe(pattern))}}
+	}
+	return validate("", pattern)
+}
+
+func validate(path string, pattern interface{}) []Error {
+	var errs []Error
+
+	if items, ok := pattern.([]interface{}); ok {
+		for i, item := range items {
+			errs = append(errs, validate(fmt.Sprintf("%s[%d]", path, i), item)...)
+		}
+		return errs
+	}
+
+	m, ok := asMap(pattern)
+	if !ok {
+		return nil
+	}
+
+	for _, key := range sortedKeys(m) {
+		value := m[key]
+		keyPath := join(path, key)
+
+		if !strings.HasPrefix(key, "$") {
+			errs = append(errs, validate(keyPath, value)...)
+			continue
+		}
+
+		switch key {
+		case "$enum":
+			items, ok := value.([]interface{})
+			if !ok || len(items) == 0 {
+				errs = append(errs, Error{Path: keyPath, Message: "$enum expects a non-empty list of values"})
+				continue
+			}
+			for i, item := range items {
+				if _, isMap := asMap(item); isMap {
+					errs = append(errs, Error{Path: fmt.Sprintf("%s[%d]", keyPath, i), Message: "$enum values must be scalars"})
+				} else if _, isList := item.([]interface{}); isList {
+					errs = append(errs, Error{Path: fmt.Sprintf("%s[%d]", keyPath, i), Message: "$enum values must be scalars"})
+				}
+			}
+		case "$one-of":
+			items, ok := value.([]interface{})
+			if !ok || len(items) == 0 {
+				errs = append(errs, Error{Path: keyPath, Message: "$one-of expects a non-empty list of patterns"})
+				continue
+			}
+			errs = append(errs, validate(keyPath, items)...)
+		case "$contains":
+			if value == nil {
+				errs = append(errs, Error{Path: keyPath, Message: "$contains expects a pattern"})
+				continue
+			}
+			errs = append(errs, validate(keyPath, value)...)
+		default:
+			errs = append(errs, Error{Path: keyPath, Message: fmt.Sprintf("unknown matcho operator %q, expected one of %s", key, strings.Join(Operators, ", "))})
+		}
+	}
+
+	return errs
+}
+
+func asMap(value interface{}) (map[string]interface{}, bool) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return v, true
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			m[fmt.Sprint(key)] = item
+		}
+		return m, true
+	default:
+		return nil, false
+	}
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func join(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func describe(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case []interface{}:
+		return "a list"
+	case string:
+		return "a string"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
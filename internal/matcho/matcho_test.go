@@ -0,0 +1,87 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package matcho
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	testCases := map[string]struct {
+		document string
+		expected []string
+	}{
+		"valid": {
+			document: `{"request-method": "get", "uri": "#/Patient/.*", "user": {"data": {"roles": {"$contains": "admin"}}}}`,
+		},
+		"valid-yaml": {
+			document: "request-method:\n  $enum: [get, post]\nparams:\n  $one-of:\n    - {type: Patient}\n    - {type: Practitioner}\n",
+		},
+		"not-an-object": {
+			document: `["get"]`,
+			expected: []string{"matcho document must be an object, got a list"},
+		},
+		"unknown-operator": {
+			document: `{"user": {"$contain": "admin"}}`,
+			expected: []string{`user.$contain: unknown matcho operator "$contain", expected one of $contains, $enum, $one-of`},
+		},
+		"empty-one-of": {
+			document: `{"params": {"$one-of": []}}`,
+			expected: []string{"params.$one-of: $one-of expects a non-empty list of patterns"},
+		},
+		"nested-one-of": {
+			document: `{"params": {"$one-of": [{"type": "Patient"}, {"type": {"$enum": "Patient"}}]}}`,
+			expected: []string{"params.$one-of[1].type.$enum: $enum expects a non-empty list of values"},
+		},
+		"enum-of-objects": {
+			document: `{"request-method": {"$enum": ["get", {"x": 1}]}}`,
+			expected: []string{"request-method.$enum[1]: $enum values must be scalars"},
+		},
+		"null-contains": {
+			document: `{"roles": {"$contains": null}}`,
+			expected: []string{"roles.$contains: $contains expects a pattern"},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			pattern, err := Parse(testCase.document)
+			if err != nil {
+				t.Fatalf("unexpected parse error: %s", err)
+			}
+
+			var got []string
+			for _, e := range Validate(pattern) {
+				got = append(got, e.Error())
+			}
+
+			if !reflect.DeepEqual(got, testCase.expected) {
+				t.Errorf("expected %q, got %q", testCase.expected, got)
+			}
+		})
+	}
+}
+
+func TestParseUnbalanced(t *testing.T) {
+	if _, err := Parse(`{"user": {"id": "admin"}`); err == nil {
+		t.Fatal("expected an error for an unbalanced document")
+	}
+}
+
+func FuzzParseAndValidate(f *testing.F) {
+	f.Add(`{"resourceType": "Patient", "name": {"$contains": {"given": "John"}}}`)
+	f.Add("status:\n  $enum: [active, inactive]\n")
+	f.Add("$one-of: [1, 2]")
+	f.Add("$unknown: true")
+	f.Add("&a [*a]")
+
+	f.Fuzz(func(t *testing.T, text string) {
+		pattern, err := Parse(text)
+		if err != nil {
+			return
+		}
+		_ = Validate(pattern)
+	})
+}
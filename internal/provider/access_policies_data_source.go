@@ -0,0 +1,119 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox"
+	"sort"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &AccessPoliciesDataSource{}
+var _ datasource.DataSourceWithConfigure = &AccessPoliciesDataSource{}
+
+const accessPolicyResourceType = "AccessPolicy"
+
+func NewAccessPoliciesDataSource() datasource.DataSource {
+	return &AccessPoliciesDataSource{}
+}
+
+// AccessPoliciesDataSource defines the data source implementation.
+type AccessPoliciesDataSource struct {
+	boxDataSource
+}
+
+// AccessPoliciesDataSourceModel describes the data source data model.
+type AccessPoliciesDataSourceModel struct {
+	PageSize types.Int64                           `tfsdk:"page_size"`
+	MaxItems types.Int64                           `tfsdk:"max_items"`
+	Policies []AccessPoliciesDataSourcePolicyModel `tfsdk:"policies"`
+}
+
+// AccessPoliciesDataSourcePolicyModel describes a policy of the policies
+// attribute.
+type AccessPoliciesDataSourcePolicyModel struct {
+	ID          types.String `tfsdk:"id"`
+	Engine      types.String `tfsdk:"engine"`
+	Description types.String `tfsdk:"description"`
+	Links       types.List   `tfsdk:"links"`
+}
+
+func (d *AccessPoliciesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_access_policies"
+}
+
+func (d *AccessPoliciesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the AccessPolicy resources of the box, such as to check in a precondition that no policy of the `allow` engine, granting access to everything, exists.",
+		Attributes: map[string]schema.Attribute{
+			"page_size": pageSizeAttribute(),
+			"max_items": maxItemsAttribute("access policies"),
+			"policies": schema.ListAttribute{
+				MarkdownDescription: "Access policies of the box, ordered by ID. Each policy has an `id`, an `engine`, such as `allow`, `matcho` or `sql`, a `description` and the `links` to the clients, users or operations it applies to, such as `Client/portal`.",
+				ElementType: types.ObjectType{
+					AttrTypes: map[string]attr.Type{
+						"id":          types.StringType,
+						"engine":      types.StringType,
+						"description": types.StringType,
+						"links":       types.ListType{ElemType: types.StringType},
+					},
+				},
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (d *AccessPoliciesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model AccessPoliciesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	policies, err := d.client.SearchResources(ctx, accessPolicyResourceType, searchParams(model.PageSize), searchLimit(model.MaxItems))
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostic("Failed to List Access Policies", "Unable to search access policies", err))
+		return
+	}
+
+	model.Policies = limitItems(mapAccessPolicies(policies), model.MaxItems)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+// mapAccessPolicies maps the AccessPolicy resources stored by the box,
+// ordered by ID. Links are formatted as references, such as Client/portal.
+func mapAccessPolicies(policies []aidbox.Resource) []AccessPoliciesDataSourcePolicyModel {
+	mapped := make([]AccessPoliciesDataSourcePolicyModel, 0, len(policies))
+	for _, policy := range policies {
+		links := []attr.Value{}
+		entries, _ := policy["link"].([]interface{})
+		for _, entry := range entries {
+			link, ok := entry.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			resourceType, _ := link["resourceType"].(string)
+			id, _ := link["id"].(string)
+			links = append(links, types.StringValue(resourceType+"/"+id))
+		}
+
+		mapped = append(mapped, AccessPoliciesDataSourcePolicyModel{
+			ID:          types.StringValue(policy.ID()),
+			Engine:      jsonStringValue(policy, "engine"),
+			Description: jsonStringValue(policy, "description"),
+			Links:       types.ListValueMust(types.StringType, links),
+		})
+	}
+
+	sort.Slice(mapped, func(i, j int) bool {
+		return mapped[i].ID.ValueString() < mapped[j].ID.ValueString()
+	})
+	return mapped
+}
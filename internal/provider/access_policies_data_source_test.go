@@ -0,0 +1,44 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccAidboxAccessPoliciesDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckBox(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccAidboxAccessPoliciesDataSourceConfig("tf-acc-policies"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs("data.aidbox_access_policies.test", "policies.*", map[string]string{
+						"id":      "tf-acc-policies",
+						"engine":  "consent",
+						"links.#": "0",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testAccAidboxAccessPoliciesDataSourceConfig(id string) string {
+	return fmt.Sprintf(`
+resource "aidbox_consent_policy" "test" {
+  id             = %[1]q
+  resource_types = ["Observation"]
+}
+
+data "aidbox_access_policies" "test" {
+  depends_on = [aidbox_consent_policy.test]
+}
+`, id)
+}
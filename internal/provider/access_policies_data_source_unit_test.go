@@ -0,0 +1,33 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox"
+)
+
+func TestMapAccessPolicies(t *testing.T) {
+	policies := mapAccessPolicies([]aidbox.Resource{
+		{"resourceType": "AccessPolicy", "id": "portal", "engine": "matcho", "link": []interface{}{
+			map[string]interface{}{"resourceType": "Client", "id": "portal"},
+			map[string]interface{}{"resourceType": "Operation", "id": "FhirRead"},
+		}},
+		{"resourceType": "AccessPolicy", "id": "dev-allow-all", "engine": "allow", "description": "Development only"},
+	})
+
+	if len(policies) != 2 || policies[0].ID.ValueString() != "dev-allow-all" || policies[1].ID.ValueString() != "portal" {
+		t.Fatalf("expected the policies ordered by ID, got: %+v", policies)
+	}
+	if policies[0].Engine.ValueString() != "allow" || policies[0].Description.ValueString() != "Development only" || len(policies[0].Links.Elements()) != 0 {
+		t.Errorf("unexpected policy: %+v", policies[0])
+	}
+	expectedLinks := types.ListValueMust(types.StringType, []attr.Value{types.StringValue("Client/portal"), types.StringValue("Operation/FhirRead")})
+	if !policies[1].Links.Equal(expectedLinks) || !policies[1].Description.IsNull() {
+		t.Errorf("unexpected policy: %+v", policies[1])
+	}
+}
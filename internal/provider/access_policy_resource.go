@@ -0,0 +1,329 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/petalmd/terraform-provider-aidbox/internal/fhir"
+	"github.com/petalmd/terraform-provider-aidbox/internal/matcho"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox"
+	"sort"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &AccessPolicyResource{}
+var _ resource.ResourceWithImportState = &AccessPolicyResource{}
+var _ resource.ResourceWithValidateConfig = &AccessPolicyResource{}
+
+// accessPolicyEngines are the engines of the access policies managed by
+// aidbox_access_policy. Policies of the consent engine are managed by
+// aidbox_consent_policy.
+var accessPolicyEngines = []string{"allow", "json-schema", "matcho", "sql"}
+
+// accessPolicyEngineAttributes are the attributes holding the rule of each
+// engine which needs one.
+var accessPolicyEngineAttributes = map[string]string{
+	"json-schema": "schema",
+	"matcho":      "matcho",
+	"sql":         "sql",
+}
+
+func NewAccessPolicyResource() resource.Resource {
+	return &AccessPolicyResource{}
+}
+
+// AccessPolicyResource defines the resource implementation.
+type AccessPolicyResource struct {
+	boxResource
+}
+
+// AccessPolicyResourceModel describes the resource data model.
+type AccessPolicyResourceModel struct {
+	ID          types.String         `tfsdk:"id"`
+	Description types.String         `tfsdk:"description"`
+	Engine      types.String         `tfsdk:"engine"`
+	Matcho      yamlValue            `tfsdk:"matcho"`
+	SQL         types.String         `tfsdk:"sql"`
+	Schema      jsontypes.Normalized `tfsdk:"schema"`
+	Links       types.Set            `tfsdk:"links"`
+	VersionID   types.String         `tfsdk:"version_id"`
+}
+
+func (r *AccessPolicyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_access_policy"
+}
+
+func (r *AccessPolicyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages an AccessPolicy of the box, granting access to the requests matching its rule. " +
+			"Consent-based policies are managed by `aidbox_consent_policy`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the access policy. Changing it forces a new access policy to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validFHIRID(),
+				},
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Description of the policy",
+				Optional:            true,
+			},
+			"engine": schema.StringAttribute{
+				MarkdownDescription: "Engine evaluating the policy: `allow` to grant every request, `matcho` to match requests against the `matcho` document, `sql` to run the `sql` query or `json-schema` to validate requests against the `schema` document.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(accessPolicyEngines...),
+				},
+			},
+			"matcho": schema.StringAttribute{
+				MarkdownDescription: "Matcho document the requests must match, written as JSON or YAML, such as with `yamlencode`. Required with the `matcho` engine.",
+				CustomType:          yamlType{},
+				Optional:            true,
+				Validators: []validator.String{
+					validMatcho(),
+				},
+			},
+			"sql": schema.StringAttribute{
+				MarkdownDescription: "SQL query granting the request when it returns true, such as `SELECT {{jwt.sub}} = 'admin'`. Required with the `sql` engine.",
+				Optional:            true,
+			},
+			"schema": schema.StringAttribute{
+				MarkdownDescription: "JSON schema the requests must be valid against, such as with `jsonencode`. Required with the `json-schema` engine.",
+				CustomType:          jsontypes.NormalizedType{},
+				Optional:            true,
+			},
+			"links": schema.SetAttribute{
+				MarkdownDescription: "References to the clients, users or operations the policy applies to, such as `Client/portal`. Defaults to every request.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(
+						validFHIRReference("Client", "User", "Operation"),
+					),
+				},
+			},
+			"version_id": schema.StringAttribute{
+				MarkdownDescription: "Version of the access policy, incremented by the box on every change",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *AccessPolicyResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var model AccessPolicyResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() || model.Engine.IsUnknown() {
+		return
+	}
+
+	rules := map[string]attr.Value{
+		"matcho": model.Matcho,
+		"schema": model.Schema,
+		"sql":    model.SQL,
+	}
+	engines := make([]string, 0, len(accessPolicyEngineAttributes))
+	for engine := range accessPolicyEngineAttributes {
+		engines = append(engines, engine)
+	}
+	sort.Strings(engines)
+
+	for _, engine := range engines {
+		name := accessPolicyEngineAttributes[engine]
+		rule := rules[name]
+		switch {
+		case engine == model.Engine.ValueString() && rule.IsNull():
+			resp.Diagnostics.AddAttributeError(
+				path.Root(name),
+				"Missing Access Policy Rule",
+				fmt.Sprintf("The %s attribute is required with the %s engine.", name, engine),
+			)
+		case engine != model.Engine.ValueString() && !rule.IsNull() && !rule.IsUnknown():
+			resp.Diagnostics.AddAttributeError(
+				path.Root(name),
+				"Unexpected Access Policy Rule",
+				fmt.Sprintf("The %s attribute is only used by the %s engine.", name, engine),
+			)
+		}
+	}
+}
+
+func (r *AccessPolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var model AccessPolicyResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	policy, err := accessPolicyToResource(model)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("matcho"), "Invalid Matcho Document", err.Error())
+		return
+	}
+
+	stored, err := r.client.PutResource(ctx, policy, "")
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostic("Failed to Create Access Policy", "Unable to create access policy", err))
+		return
+	}
+
+	mapAccessPolicyFromResource(&model, stored)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *AccessPolicyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var model AccessPolicyResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stored, err := r.client.GetResourceIfModified(ctx, accessPolicyResourceType, model.ID.ValueString(), model.VersionID.ValueString())
+	if aidbox.IsNotModified(err) {
+		return
+	}
+	if aidbox.IsNotFound(err) {
+		tflog.Warn(ctx, "Access policy not found, removing from state", map[string]interface{}{"id": model.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostic("Failed to Fetch Access Policy", "Unable to fetch access policy", err))
+		return
+	}
+
+	mapAccessPolicyFromResource(&model, stored)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *AccessPolicyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var model AccessPolicyResourceModel
+	var versionID types.String
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("version_id"), &versionID)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	policy, err := accessPolicyToResource(model)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("matcho"), "Invalid Matcho Document", err.Error())
+		return
+	}
+
+	stored, err := r.client.PutResource(ctx, policy, versionID.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(updateErrorDiagnostic("access policy "+model.ID.ValueString(), "Failed to Update Access Policy", "Unable to update access policy", err))
+		return
+	}
+
+	mapAccessPolicyFromResource(&model, stored)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *AccessPolicyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var model AccessPolicyResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteResource(ctx, accessPolicyResourceType, model.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(deleteErrorDiagnostic(
+			"access policy "+model.ID.ValueString(),
+			"Failed to Delete Access Policy",
+			fmt.Sprintf("Error while trying to delete the access policy with ID %s", model.ID.ValueString()),
+			err,
+		))
+	}
+}
+
+func (r *AccessPolicyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+func accessPolicyToResource(model AccessPolicyResourceModel) (aidbox.Resource, error) {
+	policy := aidbox.Resource{
+		"resourceType": accessPolicyResourceType,
+		"id":           model.ID.ValueString(),
+		"engine":       model.Engine.ValueString(),
+	}
+	setJSONValue(policy, "description", model.Description)
+	setJSONValue(policy, "schema", model.Schema)
+
+	if !model.Matcho.IsNull() && !model.Matcho.IsUnknown() {
+		pattern, err := matcho.Parse(model.Matcho.ValueString())
+		if err != nil {
+			return nil, err
+		}
+		policy["matcho"] = pattern
+	}
+	if !model.SQL.IsNull() && !model.SQL.IsUnknown() {
+		policy["sql"] = map[string]interface{}{"query": model.SQL.ValueString()}
+	}
+
+	if !model.Links.IsNull() && !model.Links.IsUnknown() {
+		links := make([]interface{}, 0, len(model.Links.Elements()))
+		for _, element := range model.Links.Elements() {
+			resourceType, id, _ := fhir.ParseReference(element.(types.String).ValueString())
+			links = append(links, map[string]interface{}{"resourceType": resourceType, "id": id})
+		}
+		policy["link"] = links
+	}
+
+	return policy, nil
+}
+
+func mapAccessPolicyFromResource(model *AccessPolicyResourceModel, stored aidbox.Resource) {
+	model.ID = types.StringValue(stored.ID())
+	model.Description = jsonStringValue(stored, "description")
+	model.Engine = jsonStringValue(stored, "engine")
+	model.Schema = jsonNormalizedValue(stored, "schema")
+	model.SQL = jsonStringValue(jsonObject(stored, "sql"), "query")
+	model.VersionID = stringValueOrNull(stored.VersionID())
+
+	// The document is read back as JSON, which the semantic equality of
+	// yamlType matches with the YAML it was written as
+	model.Matcho = yamlValue{StringValue: basetypes.NewStringNull()}
+	if pattern, ok := stored["matcho"]; ok && pattern != nil {
+		if data, err := json.Marshal(pattern); err == nil {
+			model.Matcho = newYAMLValue(string(data))
+		}
+	}
+
+	model.Links = types.SetNull(types.StringType)
+	if entries, ok := stored["link"].([]interface{}); ok {
+		links := make([]attr.Value, 0, len(entries))
+		for _, entry := range entries {
+			link, ok := entry.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			resourceType, _ := link["resourceType"].(string)
+			id, _ := link["id"].(string)
+			links = append(links, types.StringValue(resourceType+"/"+id))
+		}
+		model.Links = types.SetValueMust(types.StringType, links)
+	}
+}
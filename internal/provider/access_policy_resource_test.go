@@ -0,0 +1,69 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccAidboxAccessPolicyResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheckBox(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_5_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccAidboxAccessPolicyResourceConfig("get"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("aidbox_access_policy.test", "id", "tf-acc-patients"),
+					resource.TestCheckResourceAttr("aidbox_access_policy.test", "engine", "matcho"),
+					resource.TestCheckResourceAttr("aidbox_access_policy.test", "links.#", "1"),
+					resource.TestCheckResourceAttrSet("aidbox_access_policy.test", "version_id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "aidbox_access_policy.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"matcho"},
+			},
+			// Import block testing, as with terraform plan -generate-config-out
+			{
+				ResourceName:    "aidbox_access_policy.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithID,
+			},
+			// Update and Read testing
+			{
+				Config: testAccAidboxAccessPolicyResourceConfig("post"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("aidbox_access_policy.test", "matcho"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccAidboxAccessPolicyResourceConfig(method string) string {
+	return fmt.Sprintf(`
+resource "aidbox_access_policy" "test" {
+  id     = "tf-acc-patients"
+  engine = "matcho"
+  matcho = yamlencode({
+    uri            = "/Patient"
+    request-method = %[1]q
+  })
+  links = ["Client/tf-acc-portal"]
+}
+`, method)
+}
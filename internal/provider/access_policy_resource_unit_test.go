@@ -0,0 +1,160 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox/fake"
+)
+
+func TestAccessPolicyResource_roundTrip(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewBoxClient()
+
+	model := AccessPolicyResourceModel{
+		ID:          types.StringValue("portal-patients"),
+		Description: types.StringNull(),
+		Engine:      types.StringValue("matcho"),
+		Matcho:      newYAMLValue("uri: /Patient\nrequest-method:\n  $enum: [get, post]\n"),
+		SQL:         types.StringNull(),
+		Schema:      jsontypes.NewNormalizedNull(),
+		Links:       types.SetValueMust(types.StringType, []attr.Value{types.StringValue("Client/portal")}),
+		VersionID:   types.StringUnknown(),
+	}
+
+	policy, err := accessPolicyToResource(model)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stored, err := client.PutResource(ctx, policy, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	links, _ := stored["link"].([]interface{})
+	if len(links) != 1 || links[0].(map[string]interface{})["resourceType"] != "Client" || links[0].(map[string]interface{})["id"] != "portal" {
+		t.Errorf("unexpected links: %v", stored["link"])
+	}
+
+	var mapped AccessPolicyResourceModel
+	mapAccessPolicyFromResource(&mapped, stored)
+	if !mapped.Links.Equal(model.Links) || mapped.Engine != model.Engine || !mapped.SQL.IsNull() || !mapped.Schema.IsNull() || mapped.VersionID.ValueString() != "1" {
+		t.Errorf("unexpected model: %+v", mapped)
+	}
+
+	// The document is read back as JSON
+	equal, diags := model.Matcho.StringSemanticEquals(ctx, mapped.Matcho)
+	if diags.HasError() || !equal {
+		t.Errorf("expected %s to be semantically equal to %s, got: %v", mapped.Matcho.ValueString(), model.Matcho.ValueString(), diags)
+	}
+}
+
+func TestAccessPolicyResource_sql(t *testing.T) {
+	model := AccessPolicyResourceModel{
+		ID:          types.StringValue("admins"),
+		Description: types.StringValue("Administrators"),
+		Engine:      types.StringValue("sql"),
+		Matcho:      yamlValue{StringValue: basetypes.NewStringNull()},
+		SQL:         types.StringValue("SELECT {{jwt.role}} = 'admin'"),
+		Schema:      jsontypes.NewNormalizedNull(),
+		Links:       types.SetNull(types.StringType),
+	}
+
+	policy, err := accessPolicyToResource(model)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if query := policy["sql"].(map[string]interface{})["query"]; query != model.SQL.ValueString() {
+		t.Errorf("unexpected query: %v", query)
+	}
+	if _, ok := policy["link"]; ok {
+		t.Errorf("expected no links, got: %v", policy["link"])
+	}
+
+	var mapped AccessPolicyResourceModel
+	mapAccessPolicyFromResource(&mapped, policy)
+	if mapped.SQL != model.SQL || !mapped.Matcho.IsNull() || !mapped.Links.IsNull() || mapped.Description != model.Description {
+		t.Errorf("unexpected model: %+v", mapped)
+	}
+}
+
+func TestAccessPolicyResource_validateConfig(t *testing.T) {
+	ctx := context.Background()
+	r := &AccessPolicyResource{}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	matchoNull := yamlValue{StringValue: basetypes.NewStringNull()}
+	testCases := map[string]struct {
+		engine      string
+		matcho      yamlValue
+		sql         types.String
+		expectError bool
+	}{
+		"allow": {
+			engine: "allow",
+			matcho: matchoNull,
+			sql:    types.StringNull(),
+		},
+		"matcho": {
+			engine: "matcho",
+			matcho: newYAMLValue("uri: /Patient"),
+			sql:    types.StringNull(),
+		},
+		"matcho-without-document": {
+			engine:      "matcho",
+			matcho:      matchoNull,
+			sql:         types.StringNull(),
+			expectError: true,
+		},
+		"sql-with-document": {
+			engine:      "sql",
+			matcho:      newYAMLValue("uri: /Patient"),
+			sql:         types.StringValue("SELECT true"),
+			expectError: true,
+		},
+		"allow-with-unknown-query": {
+			engine: "allow",
+			matcho: matchoNull,
+			sql:    types.StringUnknown(),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			model := AccessPolicyResourceModel{
+				ID:          types.StringValue("policy"),
+				Description: types.StringNull(),
+				Engine:      types.StringValue(testCase.engine),
+				Matcho:      testCase.matcho,
+				SQL:         testCase.sql,
+				Schema:      jsontypes.NewNormalizedNull(),
+				Links:       types.SetNull(types.StringType),
+				VersionID:   types.StringNull(),
+			}
+			plan := tfsdk.Plan{
+				Schema: schemaResp.Schema,
+				Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+			}
+			if diags := plan.Set(ctx, &model); diags.HasError() {
+				t.Fatalf("unable to build config: %v", diags)
+			}
+
+			resp := resource.ValidateConfigResponse{}
+			r.ValidateConfig(ctx, resource.ValidateConfigRequest{Config: tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw}}, &resp)
+			if resp.Diagnostics.HasError() != testCase.expectError {
+				t.Errorf("expected error %t, got: %v", testCase.expectError, resp.Diagnostics)
+			}
+		})
+	}
+}
@@ -0,0 +1,235 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox"
+	"regexp"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &APIKeyResource{}
+
+// API keys are stored as Client resources of the api-key type, authenticating
+// with the basic grant.
+const (
+	apiKeyResourceType = "Client"
+	apiKeyClientType   = "api-key"
+)
+
+func NewAPIKeyResource() resource.Resource {
+	return &APIKeyResource{}
+}
+
+// APIKeyResource defines the resource implementation.
+type APIKeyResource struct {
+	boxResource
+}
+
+// APIKeyResourceModel describes the resource data model.
+type APIKeyResourceModel struct {
+	ID              types.String `tfsdk:"id"`
+	Name            types.String `tfsdk:"name"`
+	Description     types.String `tfsdk:"description"`
+	RotationTrigger types.Map    `tfsdk:"rotation_trigger"`
+	Key             types.String `tfsdk:"key"`
+	VersionID       types.String `tfsdk:"version_id"`
+}
+
+func (r *APIKeyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_api_key"
+}
+
+func (r *APIKeyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a long-lived API key a service integration authenticates to the box with, using HTTP basic authentication with the `id` and `key` of the API key. " +
+			"Changing `rotation_trigger` mints a new key and revokes the old one; use the `create_before_destroy` lifecycle argument so that the old key is only revoked once the new one exists. " +
+			"API keys can't be imported, as the box doesn't return their key.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the Client holding the API key, the name followed by a random suffix, used as the username of the basic authentication",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the API key, such as the name of the integration using it. Changing it forces a new API key to be minted.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtMost(48),
+					stringvalidator.RegexMatches(regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9-]*$`), "must contain only letters, digits and dashes"),
+				},
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Description of the API key, such as what the integration using it does",
+				Optional:            true,
+			},
+			"rotation_trigger": schema.MapAttribute{
+				MarkdownDescription: "Arbitrary values which mint a new key, and revoke the old one, when changed, such as a rotation date from the `time_rotating` resource",
+				ElementType:         types.StringType,
+				Optional:            true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"key": schema.StringAttribute{
+				MarkdownDescription: "Secret of the API key, used as the password of the basic authentication. The `basic_auth_header` function builds the authorization header from the `id` and `key`.",
+				Computed:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"version_id": schema.StringAttribute{
+				MarkdownDescription: "Version of the Client, incremented by the box on every change",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *APIKeyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var model APIKeyResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, key, err := newAPIKey(model.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to Create API Key", fmt.Sprintf("Unable to generate the API key: %s", err))
+		return
+	}
+	model.ID, model.Key = types.StringValue(id), types.StringValue(key)
+
+	stored, err := r.client.PutResource(ctx, apiKeyToResource(model), "")
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostic("Failed to Create API Key", "Unable to create API key", err))
+		return
+	}
+
+	mapAPIKeyFromResource(&model, stored)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *APIKeyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var model APIKeyResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stored, err := r.client.GetResourceIfModified(ctx, apiKeyResourceType, model.ID.ValueString(), model.VersionID.ValueString())
+	if aidbox.IsNotModified(err) {
+		return
+	}
+	if aidbox.IsNotFound(err) {
+		tflog.Warn(ctx, "API key not found, removing from state", map[string]interface{}{"id": model.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostic("Failed to Fetch API Key", "Unable to fetch API key", err))
+		return
+	}
+
+	mapAPIKeyFromResource(&model, stored)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *APIKeyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var model APIKeyResourceModel
+	var versionID types.String
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("version_id"), &versionID)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only the description is updated in place, the key is kept from state.
+	stored, err := r.client.PutResource(ctx, apiKeyToResource(model), versionID.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(updateErrorDiagnostic("API key "+model.ID.ValueString(), "Failed to Update API Key", "Unable to update API key", err))
+		return
+	}
+
+	mapAPIKeyFromResource(&model, stored)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *APIKeyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var model APIKeyResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteResource(ctx, apiKeyResourceType, model.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(deleteErrorDiagnostic(
+			"API key "+model.ID.ValueString(),
+			"Failed to Delete API Key",
+			fmt.Sprintf("Error while trying to revoke the API key with ID %s", model.ID.ValueString()),
+			err,
+		))
+	}
+}
+
+// newAPIKey generates the ID and key of a new API key. The random suffix of
+// the ID lets a rotated key be created before the old one is revoked.
+func newAPIKey(name string) (string, string, error) {
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return "", "", err
+	}
+
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return "", "", err
+	}
+
+	return name + "-" + hex.EncodeToString(suffix), base64.RawURLEncoding.EncodeToString(key), nil
+}
+
+func apiKeyToResource(model APIKeyResourceModel) aidbox.Resource {
+	client := aidbox.Resource{
+		"resourceType": apiKeyResourceType,
+		"id":           model.ID.ValueString(),
+		"type":         apiKeyClientType,
+		"grant_types":  []interface{}{"basic"},
+	}
+	setJSONValue(client, "secret", model.Key)
+	setJSONValue(client, "name", model.Name)
+	setJSONValue(client, "description", model.Description)
+
+	return client
+}
+
+// mapAPIKeyFromResource maps the Client resource stored by the box to model.
+// The key is never read back, as the box only returns a hash of it.
+func mapAPIKeyFromResource(model *APIKeyResourceModel, stored aidbox.Resource) {
+	model.ID = types.StringValue(stored.ID())
+	model.Name = jsonStringValue(stored, "name")
+	model.Description = jsonStringValue(stored, "description")
+	model.VersionID = stringValueOrNull(stored.VersionID())
+}
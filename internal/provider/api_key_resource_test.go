@@ -0,0 +1,82 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/compare"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccAidboxAPIKeyResource(t *testing.T) {
+	keyChanges := statecheck.CompareValue(compare.ValuesDiffer())
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckBox(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccAidboxAPIKeyResourceConfig("Pushes lab results", "2025-01"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("aidbox_api_key.test", "name", "tf-acc-lab-results"),
+					resource.TestMatchResourceAttr("aidbox_api_key.test", "id", regexp.MustCompile(`^tf-acc-lab-results-[0-9a-f]{8}$`)),
+					resource.TestCheckResourceAttrSet("aidbox_api_key.test", "key"),
+					resource.TestCheckResourceAttrSet("aidbox_api_key.test", "version_id"),
+				),
+				ConfigStateChecks: []statecheck.StateCheck{
+					keyChanges.AddStateValue("aidbox_api_key.test", tfjsonpath.New("key")),
+				},
+			},
+			// Update and Read testing
+			{
+				Config: testAccAidboxAPIKeyResourceConfig("Pushes lab results to the box", "2025-01"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("aidbox_api_key.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("aidbox_api_key.test", "description", "Pushes lab results to the box"),
+				),
+			},
+			// Rotation testing
+			{
+				Config: testAccAidboxAPIKeyResourceConfig("Pushes lab results to the box", "2025-07"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("aidbox_api_key.test", plancheck.ResourceActionCreateBeforeDestroy),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					keyChanges.AddStateValue("aidbox_api_key.test", tfjsonpath.New("key")),
+				},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccAidboxAPIKeyResourceConfig(description, rotation string) string {
+	return fmt.Sprintf(`
+resource "aidbox_api_key" "test" {
+  name        = "tf-acc-lab-results"
+  description = %[1]q
+
+  rotation_trigger = {
+    rotation = %[2]q
+  }
+
+  lifecycle {
+    create_before_destroy = true
+  }
+}
+`, description, rotation)
+}
@@ -0,0 +1,61 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox/fake"
+)
+
+func TestNewAPIKey(t *testing.T) {
+	id, key, err := newAPIKey("lab-results")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(id, "lab-results-") || len(id) != len("lab-results-")+8 {
+		t.Errorf("expected the name followed by a random suffix, got: %s", id)
+	}
+	if len(key) != 43 {
+		t.Errorf("expected a 256 bits key, got: %s", key)
+	}
+
+	rotatedID, rotatedKey, err := newAPIKey("lab-results")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rotatedID == id || rotatedKey == key {
+		t.Errorf("expected a new API key on every call, got: %s", rotatedID)
+	}
+}
+
+func TestAPIKeyResource_keyNotReadBack(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewBoxClient()
+
+	model := APIKeyResourceModel{
+		ID:              types.StringValue("lab-results-0a1b2c3d"),
+		Name:            types.StringValue("lab-results"),
+		Description:     types.StringValue("Pushes lab results"),
+		RotationTrigger: types.MapNull(types.StringType),
+		Key:             types.StringValue("key"),
+	}
+	stored, err := client.PutResource(ctx, apiKeyToResource(model), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored["secret"] != "key" || stored["type"] != "api-key" {
+		t.Errorf("unexpected client: %v", stored)
+	}
+
+	// The box returns a hash of the secret.
+	stored["secret"] = "hash"
+	mapAPIKeyFromResource(&model, stored)
+	if model.Key.ValueString() != "key" || model.Description.ValueString() != "Pushes lab results" || model.VersionID.ValueString() != "1" {
+		t.Errorf("unexpected model: %+v", model)
+	}
+}
@@ -0,0 +1,264 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ArchivePolicyResource{}
+var _ resource.ResourceWithImportState = &ArchivePolicyResource{}
+
+const archivePolicyResourceType = "AidboxArchive"
+
+// storageAccountResourceTypes maps the storage backends of the archive module
+// to the resource type of the account the box uses to access them.
+var storageAccountResourceTypes = map[string]string{
+	"aws":   "AwsAccount",
+	"gcp":   "GcpServiceAccount",
+	"azure": "AzureAccount",
+}
+
+func NewArchivePolicyResource() resource.Resource {
+	return &ArchivePolicyResource{}
+}
+
+// ArchivePolicyResource defines the resource implementation.
+type ArchivePolicyResource struct {
+	boxResource
+}
+
+// ArchivePolicyResourceModel describes the resource data model.
+type ArchivePolicyResourceModel struct {
+	ID            types.String               `tfsdk:"id"`
+	ResourceTypes types.Set                  `tfsdk:"resource_types"`
+	OlderThan     types.String               `tfsdk:"older_than"`
+	At            types.String               `tfsdk:"at"`
+	Storage       *ArchivePolicyStorageModel `tfsdk:"storage"`
+	VersionID     types.String               `tfsdk:"version_id"`
+}
+
+// ArchivePolicyStorageModel describes the storage block.
+type ArchivePolicyStorageModel struct {
+	Backend   types.String `tfsdk:"backend"`
+	AccountID types.String `tfsdk:"account_id"`
+	Bucket    types.String `tfsdk:"bucket"`
+}
+
+func (r *ArchivePolicyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_archive_policy"
+}
+
+func (r *ArchivePolicyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages an archive policy of the archive/restore module, which moves resources older than a threshold out of the database into a cloud storage bucket every day.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the archive policy. Changing it forces a new archive policy to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validFHIRID(),
+				},
+			},
+			"resource_types": schema.SetAttribute{
+				MarkdownDescription: "Resource types archived by the policy, such as `AuditEvent`",
+				ElementType:         types.StringType,
+				Required:            true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+			"older_than": schema.StringAttribute{
+				MarkdownDescription: "Age, based on the last update of a resource, after which it is archived, such as `8760h` for a year",
+				Required:            true,
+				Validators: []validator.String{
+					validDuration(),
+				},
+			},
+			"at": schema.StringAttribute{
+				MarkdownDescription: "Time of the day the archive runs at, in the `HH:MM` format and the UTC time zone",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(timeOfDayRegexp, "must be a time of the day in the HH:MM format"),
+				},
+			},
+			"version_id": schema.StringAttribute{
+				MarkdownDescription: "Version of the archive policy, incremented by the box on every change",
+				Computed:            true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"storage": schema.SingleNestedBlock{
+				MarkdownDescription: "Cloud storage the archived resources are written to. Required.",
+				Attributes: map[string]schema.Attribute{
+					"backend": schema.StringAttribute{
+						MarkdownDescription: "Cloud storage backend, `aws`, `gcp` or `azure`. Required in this block.",
+						Optional:            true,
+						Validators: []validator.String{
+							stringvalidator.OneOf("aws", "gcp", "azure"),
+						},
+					},
+					"account_id": schema.StringAttribute{
+						MarkdownDescription: "ID of the `AwsAccount`, `GcpServiceAccount` or `AzureAccount` resource, depending on `backend`, the box accesses the bucket with. Required in this block.",
+						Optional:            true,
+					},
+					"bucket": schema.StringAttribute{
+						MarkdownDescription: "Bucket, or Azure container, the archives are written to. Required in this block.",
+						Optional:            true,
+					},
+				},
+				Validators: []validator.Object{
+					objectvalidator.IsRequired(),
+					objectvalidator.AlsoRequires(
+						path.MatchRelative().AtName("backend"),
+						path.MatchRelative().AtName("account_id"),
+						path.MatchRelative().AtName("bucket"),
+					),
+				},
+			},
+		},
+	}
+}
+
+func (r *ArchivePolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var model ArchivePolicyResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stored, err := r.client.PutResource(ctx, archivePolicyToResource(model), "")
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostic("Failed to Create Archive Policy", "Unable to create archive policy", err))
+		return
+	}
+
+	mapArchivePolicyFromResource(&model, stored)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *ArchivePolicyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var model ArchivePolicyResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stored, err := r.client.GetResourceIfModified(ctx, archivePolicyResourceType, model.ID.ValueString(), model.VersionID.ValueString())
+	if aidbox.IsNotModified(err) {
+		return
+	}
+	if aidbox.IsNotFound(err) {
+		tflog.Warn(ctx, "Archive policy not found, removing from state", map[string]interface{}{"id": model.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostic("Failed to Fetch Archive Policy", "Unable to fetch archive policy", err))
+		return
+	}
+
+	mapArchivePolicyFromResource(&model, stored)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *ArchivePolicyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var model ArchivePolicyResourceModel
+	var versionID types.String
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("version_id"), &versionID)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stored, err := r.client.PutResource(ctx, archivePolicyToResource(model), versionID.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(updateErrorDiagnostic("archive policy "+model.ID.ValueString(), "Failed to Update Archive Policy", "Unable to update archive policy", err))
+		return
+	}
+
+	mapArchivePolicyFromResource(&model, stored)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *ArchivePolicyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var model ArchivePolicyResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteResource(ctx, archivePolicyResourceType, model.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(deleteErrorDiagnostic(
+			"archive policy "+model.ID.ValueString(),
+			"Failed to Delete Archive Policy",
+			fmt.Sprintf("Error while trying to delete the archive policy with ID %s", model.ID.ValueString()),
+			err,
+		))
+	}
+}
+
+func (r *ArchivePolicyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+func archivePolicyToResource(model ArchivePolicyResourceModel) aidbox.Resource {
+	policy := aidbox.Resource{
+		"resourceType": archivePolicyResourceType,
+		"id":           model.ID.ValueString(),
+	}
+	setJSONValue(policy, "resource-types", model.ResourceTypes)
+	setJSONValue(policy, "older-than", model.OlderThan)
+	setJSONValue(policy, "at", model.At)
+
+	if model.Storage != nil {
+		storage := map[string]interface{}{}
+		setJSONValue(storage, "backend", model.Storage.Backend)
+		setJSONValue(storage, "bucket", model.Storage.Bucket)
+		if !model.Storage.AccountID.IsNull() {
+			storage["account"] = map[string]interface{}{
+				"resourceType": storageAccountResourceTypes[model.Storage.Backend.ValueString()],
+				"id":           model.Storage.AccountID.ValueString(),
+			}
+		}
+		policy["storage"] = storage
+	}
+
+	return policy
+}
+
+func mapArchivePolicyFromResource(model *ArchivePolicyResourceModel, stored aidbox.Resource) {
+	model.ID = types.StringValue(stored.ID())
+	model.ResourceTypes = jsonStringSet(stored, "resource-types")
+	model.OlderThan = jsonStringValue(stored, "older-than")
+	model.At = jsonStringValue(stored, "at")
+	model.VersionID = stringValueOrNull(stored.VersionID())
+
+	model.Storage = nil
+	if storage := jsonObject(stored, "storage"); storage != nil {
+		model.Storage = &ArchivePolicyStorageModel{
+			Backend:   jsonStringValue(storage, "backend"),
+			AccountID: jsonStringValue(jsonObject(storage, "account"), "id"),
+			Bucket:    jsonStringValue(storage, "bucket"),
+		}
+	}
+}
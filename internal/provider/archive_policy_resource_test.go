@@ -0,0 +1,79 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccAidboxArchivePolicyResource(t *testing.T) {
+	accountID := os.Getenv("AIDBOX_ACC_AWS_ACCOUNT_ID")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheckBox(t)
+			if accountID == "" {
+				t.Skip("AIDBOX_ACC_AWS_ACCOUNT_ID must be set to the ID of an AwsAccount resource of the box to test archive policies")
+			}
+		},
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_5_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccAidboxArchivePolicyResourceConfig(accountID, "8760h"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("aidbox_archive_policy.test", "id", "tf-acc-audit-events"),
+					resource.TestCheckResourceAttr("aidbox_archive_policy.test", "older_than", "8760h"),
+					resource.TestCheckResourceAttr("aidbox_archive_policy.test", "storage.account_id", accountID),
+					resource.TestCheckResourceAttrSet("aidbox_archive_policy.test", "version_id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "aidbox_archive_policy.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Import block testing, as with terraform plan -generate-config-out
+			{
+				ResourceName:    "aidbox_archive_policy.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithID,
+			},
+			// Update and Read testing
+			{
+				Config: testAccAidboxArchivePolicyResourceConfig(accountID, "4380h"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("aidbox_archive_policy.test", "older_than", "4380h"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccAidboxArchivePolicyResourceConfig(accountID, olderThan string) string {
+	return fmt.Sprintf(`
+resource "aidbox_archive_policy" "test" {
+  id             = "tf-acc-audit-events"
+  resource_types = ["AuditEvent"]
+  older_than     = %[2]q
+  at             = "03:00"
+
+  storage {
+    backend    = "aws"
+    account_id = %[1]q
+    bucket     = "tf-acc-archives"
+  }
+}
+`, accountID, olderThan)
+}
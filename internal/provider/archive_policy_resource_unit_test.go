@@ -0,0 +1,48 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox/fake"
+)
+
+func TestArchivePolicyResource_storageAccount(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewBoxClient()
+
+	model := ArchivePolicyResourceModel{
+		ID:            types.StringValue("audit-events"),
+		ResourceTypes: types.SetValueMust(types.StringType, []attr.Value{types.StringValue("AuditEvent")}),
+		OlderThan:     types.StringValue("8760h"),
+		At:            types.StringValue("03:00"),
+		Storage: &ArchivePolicyStorageModel{
+			Backend:   types.StringValue("gcp"),
+			AccountID: types.StringValue("archives"),
+			Bucket:    types.StringValue("box-archives"),
+		},
+	}
+
+	stored, err := client.PutResource(ctx, archivePolicyToResource(model), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	account := jsonObject(jsonObject(stored, "storage"), "account")
+	if account["resourceType"] != "GcpServiceAccount" || account["id"] != "archives" {
+		t.Errorf("expected a reference to the GcpServiceAccount resource, got: %v", account)
+	}
+
+	var got ArchivePolicyResourceModel
+	mapArchivePolicyFromResource(&got, stored)
+	if got.Storage == nil || *got.Storage != *model.Storage {
+		t.Errorf("unexpected storage block: %+v", got.Storage)
+	}
+	if !got.ResourceTypes.Equal(model.ResourceTypes) || got.OlderThan != model.OlderThan || got.At != model.At {
+		t.Errorf("unexpected model: %+v", got)
+	}
+}
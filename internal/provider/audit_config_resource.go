@@ -0,0 +1,211 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox"
+	"regexp"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &AuditConfigResource{}
+var _ resource.ResourceWithImportState = &AuditConfigResource{}
+
+// The audit configuration is stored in the AidboxConfig/audit resource.
+const (
+	auditConfigResourceType = "AidboxConfig"
+	auditConfigID           = "audit"
+)
+
+func NewAuditConfigResource() resource.Resource {
+	return &AuditConfigResource{}
+}
+
+// AuditConfigResource defines the resource implementation.
+type AuditConfigResource struct {
+	boxResource
+}
+
+// AuditConfigResourceModel describes the resource data model.
+type AuditConfigResourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	Enabled           types.Bool   `tfsdk:"enabled"`
+	FHIRAuditEvents   types.Bool   `tfsdk:"fhir_audit_events"`
+	ExcludedEndpoints types.Set    `tfsdk:"excluded_endpoints"`
+	Retention         types.String `tfsdk:"retention"`
+	VersionID         types.String `tfsdk:"version_id"`
+}
+
+func (r *AuditConfigResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_audit_config"
+}
+
+func (r *AuditConfigResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the audit logging configuration of a box. A box has a single audit configuration. " +
+			"Destroying the resource restores the defaults of the box.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the AidboxConfig resource holding the audit configuration, always `audit`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"version_id": schema.StringAttribute{
+				MarkdownDescription: "Version of the audit configuration, incremented by the box on every change",
+				Computed:            true,
+			},
+			"enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether the box logs the requests it serves to its audit log. Defaults to `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"fhir_audit_events": schema.BoolAttribute{
+				MarkdownDescription: "Whether the box also records the requests as FHIR AuditEvent resources, such as for a BALP compliant audit trail. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"excluded_endpoints": schema.SetAttribute{
+				MarkdownDescription: "Endpoints not logged, as a method and a path, such as `GET /health`. The method may be `*` to match every method.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(
+						stringvalidator.RegexMatches(regexp.MustCompile(`^(\*|GET|POST|PUT|PATCH|DELETE) /`), "must be a method followed by a path, such as `GET /health`"),
+					),
+				},
+			},
+			"retention": schema.StringAttribute{
+				MarkdownDescription: "Duration the audit log entries are kept for, such as `8760h`. Without it, entries are kept until they are archived or deleted.",
+				Optional:            true,
+				Validators: []validator.String{
+					validDuration(),
+				},
+			},
+		},
+	}
+}
+
+func (r *AuditConfigResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var model AuditConfigResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.checkConfigurationAbsent(ctx, "audit configuration", "aidbox_audit_config", auditConfigResourceType, auditConfigID)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stored, err := r.client.PutResource(ctx, auditConfigToResource(model), "")
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostic("Failed to Create Audit Configuration", "Unable to configure audit logging", err))
+		return
+	}
+
+	mapAuditConfigFromResource(&model, stored)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *AuditConfigResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var model AuditConfigResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stored, err := r.client.GetResource(ctx, auditConfigResourceType, auditConfigID)
+	if aidbox.IsNotFound(err) {
+		tflog.Warn(ctx, "Audit configuration not found, removing from state")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostic("Failed to Fetch Audit Configuration", "Unable to fetch the audit configuration", err))
+		return
+	}
+
+	mapAuditConfigFromResource(&model, stored)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *AuditConfigResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var model AuditConfigResourceModel
+	var versionID types.String
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("version_id"), &versionID)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stored, err := r.client.PutResource(ctx, auditConfigToResource(model), versionID.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(updateErrorDiagnostic("audit configuration", "Failed to Update Audit Configuration", "Unable to configure audit logging", err))
+		return
+	}
+
+	mapAuditConfigFromResource(&model, stored)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *AuditConfigResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	err := r.client.DeleteResource(ctx, auditConfigResourceType, auditConfigID)
+	if err != nil {
+		resp.Diagnostics.Append(deleteErrorDiagnostic("audit configuration", "Failed to Delete Audit Configuration", "Unable to restore the default audit configuration", err))
+	}
+}
+
+func (r *AuditConfigResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID != auditConfigID {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("The audit configuration of a box is imported with the ID %q, got: %q", auditConfigID, req.ID),
+		)
+		return
+	}
+
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+func auditConfigToResource(model AuditConfigResourceModel) aidbox.Resource {
+	audit := map[string]interface{}{}
+	setJSONValue(audit, "enabled", model.Enabled)
+	setJSONValue(audit, "fhir-audit-events", model.FHIRAuditEvents)
+	setJSONValue(audit, "excluded-endpoints", model.ExcludedEndpoints)
+	setJSONValue(audit, "retention", model.Retention)
+
+	return aidbox.Resource{
+		"resourceType": auditConfigResourceType,
+		"id":           auditConfigID,
+		"audit":        audit,
+	}
+}
+
+func mapAuditConfigFromResource(model *AuditConfigResourceModel, stored aidbox.Resource) {
+	audit := jsonObject(stored, "audit")
+
+	model.ID = types.StringValue(auditConfigID)
+	model.VersionID = stringValueOrNull(stored.VersionID())
+	model.Enabled = types.BoolValue(jsonBoolValue(audit, "enabled").ValueBool())
+	model.FHIRAuditEvents = types.BoolValue(jsonBoolValue(audit, "fhir-audit-events").ValueBool())
+	model.ExcludedEndpoints = jsonStringSet(audit, "excluded-endpoints")
+	model.Retention = jsonStringValue(audit, "retention")
+}
@@ -0,0 +1,67 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccAidboxAuditConfigResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheckBox(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_5_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccAidboxAuditConfigResourceConfig(false, "8760h"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("aidbox_audit_config.test", "id", "audit"),
+					resource.TestCheckResourceAttr("aidbox_audit_config.test", "enabled", "true"),
+					resource.TestCheckResourceAttr("aidbox_audit_config.test", "fhir_audit_events", "false"),
+					resource.TestCheckResourceAttr("aidbox_audit_config.test", "excluded_endpoints.#", "1"),
+					resource.TestCheckTypeSetElemAttr("aidbox_audit_config.test", "excluded_endpoints.*", "GET /health"),
+					resource.TestCheckResourceAttr("aidbox_audit_config.test", "retention", "8760h"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "aidbox_audit_config.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Import block testing, as with terraform plan -generate-config-out
+			{
+				ResourceName:    "aidbox_audit_config.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithID,
+			},
+			// Update and Read testing
+			{
+				Config: testAccAidboxAuditConfigResourceConfig(true, "17520h"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("aidbox_audit_config.test", "fhir_audit_events", "true"),
+					resource.TestCheckResourceAttr("aidbox_audit_config.test", "retention", "17520h"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccAidboxAuditConfigResourceConfig(fhirAuditEvents bool, retention string) string {
+	return fmt.Sprintf(`
+resource "aidbox_audit_config" "test" {
+  fhir_audit_events  = %[1]t
+  excluded_endpoints = ["GET /health"]
+  retention          = %[2]q
+}
+`, fhirAuditEvents, retention)
+}
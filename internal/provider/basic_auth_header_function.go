@@ -0,0 +1,61 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"strings"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &BasicAuthHeaderFunction{}
+
+func NewBasicAuthHeaderFunction() function.Function {
+	return &BasicAuthHeaderFunction{}
+}
+
+// BasicAuthHeaderFunction defines the function implementation.
+type BasicAuthHeaderFunction struct{}
+
+func (f *BasicAuthHeaderFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "basic_auth_header"
+}
+
+func (f *BasicAuthHeaderFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Build a Basic Authorization header value",
+		MarkdownDescription: "Returns the `Basic` Authorization header value for a client id and secret, as accepted by Aidbox for clients with the `basic` grant type.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "client_id",
+				MarkdownDescription: "Client id",
+			},
+			function.StringParameter{
+				Name:                "client_secret",
+				MarkdownDescription: "Client secret",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *BasicAuthHeaderFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var clientID, clientSecret string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &clientID, &clientSecret))
+	if resp.Error != nil {
+		return
+	}
+
+	// RFC 7617 does not allow a colon in the user-id.
+	if strings.Contains(clientID, ":") {
+		resp.Error = function.NewArgumentFuncError(0, "client_id must not contain a colon")
+		return
+	}
+
+	credentials := base64.StdEncoding.EncodeToString([]byte(clientID + ":" + clientSecret))
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, "Basic "+credentials))
+}
@@ -0,0 +1,40 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccBasicAuthHeaderFunction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "test" {
+  value = provider::aidbox::basic_auth_header("basic-client", "secret")
+}
+`,
+				Check: resource.TestCheckOutput("test", "Basic YmFzaWMtY2xpZW50OnNlY3JldA=="),
+			},
+			{
+				Config: `
+output "test" {
+  value = provider::aidbox::basic_auth_header("basic:client", "secret")
+}
+`,
+				ExpectError: regexp.MustCompile(`client_id must not contain a colon`),
+			},
+		},
+	})
+}
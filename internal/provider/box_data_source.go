@@ -0,0 +1,68 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// boxDataSource is embedded by data sources reading the configuration of an
+// Aidbox box, to share the configuration of the box client.
+type boxDataSource struct {
+	client BoxClient
+
+	// metadata is nil when the provider data doesn't cache the capability
+	// statement of the box.
+	metadata *boxMetadata
+}
+
+func (d *boxDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*ProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	if data.BoxClient == nil {
+		resp.Diagnostics.AddError(
+			"Box Not Configured",
+			"This data source reads the configuration of an Aidbox box. Please provide 'box_url', 'box_client_id' and 'box_client_secret' in the provider configuration or through the 'AIDBOX_BOX_URL', 'AIDBOX_BOX_CLIENT_ID' and 'AIDBOX_BOX_CLIENT_SECRET' environment variables.",
+		)
+
+		return
+	}
+
+	d.client = data.BoxClient
+	d.metadata = data.BoxMetadata
+}
+
+// operationNotSupported reports whether the capability statement of the box
+// doesn't declare the operation name of resourceType, such as to tell a
+// missing operation apart from a missing resource. It is false when the
+// capability statement can't be read.
+func (d *boxDataSource) operationNotSupported(ctx context.Context, resourceType, name string) bool {
+	if d.metadata == nil {
+		return false
+	}
+
+	supported, err := d.metadata.supportsOperation(ctx, resourceType, name)
+	if err != nil {
+		tflog.Debug(ctx, "Failed to read the capability statement of the box", map[string]interface{}{"error": err.Error()})
+		return false
+	}
+	return !supported
+}
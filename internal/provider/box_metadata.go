@@ -0,0 +1,84 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox"
+	"sync"
+	"time"
+)
+
+const (
+	// boxMetadataPath is the path of the capability statement of the box.
+	boxMetadataPath = "/fhir/metadata"
+	// boxMetadataTTL is how long the capability statement is cached, long
+	// enough for a plan while still picking up the changes of a long apply.
+	boxMetadataTTL = 5 * time.Minute
+)
+
+// boxMetadata caches the capability statement of the box, so that the data
+// sources of a provider instance read it at most once per TTL.
+type boxMetadata struct {
+	client BoxClient
+	ttl    time.Duration
+
+	mu        sync.Mutex
+	statement aidbox.Resource
+	expires   time.Time
+}
+
+func newBoxMetadata(client BoxClient, ttl time.Duration) *boxMetadata {
+	return &boxMetadata{client: client, ttl: ttl}
+}
+
+// capabilityStatement returns the capability statement of the box, reading
+// it again once the cached one expired. Errors are not cached.
+func (m *boxMetadata) capabilityStatement(ctx context.Context) (aidbox.Resource, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.statement != nil && time.Now().Before(m.expires) {
+		return m.statement, nil
+	}
+
+	statement, err := m.client.RunOperation(ctx, boxMetadataPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	m.statement, m.expires = statement, time.Now().Add(m.ttl)
+
+	return statement, nil
+}
+
+// supportsOperation reports whether the capability statement declares the
+// operation name, such as `expand`, for resourceType.
+func (m *boxMetadata) supportsOperation(ctx context.Context, resourceType, name string) (bool, error) {
+	statement, err := m.capabilityStatement(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	rests, _ := statement["rest"].([]interface{})
+	for _, entry := range rests {
+		rest, _ := entry.(map[string]interface{})
+		resources, _ := rest["resource"].([]interface{})
+		for _, entry := range resources {
+			resource, _ := entry.(map[string]interface{})
+			if resource["type"] != resourceType {
+				continue
+			}
+
+			operations, _ := resource["operation"].([]interface{})
+			for _, entry := range operations {
+				operation, _ := entry.(map[string]interface{})
+				if operation["name"] == name {
+					return true, nil
+				}
+			}
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,69 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox/fake"
+)
+
+func TestBoxMetadata(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewBoxClient()
+
+	reads := 0
+	client.HandleOperation(boxMetadataPath, func(params url.Values) (aidbox.Resource, error) {
+		reads++
+		return aidbox.Resource{
+			"resourceType": "CapabilityStatement",
+			"rest": []interface{}{
+				map[string]interface{}{
+					"mode": "server",
+					"resource": []interface{}{
+						map[string]interface{}{"type": "ValueSet", "operation": []interface{}{
+							map[string]interface{}{"name": "expand", "definition": "http://hl7.org/fhir/OperationDefinition/ValueSet-expand"},
+						}},
+						map[string]interface{}{"type": "CodeSystem"},
+					},
+				},
+			},
+		}, nil
+	})
+
+	metadata := newBoxMetadata(client, time.Hour)
+	for _, testCase := range []struct {
+		resourceType, name string
+		supported          bool
+	}{
+		{"ValueSet", "expand", true},
+		{"CodeSystem", "lookup", false},
+		{"Patient", "expand", false},
+	} {
+		supported, err := metadata.supportsOperation(ctx, testCase.resourceType, testCase.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if supported != testCase.supported {
+			t.Errorf("expected %s/$%s to be supported to be %t", testCase.resourceType, testCase.name, testCase.supported)
+		}
+	}
+	if reads != 1 {
+		t.Errorf("expected the capability statement to be read once, got: %d reads", reads)
+	}
+
+	expired := newBoxMetadata(client, 0)
+	for i := 0; i < 2; i++ {
+		if _, err := expired.capabilityStatement(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if reads != 3 {
+		t.Errorf("expected an expired capability statement to be read again, got: %d reads", reads)
+	}
+}
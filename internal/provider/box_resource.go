@@ -0,0 +1,74 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox"
+)
+
+// boxResource is embedded by resources managing the configuration of an
+// Aidbox box, to share the configuration of the box client.
+type boxResource struct {
+	client BoxClient
+
+	// metadata is nil when the provider data doesn't cache the capability
+	// statement of the box.
+	metadata *boxMetadata
+}
+
+func (r *boxResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*ProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	if data.BoxClient == nil {
+		resp.Diagnostics.AddError(
+			"Box Not Configured",
+			"This resource manages the configuration of an Aidbox box. Please provide 'box_url', 'box_client_id' and 'box_client_secret' in the provider configuration or through the 'AIDBOX_BOX_URL', 'AIDBOX_BOX_CLIENT_ID' and 'AIDBOX_BOX_CLIENT_SECRET' environment variables.",
+		)
+
+		return
+	}
+
+	r.client = data.BoxClient
+	r.metadata = data.BoxMetadata
+}
+
+// checkConfigurationAbsent fails the creation of a resource holding a
+// box-wide configuration when the box already has one, as creating the
+// resource would replace it without the plan showing what changes. typeName
+// is the Terraform type of the resource, such as aidbox_audit_config, and id
+// the ID of the AidboxConfig resource, which is also the import ID.
+func (r *boxResource) checkConfigurationAbsent(ctx context.Context, object, typeName, resourceType, id string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	_, err := r.client.GetResource(ctx, resourceType, id)
+	switch {
+	case err == nil:
+		diags.AddError(
+			"Configuration Already Exists",
+			fmt.Sprintf("The %s already exists on the box. Import it with `terraform import %s.<name> %s` to manage it with Terraform.", object, typeName, id),
+		)
+	case !aidbox.IsNotFound(err):
+		diags.Append(apiErrorDiagnostic("Failed to Fetch Configuration", fmt.Sprintf("Unable to check whether the %s already exists", object), err))
+	}
+
+	return diags
+}
@@ -0,0 +1,41 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox/fake"
+)
+
+// Ensure the fake box client satisfies the box client interface of the
+// provider.
+var _ BoxClient = &fake.BoxClient{}
+
+func TestBoxResourceConfigure_boxNotConfigured(t *testing.T) {
+	var r boxResource
+	var resp resource.ConfigureResponse
+	r.Configure(context.Background(), resource.ConfigureRequest{ProviderData: &ProviderData{Client: fake.NewClient()}}, &resp)
+
+	if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != "Box Not Configured" {
+		t.Fatalf("expected box not configured error, got: %v", resp.Diagnostics)
+	}
+}
+
+func TestBoxResourceConfigure(t *testing.T) {
+	client := fake.NewBoxClient()
+
+	var r boxResource
+	var resp resource.ConfigureResponse
+	r.Configure(context.Background(), resource.ConfigureRequest{ProviderData: &ProviderData{BoxClient: client}}, &resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	if r.client != client {
+		t.Errorf("expected the box client to be configured")
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"strconv"
+	"strings"
+)
+
+// settingsAPIVersion is the first release of Aidbox with the settings API.
+// Older boxes are configured through environment variables.
+const settingsAPIVersion = "2408"
+
+// version returns the version of Aidbox the box runs, as reported by its
+// capability statement, such as `2502.1`, or an empty string when the box
+// doesn't report it.
+func (m *boxMetadata) version(ctx context.Context) (string, error) {
+	statement, err := m.capabilityStatement(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	software, _ := statement["software"].(map[string]interface{})
+	version, _ := software["version"].(string)
+	return version, nil
+}
+
+// requireBoxVersion returns an error when the box runs a version of Aidbox
+// older than minimum, which feature requires. A box whose version can't be
+// determined, such as one running an edge build, is assumed to be recent
+// enough, so that the API call itself reports what is missing.
+func requireBoxVersion(ctx context.Context, metadata *boxMetadata, feature, minimum string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if metadata == nil {
+		return diags
+	}
+
+	version, err := metadata.version(ctx)
+	if err != nil {
+		tflog.Debug(ctx, "Failed to read the capability statement of the box", map[string]interface{}{"error": err.Error()})
+		return diags
+	}
+	if compareBoxVersions(version, minimum) >= 0 {
+		return diags
+	}
+
+	diags.AddError(
+		"Unsupported Aidbox Version",
+		fmt.Sprintf("%s requires Aidbox %s or later, the box runs Aidbox %s. Please upgrade the box.", feature, minimum, version),
+	)
+	return diags
+}
+
+// compareBoxVersions compares the dot separated numeric versions a and b,
+// returning -1, 0 or 1. Versions which aren't numeric, such as `edge`, are
+// greater than any other.
+func compareBoxVersions(a, b string) int {
+	aParts, aOK := parseBoxVersion(a)
+	bParts, bOK := parseBoxVersion(b)
+	switch {
+	case !aOK && !bOK:
+		return 0
+	case !aOK:
+		return 1
+	case !bOK:
+		return -1
+	}
+
+	for i := 0; i < max(len(aParts), len(bParts)); i++ {
+		var aPart, bPart int
+		if i < len(aParts) {
+			aPart = aParts[i]
+		}
+		if i < len(bParts) {
+			bPart = bParts[i]
+		}
+		if aPart != bPart {
+			if aPart < bPart {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// parseBoxVersion returns the numeric parts of version, ignoring a suffix
+// such as `-rc1`.
+func parseBoxVersion(version string) ([]int, bool) {
+	version, _, _ = strings.Cut(version, "-")
+	if version == "" {
+		return nil, false
+	}
+
+	var parts []int
+	for _, field := range strings.Split(version, ".") {
+		part, err := strconv.Atoi(field)
+		if err != nil {
+			return nil, false
+		}
+		parts = append(parts, part)
+	}
+	return parts, true
+}
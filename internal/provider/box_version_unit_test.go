@@ -0,0 +1,64 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox/fake"
+)
+
+func TestCompareBoxVersions(t *testing.T) {
+	for _, testCase := range []struct {
+		a, b     string
+		expected int
+	}{
+		{"2408", "2408", 0},
+		{"2408.0", "2408", 0},
+		{"2407.3", "2408", -1},
+		{"2502.1", "2408", 1},
+		{"2408.1-rc1", "2408.1", 0},
+		{"edge", "2408", 1},
+		{"", "2408", 1},
+	} {
+		if got := compareBoxVersions(testCase.a, testCase.b); got != testCase.expected {
+			t.Errorf("expected %q compared to %q to be %d, got: %d", testCase.a, testCase.b, testCase.expected, got)
+		}
+	}
+}
+
+func TestRequireBoxVersion(t *testing.T) {
+	ctx := context.Background()
+
+	metadataWithVersion := func(version string) *boxMetadata {
+		client := fake.NewBoxClient()
+		client.HandleOperation(boxMetadataPath, func(params url.Values) (aidbox.Resource, error) {
+			return aidbox.Resource{
+				"resourceType": "CapabilityStatement",
+				"software":     map[string]interface{}{"name": "Aidbox", "version": version},
+			}, nil
+		})
+		return newBoxMetadata(client, time.Hour)
+	}
+
+	if diags := requireBoxVersion(ctx, metadataWithVersion("2502.1"), "The aidbox_setting resource", settingsAPIVersion); diags.HasError() {
+		t.Errorf("unexpected diagnostics for a recent box: %v", diags)
+	}
+	if diags := requireBoxVersion(ctx, nil, "The aidbox_setting resource", settingsAPIVersion); diags.HasError() {
+		t.Errorf("unexpected diagnostics without metadata: %v", diags)
+	}
+
+	diags := requireBoxVersion(ctx, metadataWithVersion("2312.2"), "The aidbox_setting resource", settingsAPIVersion)
+	if !diags.HasError() || diags.Errors()[0].Summary() != "Unsupported Aidbox Version" {
+		t.Fatalf("expected an unsupported version error, got: %v", diags)
+	}
+	if detail := diags.Errors()[0].Detail(); !strings.Contains(detail, "requires Aidbox 2408 or later") || !strings.Contains(detail, "2312.2") {
+		t.Errorf("unexpected detail: %s", detail)
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox"
+	"net/url"
+)
+
+// CheckReport is the result of the self-check of the credentials the
+// provider reads from the environment.
+type CheckReport struct {
+	// Error is set when the provider can't be configured from the
+	// environment, such as without any credentials.
+	Error  string       `json:"error,omitempty"`
+	Portal *CheckResult `json:"portal,omitempty"`
+	Box    *CheckResult `json:"box,omitempty"`
+}
+
+// CheckResult is the result of an authenticated call to the portal or the
+// box.
+type CheckResult struct {
+	URL string `json:"url"`
+	OK  bool   `json:"ok"`
+	// Version is the Aidbox version of the box, when it reports it.
+	Version string `json:"version,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// OK reports whether the provider could be configured and every configured
+// API accepted its credentials.
+func (r CheckReport) OK() bool {
+	return r.Error == "" && (r.Portal == nil || r.Portal.OK) && (r.Box == nil || r.Box.OK)
+}
+
+// Check configures the provider from the environment variables, as Terraform
+// does with an empty provider block, then makes an authenticated call that
+// changes nothing to the portal, when a token is set, and to the box, when a
+// box URL is set.
+func Check(ctx context.Context, version string) CheckReport {
+	var report CheckReport
+
+	data, diags := configureFromEnvironment(ctx, version)
+	if diags.HasError() {
+		report.Error = diagnosticsError(diags).Error()
+		return report
+	}
+
+	if data.Token != "" {
+		report.Portal = &CheckResult{URL: data.Endpoint, OK: true}
+		if diags := validateCredentials(ctx, data); diags.HasError() {
+			report.Portal.OK = false
+			report.Portal.Error = diagnosticsError(diags).Error()
+		}
+	}
+
+	if box, ok := data.BoxClient.(*aidbox.BoxClient); ok {
+		report.Box = &CheckResult{URL: box.URL, OK: true}
+		_, err := box.SearchResources(ctx, "Client", url.Values{"_count": {"1"}, "_elements": {"id"}}, 1)
+		if err != nil {
+			report.Box.OK = false
+			report.Box.Error = checkError(err)
+		} else if version, err := data.BoxMetadata.version(ctx); err == nil {
+			report.Box.Version = version
+		}
+	}
+
+	return report
+}
+
+// configureFromEnvironment configures the provider with an empty provider
+// block, so that every setting is read from its environment variable.
+func configureFromEnvironment(ctx context.Context, version string) (*ProviderData, diag.Diagnostics) {
+	p := New(version)()
+
+	var schemaResp provider.SchemaResponse
+	p.Schema(ctx, provider.SchemaRequest{}, &schemaResp)
+	configType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	attributes := make(map[string]tftypes.Value, len(configType.AttributeTypes))
+	for name, attributeType := range configType.AttributeTypes {
+		attributes[name] = tftypes.NewValue(attributeType, nil)
+	}
+
+	var resp provider.ConfigureResponse
+	p.Configure(ctx, provider.ConfigureRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(configType, attributes)},
+	}, &resp)
+	if resp.Diagnostics.HasError() {
+		return nil, resp.Diagnostics
+	}
+
+	data, ok := resp.ResourceData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Provider Data", fmt.Sprintf("Expected *ProviderData, got: %T", resp.ResourceData))
+		return nil, resp.Diagnostics
+	}
+	return data, resp.Diagnostics
+}
+
+// checkError describes err with the details of API errors.
+func checkError(err error) string {
+	var apiErr *aidbox.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Details()
+	}
+	return err.Error()
+}
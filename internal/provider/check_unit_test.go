@@ -0,0 +1,73 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox/aidboxtest"
+)
+
+func TestCheck(t *testing.T) {
+	portal := aidboxtest.NewPortalServer("token")
+	defer portal.Close()
+
+	box := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, password, ok := r.BasicAuth(); !ok || user != "root" || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/Client":
+			fmt.Fprint(w, `{"resourceType": "Bundle", "entry": []}`)
+		case boxMetadataPath:
+			fmt.Fprint(w, `{"resourceType": "CapabilityStatement", "software": {"name": "Aidbox", "version": "2502.1"}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer box.Close()
+
+	for _, name := range []string{"AIDBOX_PORTAL_RPC_PATH", "AIDBOX_PORTAL_RPC_NAMESPACE", "AIDBOX_BOX_TOKEN_CACHE_DIR"} {
+		t.Setenv(name, "")
+	}
+	t.Setenv("AIDBOX_ENDPOINT", portal.Endpoint())
+	t.Setenv("AIDBOX_API_TOKEN", "token")
+	t.Setenv("AIDBOX_BOX_URL", box.URL)
+	t.Setenv("AIDBOX_BOX_CLIENT_ID", "root")
+	t.Setenv("AIDBOX_BOX_CLIENT_SECRET", "secret")
+
+	report := Check(context.Background(), "test")
+	if !report.OK() {
+		t.Fatalf("unexpected failed check: %+v", report)
+	}
+	if report.Portal == nil || report.Portal.URL != portal.Endpoint() {
+		t.Errorf("unexpected portal result: %+v", report.Portal)
+	}
+	if report.Box == nil || report.Box.URL != box.URL || report.Box.Version != "2502.1" {
+		t.Errorf("unexpected box result: %+v", report.Box)
+	}
+
+	t.Setenv("AIDBOX_API_TOKEN", "invalid")
+	t.Setenv("AIDBOX_BOX_CLIENT_SECRET", "invalid")
+	report = Check(context.Background(), "test")
+	if report.OK() || report.Portal.OK || report.Box.OK {
+		t.Fatalf("expected failed checks, got: %+v", report)
+	}
+	if !strings.Contains(report.Portal.Error, "Invalid API Token") || !strings.Contains(report.Box.Error, "401") {
+		t.Errorf("unexpected errors: %q, %q", report.Portal.Error, report.Box.Error)
+	}
+
+	t.Setenv("AIDBOX_API_TOKEN", "")
+	t.Setenv("AIDBOX_BOX_URL", "")
+	report = Check(context.Background(), "test")
+	if report.OK() || !strings.Contains(report.Error, "No API Token Provided") {
+		t.Errorf("expected a configuration error, got: %+v", report)
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ClientDataSource{}
+var _ datasource.DataSourceWithConfigure = &ClientDataSource{}
+
+const clientResourceType = "Client"
+
+func NewClientDataSource() datasource.DataSource {
+	return &ClientDataSource{}
+}
+
+// ClientDataSource defines the data source implementation.
+type ClientDataSource struct {
+	boxDataSource
+}
+
+// ClientDataSourceModel describes the data source data model.
+type ClientDataSourceModel struct {
+	ID         types.String `tfsdk:"id"`
+	Type       types.String `tfsdk:"type"`
+	GrantTypes types.Set    `tfsdk:"grant_types"`
+	Scopes     types.Set    `tfsdk:"scopes"`
+	FirstParty types.Bool   `tfsdk:"first_party"`
+	VersionID  types.String `tfsdk:"version_id"`
+}
+
+func (d *ClientDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_client"
+}
+
+func (d *ClientDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads an OAuth Client of the box, such as a client the box creates on startup. The secret of the client is never read.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the Client, its `client_id`",
+				Required:            true,
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Type of the Client, such as `smart-app`",
+				Computed:            true,
+			},
+			"grant_types": schema.SetAttribute{
+				MarkdownDescription: "OAuth grant types the Client may use, such as `client_credentials` or `authorization_code`",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"scopes": schema.SetAttribute{
+				MarkdownDescription: "Scopes the Client may request",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"first_party": schema.BoolAttribute{
+				MarkdownDescription: "Whether the Client is a first party client, which users are not asked to authorize",
+				Computed:            true,
+			},
+			"version_id": schema.StringAttribute{
+				MarkdownDescription: "Version of the Client, incremented by the box on every change",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *ClientDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model ClientDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stored, err := d.client.GetResource(ctx, clientResourceType, model.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostic(
+			"Failed to Fetch Client",
+			fmt.Sprintf("Unable to fetch the client with ID %s", model.ID.ValueString()),
+			err,
+		))
+		return
+	}
+
+	mapClientFromResource(&model, stored)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+// mapClientFromResource maps the Client resource stored by the box to model,
+// leaving out its secret.
+func mapClientFromResource(model *ClientDataSourceModel, stored aidbox.Resource) {
+	model.ID = types.StringValue(stored.ID())
+	model.Type = jsonStringValue(stored, "type")
+	model.GrantTypes = jsonStringSet(stored, "grant_types")
+	model.Scopes = jsonStringSet(stored, "scope")
+	model.FirstParty = types.BoolValue(jsonBoolValue(stored, "first_party").ValueBool())
+	model.VersionID = stringValueOrNull(stored.VersionID())
+}
@@ -0,0 +1,49 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccAidboxClientDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckBox(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccAidboxClientDataSourceConfig("tf-acc-client"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.aidbox_client.test", "id", "tf-acc-client"),
+					resource.TestCheckResourceAttr("data.aidbox_client.test", "type", "smart-app"),
+					resource.TestCheckResourceAttr("data.aidbox_client.test", "grant_types.#", "1"),
+					resource.TestCheckTypeSetElemAttr("data.aidbox_client.test", "grant_types.*", "authorization_code"),
+					resource.TestCheckTypeSetElemAttr("data.aidbox_client.test", "scopes.*", "launch"),
+					resource.TestCheckResourceAttr("data.aidbox_client.test", "first_party", "false"),
+					resource.TestCheckResourceAttrPair("data.aidbox_client.test", "version_id", "aidbox_smart_app.test", "version_id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccAidboxClientDataSourceConfig(id string) string {
+	return fmt.Sprintf(`
+resource "aidbox_smart_app" "test" {
+  id           = %[1]q
+  name         = "Client"
+  launch_uri   = "https://apps.example.com/launch"
+  redirect_uri = "https://apps.example.com/"
+  scopes       = ["launch", "openid"]
+}
+
+data "aidbox_client" "test" {
+  id = aidbox_smart_app.test.id
+}
+`, id)
+}
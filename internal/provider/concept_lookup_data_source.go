@@ -0,0 +1,195 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ConceptLookupDataSource{}
+var _ datasource.DataSourceWithConfigure = &ConceptLookupDataSource{}
+
+const codeSystemLookupOperation = "/CodeSystem/$lookup"
+
+func NewConceptLookupDataSource() datasource.DataSource {
+	return &ConceptLookupDataSource{}
+}
+
+// ConceptLookupDataSource defines the data source implementation.
+type ConceptLookupDataSource struct {
+	boxDataSource
+}
+
+// ConceptLookupDataSourceModel describes the data source data model.
+type ConceptLookupDataSourceModel struct {
+	System     types.String                           `tfsdk:"system"`
+	Code       types.String                           `tfsdk:"code"`
+	Name       types.String                           `tfsdk:"name"`
+	Version    types.String                           `tfsdk:"version"`
+	Display    types.String                           `tfsdk:"display"`
+	Properties []ConceptLookupDataSourcePropertyModel `tfsdk:"properties"`
+}
+
+// ConceptLookupDataSourcePropertyModel describes a property of the
+// properties attribute.
+type ConceptLookupDataSourcePropertyModel struct {
+	Code  types.String `tfsdk:"code"`
+	Value types.String `tfsdk:"value"`
+}
+
+func (d *ConceptLookupDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_concept_lookup"
+}
+
+func (d *ConceptLookupDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Looks up a code in a code system with the `$lookup` operation of the box. Reading fails when the code system doesn't define the code, which catches invalid codes in seed data at plan time.",
+		Attributes: map[string]schema.Attribute{
+			"system": schema.StringAttribute{
+				MarkdownDescription: "Canonical URL of the code system, such as `http://loinc.org`",
+				Required:            true,
+			},
+			"code": schema.StringAttribute{
+				MarkdownDescription: "Code to look up, such as `8867-4`",
+				Required:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the code system",
+				Computed:            true,
+			},
+			"version": schema.StringAttribute{
+				MarkdownDescription: "Version of the code system the code was found in",
+				Computed:            true,
+			},
+			"display": schema.StringAttribute{
+				MarkdownDescription: "Display of the code",
+				Computed:            true,
+			},
+			"properties": schema.ListAttribute{
+				MarkdownDescription: "Properties of the code, in the order returned by the box. Each property has a `code` and a `value`. A property with several values, such as `parent`, is repeated.",
+				ElementType: types.ObjectType{
+					AttrTypes: map[string]attr.Type{
+						"code":  types.StringType,
+						"value": types.StringType,
+					},
+				},
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (d *ConceptLookupDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model ConceptLookupDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := url.Values{
+		"system": {model.System.ValueString()},
+		"code":   {model.Code.ValueString()},
+	}
+	result, err := d.client.RunOperation(ctx, codeSystemLookupOperation, params)
+	if aidbox.IsNotFound(err) && d.operationNotSupported(ctx, "CodeSystem", "lookup") {
+		resp.Diagnostics.AddError(
+			"Operation Not Supported",
+			"The box doesn't support the CodeSystem/$lookup operation. Please check that its terminology service is enabled.",
+		)
+		return
+	}
+	if aidbox.IsNotFound(err) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("code"),
+			"Concept Not Found",
+			fmt.Sprintf("The code system %s doesn't define the code %q.", model.System.ValueString(), model.Code.ValueString()),
+		)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostic(
+			"Failed to Look Up Concept",
+			fmt.Sprintf("Unable to look up the code %q in the code system %s", model.Code.ValueString(), model.System.ValueString()),
+			err,
+		))
+		return
+	}
+
+	mapConceptLookup(&model, result)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+// mapConceptLookup maps the Parameters resource returned by $lookup to model.
+func mapConceptLookup(model *ConceptLookupDataSourceModel, result aidbox.Resource) {
+	model.Name = types.StringNull()
+	model.Version = types.StringNull()
+	model.Display = types.StringNull()
+	model.Properties = []ConceptLookupDataSourcePropertyModel{}
+
+	parameters, _ := result["parameter"].([]interface{})
+	for _, entry := range parameters {
+		parameter, ok := entry.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		switch parameter["name"] {
+		case "name":
+			model.Name = parameterValue(parameter)
+		case "version":
+			model.Version = parameterValue(parameter)
+		case "display":
+			model.Display = parameterValue(parameter)
+		case "property":
+			property := ConceptLookupDataSourcePropertyModel{Code: types.StringNull(), Value: types.StringNull()}
+			parts, _ := parameter["part"].([]interface{})
+			for _, entry := range parts {
+				part, ok := entry.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				switch part["name"] {
+				case "code":
+					property.Code = parameterValue(part)
+				case "value":
+					property.Value = parameterValue(part)
+				}
+			}
+			model.Properties = append(model.Properties, property)
+		}
+	}
+}
+
+// parameterValue returns the value[x] of a parameter as a string. Codings
+// are returned as their code.
+func parameterValue(parameter map[string]interface{}) types.String {
+	for key, value := range parameter {
+		if !strings.HasPrefix(key, "value") {
+			continue
+		}
+
+		switch value := value.(type) {
+		case string:
+			return types.StringValue(value)
+		case bool:
+			return types.StringValue(strconv.FormatBool(value))
+		case float64:
+			return types.StringValue(strconv.FormatFloat(value, 'f', -1, 64))
+		case map[string]interface{}:
+			return jsonStringValue(value, "code")
+		}
+	}
+	return types.StringNull()
+}
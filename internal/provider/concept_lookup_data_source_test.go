@@ -0,0 +1,43 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccAidboxConceptLookupDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckBox(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccAidboxConceptLookupDataSourceConfig("female"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.aidbox_concept_lookup.test", "display", "Female"),
+					resource.TestCheckResourceAttrSet("data.aidbox_concept_lookup.test", "properties.#"),
+				),
+			},
+			// Unknown code testing
+			{
+				Config:      testAccAidboxConceptLookupDataSourceConfig("woman"),
+				ExpectError: regexp.MustCompile(`Concept Not Found`),
+			},
+		},
+	})
+}
+
+func testAccAidboxConceptLookupDataSourceConfig(code string) string {
+	return fmt.Sprintf(`
+data "aidbox_concept_lookup" "test" {
+  system = "http://hl7.org/fhir/administrative-gender"
+  code   = %[1]q
+}
+`, code)
+}
@@ -0,0 +1,44 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox"
+)
+
+func TestMapConceptLookup(t *testing.T) {
+	var model ConceptLookupDataSourceModel
+	mapConceptLookup(&model, aidbox.Resource{
+		"resourceType": "Parameters",
+		"parameter": []interface{}{
+			map[string]interface{}{"name": "name", "valueString": "LOINC"},
+			map[string]interface{}{"name": "version", "valueString": "2.77"},
+			map[string]interface{}{"name": "display", "valueString": "Heart rate"},
+			map[string]interface{}{"name": "property", "part": []interface{}{
+				map[string]interface{}{"name": "code", "valueCode": "COMPONENT"},
+				map[string]interface{}{"name": "value", "valueCoding": map[string]interface{}{"system": "http://loinc.org", "code": "LP415671-9"}},
+			}},
+			map[string]interface{}{"name": "property", "part": []interface{}{
+				map[string]interface{}{"name": "code", "valueCode": "inactive"},
+				map[string]interface{}{"name": "value", "valueBoolean": false},
+			}},
+		},
+	})
+
+	if model.Name.ValueString() != "LOINC" || model.Version.ValueString() != "2.77" || model.Display.ValueString() != "Heart rate" {
+		t.Errorf("unexpected model: %+v", model)
+	}
+
+	expected := []string{"COMPONENT=LP415671-9", "inactive=false"}
+	if len(model.Properties) != len(expected) {
+		t.Fatalf("expected properties %v, got: %+v", expected, model.Properties)
+	}
+	for i, property := range model.Properties {
+		if got := property.Code.ValueString() + "=" + property.Value.ValueString(); got != expected[i] {
+			t.Errorf("expected property %s at %d, got: %s", expected[i], i, got)
+		}
+	}
+}
@@ -0,0 +1,277 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/petalmd/terraform-provider-aidbox/internal/fhir"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox"
+	"regexp"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ConsentPolicyResource{}
+var _ resource.ResourceWithImportState = &ConsentPolicyResource{}
+
+// Consent policies are stored as AccessPolicy resources evaluated by the
+// consent engine of the box.
+const (
+	consentPolicyResourceType = "AccessPolicy"
+	consentPolicyEngine       = "consent"
+)
+
+// fhirTokenRegexp matches a FHIR code with its system, in the token search
+// parameter format.
+var fhirTokenRegexp = regexp.MustCompile(`^[^|\s]+\|[^|\s]+$`)
+
+func NewConsentPolicyResource() resource.Resource {
+	return &ConsentPolicyResource{}
+}
+
+// ConsentPolicyResource defines the resource implementation.
+type ConsentPolicyResource struct {
+	boxResource
+}
+
+// ConsentPolicyResourceModel describes the resource data model.
+type ConsentPolicyResourceModel struct {
+	ID              types.String                `tfsdk:"id"`
+	Description     types.String                `tfsdk:"description"`
+	ResourceTypes   types.Set                   `tfsdk:"resource_types"`
+	DefaultDecision types.String                `tfsdk:"default_decision"`
+	Template        *ConsentPolicyTemplateModel `tfsdk:"template"`
+	VersionID       types.String                `tfsdk:"version_id"`
+}
+
+// ConsentPolicyTemplateModel describes the template block.
+type ConsentPolicyTemplateModel struct {
+	Category types.String `tfsdk:"category"`
+	Actions  types.Set    `tfsdk:"actions"`
+	Purposes types.Set    `tfsdk:"purposes"`
+}
+
+func (r *ConsentPolicyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_consent_policy"
+}
+
+func (r *ConsentPolicyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a consent-based access policy, which grants access to the resources of a patient according to the active Consent resources of the patient matching a template. " +
+			"The policy is stored as an AccessPolicy evaluated by the consent engine of the box.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the access policy. Changing it forces a new consent policy to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validFHIRID(),
+				},
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Description of the policy",
+				Optional:            true,
+			},
+			"resource_types": schema.SetAttribute{
+				MarkdownDescription: "Resource types the policy protects, such as `Observation`",
+				ElementType:         types.StringType,
+				Required:            true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(
+						stringvalidator.OneOf(fhir.ResourceTypes...),
+					),
+				},
+			},
+			"default_decision": schema.StringAttribute{
+				MarkdownDescription: "Decision when the patient has no active Consent matching the template, `permit` for an opt-out policy or `deny` for an opt-in policy. Defaults to `deny`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("deny"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("permit", "deny"),
+				},
+			},
+			"version_id": schema.StringAttribute{
+				MarkdownDescription: "Version of the access policy, incremented by the box on every change",
+				Computed:            true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"template": schema.SingleNestedBlock{
+				MarkdownDescription: "Template the Consent resources of a patient must match to be enforced by the policy. Required.",
+				Attributes: map[string]schema.Attribute{
+					"category": schema.StringAttribute{
+						MarkdownDescription: "Category of the Consent resources, as a system and a code separated by `|`, such as `http://loinc.org|59284-0`. Required in this block.",
+						Optional:            true,
+						Validators: []validator.String{
+							stringvalidator.RegexMatches(fhirTokenRegexp, "must be a system and a code separated by |"),
+						},
+					},
+					"actions": schema.SetAttribute{
+						MarkdownDescription: "Actions the Consent provisions must cover, such as `access` or `disclose`. Defaults to any action.",
+						ElementType:         types.StringType,
+						Optional:            true,
+						Validators: []validator.Set{
+							setvalidator.ValueStringsAre(
+								stringvalidator.OneOf("access", "collect", "use", "disclose", "correct"),
+							),
+						},
+					},
+					"purposes": schema.SetAttribute{
+						MarkdownDescription: "Purposes of use the Consent provisions must cover, as codes of the `http://terminology.hl7.org/CodeSystem/v3-ActReason` system, such as `TREAT`. Defaults to any purpose.",
+						ElementType:         types.StringType,
+						Optional:            true,
+					},
+				},
+				Validators: []validator.Object{
+					objectvalidator.IsRequired(),
+					objectvalidator.AlsoRequires(
+						path.MatchRelative().AtName("category"),
+					),
+				},
+			},
+		},
+	}
+}
+
+func (r *ConsentPolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var model ConsentPolicyResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stored, err := r.client.PutResource(ctx, consentPolicyToResource(model), "")
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostic("Failed to Create Consent Policy", "Unable to create consent policy", err))
+		return
+	}
+
+	mapConsentPolicyFromResource(&model, stored)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *ConsentPolicyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var model ConsentPolicyResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stored, err := r.client.GetResourceIfModified(ctx, consentPolicyResourceType, model.ID.ValueString(), model.VersionID.ValueString())
+	if aidbox.IsNotModified(err) {
+		return
+	}
+	if aidbox.IsNotFound(err) {
+		tflog.Warn(ctx, "Consent policy not found, removing from state", map[string]interface{}{"id": model.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostic("Failed to Fetch Consent Policy", "Unable to fetch consent policy", err))
+		return
+	}
+
+	mapConsentPolicyFromResource(&model, stored)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *ConsentPolicyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var model ConsentPolicyResourceModel
+	var versionID types.String
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("version_id"), &versionID)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stored, err := r.client.PutResource(ctx, consentPolicyToResource(model), versionID.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(updateErrorDiagnostic("consent policy "+model.ID.ValueString(), "Failed to Update Consent Policy", "Unable to update consent policy", err))
+		return
+	}
+
+	mapConsentPolicyFromResource(&model, stored)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *ConsentPolicyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var model ConsentPolicyResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteResource(ctx, consentPolicyResourceType, model.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(deleteErrorDiagnostic(
+			"consent policy "+model.ID.ValueString(),
+			"Failed to Delete Consent Policy",
+			fmt.Sprintf("Error while trying to delete the consent policy with ID %s", model.ID.ValueString()),
+			err,
+		))
+	}
+}
+
+func (r *ConsentPolicyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+func consentPolicyToResource(model ConsentPolicyResourceModel) aidbox.Resource {
+	consent := map[string]interface{}{}
+	setJSONValue(consent, "resource-types", model.ResourceTypes)
+	setJSONValue(consent, "default-decision", model.DefaultDecision)
+
+	if model.Template != nil {
+		template := map[string]interface{}{}
+		setJSONValue(template, "category", model.Template.Category)
+		setJSONValue(template, "actions", model.Template.Actions)
+		setJSONValue(template, "purposes", model.Template.Purposes)
+		consent["template"] = template
+	}
+
+	policy := aidbox.Resource{
+		"resourceType": consentPolicyResourceType,
+		"id":           model.ID.ValueString(),
+		"engine":       consentPolicyEngine,
+		"consent":      consent,
+	}
+	setJSONValue(policy, "description", model.Description)
+
+	return policy
+}
+
+func mapConsentPolicyFromResource(model *ConsentPolicyResourceModel, stored aidbox.Resource) {
+	consent := jsonObject(stored, "consent")
+
+	model.ID = types.StringValue(stored.ID())
+	model.Description = jsonStringValue(stored, "description")
+	model.ResourceTypes = jsonStringSet(consent, "resource-types")
+	model.DefaultDecision = jsonStringValue(consent, "default-decision")
+	model.VersionID = stringValueOrNull(stored.VersionID())
+
+	model.Template = nil
+	if template := jsonObject(consent, "template"); template != nil {
+		model.Template = &ConsentPolicyTemplateModel{
+			Category: jsonStringValue(template, "category"),
+			Actions:  jsonStringSet(template, "actions"),
+			Purposes: jsonStringSet(template, "purposes"),
+		}
+	}
+}
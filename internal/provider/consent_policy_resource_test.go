@@ -0,0 +1,71 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccAidboxConsentPolicyResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheckBox(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_5_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccAidboxConsentPolicyResourceConfig("deny"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("aidbox_consent_policy.test", "id", "tf-acc-research"),
+					resource.TestCheckResourceAttr("aidbox_consent_policy.test", "default_decision", "deny"),
+					resource.TestCheckResourceAttr("aidbox_consent_policy.test", "template.category", "http://loinc.org|57016-8"),
+					resource.TestCheckResourceAttr("aidbox_consent_policy.test", "template.purposes.#", "1"),
+					resource.TestCheckResourceAttrSet("aidbox_consent_policy.test", "version_id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "aidbox_consent_policy.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Import block testing, as with terraform plan -generate-config-out
+			{
+				ResourceName:    "aidbox_consent_policy.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithID,
+			},
+			// Update and Read testing
+			{
+				Config: testAccAidboxConsentPolicyResourceConfig("permit"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("aidbox_consent_policy.test", "default_decision", "permit"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccAidboxConsentPolicyResourceConfig(defaultDecision string) string {
+	return fmt.Sprintf(`
+resource "aidbox_consent_policy" "test" {
+  id               = "tf-acc-research"
+  resource_types   = ["Observation", "Condition"]
+  default_decision = %[1]q
+
+  template {
+    category = "http://loinc.org|57016-8"
+    actions  = ["access"]
+    purposes = ["HRESCH"]
+  }
+}
+`, defaultDecision)
+}
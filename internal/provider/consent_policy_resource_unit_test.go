@@ -0,0 +1,47 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox/fake"
+)
+
+func TestConsentPolicyResource_roundTrip(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewBoxClient()
+
+	model := ConsentPolicyResourceModel{
+		ID:              types.StringValue("research"),
+		Description:     types.StringNull(),
+		ResourceTypes:   types.SetValueMust(types.StringType, []attr.Value{types.StringValue("Observation")}),
+		DefaultDecision: types.StringValue("deny"),
+		Template: &ConsentPolicyTemplateModel{
+			Category: types.StringValue("http://loinc.org|57016-8"),
+			Actions:  types.SetNull(types.StringType),
+			Purposes: types.SetValueMust(types.StringType, []attr.Value{types.StringValue("HRESCH")}),
+		},
+	}
+
+	stored, err := client.PutResource(ctx, consentPolicyToResource(model), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored["engine"] != "consent" {
+		t.Errorf("expected the access policy to use the consent engine, got: %v", stored["engine"])
+	}
+
+	var got ConsentPolicyResourceModel
+	mapConsentPolicyFromResource(&got, stored)
+	if got.Template == nil || got.Template.Category != model.Template.Category || !got.Template.Actions.IsNull() || !got.Template.Purposes.Equal(model.Template.Purposes) {
+		t.Errorf("unexpected template block: %+v", got.Template)
+	}
+	if !got.ResourceTypes.Equal(model.ResourceTypes) || got.DefaultDecision != model.DefaultDecision || !got.Description.IsNull() {
+		t.Errorf("unexpected model: %+v", got)
+	}
+}
@@ -0,0 +1,199 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"encoding/json"
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// The helpers below convert values decoded from API responses into framework
+// values, mapping fields absent from the response to null rather than to
+// their zero value, which would otherwise show up as noisy diffs.
+
+// stringValueOrNull returns a null string for an empty API value.
+func stringValueOrNull(value string) types.String {
+	if value == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(value)
+}
+
+// int64PointerValueOrNull returns a null number for an absent API value.
+func int64PointerValueOrNull(value *int) types.Int64 {
+	if value == nil {
+		return types.Int64Null()
+	}
+	return types.Int64Value(int64(*value))
+}
+
+// boolPointerValueOrNull returns a null boolean for an absent API value.
+func boolPointerValueOrNull(value *bool) types.Bool {
+	return types.BoolPointerValue(value)
+}
+
+// The helpers below read attributes of box resources, which are decoded from
+// JSON, mapping absent or mistyped attributes to null.
+
+// jsonStringValue returns the string attribute key of object.
+func jsonStringValue(object map[string]interface{}, key string) types.String {
+	value, ok := object[key].(string)
+	if !ok {
+		return types.StringNull()
+	}
+	return types.StringValue(value)
+}
+
+// jsonInt64Value returns the number attribute key of object as an integer.
+func jsonInt64Value(object map[string]interface{}, key string) types.Int64 {
+	value, ok := object[key].(float64)
+	if !ok {
+		return types.Int64Null()
+	}
+	return types.Int64Value(int64(value))
+}
+
+// jsonBoolValue returns the boolean attribute key of object.
+func jsonBoolValue(object map[string]interface{}, key string) types.Bool {
+	value, ok := object[key].(bool)
+	if !ok {
+		return types.BoolNull()
+	}
+	return types.BoolValue(value)
+}
+
+// jsonNormalizedValue returns the attribute key of object, of any type,
+// encoded as a JSON document.
+func jsonNormalizedValue(object map[string]interface{}, key string) jsontypes.Normalized {
+	value, ok := object[key]
+	if !ok || value == nil {
+		return jsontypes.NewNormalizedNull()
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return jsontypes.NewNormalizedNull()
+	}
+	return jsontypes.NewNormalizedValue(string(data))
+}
+
+// jsonObject returns the object attribute key of object, or nil.
+func jsonObject(object map[string]interface{}, key string) map[string]interface{} {
+	value, _ := object[key].(map[string]interface{})
+	return value
+}
+
+// secretValueOrPrior returns the secret attribute key of object, or prior
+// when the box does not return the secret.
+func secretValueOrPrior(object map[string]interface{}, key string, prior types.String) types.String {
+	if value := jsonStringValue(object, key); !value.IsNull() {
+		return value
+	}
+	if prior.IsUnknown() {
+		return types.StringNull()
+	}
+	return prior
+}
+
+// setJSONValue sets the attribute key of object to the value of a known,
+// non-null framework value and leaves it unset otherwise.
+func setJSONValue(object map[string]interface{}, key string, value attr.Value) {
+	if value.IsNull() || value.IsUnknown() {
+		return
+	}
+
+	object[key] = valueToJSON(value)
+}
+
+// valueToJSON converts a known framework value of a primitive, list, set or
+// map type, or a JSON document, into the equivalent value encoded by
+// encoding/json.
+func valueToJSON(value attr.Value) interface{} {
+	if value.IsNull() || value.IsUnknown() {
+		return nil
+	}
+
+	switch v := value.(type) {
+	case types.String:
+		return v.ValueString()
+	case types.Int64:
+		return v.ValueInt64()
+	case types.Bool:
+		return v.ValueBool()
+	case jsontypes.Normalized:
+		var decoded interface{}
+		if err := json.Unmarshal([]byte(v.ValueString()), &decoded); err != nil {
+			return nil
+		}
+		return decoded
+	case fhirResourceJSON:
+		return valueToJSON(v.Normalized)
+	case types.List:
+		return elementsToJSON(v.Elements())
+	case types.Set:
+		return elementsToJSON(v.Elements())
+	case types.Map:
+		elements := make(map[string]interface{}, len(v.Elements()))
+		for key, element := range v.Elements() {
+			elements[key] = valueToJSON(element)
+		}
+		return elements
+	default:
+		return nil
+	}
+}
+
+func elementsToJSON(elements []attr.Value) []interface{} {
+	values := make([]interface{}, len(elements))
+	for i, element := range elements {
+		values[i] = valueToJSON(element)
+	}
+	return values
+}
+
+// jsonStringList returns the array of strings attribute key of object as a
+// list, or a null list when it is absent.
+func jsonStringList(object map[string]interface{}, key string) types.List {
+	items, ok := object[key].([]interface{})
+	if !ok {
+		return types.ListNull(types.StringType)
+	}
+
+	elements := make([]attr.Value, 0, len(items))
+	for _, item := range items {
+		if value, ok := item.(string); ok {
+			elements = append(elements, types.StringValue(value))
+		}
+	}
+	return types.ListValueMust(types.StringType, elements)
+}
+
+// jsonStringSet returns the array of strings attribute key of object as a
+// set, or a null set when it is absent.
+func jsonStringSet(object map[string]interface{}, key string) types.Set {
+	list := jsonStringList(object, key)
+	if list.IsNull() {
+		return types.SetNull(types.StringType)
+	}
+	return types.SetValueMust(types.StringType, list.Elements())
+}
+
+// jsonStringMap returns the object of strings attribute key of object as a
+// map, or a null map when it is absent.
+func jsonStringMap(object map[string]interface{}, key string) types.Map {
+	items, ok := object[key].(map[string]interface{})
+	if !ok {
+		return types.MapNull(types.StringType)
+	}
+
+	elements := make(map[string]attr.Value, len(items))
+	for name, item := range items {
+		if value, ok := item.(string); ok {
+			elements[name] = types.StringValue(value)
+		}
+	}
+	return types.MapValueMust(types.StringType, elements)
+}
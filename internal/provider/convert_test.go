@@ -0,0 +1,76 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestConvertAbsentValuesToNull(t *testing.T) {
+	if !stringValueOrNull("").IsNull() {
+		t.Error("expected empty string to convert to null")
+	}
+	if got := stringValueOrNull("active").ValueString(); got != "active" {
+		t.Errorf("expected active, got %s", got)
+	}
+
+	if !int64PointerValueOrNull(nil).IsNull() {
+		t.Error("expected absent number to convert to null")
+	}
+	zero := 0
+	if v := int64PointerValueOrNull(&zero); v.IsNull() || v.ValueInt64() != 0 {
+		t.Errorf("expected explicit 0 to be kept, got %s", v)
+	}
+
+	if !boolPointerValueOrNull(nil).IsNull() {
+		t.Error("expected absent boolean to convert to null")
+	}
+	offline := false
+	if v := boolPointerValueOrNull(&offline); v.IsNull() || v.ValueBool() {
+		t.Errorf("expected explicit false to be kept, got %s", v)
+	}
+}
+
+func TestConvertJSONValues(t *testing.T) {
+	object := map[string]interface{}{}
+	setJSONValue(object, "url", types.StringValue("https://example.com"))
+	setJSONValue(object, "absent", types.StringNull())
+	setJSONValue(object, "events", types.ListValueMust(types.StringType, []attr.Value{types.StringValue("create")}))
+	setJSONValue(object, "headers", types.MapValueMust(types.StringType, map[string]attr.Value{"X-Key": types.StringValue("key")}))
+	setJSONValue(object, "schema", jsontypes.NewNormalizedValue(`{"b": 1, "a": [true]}`))
+
+	// Round-trip through JSON as the box would
+	data, err := json.Marshal(object)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := decoded["absent"]; ok {
+		t.Error("expected null values to be omitted")
+	}
+	if got := jsonStringValue(decoded, "url").ValueString(); got != "https://example.com" {
+		t.Errorf("expected url, got %s", got)
+	}
+	if got := jsonStringList(decoded, "events"); len(got.Elements()) != 1 || got.Elements()[0] != types.StringValue("create") {
+		t.Errorf("unexpected events: %s", got)
+	}
+	if got := jsonStringMap(decoded, "headers"); got.Elements()["X-Key"] != types.StringValue("key") {
+		t.Errorf("unexpected headers: %s", got)
+	}
+	if got := jsonNormalizedValue(decoded, "schema").ValueString(); got != `{"a":[true],"b":1}` {
+		t.Errorf("unexpected schema: %s", got)
+	}
+	if !jsonStringSet(decoded, "absent").IsNull() || !jsonInt64Value(decoded, "url").IsNull() {
+		t.Error("expected absent or mistyped values to convert to null")
+	}
+}
@@ -0,0 +1,217 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox"
+	"regexp"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &DBSettingsResource{}
+var _ resource.ResourceWithImportState = &DBSettingsResource{}
+
+// The database settings are stored in the AidboxConfig/db-settings resource.
+const (
+	dbSettingsResourceType = "AidboxConfig"
+	dbSettingsID           = "db-settings"
+)
+
+// pgIdentifierRegexp matches the lowercase PostgreSQL identifiers the box
+// accepts for database objects, such as schemas and sequences.
+var pgIdentifierRegexp = regexp.MustCompile(`^[a-z_][a-z0-9_]*$`)
+
+func NewDBSettingsResource() resource.Resource {
+	return &DBSettingsResource{}
+}
+
+// DBSettingsResource defines the resource implementation.
+type DBSettingsResource struct {
+	boxResource
+}
+
+// DBSettingsResourceModel describes the resource data model.
+type DBSettingsResourceModel struct {
+	ID                  types.String `tfsdk:"id"`
+	SQLStatementTimeout types.String `tfsdk:"sql_statement_timeout"`
+	SearchTimeout       types.String `tfsdk:"search_timeout"`
+	Schema              types.String `tfsdk:"schema"`
+	History             types.Bool   `tfsdk:"history"`
+	VersionID           types.String `tfsdk:"version_id"`
+}
+
+func (r *DBSettingsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_db_settings"
+}
+
+func (r *DBSettingsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the database settings of a box. A box has a single set of database settings. " +
+			"Timeouts are updated in place, while changing the fhirbase storage options, which requires a migration of the database, forces the settings to be replaced. " +
+			"Destroying the resource restores the defaults of the box.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the AidboxConfig resource holding the database settings, always `db-settings`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"version_id": schema.StringAttribute{
+				MarkdownDescription: "Version of the database settings, incremented by the box on every change",
+				Computed:            true,
+			},
+			"sql_statement_timeout": schema.StringAttribute{
+				MarkdownDescription: "Maximum duration of the statements run through the `$sql` and `$psql` endpoints, such as `30s`",
+				Optional:            true,
+				Validators: []validator.String{
+					validDuration(),
+				},
+			},
+			"search_timeout": schema.StringAttribute{
+				MarkdownDescription: "Maximum duration of the queries run by FHIR searches, such as `10s`",
+				Optional:            true,
+				Validators: []validator.String{
+					validDuration(),
+				},
+			},
+			"schema": schema.StringAttribute{
+				MarkdownDescription: "PostgreSQL schema fhirbase stores the resources in. Changing it forces the settings to be replaced.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(pgIdentifierRegexp, "must be a lowercase PostgreSQL identifier"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"history": schema.BoolAttribute{
+				MarkdownDescription: "Whether fhirbase keeps the history of the resources. Changing it forces the settings to be replaced.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *DBSettingsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var model DBSettingsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.checkConfigurationAbsent(ctx, "database settings", "aidbox_db_settings", dbSettingsResourceType, dbSettingsID)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stored, err := r.client.PutResource(ctx, dbSettingsToResource(model), "")
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostic("Failed to Create Database Settings", "Unable to configure the database settings", err))
+		return
+	}
+
+	mapDBSettingsFromResource(&model, stored)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *DBSettingsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var model DBSettingsResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stored, err := r.client.GetResource(ctx, dbSettingsResourceType, dbSettingsID)
+	if aidbox.IsNotFound(err) {
+		tflog.Warn(ctx, "Database settings not found, removing from state")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostic("Failed to Fetch Database Settings", "Unable to fetch the database settings", err))
+		return
+	}
+
+	mapDBSettingsFromResource(&model, stored)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *DBSettingsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var model DBSettingsResourceModel
+	var versionID types.String
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("version_id"), &versionID)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stored, err := r.client.PutResource(ctx, dbSettingsToResource(model), versionID.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(updateErrorDiagnostic("database settings", "Failed to Update Database Settings", "Unable to configure the database settings", err))
+		return
+	}
+
+	mapDBSettingsFromResource(&model, stored)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *DBSettingsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	err := r.client.DeleteResource(ctx, dbSettingsResourceType, dbSettingsID)
+	if err != nil {
+		resp.Diagnostics.Append(deleteErrorDiagnostic("database settings", "Failed to Delete Database Settings", "Unable to restore the default database settings", err))
+	}
+}
+
+func (r *DBSettingsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID != dbSettingsID {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("The database settings of a box are imported with the ID %q, got: %q", dbSettingsID, req.ID),
+		)
+		return
+	}
+
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+func dbSettingsToResource(model DBSettingsResourceModel) aidbox.Resource {
+	db := map[string]interface{}{}
+	setJSONValue(db, "statement-timeout", model.SQLStatementTimeout)
+	setJSONValue(db, "search-timeout", model.SearchTimeout)
+	setJSONValue(db, "schema", model.Schema)
+	setJSONValue(db, "history", model.History)
+
+	return aidbox.Resource{
+		"resourceType": dbSettingsResourceType,
+		"id":           dbSettingsID,
+		"db":           db,
+	}
+}
+
+func mapDBSettingsFromResource(model *DBSettingsResourceModel, stored aidbox.Resource) {
+	db := jsonObject(stored, "db")
+
+	model.ID = types.StringValue(dbSettingsID)
+	model.VersionID = stringValueOrNull(stored.VersionID())
+	model.SQLStatementTimeout = jsonStringValue(db, "statement-timeout")
+	model.SearchTimeout = jsonStringValue(db, "search-timeout")
+	model.Schema = jsonStringValue(db, "schema")
+	model.History = jsonBoolValue(db, "history")
+}
@@ -0,0 +1,81 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccAidboxDBSettingsResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheckBox(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_5_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccAidboxDBSettingsResourceConfig("30s", true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("aidbox_db_settings.test", "id", "db-settings"),
+					resource.TestCheckResourceAttr("aidbox_db_settings.test", "sql_statement_timeout", "30s"),
+					resource.TestCheckResourceAttr("aidbox_db_settings.test", "history", "true"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "aidbox_db_settings.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Import block testing, as with terraform plan -generate-config-out
+			{
+				ResourceName:    "aidbox_db_settings.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithID,
+			},
+			// Timeouts are updated in place
+			{
+				Config: testAccAidboxDBSettingsResourceConfig("1m", true),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("aidbox_db_settings.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("aidbox_db_settings.test", "sql_statement_timeout", "1m"),
+				),
+			},
+			// Storage options replace the settings
+			{
+				Config: testAccAidboxDBSettingsResourceConfig("1m", false),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("aidbox_db_settings.test", plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("aidbox_db_settings.test", "history", "false"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccAidboxDBSettingsResourceConfig(statementTimeout string, history bool) string {
+	return fmt.Sprintf(`
+resource "aidbox_db_settings" "test" {
+  sql_statement_timeout = %[1]q
+  search_timeout        = "10s"
+  history               = %[2]t
+}
+`, statementTimeout, history)
+}
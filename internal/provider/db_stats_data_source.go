@@ -0,0 +1,99 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox"
+	"sort"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &DBStatsDataSource{}
+var _ datasource.DataSourceWithConfigure = &DBStatsDataSource{}
+
+func NewDBStatsDataSource() datasource.DataSource {
+	return &DBStatsDataSource{}
+}
+
+// DBStatsDataSource defines the data source implementation.
+type DBStatsDataSource struct {
+	boxDataSource
+}
+
+// DBStatsDataSourceModel describes the data source data model.
+type DBStatsDataSourceModel struct {
+	ResourceTypes []DBStatsDataSourceTableModel `tfsdk:"resource_types"`
+	TotalSize     types.Int64                   `tfsdk:"total_size"`
+}
+
+// DBStatsDataSourceTableModel describes a resource type of the
+// resource_types attribute.
+type DBStatsDataSourceTableModel struct {
+	ResourceType types.String `tfsdk:"resource_type"`
+	Count        types.Int64  `tfsdk:"count"`
+	TableSize    types.Int64  `tfsdk:"table_size"`
+	IndexSize    types.Int64  `tfsdk:"index_size"`
+}
+
+func (d *DBStatsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_db_stats"
+}
+
+func (d *DBStatsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads the number of resources and the size of the tables of each resource type in the database of the box, such as to feed capacity dashboards from Terraform outputs.",
+		Attributes: map[string]schema.Attribute{
+			"resource_types": schema.ListAttribute{
+				MarkdownDescription: "Statistics of each resource type, sorted by resource type. Each has a `resource_type`, a `count` of resources, as estimated by PostgreSQL, and a `table_size` and an `index_size`, in bytes.",
+				ElementType: types.ObjectType{
+					AttrTypes: map[string]attr.Type{
+						"resource_type": types.StringType,
+						"count":         types.Int64Type,
+						"table_size":    types.Int64Type,
+						"index_size":    types.Int64Type,
+					},
+				},
+				Computed: true,
+			},
+			"total_size": schema.Int64Attribute{
+				MarkdownDescription: "Size of the tables and indexes of all resource types, in bytes",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *DBStatsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	stats, err := d.client.ListTableStats(ctx)
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostic("Failed to Fetch Database Statistics", "Unable to fetch the database statistics of the box", err))
+		return
+	}
+
+	var model DBStatsDataSourceModel
+	mapDBStats(&model, stats)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func mapDBStats(model *DBStatsDataSourceModel, stats []aidbox.TableStats) {
+	sort.Slice(stats, func(i, j int) bool { return stats[i].ResourceType < stats[j].ResourceType })
+
+	var totalSize int64
+	model.ResourceTypes = make([]DBStatsDataSourceTableModel, 0, len(stats))
+	for _, table := range stats {
+		model.ResourceTypes = append(model.ResourceTypes, DBStatsDataSourceTableModel{
+			ResourceType: types.StringValue(table.ResourceType),
+			Count:        types.Int64Value(table.Count),
+			TableSize:    types.Int64Value(table.TableSize),
+			IndexSize:    types.Int64Value(table.IndexSize),
+		})
+		totalSize += table.TableSize + table.IndexSize
+	}
+	model.TotalSize = types.Int64Value(totalSize)
+}
@@ -0,0 +1,33 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccAidboxDBStatsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckBox(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccAidboxDBStatsDataSourceConfig(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.aidbox_db_stats.test", "resource_types.#"),
+					resource.TestCheckResourceAttrSet("data.aidbox_db_stats.test", "total_size"),
+				),
+			},
+		},
+	})
+}
+
+func testAccAidboxDBStatsDataSourceConfig() string {
+	return `
+data "aidbox_db_stats" "test" {}
+`
+}
@@ -0,0 +1,28 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox"
+)
+
+func TestMapDBStats(t *testing.T) {
+	var model DBStatsDataSourceModel
+	mapDBStats(&model, []aidbox.TableStats{
+		{ResourceType: "Patient", Count: 1200, TableSize: 1048576, IndexSize: 262144},
+		{ResourceType: "Observation", Count: 50000, TableSize: 8388608, IndexSize: 2097152},
+	})
+
+	if len(model.ResourceTypes) != 2 || model.ResourceTypes[0].ResourceType.ValueString() != "Observation" {
+		t.Fatalf("expected the resource types sorted, got: %+v", model.ResourceTypes)
+	}
+	if model.ResourceTypes[1].Count.ValueInt64() != 1200 {
+		t.Errorf("unexpected Patient statistics: %+v", model.ResourceTypes[1])
+	}
+	if model.TotalSize.ValueInt64() != 1048576+262144+8388608+2097152 {
+		t.Errorf("unexpected total size: %s", model.TotalSize)
+	}
+}
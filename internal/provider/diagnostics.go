@@ -0,0 +1,62 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox"
+)
+
+// apiErrorDiagnostic builds an error diagnostic for a failed API call. For
+// API errors the detail includes the RPC method, the error codes, the
+// request ID, the issues reported by the box and a hint on how to resolve the
+// error.
+func apiErrorDiagnostic(summary string, detail string, err error) diag.Diagnostic {
+	var apiErr *aidbox.APIError
+	if !errors.As(err, &apiErr) {
+		return diag.NewErrorDiagnostic(summary, fmt.Sprintf("%s: %s", detail, err))
+	}
+
+	// The issues of an OperationOutcome are listed one per line
+	if len(apiErr.Issues) > 1 {
+		return diag.NewErrorDiagnostic(summary, fmt.Sprintf("%s:\n\n%s", detail, apiErr.Details()))
+	}
+	return diag.NewErrorDiagnostic(summary, fmt.Sprintf("%s: %s", detail, apiErr.Details()))
+}
+
+// diagnosticsError returns the first error of diags, with its detail.
+func diagnosticsError(diags diag.Diagnostics) error {
+	for _, d := range diags.Errors() {
+		return fmt.Errorf("%s: %s", d.Summary(), d.Detail())
+	}
+	return nil
+}
+
+// deleteErrorDiagnostic builds the diagnostic of a failed deletion of object,
+// such as "webhook deploy". Objects already deleted outside of Terraform only
+// produce a warning, as destroying them succeeded.
+func deleteErrorDiagnostic(object string, summary string, detail string, err error) diag.Diagnostic {
+	if aidbox.IsNotFound(err) {
+		return diag.NewWarningDiagnostic(
+			"Resource Already Deleted",
+			fmt.Sprintf("The %s was already deleted outside of Terraform and has been removed from state.", object),
+		)
+	}
+	return apiErrorDiagnostic(summary, detail, err)
+}
+
+// updateErrorDiagnostic builds the diagnostic of a failed update of object,
+// such as "webhook deploy". Updates are conditional on the version in state,
+// so a conflict means the object was changed outside of Terraform since.
+func updateErrorDiagnostic(object string, summary string, detail string, err error) diag.Diagnostic {
+	if aidbox.IsConflict(err) {
+		return diag.NewErrorDiagnostic(
+			"Resource Modified Concurrently",
+			fmt.Sprintf("The %s was modified outside of Terraform since it was last read. Run terraform plan again to review the changes before applying them.", object),
+		)
+	}
+	return apiErrorDiagnostic(summary, detail, err)
+}
@@ -0,0 +1,61 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox"
+)
+
+func TestAPIErrorDiagnostic(t *testing.T) {
+	d := apiErrorDiagnostic("Failed to Fetch License", "Unable to fetch license", errors.New("connection refused"))
+	if d.Summary() != "Failed to Fetch License" || d.Detail() != "Unable to fetch license: connection refused" {
+		t.Errorf("unexpected diagnostic: %s: %s", d.Summary(), d.Detail())
+	}
+
+	apiErr := &aidbox.APIError{
+		Code:       aidbox.ErrorCodeForbidden,
+		StatusCode: http.StatusForbidden,
+		Message:    "Access denied",
+		Method:     "portal.portal/get-license",
+	}
+	d = apiErrorDiagnostic("Failed to Fetch License", "Unable to fetch license", apiErr)
+	if !strings.HasPrefix(d.Detail(), "Unable to fetch license: Access denied") || !strings.Contains(d.Detail(), "RPC method: portal.portal/get-license") {
+		t.Errorf("unexpected detail:\n%s", d.Detail())
+	}
+
+	apiErr = &aidbox.APIError{
+		Code:       aidbox.ErrorCodeUnknown,
+		StatusCode: http.StatusUnprocessableEntity,
+		Message:    "- error (invalid) at Hook.url: invalid URL\n- warning (informational): events are deprecated",
+		Request:    "PUT /Hook/deploy",
+		Issues: []aidbox.OperationOutcomeIssue{
+			{Severity: "error", Code: "invalid", Diagnostics: "invalid URL", Expression: []string{"Hook.url"}},
+			{Severity: "warning", Code: "informational", Diagnostics: "events are deprecated"},
+		},
+	}
+	d = apiErrorDiagnostic("Failed to Create Webhook", "Unable to create webhook", apiErr)
+	if !strings.HasPrefix(d.Detail(), "Unable to create webhook:\n\n- error (invalid) at Hook.url: invalid URL\n- warning (informational): events are deprecated\n\nRequest: PUT /Hook/deploy") {
+		t.Errorf("unexpected detail:\n%s", d.Detail())
+	}
+}
+
+func TestDeleteErrorDiagnostic(t *testing.T) {
+	notFound := &aidbox.APIError{Code: aidbox.ErrorCodeNotFound, StatusCode: http.StatusNotFound, Request: "DELETE /Hook/deploy"}
+	d := deleteErrorDiagnostic("webhook deploy", "Failed to Delete Webhook", "Unable to delete webhook", notFound)
+	if d.Severity() != diag.SeverityWarning || !strings.Contains(d.Detail(), "The webhook deploy was already deleted outside of Terraform") {
+		t.Errorf("expected an already deleted warning, got: %s: %s", d.Summary(), d.Detail())
+	}
+
+	forbidden := &aidbox.APIError{Code: aidbox.ErrorCodeForbidden, StatusCode: http.StatusForbidden, Request: "DELETE /Hook/deploy"}
+	d = deleteErrorDiagnostic("webhook deploy", "Failed to Delete Webhook", "Unable to delete webhook", forbidden)
+	if d.Severity() != diag.SeverityError || d.Summary() != "Failed to Delete Webhook" {
+		t.Errorf("expected an error, got: %s: %s", d.Summary(), d.Detail())
+	}
+}
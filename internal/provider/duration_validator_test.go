@@ -0,0 +1,40 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestDurationValidator(t *testing.T) {
+	testCases := map[string]struct {
+		value       types.String
+		expectError bool
+	}{
+		"valid":    {value: types.StringValue("30s")},
+		"null":     {value: types.StringNull()},
+		"unknown":  {value: types.StringUnknown()},
+		"invalid":  {value: types.StringValue("30 seconds"), expectError: true},
+		"negative": {value: types.StringValue("-1m"), expectError: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resp := &validator.StringResponse{}
+			validDuration().ValidateString(context.Background(), validator.StringRequest{
+				Path:        path.Root("interval"),
+				ConfigValue: testCase.value,
+			}, resp)
+
+			if resp.Diagnostics.HasError() != testCase.expectError {
+				t.Errorf("expected error %t, got: %v", testCase.expectError, resp.Diagnostics)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/petalmd/terraform-provider-aidbox/internal/edn"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &EDNToJSONFunction{}
+
+func NewEDNToJSONFunction() function.Function {
+	return &EDNToJSONFunction{}
+}
+
+// EDNToJSONFunction defines the function implementation.
+type EDNToJSONFunction struct{}
+
+func (f *EDNToJSONFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "edn_to_json"
+}
+
+func (f *EDNToJSONFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Convert zen/EDN text to JSON",
+		MarkdownDescription: "Converts a single EDN form, such as a zen namespace, into normalized JSON with sorted object keys. " +
+			"Keywords and symbols become strings without their leading colon, lists, vectors and sets become arrays " +
+			"(sets in a deterministic order), and tagged literals such as `#inst` are replaced by their value.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "edn",
+				MarkdownDescription: "EDN text to convert",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *EDNToJSONFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var text string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &text))
+	if resp.Error != nil {
+		return
+	}
+
+	converted, err := edn.ToJSON(text)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, string(converted)))
+}
@@ -0,0 +1,40 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccEDNToJSONFunction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "test" {
+  value = provider::aidbox::edn_to_json("{:zen/tags #{aidbox/system} :port 8080}")
+}
+`,
+				Check: resource.TestCheckOutput("test", `{"port":8080,"zen/tags":["aidbox/system"]}`),
+			},
+			{
+				Config: `
+output "test" {
+  value = provider::aidbox::edn_to_json("{:port")
+}
+`,
+				ExpectError: regexp.MustCompile(`unexpected end of input`),
+			},
+		},
+	})
+}
@@ -0,0 +1,125 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/petalmd/terraform-provider-aidbox/internal/edn"
+	"reflect"
+)
+
+// Ensure the implementations satisfy the expected interfaces.
+var _ basetypes.StringTypable = ednType{}
+var _ basetypes.StringValuableWithSemanticEquals = ednValue{}
+var _ xattr.ValidateableAttribute = ednValue{}
+
+// ednType is the type of attributes holding an EDN form, such as a zen
+// namespace. Forms are compared as decoded by the edn package, so that
+// differences in whitespace, commas, comments or map key order never show up
+// as drift.
+type ednType struct {
+	basetypes.StringType
+}
+
+func (t ednType) String() string {
+	return "ednType"
+}
+
+func (t ednType) ValueType(ctx context.Context) attr.Value {
+	return ednValue{}
+}
+
+func (t ednType) Equal(o attr.Type) bool {
+	other, ok := o.(ednType)
+	return ok && t.StringType.Equal(other.StringType)
+}
+
+func (t ednType) ValueFromString(ctx context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return ednValue{StringValue: in}, nil
+}
+
+func (t ednType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	stringValuable, diags := t.ValueFromString(ctx, stringValue)
+	if diags.HasError() {
+		return nil, fmt.Errorf("unexpected error converting StringValue to StringValuable: %v", diags)
+	}
+	return stringValuable, nil
+}
+
+// ednValue is a value of ednType.
+type ednValue struct {
+	basetypes.StringValue
+}
+
+func newEDNValue(value string) ednValue {
+	return ednValue{StringValue: basetypes.NewStringValue(value)}
+}
+
+func (v ednValue) Type(ctx context.Context) attr.Type {
+	return ednType{}
+}
+
+func (v ednValue) Equal(o attr.Value) bool {
+	other, ok := o.(ednValue)
+	return ok && v.StringValue.Equal(other.StringValue)
+}
+
+// StringSemanticEquals reports whether both forms decode to the same value.
+// As the edn package decodes keywords and symbols to strings, a keyword equals
+// the string of its name.
+func (v ednValue) StringSemanticEquals(ctx context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(ednValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T, got: %T. Please report this to the provider developers.", v, newValuable),
+		)
+		return false, diags
+	}
+
+	current, err := edn.Decode(v.ValueString())
+	if err != nil {
+		diags.AddError("Semantic Equality Check Error", "Unable to decode the EDN form: "+err.Error())
+		return false, diags
+	}
+	updated, err := edn.Decode(newValue.ValueString())
+	if err != nil {
+		diags.AddError("Semantic Equality Check Error", "Unable to decode the EDN form: "+err.Error())
+		return false, diags
+	}
+
+	return reflect.DeepEqual(current, updated), diags
+}
+
+func (v ednValue) ValidateAttribute(ctx context.Context, req xattr.ValidateAttributeRequest, resp *xattr.ValidateAttributeResponse) {
+	if v.IsNull() || v.IsUnknown() {
+		return
+	}
+
+	if _, err := edn.Decode(v.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid EDN Form",
+			fmt.Sprintf("The value must be a single EDN form: %s", err),
+		)
+	}
+}
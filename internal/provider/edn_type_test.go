@@ -0,0 +1,60 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+func TestEDNSemanticEquals(t *testing.T) {
+	configured := "{ns clinic\n ;; Patients of the clinic\n patient {:zen/tags #{zen/schema aidbox/repository}\n          :type zen/map}}"
+
+	for name, testCase := range map[string]struct {
+		stored   string
+		expected bool
+	}{
+		"formatting": {
+			stored:   "{patient {:type zen/map, :zen/tags #{aidbox/repository zen/schema}} ns clinic}",
+			expected: true,
+		},
+		"changed value": {
+			stored:   "{ns clinic patient {:zen/tags #{zen/schema aidbox/repository} :type zen/vector}}",
+			expected: false,
+		},
+		"removed tag": {
+			stored:   "{ns clinic patient {:zen/tags #{zen/schema} :type zen/map}}",
+			expected: false,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			equal, diags := newEDNValue(configured).StringSemanticEquals(context.Background(), newEDNValue(testCase.stored))
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			if equal != testCase.expected {
+				t.Errorf("expected semantic equality %t, got: %t", testCase.expected, equal)
+			}
+		})
+	}
+}
+
+func TestEDNValidateAttribute(t *testing.T) {
+	for value, expectError := range map[string]bool{
+		"{ns clinic}":          false,
+		"[:a :b]":              false,
+		"{ns clinic":           true,
+		"{ns clinic} {ns lab}": true,
+		"":                     true,
+	} {
+		resp := &xattr.ValidateAttributeResponse{}
+		newEDNValue(value).ValidateAttribute(context.Background(), xattr.ValidateAttributeRequest{Path: path.Root("namespace")}, resp)
+		if resp.Diagnostics.HasError() != expectError {
+			t.Errorf("expected error %t for %q, got: %v", expectError, value, resp.Diagnostics)
+		}
+	}
+}
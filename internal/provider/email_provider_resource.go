@@ -0,0 +1,471 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &EmailProviderResource{}
+var _ resource.ResourceWithImportState = &EmailProviderResource{}
+var _ resource.ResourceWithConfigValidators = &EmailProviderResource{}
+var _ resource.ResourceWithValidateConfig = &EmailProviderResource{}
+
+// The email provider is stored in the default provider of the
+// AidboxConfig/provider resource.
+const (
+	emailProviderResourceType = "AidboxConfig"
+	emailProviderID           = "provider"
+)
+
+func NewEmailProviderResource() resource.Resource {
+	return &EmailProviderResource{}
+}
+
+// EmailProviderResource defines the resource implementation.
+type EmailProviderResource struct {
+	boxResource
+}
+
+// EmailProviderResourceModel describes the resource data model.
+type EmailProviderResourceModel struct {
+	ID        types.String                `tfsdk:"id"`
+	From      types.String                `tfsdk:"from"`
+	SMTP      *EmailProviderSMTPModel     `tfsdk:"smtp"`
+	Mailgun   *EmailProviderMailgunModel  `tfsdk:"mailgun"`
+	Postmark  *EmailProviderPostmarkModel `tfsdk:"postmark"`
+	VersionID types.String                `tfsdk:"version_id"`
+}
+
+// EmailProviderSMTPModel describes the smtp block.
+type EmailProviderSMTPModel struct {
+	Host              types.String `tfsdk:"host"`
+	Port              types.Int64  `tfsdk:"port"`
+	Username          types.String `tfsdk:"username"`
+	Password          types.String `tfsdk:"password"`
+	PasswordWO        types.String `tfsdk:"password_wo"`
+	PasswordWOVersion types.Int64  `tfsdk:"password_wo_version"`
+	TLS               types.Bool   `tfsdk:"tls"`
+}
+
+// EmailProviderMailgunModel describes the mailgun block.
+type EmailProviderMailgunModel struct {
+	URL             types.String `tfsdk:"url"`
+	Username        types.String `tfsdk:"username"`
+	APIKey          types.String `tfsdk:"api_key"`
+	APIKeyWO        types.String `tfsdk:"api_key_wo"`
+	APIKeyWOVersion types.Int64  `tfsdk:"api_key_wo_version"`
+}
+
+// EmailProviderPostmarkModel describes the postmark block.
+type EmailProviderPostmarkModel struct {
+	APIKey          types.String `tfsdk:"api_key"`
+	APIKeyWO        types.String `tfsdk:"api_key_wo"`
+	APIKeyWOVersion types.Int64  `tfsdk:"api_key_wo_version"`
+}
+
+func (r *EmailProviderResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_email_provider"
+}
+
+func (r *EmailProviderResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the email provider a box uses to send emails, such as password reset and notification emails. A box has a single email provider, configured with exactly one of the `smtp`, `mailgun` or `postmark` blocks.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the AidboxConfig resource holding the email provider, always `provider`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"version_id": schema.StringAttribute{
+				MarkdownDescription: "Version of the email provider, incremented by the box on every change",
+				Computed:            true,
+			},
+			"from": schema.StringAttribute{
+				MarkdownDescription: "Sender address of the emails",
+				Required:            true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"smtp": schema.SingleNestedBlock{
+				MarkdownDescription: "Send emails through an SMTP server",
+				Attributes: map[string]schema.Attribute{
+					"host": schema.StringAttribute{
+						MarkdownDescription: "Host name of the SMTP server. Required in this block.",
+						Optional:            true,
+					},
+					"port": schema.Int64Attribute{
+						MarkdownDescription: "Port of the SMTP server",
+						Optional:            true,
+					},
+					"username": schema.StringAttribute{
+						MarkdownDescription: "User name to authenticate to the SMTP server",
+						Optional:            true,
+					},
+					"password": schema.StringAttribute{
+						MarkdownDescription: "Password to authenticate to the SMTP server, stored in the Terraform state. Prefer `password_wo` with Terraform 1.11 and later.",
+						Optional:            true,
+						Sensitive:           true,
+					},
+					"password_wo": schema.StringAttribute{
+						MarkdownDescription: "Password to authenticate to the SMTP server, never stored in the Terraform state. Requires Terraform 1.11 or later. Increment `password_wo_version` to update the password.",
+						Optional:            true,
+						Sensitive:           true,
+						WriteOnly:           true,
+						Validators: []validator.String{
+							stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("password")),
+						},
+					},
+					"password_wo_version": schema.Int64Attribute{
+						MarkdownDescription: "Version of `password_wo`. As write-only values are not stored in state, changing this version is what triggers an update of the password.",
+						Optional:            true,
+						Validators: []validator.Int64{
+							int64validator.AlsoRequires(path.MatchRelative().AtParent().AtName("password_wo")),
+						},
+					},
+					"tls": schema.BoolAttribute{
+						MarkdownDescription: "Whether to connect to the SMTP server with TLS",
+						Optional:            true,
+					},
+				},
+				Validators: []validator.Object{
+					objectvalidator.AlsoRequires(path.MatchRelative().AtName("host")),
+				},
+			},
+			"mailgun": schema.SingleNestedBlock{
+				MarkdownDescription: "Send emails through Mailgun",
+				Attributes: map[string]schema.Attribute{
+					"url": schema.StringAttribute{
+						MarkdownDescription: "Mailgun API URL of the sending domain, such as `https://api.mailgun.net/v3/mg.example.com`. Required in this block.",
+						Optional:            true,
+					},
+					"username": schema.StringAttribute{
+						MarkdownDescription: "User name to authenticate to the Mailgun API, usually `api`",
+						Optional:            true,
+					},
+					"api_key": schema.StringAttribute{
+						MarkdownDescription: "Mailgun API key, stored in the Terraform state. Prefer `api_key_wo` with Terraform 1.11 and later. Exactly one of `api_key` or `api_key_wo` must be set in this block.",
+						Optional:            true,
+						Sensitive:           true,
+					},
+					"api_key_wo": schema.StringAttribute{
+						MarkdownDescription: "Mailgun API key, never stored in the Terraform state. Requires Terraform 1.11 or later. Increment `api_key_wo_version` to update the key.",
+						Optional:            true,
+						Sensitive:           true,
+						WriteOnly:           true,
+						Validators: []validator.String{
+							stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("api_key")),
+						},
+					},
+					"api_key_wo_version": schema.Int64Attribute{
+						MarkdownDescription: "Version of `api_key_wo`. As write-only values are not stored in state, changing this version is what triggers an update of the key.",
+						Optional:            true,
+						Validators: []validator.Int64{
+							int64validator.AlsoRequires(path.MatchRelative().AtParent().AtName("api_key_wo")),
+						},
+					},
+				},
+				Validators: []validator.Object{
+					objectvalidator.AlsoRequires(path.MatchRelative().AtName("url")),
+				},
+			},
+			"postmark": schema.SingleNestedBlock{
+				MarkdownDescription: "Send emails through Postmark",
+				Attributes: map[string]schema.Attribute{
+					"api_key": schema.StringAttribute{
+						MarkdownDescription: "Postmark server API token, stored in the Terraform state. Prefer `api_key_wo` with Terraform 1.11 and later. Exactly one of `api_key` or `api_key_wo` must be set in this block.",
+						Optional:            true,
+						Sensitive:           true,
+					},
+					"api_key_wo": schema.StringAttribute{
+						MarkdownDescription: "Postmark server API token, never stored in the Terraform state. Requires Terraform 1.11 or later. Increment `api_key_wo_version` to update the token.",
+						Optional:            true,
+						Sensitive:           true,
+						WriteOnly:           true,
+						Validators: []validator.String{
+							stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("api_key")),
+						},
+					},
+					"api_key_wo_version": schema.Int64Attribute{
+						MarkdownDescription: "Version of `api_key_wo`. As write-only values are not stored in state, changing this version is what triggers an update of the token.",
+						Optional:            true,
+						Validators: []validator.Int64{
+							int64validator.AlsoRequires(path.MatchRelative().AtParent().AtName("api_key_wo")),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *EmailProviderResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("smtp"),
+			path.MatchRoot("mailgun"),
+			path.MatchRoot("postmark"),
+		),
+	}
+}
+
+func (r *EmailProviderResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var model EmailProviderResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The API key is required in its block, either as a plain or a write-only
+	// value, which the schema validators can't express for optional blocks.
+	missingAPIKey := func(block string, apiKey, apiKeyWO types.String) {
+		if apiKey.IsNull() && apiKeyWO.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(block).AtName("api_key"),
+				"Missing API Key",
+				fmt.Sprintf("The %s block requires one of api_key or api_key_wo.", block),
+			)
+		}
+	}
+	if model.Mailgun != nil {
+		missingAPIKey("mailgun", model.Mailgun.APIKey, model.Mailgun.APIKeyWO)
+	}
+	if model.Postmark != nil {
+		missingAPIKey("postmark", model.Postmark.APIKey, model.Postmark.APIKeyWO)
+	}
+}
+
+func (r *EmailProviderResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var model EmailProviderResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.checkConfigurationAbsent(ctx, "email provider", "aidbox_email_provider", emailProviderResourceType, emailProviderID)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	secret, diags := emailProviderSecret(ctx, req.Config, model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stored, err := r.client.PutResource(ctx, emailProviderToResource(model, secret), "")
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostic("Failed to Create Email Provider", "Unable to configure the email provider", err))
+		return
+	}
+
+	mapEmailProviderFromResource(&model, stored)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *EmailProviderResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var model EmailProviderResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stored, err := r.client.GetResource(ctx, emailProviderResourceType, emailProviderID)
+	if aidbox.IsNotFound(err) {
+		tflog.Warn(ctx, "Email provider not found, removing from state")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostic("Failed to Fetch Email Provider", "Unable to fetch the email provider", err))
+		return
+	}
+
+	mapEmailProviderFromResource(&model, stored)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *EmailProviderResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var model EmailProviderResourceModel
+	var versionID types.String
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("version_id"), &versionID)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	secret, diags := emailProviderSecret(ctx, req.Config, model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stored, err := r.client.PutResource(ctx, emailProviderToResource(model, secret), versionID.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(updateErrorDiagnostic("email provider", "Failed to Update Email Provider", "Unable to configure the email provider", err))
+		return
+	}
+
+	mapEmailProviderFromResource(&model, stored)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *EmailProviderResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	err := r.client.DeleteResource(ctx, emailProviderResourceType, emailProviderID)
+	if err != nil {
+		resp.Diagnostics.Append(deleteErrorDiagnostic("email provider", "Failed to Delete Email Provider", "Unable to remove the email provider", err))
+	}
+}
+
+func (r *EmailProviderResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID != emailProviderID {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("The email provider of a box is imported with the ID %q, got: %q", emailProviderID, req.ID),
+		)
+		return
+	}
+
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// emailProviderSecret returns the configured password or API key of the
+// provider block. Write-only values are only available in the configuration,
+// never in the plan.
+func emailProviderSecret(ctx context.Context, config tfsdk.Config, model EmailProviderResourceModel) (types.String, diag.Diagnostics) {
+	var secret types.String
+	var block, name string
+	switch {
+	case model.SMTP != nil:
+		secret, block, name = model.SMTP.Password, "smtp", "password_wo"
+	case model.Mailgun != nil:
+		secret, block, name = model.Mailgun.APIKey, "mailgun", "api_key_wo"
+	case model.Postmark != nil:
+		secret, block, name = model.Postmark.APIKey, "postmark", "api_key_wo"
+	default:
+		return types.StringNull(), nil
+	}
+	if !secret.IsNull() {
+		return secret, nil
+	}
+
+	var secretWO types.String
+	diags := config.GetAttribute(ctx, path.Root(block).AtName(name), &secretWO)
+	return secretWO, diags
+}
+
+// emailProviderToResource builds the AidboxConfig resource holding the email
+// provider configured by model, with secret as its password or API key.
+func emailProviderToResource(model EmailProviderResourceModel, secret types.String) aidbox.Resource {
+	provider := map[string]interface{}{}
+	setJSONValue(provider, "from", model.From)
+
+	switch {
+	case model.SMTP != nil:
+		provider["type"] = "smtp"
+		setJSONValue(provider, "host", model.SMTP.Host)
+		setJSONValue(provider, "port", model.SMTP.Port)
+		setJSONValue(provider, "username", model.SMTP.Username)
+		setJSONValue(provider, "password", secret)
+		setJSONValue(provider, "tls", model.SMTP.TLS)
+	case model.Mailgun != nil:
+		provider["type"] = "mailgun"
+		setJSONValue(provider, "url", model.Mailgun.URL)
+		setJSONValue(provider, "username", model.Mailgun.Username)
+		setJSONValue(provider, "password", secret)
+	case model.Postmark != nil:
+		provider["type"] = "postmark"
+		setJSONValue(provider, "api-key", secret)
+	}
+
+	return aidbox.Resource{
+		"resourceType": emailProviderResourceType,
+		"id":           emailProviderID,
+		"provider": map[string]interface{}{
+			"default": provider,
+		},
+	}
+}
+
+// mapEmailProviderFromResource maps the AidboxConfig resource stored by the
+// box to model. Secrets the box does not return keep their value from model,
+// and are only read back when they are stored in state.
+func mapEmailProviderFromResource(model *EmailProviderResourceModel, stored aidbox.Resource) {
+	provider := jsonObject(jsonObject(stored, "provider"), "default")
+
+	model.ID = types.StringValue(emailProviderID)
+	model.VersionID = stringValueOrNull(stored.VersionID())
+	model.From = jsonStringValue(provider, "from")
+
+	var secret types.String
+	secretVersion := types.Int64Null()
+	switch jsonStringValue(provider, "type").ValueString() {
+	case "smtp":
+		if model.SMTP != nil {
+			secret, secretVersion = model.SMTP.Password, model.SMTP.PasswordWOVersion
+		}
+		model.SMTP = &EmailProviderSMTPModel{
+			Host:              jsonStringValue(provider, "host"),
+			Port:              jsonInt64Value(provider, "port"),
+			Username:          jsonStringValue(provider, "username"),
+			Password:          types.StringNull(),
+			PasswordWO:        types.StringNull(),
+			PasswordWOVersion: secretVersion,
+			TLS:               jsonBoolValue(provider, "tls"),
+		}
+		if secretVersion.IsNull() {
+			model.SMTP.Password = secretValueOrPrior(provider, "password", secret)
+		}
+		model.Mailgun, model.Postmark = nil, nil
+	case "mailgun":
+		if model.Mailgun != nil {
+			secret, secretVersion = model.Mailgun.APIKey, model.Mailgun.APIKeyWOVersion
+		}
+		model.Mailgun = &EmailProviderMailgunModel{
+			URL:             jsonStringValue(provider, "url"),
+			Username:        jsonStringValue(provider, "username"),
+			APIKey:          types.StringNull(),
+			APIKeyWO:        types.StringNull(),
+			APIKeyWOVersion: secretVersion,
+		}
+		if secretVersion.IsNull() {
+			model.Mailgun.APIKey = secretValueOrPrior(provider, "password", secret)
+		}
+		model.SMTP, model.Postmark = nil, nil
+	case "postmark":
+		if model.Postmark != nil {
+			secret, secretVersion = model.Postmark.APIKey, model.Postmark.APIKeyWOVersion
+		}
+		model.Postmark = &EmailProviderPostmarkModel{
+			APIKey:          types.StringNull(),
+			APIKeyWO:        types.StringNull(),
+			APIKeyWOVersion: secretVersion,
+		}
+		if secretVersion.IsNull() {
+			model.Postmark.APIKey = secretValueOrPrior(provider, "api-key", secret)
+		}
+		model.SMTP, model.Mailgun = nil, nil
+	default:
+		model.SMTP, model.Mailgun, model.Postmark = nil, nil, nil
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccAidboxEmailProviderResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheckBox(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_5_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccAidboxEmailProviderResourceConfigSMTP("noreply@example.com"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("aidbox_email_provider.test", "id", "provider"),
+					resource.TestCheckResourceAttr("aidbox_email_provider.test", "from", "noreply@example.com"),
+					resource.TestCheckResourceAttr("aidbox_email_provider.test", "smtp.host", "smtp.example.com"),
+					resource.TestCheckResourceAttr("aidbox_email_provider.test", "smtp.port", "587"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "aidbox_email_provider.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"smtp.password"},
+			},
+			// Import block testing, as with terraform plan -generate-config-out
+			{
+				// The SMTP password is not read back from the box
+				ResourceName:       "aidbox_email_provider.test",
+				ImportState:        true,
+				ImportStateKind:    resource.ImportBlockWithID,
+				ExpectNonEmptyPlan: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccAidboxEmailProviderResourceConfigPostmark("alerts@example.com"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("aidbox_email_provider.test", "from", "alerts@example.com"),
+					resource.TestCheckNoResourceAttr("aidbox_email_provider.test", "smtp.host"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccAidboxEmailProviderResourceConfigSMTP(from string) string {
+	return fmt.Sprintf(`
+resource "aidbox_email_provider" "test" {
+  from = %[1]q
+
+  smtp {
+    host     = "smtp.example.com"
+    port     = 587
+    username = "aidbox"
+    password = "tf-acc-password"
+    tls      = true
+  }
+}
+`, from)
+}
+
+func testAccAidboxEmailProviderResourceConfigPostmark(from string) string {
+	return fmt.Sprintf(`
+resource "aidbox_email_provider" "test" {
+  from = %[1]q
+
+  postmark {
+    api_key = "tf-acc-token"
+  }
+}
+`, from)
+}
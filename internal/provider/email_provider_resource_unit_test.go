@@ -0,0 +1,309 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox/fake"
+)
+
+func TestEmailProviderResource_roundTrip(t *testing.T) {
+	testCases := map[string]EmailProviderResourceModel{
+		"smtp": {
+			From: types.StringValue("noreply@example.com"),
+			SMTP: &EmailProviderSMTPModel{
+				Host:     types.StringValue("smtp.example.com"),
+				Port:     types.Int64Value(587),
+				Username: types.StringValue("aidbox"),
+				Password: types.StringValue("password"),
+				TLS:      types.BoolValue(true),
+			},
+		},
+		"mailgun": {
+			From: types.StringValue("noreply@example.com"),
+			Mailgun: &EmailProviderMailgunModel{
+				URL:      types.StringValue("https://api.mailgun.net/v3/mg.example.com"),
+				Username: types.StringValue("api"),
+				APIKey:   types.StringValue("key"),
+			},
+		},
+		"postmark": {
+			From:     types.StringValue("noreply@example.com"),
+			Postmark: &EmailProviderPostmarkModel{APIKey: types.StringValue("token")},
+		},
+	}
+
+	for name, model := range testCases {
+		t.Run(name, func(t *testing.T) {
+			secret, diags := emailProviderSecret(context.Background(), tfsdk.Config{}, model)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			client := fake.NewBoxClient()
+			stored, err := client.PutResource(context.Background(), emailProviderToResource(model, secret), "")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := model
+			mapEmailProviderFromResource(&got, stored)
+
+			model.ID = types.StringValue("provider")
+			model.VersionID = types.StringValue("1")
+			if !reflect.DeepEqual(got, model) {
+				t.Errorf("expected %+v, got %+v", model, got)
+			}
+		})
+	}
+}
+
+func TestEmailProviderResource_secretNotReturned(t *testing.T) {
+	model := EmailProviderResourceModel{
+		From:     types.StringValue("noreply@example.com"),
+		Postmark: &EmailProviderPostmarkModel{APIKey: types.StringValue("token")},
+	}
+
+	stored := emailProviderToResource(model, model.Postmark.APIKey)
+	delete(jsonObject(jsonObject(stored, "provider"), "default"), "api-key")
+
+	mapEmailProviderFromResource(&model, stored)
+	if model.Postmark == nil || model.Postmark.APIKey.ValueString() != "token" {
+		t.Errorf("expected the API key to be kept, got: %+v", model.Postmark)
+	}
+}
+
+func TestEmailProviderResource_typeChangedOutsideTerraform(t *testing.T) {
+	model := EmailProviderResourceModel{
+		From:     types.StringValue("noreply@example.com"),
+		Postmark: &EmailProviderPostmarkModel{APIKey: types.StringValue("token")},
+	}
+
+	stored := emailProviderToResource(EmailProviderResourceModel{
+		From: types.StringValue("noreply@example.com"),
+		SMTP: &EmailProviderSMTPModel{Host: types.StringValue("smtp.example.com")},
+	}, types.StringNull())
+
+	mapEmailProviderFromResource(&model, stored)
+	if model.Postmark != nil || model.SMTP == nil || model.SMTP.Host.ValueString() != "smtp.example.com" {
+		t.Errorf("expected the smtp block only, got: %+v", model)
+	}
+}
+
+func TestEmailProviderResourceUpdate_conflict(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewBoxClient()
+
+	r := &EmailProviderResource{}
+	r.Configure(ctx, resource.ConfigureRequest{ProviderData: &ProviderData{BoxClient: client}}, &resource.ConfigureResponse{})
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	model := EmailProviderResourceModel{
+		From:     types.StringValue("noreply@example.com"),
+		Postmark: &EmailProviderPostmarkModel{APIKey: types.StringValue("token")},
+	}
+	stored, err := client.PutResource(ctx, emailProviderToResource(model, model.Postmark.APIKey), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	mapEmailProviderFromResource(&model, stored)
+
+	update := func() resource.UpdateResponse {
+		t.Helper()
+
+		state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+		if diags := state.Set(ctx, &model); diags.HasError() {
+			t.Fatalf("unable to build state: %v", diags)
+		}
+		planned := model
+		planned.From = types.StringValue("planned@example.com")
+		planned.VersionID = types.StringUnknown()
+		plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+		if diags := plan.Set(ctx, &planned); diags.HasError() {
+			t.Fatalf("unable to build plan: %v", diags)
+		}
+
+		resp := resource.UpdateResponse{State: state}
+		r.Update(ctx, resource.UpdateRequest{Plan: plan, State: state, Config: tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw}}, &resp)
+		return resp
+	}
+
+	// The provider is changed outside of Terraform after the plan was made
+	client.UpdateResource(emailProviderResourceType, emailProviderID, func(provider aidbox.Resource) {
+		jsonObject(jsonObject(provider, "provider"), "default")["from"] = "moved@example.com"
+	})
+	resp := update()
+	if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != "Resource Modified Concurrently" {
+		t.Errorf("expected a conflict, got: %v", resp.Diagnostics)
+	}
+	stored, _ = client.Resource(emailProviderResourceType, emailProviderID)
+	if from := jsonObject(jsonObject(stored, "provider"), "default")["from"]; from != "moved@example.com" {
+		t.Errorf("expected the provider not to be replaced, got: %v", from)
+	}
+
+	// Once refreshed, the update applies
+	model.VersionID = types.StringValue("2")
+	resp = update()
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	stored, _ = client.Resource(emailProviderResourceType, emailProviderID)
+	if from := jsonObject(jsonObject(stored, "provider"), "default")["from"]; from != "planned@example.com" || stored.VersionID() != "3" {
+		t.Errorf("expected the planned provider, got: %v", stored)
+	}
+}
+
+func TestEmailProviderResourceCreate_alreadyConfigured(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewBoxClient()
+
+	r := &EmailProviderResource{}
+	r.Configure(ctx, resource.ConfigureRequest{ProviderData: &ProviderData{BoxClient: client}}, &resource.ConfigureResponse{})
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	create := func(from string) resource.CreateResponse {
+		t.Helper()
+
+		model := EmailProviderResourceModel{
+			ID:        types.StringUnknown(),
+			From:      types.StringValue(from),
+			Postmark:  &EmailProviderPostmarkModel{APIKey: types.StringValue("token")},
+			VersionID: types.StringUnknown(),
+		}
+		plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+		if diags := plan.Set(ctx, &model); diags.HasError() {
+			t.Fatalf("unable to build plan: %v", diags)
+		}
+
+		resp := resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}}
+		r.Create(ctx, resource.CreateRequest{Plan: plan, Config: tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw}}, &resp)
+		return resp
+	}
+
+	if resp := create("noreply@example.com"); resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	// A second provider doesn't replace the one already configured
+	resp := create("other@example.com")
+	if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != "Configuration Already Exists" {
+		t.Errorf("expected the configuration to already exist, got: %v", resp.Diagnostics)
+	}
+	stored, _ := client.Resource(emailProviderResourceType, emailProviderID)
+	if from := jsonObject(jsonObject(stored, "provider"), "default")["from"]; from != "noreply@example.com" {
+		t.Errorf("expected the provider not to be replaced, got: %v", from)
+	}
+}
+
+func TestEmailProviderResource_writeOnlySecret(t *testing.T) {
+	ctx := context.Background()
+	r := &EmailProviderResource{}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	model := EmailProviderResourceModel{
+		ID:   types.StringUnknown(),
+		From: types.StringValue("noreply@example.com"),
+		Mailgun: &EmailProviderMailgunModel{
+			URL:             types.StringValue("https://api.mailgun.net/v3/mg.example.com"),
+			APIKey:          types.StringNull(),
+			APIKeyWO:        types.StringValue("key"),
+			APIKeyWOVersion: types.Int64Value(1),
+		},
+		VersionID: types.StringUnknown(),
+	}
+	plan := tfsdk.Plan{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	if diags := plan.Set(ctx, &model); diags.HasError() {
+		t.Fatalf("unable to build config: %v", diags)
+	}
+	config := tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw}
+
+	// Write-only values are null in the plan
+	model.Mailgun.APIKeyWO = types.StringNull()
+	secret, diags := emailProviderSecret(ctx, config, model)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if secret.ValueString() != "key" {
+		t.Fatalf("expected the write-only API key, got: %s", secret)
+	}
+
+	client := fake.NewBoxClient()
+	stored, err := client.PutResource(ctx, emailProviderToResource(model, secret), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if jsonObject(jsonObject(stored, "provider"), "default")["password"] != "key" {
+		t.Errorf("expected the API key to be sent to the box, got: %v", stored)
+	}
+
+	mapEmailProviderFromResource(&model, stored)
+	if !model.Mailgun.APIKey.IsNull() || !model.Mailgun.APIKeyWO.IsNull() {
+		t.Errorf("expected no API key in state, got: %+v", model.Mailgun)
+	}
+	if model.Mailgun.APIKeyWOVersion.ValueInt64() != 1 || model.Mailgun.URL.ValueString() != "https://api.mailgun.net/v3/mg.example.com" {
+		t.Errorf("unexpected model: %+v", model.Mailgun)
+	}
+}
+
+func TestEmailProviderResourceValidateConfig_missingAPIKey(t *testing.T) {
+	ctx := context.Background()
+	r := &EmailProviderResource{}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	testCases := map[string]struct {
+		postmark    EmailProviderPostmarkModel
+		expectError bool
+	}{
+		"api-key": {
+			postmark: EmailProviderPostmarkModel{APIKey: types.StringValue("token")},
+		},
+		"api-key-wo": {
+			postmark: EmailProviderPostmarkModel{APIKeyWO: types.StringValue("token"), APIKeyWOVersion: types.Int64Value(1)},
+		},
+		"missing": {
+			expectError: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			model := EmailProviderResourceModel{
+				ID:        types.StringNull(),
+				From:      types.StringValue("noreply@example.com"),
+				Postmark:  &testCase.postmark,
+				VersionID: types.StringNull(),
+			}
+			plan := tfsdk.Plan{
+				Schema: schemaResp.Schema,
+				Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+			}
+			if diags := plan.Set(ctx, &model); diags.HasError() {
+				t.Fatalf("unable to build config: %v", diags)
+			}
+
+			resp := &resource.ValidateConfigResponse{}
+			r.ValidateConfig(ctx, resource.ValidateConfigRequest{Config: tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw}}, resp)
+			if resp.Diagnostics.HasError() != testCase.expectError {
+				t.Errorf("expected error: %t, got: %v", testCase.expectError, resp.Diagnostics)
+			}
+		})
+	}
+}
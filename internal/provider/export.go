@@ -0,0 +1,258 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"io"
+	"math/big"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Export writes the import blocks of the resources of the box managed by
+// resourceTypes, or by all the importable resource types when empty,
+// followed by their configuration, as read by the provider.
+//
+// Sensitive values are not read back by the box and are exported as null,
+// to be completed before applying.
+func Export(ctx context.Context, client BoxClient, resourceTypes []string, w io.Writer) error {
+	if len(resourceTypes) == 0 {
+		resourceTypes = importableResourceTypes()
+	}
+	resourceTypes = append([]string(nil), resourceTypes...)
+	sort.Strings(resourceTypes)
+
+	ids, err := listImportableResources(ctx, client, resourceTypes)
+	if err != nil {
+		return err
+	}
+	imports, hcl := importBlocks(resourceTypes, ids)
+	if _, err := io.WriteString(w, hcl.ValueString()); err != nil {
+		return err
+	}
+
+	resources := exportedResources(ctx)
+	data := &ProviderData{BoxClient: client, BoxMetadata: newBoxMetadata(client, boxMetadataTTL)}
+	for _, imported := range imports {
+		resourceType, name, _ := strings.Cut(imported.To.ValueString(), ".")
+		newResource, ok := resources[resourceType]
+		if !ok {
+			return fmt.Errorf("unsupported resource type %s", resourceType)
+		}
+
+		resourceSchema, state, err := readImportedResource(ctx, newResource(), data, imported.ID.ValueString())
+		if err != nil {
+			return fmt.Errorf("unable to read %s: %w", imported.To.ValueString(), err)
+		}
+		// The resource was deleted since it was listed
+		if state.IsNull() {
+			continue
+		}
+
+		var b strings.Builder
+		fmt.Fprintf(&b, "\nresource %q %q {\n", resourceType, name)
+		writeHCLBody(&b, 1, resourceSchema.Attributes, resourceSchema.Blocks, state)
+		b.WriteString("}\n")
+		if _, err := io.WriteString(w, b.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// exportedResources returns the resources of the provider by Terraform
+// resource type.
+func exportedResources(ctx context.Context) map[string]func() resource.Resource {
+	resources := map[string]func() resource.Resource{}
+	for _, newResource := range (&AidboxProvider{}).Resources(ctx) {
+		var resp resource.MetadataResponse
+		newResource().Metadata(ctx, resource.MetadataRequest{ProviderTypeName: "aidbox"}, &resp)
+		resources[resp.TypeName] = newResource
+	}
+	return resources
+}
+
+// readImportedResource imports the resource with the given ID, then reads it
+// as Terraform does. The state is null when the resource does not exist.
+func readImportedResource(ctx context.Context, r resource.Resource, data *ProviderData, id string) (schema.Schema, tftypes.Value, error) {
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+
+	importer, ok := r.(resource.ResourceWithImportState)
+	if !ok {
+		return schemaResp.Schema, state.Raw, fmt.Errorf("resource does not support import")
+	}
+	if configurable, ok := r.(resource.ResourceWithConfigure); ok {
+		var configureResp resource.ConfigureResponse
+		configurable.Configure(ctx, resource.ConfigureRequest{ProviderData: data}, &configureResp)
+		if configureResp.Diagnostics.HasError() {
+			return schemaResp.Schema, state.Raw, diagnosticsError(configureResp.Diagnostics)
+		}
+	}
+
+	importResp := resource.ImportStateResponse{State: state}
+	importer.ImportState(ctx, resource.ImportStateRequest{ID: id}, &importResp)
+	if importResp.Diagnostics.HasError() {
+		return schemaResp.Schema, state.Raw, diagnosticsError(importResp.Diagnostics)
+	}
+
+	readResp := resource.ReadResponse{State: importResp.State}
+	r.Read(ctx, resource.ReadRequest{State: importResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		return schemaResp.Schema, state.Raw, diagnosticsError(readResp.Diagnostics)
+	}
+	return schemaResp.Schema, readResp.State.Raw, nil
+}
+
+// writeHCLBody writes the configurable attributes and the blocks of value,
+// sorted by name. Attributes only computed by the provider are left out.
+func writeHCLBody(b *strings.Builder, indent int, attributes map[string]schema.Attribute, blocks map[string]schema.Block, value tftypes.Value) {
+	var values map[string]tftypes.Value
+	if err := value.As(&values); err != nil {
+		return
+	}
+	prefix := strings.Repeat("  ", indent)
+	start := b.Len()
+
+	names := make([]string, 0, len(attributes))
+	for name := range attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		attribute := attributes[name]
+		if (!attribute.IsOptional() && !attribute.IsRequired()) || attribute.IsWriteOnly() {
+			continue
+		}
+
+		attributeValue := values[name]
+		switch {
+		case attribute.IsSensitive() && (attribute.IsRequired() || !attributeValue.IsNull()):
+			fmt.Fprintf(b, "%s%s = null # sensitive\n", prefix, name)
+		case attributeValue.IsNull() || !attributeValue.IsKnown():
+			continue
+		default:
+			fmt.Fprintf(b, "%s%s = %s\n", prefix, name, hclValue(attributeValue, indent))
+		}
+	}
+
+	names = names[:0]
+	for name := range blocks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		blockValue := values[name]
+		if blockValue.IsNull() || !blockValue.IsKnown() {
+			continue
+		}
+
+		var nested schema.NestedBlockObject
+		var elements []tftypes.Value
+		switch block := blocks[name].(type) {
+		case schema.SingleNestedBlock:
+			nested = schema.NestedBlockObject{Attributes: block.Attributes, Blocks: block.Blocks}
+			elements = []tftypes.Value{blockValue}
+		case schema.ListNestedBlock:
+			nested = block.NestedObject
+			_ = blockValue.As(&elements)
+		case schema.SetNestedBlock:
+			nested = block.NestedObject
+			_ = blockValue.As(&elements)
+		default:
+			continue
+		}
+
+		for _, element := range elements {
+			// Blocks are separated from what precedes them in the body
+			if b.Len() > start {
+				b.WriteString("\n")
+			}
+			fmt.Fprintf(b, "%s%s {\n", prefix, name)
+			writeHCLBody(b, indent+1, nested.Attributes, nested.Blocks, element)
+			fmt.Fprintf(b, "%s}\n", prefix)
+		}
+	}
+}
+
+var hclIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+// hclValue returns the HCL expression of value, with nested collections
+// indented from indent.
+func hclValue(value tftypes.Value, indent int) string {
+	if value.IsNull() || !value.IsKnown() {
+		return "null"
+	}
+
+	prefix := strings.Repeat("  ", indent)
+	switch {
+	case value.Type().Is(tftypes.String):
+		var s string
+		_ = value.As(&s)
+		return hclString(s)
+	case value.Type().Is(tftypes.Number):
+		var n big.Float
+		_ = value.As(&n)
+		return n.Text('f', -1)
+	case value.Type().Is(tftypes.Bool):
+		var v bool
+		_ = value.As(&v)
+		return fmt.Sprint(v)
+	case value.Type().Is(tftypes.List{}), value.Type().Is(tftypes.Set{}), value.Type().Is(tftypes.Tuple{}):
+		var elements []tftypes.Value
+		_ = value.As(&elements)
+		if len(elements) == 0 {
+			return "[]"
+		}
+		var b strings.Builder
+		b.WriteString("[\n")
+		for _, element := range elements {
+			fmt.Fprintf(&b, "%s  %s,\n", prefix, hclValue(element, indent+1))
+		}
+		fmt.Fprintf(&b, "%s]", prefix)
+		return b.String()
+	default:
+		var elements map[string]tftypes.Value
+		_ = value.As(&elements)
+		keys := make([]string, 0, len(elements))
+		for key, element := range elements {
+			if !element.IsNull() {
+				keys = append(keys, key)
+			}
+		}
+		if len(keys) == 0 {
+			return "{}"
+		}
+		sort.Strings(keys)
+		var b strings.Builder
+		b.WriteString("{\n")
+		for _, key := range keys {
+			name := key
+			if !hclIdentifier.MatchString(key) {
+				name = hclString(key)
+			}
+			fmt.Fprintf(&b, "%s  %s = %s\n", prefix, name, hclValue(elements[key], indent+1))
+		}
+		fmt.Fprintf(&b, "%s}", prefix)
+		return b.String()
+	}
+}
+
+// hclString returns s as a quoted HCL string, escaping template sequences.
+func hclString(s string) string {
+	quoted := fmt.Sprintf("%q", s)
+	quoted = strings.ReplaceAll(quoted, "${", "$${")
+	return strings.ReplaceAll(quoted, "%{", "%%{")
+}
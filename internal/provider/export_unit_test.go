@@ -0,0 +1,94 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox/fake"
+)
+
+func TestExport(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewBoxClient()
+
+	resources := []aidbox.Resource{
+		{
+			"resourceType": webhookResourceType,
+			"id":           "notify-ci",
+			"url":          "https://ci.example.com/hooks/${box}",
+			"events":       []interface{}{"User/create"},
+			"headers":      map[string]interface{}{"Authorization": "Bearer secret"},
+			"status":       "active",
+			"retry":        map[string]interface{}{"max-attempts": 3},
+		},
+		{
+			"resourceType": smsProviderResourceType,
+			"id":           smsProviderID,
+			"sms": map[string]interface{}{
+				"type":        "twilio",
+				"account-sid": "AC0123",
+				"from":        "+15550100",
+				"auth-token":  "secret",
+			},
+		},
+	}
+	for _, r := range resources {
+		if _, err := client.PutResource(ctx, r, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var out bytes.Buffer
+	if err := Export(ctx, client, []string{"aidbox_webhook", "aidbox_sms_provider"}, &out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := `import {
+  to = aidbox_sms_provider.sms_provider
+  id = "sms-provider"
+}
+
+import {
+  to = aidbox_webhook.notify_ci
+  id = "notify-ci"
+}
+
+resource "aidbox_sms_provider" "sms_provider" {
+  twilio {
+    account_sid = "AC0123"
+    auth_token = null # sensitive
+    from = "+15550100"
+  }
+}
+
+resource "aidbox_webhook" "notify_ci" {
+  enabled = true
+  events = [
+    "User/create",
+  ]
+  headers = null # sensitive
+  id = "notify-ci"
+  url = "https://ci.example.com/hooks/$${box}"
+
+  retry {
+    max_attempts = 3
+  }
+}
+`
+	if out.String() != expected {
+		t.Errorf("unexpected configuration:\n%s", out.String())
+	}
+}
+
+func TestExport_unsupportedResourceType(t *testing.T) {
+	err := Export(context.Background(), fake.NewBoxClient(), []string{"aidbox_project"}, &bytes.Buffer{})
+	if err == nil || !strings.Contains(err.Error(), "aidbox_project") {
+		t.Errorf("expected an unsupported resource type error, got: %v", err)
+	}
+}
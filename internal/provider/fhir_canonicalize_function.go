@@ -0,0 +1,57 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/petalmd/terraform-provider-aidbox/internal/fhir"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &FHIRCanonicalizeFunction{}
+
+func NewFHIRCanonicalizeFunction() function.Function {
+	return &FHIRCanonicalizeFunction{}
+}
+
+// FHIRCanonicalizeFunction defines the function implementation.
+type FHIRCanonicalizeFunction struct{}
+
+func (f *FHIRCanonicalizeFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "fhir_canonicalize"
+}
+
+func (f *FHIRCanonicalizeFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Canonicalize a FHIR JSON document",
+		MarkdownDescription: "Returns the canonical JSON form of a FHIR document so that equivalent resources compare equal: " +
+			"object keys are sorted, nulls and the empty objects or arrays they leave behind are removed, and the server-managed " +
+			"`meta.versionId`, `meta.lastUpdated` and `meta.createdAt` fields are dropped from every resource in the document.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "document",
+				MarkdownDescription: "JSON-encoded FHIR resource or bundle",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *FHIRCanonicalizeFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var document string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &document))
+	if resp.Error != nil {
+		return
+	}
+
+	canonical, err := fhir.Canonicalize([]byte(document))
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, string(canonical)))
+}
@@ -0,0 +1,45 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccFHIRCanonicalizeFunction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "test" {
+  value = provider::aidbox::fhir_canonicalize(jsonencode({
+    resourceType = "Patient"
+    meta         = { versionId = "2", lastUpdated = "2024-01-01T00:00:00Z" }
+    gender       = null
+    active       = true
+  }))
+}
+`,
+				Check: resource.TestCheckOutput("test", `{"active":true,"resourceType":"Patient"}`),
+			},
+			{
+				Config: `
+output "test" {
+  value = provider::aidbox::fhir_canonicalize("{")
+}
+`,
+				ExpectError: regexp.MustCompile(`invalid JSON`),
+			},
+		},
+	})
+}
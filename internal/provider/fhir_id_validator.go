@@ -0,0 +1,44 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/petalmd/terraform-provider-aidbox/internal/fhir"
+)
+
+var _ validator.String = fhirIDValidator{}
+
+// fhirIDValidator validates that a string attribute is a valid FHIR resource
+// id, so that an invalid id is reported at plan time rather than by the box.
+type fhirIDValidator struct{}
+
+// validFHIRID returns a validator for the id attributes of box resources.
+func validFHIRID() validator.String {
+	return fhirIDValidator{}
+}
+
+func (v fhirIDValidator) Description(ctx context.Context) string {
+	return "value must be a FHIR id, of 1 to 64 letters, digits, `-` or `.`"
+}
+
+func (v fhirIDValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v fhirIDValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if err := fhir.ValidateID(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid FHIR ID",
+			fmt.Sprintf("The value %q is not a valid FHIR id: %s", req.ConfigValue.ValueString(), err),
+		)
+	}
+}
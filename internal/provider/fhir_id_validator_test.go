@@ -0,0 +1,43 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestFHIRIDValidator(t *testing.T) {
+	testCases := map[string]struct {
+		value       types.String
+		expectError bool
+	}{
+		"valid":      {value: types.StringValue("portal-app.v2")},
+		"null":       {value: types.StringNull()},
+		"unknown":    {value: types.StringUnknown()},
+		"empty":      {value: types.StringValue(""), expectError: true},
+		"underscore": {value: types.StringValue("portal_app"), expectError: true},
+		"slash":      {value: types.StringValue("Client/portal"), expectError: true},
+		"too long":   {value: types.StringValue(strings.Repeat("a", 65)), expectError: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resp := &validator.StringResponse{}
+			validFHIRID().ValidateString(context.Background(), validator.StringRequest{
+				Path:        path.Root("id"),
+				ConfigValue: testCase.value,
+			}, resp)
+
+			if resp.Diagnostics.HasError() != testCase.expectError {
+				t.Errorf("expected error %t, got: %v", testCase.expectError, resp.Diagnostics)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/petalmd/terraform-provider-aidbox/internal/fhir"
+	"slices"
+	"strings"
+)
+
+var _ validator.String = fhirReferenceValidator{}
+
+// fhirReferenceValidator validates that a string attribute is a relative
+// reference to a resource, such as `Client/portal`, optionally to one of the
+// given resource types.
+type fhirReferenceValidator struct {
+	resourceTypes []string
+}
+
+// validFHIRReference returns a validator for reference attributes, accepting
+// any resource type when none is given.
+func validFHIRReference(resourceTypes ...string) validator.String {
+	return fhirReferenceValidator{resourceTypes: resourceTypes}
+}
+
+func (v fhirReferenceValidator) Description(ctx context.Context) string {
+	if len(v.resourceTypes) == 0 {
+		return "value must be a reference to a resource, such as `Client/portal`"
+	}
+	return fmt.Sprintf("value must be a reference to a %s resource", strings.Join(v.resourceTypes, " or "))
+}
+
+func (v fhirReferenceValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v fhirReferenceValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	resourceType, _, err := fhir.ParseReference(req.ConfigValue.ValueString())
+	if err == nil && len(v.resourceTypes) > 0 && !slices.Contains(v.resourceTypes, resourceType) {
+		err = fmt.Errorf("resource type must be %s", strings.Join(v.resourceTypes, " or "))
+	}
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid FHIR Reference",
+			fmt.Sprintf("The value %q is not a valid reference: %s", req.ConfigValue.ValueString(), err),
+		)
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestFHIRReferenceValidator(t *testing.T) {
+	testCases := map[string]struct {
+		value       types.String
+		expectError bool
+	}{
+		"valid":          {value: types.StringValue("Client/portal")},
+		"versioned":      {value: types.StringValue("User/admin/_history/2")},
+		"null":           {value: types.StringNull()},
+		"unknown":        {value: types.StringUnknown()},
+		"id only":        {value: types.StringValue("portal"), expectError: true},
+		"other type":     {value: types.StringValue("Patient/pt-1"), expectError: true},
+		"lowercase type": {value: types.StringValue("client/portal"), expectError: true},
+		"invalid id":     {value: types.StringValue("Client/portal_app"), expectError: true},
+		"absolute":       {value: types.StringValue("https://box.example.com/Client/portal"), expectError: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resp := &validator.StringResponse{}
+			validFHIRReference("Client", "User").ValidateString(context.Background(), validator.StringRequest{
+				Path:        path.Root("client"),
+				ConfigValue: testCase.value,
+			}, resp)
+
+			if resp.Diagnostics.HasError() != testCase.expectError {
+				t.Errorf("expected error %t, got: %v", testCase.expectError, resp.Diagnostics)
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"reflect"
+	"slices"
+)
+
+// Ensure the implementations satisfy the expected interfaces.
+var _ basetypes.StringTypable = fhirResourceJSONType{}
+var _ basetypes.StringValuableWithSemanticEquals = fhirResourceJSON{}
+
+// serverMetaAttributes are the attributes of the meta of a resource the box
+// sets on every change.
+var serverMetaAttributes = []string{"versionId", "lastUpdated", "createdAt"}
+
+// serverMetaExtensions are the URLs of the extensions of the meta of a
+// resource the box sets on creation, depending on the FHIR mode of the box.
+var serverMetaExtensions = []string{"ex:createdAt", "https://fhir.aidbox.app/fhir/StructureDefinition/created-at"}
+
+// fhirResourceJSONType is the type of JSON attributes holding the body of a
+// resource. Like jsontypes.NormalizedType, it ignores whitespace and key
+// order, and it also ignores the meta attributes the box manages, so that
+// reading a resource back never shows a difference.
+type fhirResourceJSONType struct {
+	jsontypes.NormalizedType
+}
+
+func (t fhirResourceJSONType) String() string {
+	return "fhirResourceJSONType"
+}
+
+func (t fhirResourceJSONType) ValueType(ctx context.Context) attr.Value {
+	return fhirResourceJSON{}
+}
+
+func (t fhirResourceJSONType) Equal(o attr.Type) bool {
+	other, ok := o.(fhirResourceJSONType)
+	return ok && t.NormalizedType.Equal(other.NormalizedType)
+}
+
+func (t fhirResourceJSONType) ValueFromString(ctx context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return fhirResourceJSON{Normalized: jsontypes.Normalized{StringValue: in}}, nil
+}
+
+func (t fhirResourceJSONType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	stringValuable, diags := t.ValueFromString(ctx, stringValue)
+	if diags.HasError() {
+		return nil, fmt.Errorf("unexpected error converting StringValue to StringValuable: %v", diags)
+	}
+	return stringValuable, nil
+}
+
+// fhirResourceJSON is a value of fhirResourceJSONType.
+type fhirResourceJSON struct {
+	jsontypes.Normalized
+}
+
+func newFHIRResourceJSONValue(value string) fhirResourceJSON {
+	return fhirResourceJSON{Normalized: jsontypes.NewNormalizedValue(value)}
+}
+
+func (v fhirResourceJSON) Type(ctx context.Context) attr.Type {
+	return fhirResourceJSONType{}
+}
+
+func (v fhirResourceJSON) Equal(o attr.Value) bool {
+	other, ok := o.(fhirResourceJSON)
+	return ok && v.Normalized.Equal(other.Normalized)
+}
+
+// StringSemanticEquals reports whether both bodies are the same resource once
+// the meta attributes managed by the box are removed.
+func (v fhirResourceJSON) StringSemanticEquals(ctx context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(fhirResourceJSON)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T, got: %T. Please report this to the provider developers.", v, newValuable),
+		)
+		return false, diags
+	}
+
+	var current, updated interface{}
+	if err := json.Unmarshal([]byte(v.ValueString()), &current); err != nil {
+		diags.AddError("Semantic Equality Check Error", "Unable to decode the resource: "+err.Error())
+		return false, diags
+	}
+	if err := json.Unmarshal([]byte(newValue.ValueString()), &updated); err != nil {
+		diags.AddError("Semantic Equality Check Error", "Unable to decode the resource: "+err.Error())
+		return false, diags
+	}
+
+	return reflect.DeepEqual(withoutServerMeta(current), withoutServerMeta(updated)), diags
+}
+
+// withoutServerMeta returns a copy of the decoded resource without the meta
+// attributes managed by the box, and without meta when nothing else is left.
+func withoutServerMeta(decoded interface{}) interface{} {
+	resource, ok := decoded.(map[string]interface{})
+	if !ok {
+		return decoded
+	}
+	meta, ok := resource["meta"].(map[string]interface{})
+	if !ok {
+		return decoded
+	}
+
+	stripped := make(map[string]interface{}, len(meta))
+	for key, value := range meta {
+		stripped[key] = value
+	}
+	for _, key := range serverMetaAttributes {
+		delete(stripped, key)
+	}
+	if extensions, ok := stripped["extension"].([]interface{}); ok {
+		kept := []interface{}{}
+		for _, extension := range extensions {
+			object, _ := extension.(map[string]interface{})
+			if url, _ := object["url"].(string); !slices.Contains(serverMetaExtensions, url) {
+				kept = append(kept, extension)
+			}
+		}
+		if len(kept) == 0 {
+			delete(stripped, "extension")
+		} else {
+			stripped["extension"] = kept
+		}
+	}
+
+	copied := make(map[string]interface{}, len(resource))
+	for key, value := range resource {
+		copied[key] = value
+	}
+	if len(stripped) == 0 {
+		delete(copied, "meta")
+	} else {
+		copied["meta"] = stripped
+	}
+	return copied
+}
@@ -0,0 +1,63 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestFHIRResourceJSONSemanticEquals(t *testing.T) {
+	configured := `{"resourceType": "Patient", "active": true, "meta": {"tag": [{"code": "vip"}]}}`
+
+	for name, testCase := range map[string]struct {
+		stored   string
+		expected bool
+	}{
+		"formatting": {
+			stored:   `{"meta":{"tag":[{"code":"vip"}]},"active":true,"resourceType":"Patient"}`,
+			expected: true,
+		},
+		"server meta": {
+			stored: `{"resourceType": "Patient", "active": true, "meta": {"tag": [{"code": "vip"}], "versionId": "3", "lastUpdated": "2025-01-02T03:04:05Z",
+				"extension": [{"url": "ex:createdAt", "valueInstant": "2025-01-01T00:00:00Z"}]}}`,
+			expected: true,
+		},
+		"changed attribute": {
+			stored:   `{"resourceType": "Patient", "active": false, "meta": {"tag": [{"code": "vip"}], "versionId": "3"}}`,
+			expected: false,
+		},
+		"removed tag": {
+			stored:   `{"resourceType": "Patient", "active": true, "meta": {"versionId": "3"}}`,
+			expected: false,
+		},
+		"added extension": {
+			stored:   `{"resourceType": "Patient", "active": true, "meta": {"tag": [{"code": "vip"}], "extension": [{"url": "http://example.com/source", "valueString": "import"}]}}`,
+			expected: false,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			equal, diags := newFHIRResourceJSONValue(configured).StringSemanticEquals(context.Background(), newFHIRResourceJSONValue(testCase.stored))
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			if equal != testCase.expected {
+				t.Errorf("expected semantic equality %t, got: %t", testCase.expected, equal)
+			}
+		})
+	}
+
+	// Server meta alone, without any configured meta.
+	equal, diags := newFHIRResourceJSONValue(`{"active": true}`).StringSemanticEquals(context.Background(), newFHIRResourceJSONValue(`{"active": true, "meta": {"versionId": "1"}}`))
+	if diags.HasError() || !equal {
+		t.Errorf("expected a body without meta to equal the stored one, got: %t, %v", equal, diags)
+	}
+
+	_, diags = newFHIRResourceJSONValue(configured).StringSemanticEquals(context.Background(), types.StringValue(configured))
+	if !diags.HasError() {
+		t.Error("expected an error comparing with another value type")
+	}
+}
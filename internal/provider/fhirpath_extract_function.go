@@ -0,0 +1,86 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/petalmd/terraform-provider-aidbox/internal/fhirpath"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &FHIRPathExtractFunction{}
+
+func NewFHIRPathExtractFunction() function.Function {
+	return &FHIRPathExtractFunction{}
+}
+
+// FHIRPathExtractFunction defines the function implementation.
+type FHIRPathExtractFunction struct{}
+
+func (f *FHIRPathExtractFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "fhirpath_extract"
+}
+
+func (f *FHIRPathExtractFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Evaluate a FHIRPath expression against a resource",
+		MarkdownDescription: "Evaluates a FHIRPath expression against a JSON-encoded FHIR resource and returns the resulting collection as a tuple. " +
+			"Only a subset of FHIRPath is supported: member navigation (`name.given`), indexing (`name[0]`), `first()`, `last()` " +
+			"and `where()` with a single `=` or `!=` comparison against a string, number or boolean literal.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "resource",
+				MarkdownDescription: "JSON-encoded FHIR resource",
+			},
+			function.StringParameter{
+				Name:                "expression",
+				MarkdownDescription: "FHIRPath expression to evaluate",
+			},
+		},
+		Return: function.DynamicReturn{},
+	}
+}
+
+func (f *FHIRPathExtractFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var resource, expression string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &resource, &expression))
+	if resp.Error != nil {
+		return
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader([]byte(resource)))
+	decoder.UseNumber()
+
+	var decoded interface{}
+	if err := decoder.Decode(&decoded); err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("resource is not valid JSON: %s", err))
+		return
+	}
+
+	parsed, err := fhirpath.Parse(expression)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, err.Error())
+		return
+	}
+
+	collection, err := parsed.Evaluate(decoded)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	result, err := jsonToValue(ctx, collection)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, types.DynamicValue(result)))
+}
@@ -0,0 +1,57 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccFHIRPathExtractFunction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+locals {
+  patient = jsonencode({
+    resourceType = "Patient"
+    name = [
+      { use = "official", given = ["Peter", "James"] },
+      { use = "usual", given = ["Jim"] },
+    ]
+  })
+}
+
+output "given" {
+  value = join(",", provider::aidbox::fhirpath_extract(local.patient, "Patient.name.given"))
+}
+
+output "usual" {
+  value = one(provider::aidbox::fhirpath_extract(local.patient, "name.where(use = 'usual').given"))
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("given", "Peter,James,Jim"),
+					resource.TestCheckOutput("usual", "Jim"),
+				),
+			},
+			{
+				Config: `
+output "test" {
+  value = provider::aidbox::fhirpath_extract("{}", "name.exists()")
+}
+`,
+				ExpectError: regexp.MustCompile(`unsupported function exists\(\)`),
+			},
+		},
+	})
+}
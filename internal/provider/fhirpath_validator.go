@@ -0,0 +1,44 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/petalmd/terraform-provider-aidbox/internal/fhirpath"
+)
+
+var _ validator.String = fhirPathValidator{}
+
+// fhirPathValidator validates that a string attribute is a FHIRPath
+// expression supported by the fhirpath package.
+type fhirPathValidator struct{}
+
+// validFHIRPath returns a validator for FHIRPath expressions.
+func validFHIRPath() validator.String {
+	return fhirPathValidator{}
+}
+
+func (v fhirPathValidator) Description(ctx context.Context) string {
+	return "value must be a FHIRPath expression, such as `meta.security`"
+}
+
+func (v fhirPathValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v fhirPathValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := fhirpath.Parse(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid FHIRPath Expression",
+			fmt.Sprintf("The value must be a FHIRPath expression: %s", err),
+		)
+	}
+}
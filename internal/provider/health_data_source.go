@@ -0,0 +1,81 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &HealthDataSource{}
+var _ datasource.DataSourceWithConfigure = &HealthDataSource{}
+
+func NewHealthDataSource() datasource.DataSource {
+	return &HealthDataSource{}
+}
+
+// HealthDataSource defines the data source implementation.
+type HealthDataSource struct {
+	boxDataSource
+}
+
+// HealthDataSourceModel describes the data source data model.
+type HealthDataSourceModel struct {
+	Status  types.String `tfsdk:"status"`
+	Healthy types.Bool   `tfsdk:"healthy"`
+	Checks  types.Map    `tfsdk:"checks"`
+}
+
+func (d *HealthDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_health"
+}
+
+func (d *HealthDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads the health of the box from its health endpoint, such as to check the box is ready in a precondition before configuring it. Reading an unhealthy box succeeds, only a box that doesn't answer fails.",
+		Attributes: map[string]schema.Attribute{
+			"status": schema.StringAttribute{
+				MarkdownDescription: "Status of the box, one of `pass`, `warn` or `fail`",
+				Computed:            true,
+			},
+			"healthy": schema.BoolAttribute{
+				MarkdownDescription: "Whether the box is ready to serve requests, that is its status is `pass` or `warn`",
+				Computed:            true,
+			},
+			"checks": schema.MapAttribute{
+				MarkdownDescription: "Status of each subsystem of the box, such as `db`, by name",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *HealthDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	health, err := d.client.Health(ctx)
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostic("Failed to Fetch Box Health", "Unable to fetch the health of the box", err))
+		return
+	}
+
+	var model HealthDataSourceModel
+	mapHealth(&model, health)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func mapHealth(model *HealthDataSourceModel, health aidbox.Health) {
+	checks := make(map[string]attr.Value, len(health.Checks))
+	for name, check := range health.Checks {
+		checks[name] = types.StringValue(check.Status)
+	}
+
+	model.Status = types.StringValue(health.Status)
+	model.Healthy = types.BoolValue(health.Healthy())
+	model.Checks = types.MapValueMust(types.StringType, checks)
+}
@@ -0,0 +1,34 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccAidboxHealthDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckBox(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccAidboxHealthDataSourceConfig(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.aidbox_health.test", "healthy", "true"),
+					resource.TestCheckResourceAttrSet("data.aidbox_health.test", "status"),
+					resource.TestCheckResourceAttrSet("data.aidbox_health.test", "checks.%"),
+				),
+			},
+		},
+	})
+}
+
+func testAccAidboxHealthDataSourceConfig() string {
+	return `
+data "aidbox_health" "test" {}
+`
+}
@@ -0,0 +1,39 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox/fake"
+)
+
+func TestMapHealth(t *testing.T) {
+	client := fake.NewBoxClient()
+	client.SetHealth(aidbox.Health{
+		Status: aidbox.HealthStatusFail,
+		Checks: map[string]aidbox.HealthCheck{
+			"db":    {Status: aidbox.HealthStatusFail, Output: "connection refused"},
+			"cache": {Status: aidbox.HealthStatusPass},
+		},
+	})
+
+	health, err := client.Health(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var model HealthDataSourceModel
+	mapHealth(&model, health)
+
+	if model.Status.ValueString() != "fail" || model.Healthy.ValueBool() {
+		t.Errorf("expected an unhealthy box, got: %+v", model)
+	}
+	if db := model.Checks.Elements()["db"]; db == nil || !db.Equal(types.StringValue("fail")) || len(model.Checks.Elements()) != 2 {
+		t.Errorf("unexpected checks: %v", model.Checks)
+	}
+}
@@ -0,0 +1,249 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"regexp"
+	"strings"
+)
+
+// hostnameRegexp matches the DNS names identity providers are served on,
+// such as `example.okta.com`.
+var hostnameRegexp = regexp.MustCompile(`^([a-z0-9]([a-z0-9-]*[a-z0-9])?\.)+[a-z]{2,}$`)
+
+// uuidRegexp matches the IDs of Entra ID tenants and objects.
+var uuidRegexp = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+
+// userAttributeRegexp matches the attributes of Aidbox users the claims and
+// attributes of identity providers are mapped to, such as `name.givenName`.
+var userAttributeRegexp = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9]*(\.[a-zA-Z][a-zA-Z0-9]*)*$`)
+
+// oidcScopes are the scopes requested from OpenID Connect providers to get
+// the claims of oidcClaimMapping.
+var oidcScopes = []string{"openid", "profile", "email"}
+
+// oidcClaimMapping maps the attributes of Aidbox users to the standard
+// OpenID Connect claims they are set from.
+var oidcClaimMapping = map[string]string{
+	"email":           "email",
+	"name.givenName":  "given_name",
+	"name.familyName": "family_name",
+	"userName":        "preferred_username",
+}
+
+// identityProviderPreset holds the configuration of an identity provider
+// derived from a preset block, such as `okta`.
+type identityProviderPreset struct {
+	Type              string
+	AuthorizeEndpoint string
+	TokenEndpoint     string
+	UserinfoEndpoint  string
+	JWKSURI           string
+	Scopes            []string
+	ClaimMapping      map[string]string
+
+	// AuthorizeParams are added to the query of the authorization requests.
+	AuthorizeParams map[string]string
+
+	// RoleClaim is the claim listing the values RoleMappings map to Aidbox
+	// roles, such as the roles or groups of the user.
+	RoleClaim    string
+	RoleMappings []identityProviderRoleMapping
+
+	// AllowedClaim is the claim which must have one of AllowedValues for
+	// users to sign in, such as the groups of the user.
+	AllowedClaim  string
+	AllowedValues []string
+}
+
+// identityProviderRoleMapping maps a value of the role claim of an identity
+// provider to an Aidbox role.
+type identityProviderRoleMapping struct {
+	Value string
+	Role  string
+}
+
+// identityProviderPresetFor returns the preset configured in model, or nil
+// when no preset block is set. It returns false when the preset depends on
+// values unknown until apply.
+func identityProviderPresetFor(model IdentityProviderResourceModel) (*identityProviderPreset, bool) {
+	switch {
+	case model.Okta != nil:
+		return oktaPreset(*model.Okta)
+	case model.Auth0 != nil:
+		return auth0Preset(*model.Auth0)
+	case model.Keycloak != nil:
+		return keycloakPreset(*model.Keycloak)
+	case model.AzureAD != nil:
+		return azureADPreset(*model.AzureAD)
+	case model.Google != nil:
+		return googlePreset(*model.Google)
+	default:
+		return nil, true
+	}
+}
+
+// oktaPreset derives the endpoints of the Okta org authorization server, or
+// of a custom authorization server when its ID is set.
+func oktaPreset(okta IdentityProviderOktaModel) (*identityProviderPreset, bool) {
+	if okta.Domain.IsUnknown() || okta.AuthorizationServerID.IsUnknown() {
+		return nil, false
+	}
+
+	base := "https://" + okta.Domain.ValueString() + "/oauth2"
+	if !okta.AuthorizationServerID.IsNull() {
+		base += "/" + okta.AuthorizationServerID.ValueString()
+	}
+
+	return &identityProviderPreset{
+		Type:              "okta",
+		AuthorizeEndpoint: base + "/v1/authorize",
+		TokenEndpoint:     base + "/v1/token",
+		UserinfoEndpoint:  base + "/v1/userinfo",
+		JWKSURI:           base + "/v1/keys",
+		Scopes:            oidcScopes,
+		ClaimMapping:      oidcClaimMapping,
+	}, true
+}
+
+// auth0Preset derives the endpoints of an Auth0 tenant. Auth0 has no
+// preferred_username claim, the nickname of users is used instead.
+func auth0Preset(auth0 IdentityProviderAuth0Model) (*identityProviderPreset, bool) {
+	if auth0.Domain.IsUnknown() || auth0.Connection.IsUnknown() {
+		return nil, false
+	}
+
+	base := "https://" + auth0.Domain.ValueString()
+	claimMapping := map[string]string{}
+	for attribute, claim := range oidcClaimMapping {
+		claimMapping[attribute] = claim
+	}
+	claimMapping["userName"] = "nickname"
+
+	preset := &identityProviderPreset{
+		Type:              "auth0",
+		AuthorizeEndpoint: base + "/authorize",
+		TokenEndpoint:     base + "/oauth/token",
+		UserinfoEndpoint:  base + "/userinfo",
+		JWKSURI:           base + "/.well-known/jwks.json",
+		Scopes:            oidcScopes,
+		ClaimMapping:      claimMapping,
+	}
+	if !auth0.Connection.IsNull() {
+		preset.AuthorizeParams = map[string]string{"connection": auth0.Connection.ValueString()}
+	}
+	return preset, true
+}
+
+// keycloakPreset derives the endpoints of a Keycloak realm, and maps the
+// realm roles of users to Aidbox roles.
+func keycloakPreset(keycloak IdentityProviderKeycloakModel) (*identityProviderPreset, bool) {
+	if keycloak.BaseURL.IsUnknown() || keycloak.Realm.IsUnknown() {
+		return nil, false
+	}
+
+	roleMappings := make([]identityProviderRoleMapping, 0, len(keycloak.RoleMappings))
+	for _, mapping := range keycloak.RoleMappings {
+		if mapping.RealmRole.IsUnknown() || mapping.Role.IsUnknown() {
+			return nil, false
+		}
+		roleMappings = append(roleMappings, identityProviderRoleMapping{Value: mapping.RealmRole.ValueString(), Role: mapping.Role.ValueString()})
+	}
+
+	base := strings.TrimSuffix(keycloak.BaseURL.ValueString(), "/") + "/realms/" + keycloak.Realm.ValueString() + "/protocol/openid-connect"
+	return &identityProviderPreset{
+		Type:              "keycloak",
+		AuthorizeEndpoint: base + "/auth",
+		TokenEndpoint:     base + "/token",
+		UserinfoEndpoint:  base + "/userinfo",
+		JWKSURI:           base + "/certs",
+		Scopes:            oidcScopes,
+		ClaimMapping:      oidcClaimMapping,
+		RoleClaim:         "realm_access.roles",
+		RoleMappings:      roleMappings,
+	}, true
+}
+
+// azureADPreset derives the v2.0 endpoints of an Entra ID tenant. The groups
+// of users are read from the groups claim of the ID token, which the
+// userinfo endpoint of Entra ID does not return.
+func azureADPreset(azureAD IdentityProviderAzureADModel) (*identityProviderPreset, bool) {
+	if azureAD.TenantID.IsUnknown() || azureAD.AllowedGroups.IsUnknown() {
+		return nil, false
+	}
+
+	roleMappings := make([]identityProviderRoleMapping, 0, len(azureAD.RoleMappings))
+	for _, mapping := range azureAD.RoleMappings {
+		if mapping.Group.IsUnknown() || mapping.Role.IsUnknown() {
+			return nil, false
+		}
+		roleMappings = append(roleMappings, identityProviderRoleMapping{Value: mapping.Group.ValueString(), Role: mapping.Role.ValueString()})
+	}
+
+	var allowedGroups []string
+	for _, group := range azureAD.AllowedGroups.Elements() {
+		value, ok := group.(types.String)
+		if !ok || value.IsUnknown() {
+			return nil, false
+		}
+		allowedGroups = append(allowedGroups, value.ValueString())
+	}
+
+	base := "https://login.microsoftonline.com/" + azureAD.TenantID.ValueString()
+	return &identityProviderPreset{
+		Type:              "azure-ad",
+		AuthorizeEndpoint: base + "/oauth2/v2.0/authorize",
+		TokenEndpoint:     base + "/oauth2/v2.0/token",
+		JWKSURI:           base + "/discovery/v2.0/keys",
+		Scopes:            oidcScopes,
+		ClaimMapping:      oidcClaimMapping,
+		RoleClaim:         "groups",
+		RoleMappings:      roleMappings,
+		AllowedClaim:      "groups",
+		AllowedValues:     allowedGroups,
+	}, true
+}
+
+// googlePreset derives the endpoints of Google. Google has no
+// preferred_username claim, the email of users is used instead. Sign in is
+// restricted to Google Workspace domains with the hd claim, which is also
+// passed to the authorization endpoint to skip the account chooser when a
+// single domain is allowed.
+func googlePreset(google IdentityProviderGoogleModel) (*identityProviderPreset, bool) {
+	if google.HostedDomains.IsUnknown() {
+		return nil, false
+	}
+
+	var hostedDomains []string
+	for _, domain := range google.HostedDomains.Elements() {
+		value, ok := domain.(types.String)
+		if !ok || value.IsUnknown() {
+			return nil, false
+		}
+		hostedDomains = append(hostedDomains, value.ValueString())
+	}
+
+	claimMapping := map[string]string{}
+	for attribute, claim := range oidcClaimMapping {
+		claimMapping[attribute] = claim
+	}
+	claimMapping["userName"] = "email"
+
+	preset := &identityProviderPreset{
+		Type:              "google",
+		AuthorizeEndpoint: "https://accounts.google.com/o/oauth2/v2/auth",
+		TokenEndpoint:     "https://oauth2.googleapis.com/token",
+		UserinfoEndpoint:  "https://openidconnect.googleapis.com/v1/userinfo",
+		JWKSURI:           "https://www.googleapis.com/oauth2/v3/certs",
+		Scopes:            oidcScopes,
+		ClaimMapping:      claimMapping,
+		AllowedClaim:      "hd",
+		AllowedValues:     hostedDomains,
+	}
+	if len(hostedDomains) == 1 {
+		preset.AuthorizeParams = map[string]string{"hd": hostedDomains[0]}
+	}
+	return preset, true
+}
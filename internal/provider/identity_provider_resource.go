@@ -0,0 +1,696 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox"
+	"slices"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &IdentityProviderResource{}
+var _ resource.ResourceWithConfigValidators = &IdentityProviderResource{}
+var _ resource.ResourceWithImportState = &IdentityProviderResource{}
+var _ resource.ResourceWithModifyPlan = &IdentityProviderResource{}
+var _ resource.ResourceWithValidateConfig = &IdentityProviderResource{}
+
+const identityProviderResourceType = "IdentityProvider"
+
+func NewIdentityProviderResource() resource.Resource {
+	return &IdentityProviderResource{}
+}
+
+// IdentityProviderResource defines the resource implementation.
+type IdentityProviderResource struct {
+	boxResource
+}
+
+// IdentityProviderResourceModel describes the resource data model.
+type IdentityProviderResourceModel struct {
+	ID                    types.String                   `tfsdk:"id"`
+	Title                 types.String                   `tfsdk:"title"`
+	Active                types.Bool                     `tfsdk:"active"`
+	ClientID              types.String                   `tfsdk:"client_id"`
+	ClientSecret          types.String                   `tfsdk:"client_secret"`
+	ClientSecretWO        types.String                   `tfsdk:"client_secret_wo"`
+	ClientSecretWOVersion types.Int64                    `tfsdk:"client_secret_wo_version"`
+	RedirectURI           types.String                   `tfsdk:"redirect_uri"`
+	AuthorizeEndpoint     types.String                   `tfsdk:"authorize_endpoint"`
+	TokenEndpoint         types.String                   `tfsdk:"token_endpoint"`
+	UserinfoEndpoint      types.String                   `tfsdk:"userinfo_endpoint"`
+	JWKSURI               types.String                   `tfsdk:"jwks_uri"`
+	Scopes                types.Set                      `tfsdk:"scopes"`
+	ClaimMapping          types.Map                      `tfsdk:"claim_mapping"`
+	Okta                  *IdentityProviderOktaModel     `tfsdk:"okta"`
+	Auth0                 *IdentityProviderAuth0Model    `tfsdk:"auth0"`
+	Keycloak              *IdentityProviderKeycloakModel `tfsdk:"keycloak"`
+	AzureAD               *IdentityProviderAzureADModel  `tfsdk:"azure_ad"`
+	Google                *IdentityProviderGoogleModel   `tfsdk:"google"`
+	VersionID             types.String                   `tfsdk:"version_id"`
+}
+
+// IdentityProviderOktaModel describes the okta block.
+type IdentityProviderOktaModel struct {
+	Domain                types.String `tfsdk:"domain"`
+	AuthorizationServerID types.String `tfsdk:"authorization_server_id"`
+}
+
+// IdentityProviderAuth0Model describes the auth0 block.
+type IdentityProviderAuth0Model struct {
+	Domain     types.String `tfsdk:"domain"`
+	Connection types.String `tfsdk:"connection"`
+}
+
+// IdentityProviderKeycloakModel describes the keycloak block.
+type IdentityProviderKeycloakModel struct {
+	BaseURL      types.String                        `tfsdk:"base_url"`
+	Realm        types.String                        `tfsdk:"realm"`
+	RoleMappings []IdentityProviderKeycloakRoleModel `tfsdk:"role_mapping"`
+}
+
+// IdentityProviderKeycloakRoleModel describes the role_mapping blocks of the
+// keycloak block.
+type IdentityProviderKeycloakRoleModel struct {
+	RealmRole types.String `tfsdk:"realm_role"`
+	Role      types.String `tfsdk:"role"`
+}
+
+// IdentityProviderAzureADModel describes the azure_ad block.
+type IdentityProviderAzureADModel struct {
+	TenantID      types.String                       `tfsdk:"tenant_id"`
+	AllowedGroups types.Set                          `tfsdk:"allowed_groups"`
+	RoleMappings  []IdentityProviderAzureADRoleModel `tfsdk:"role_mapping"`
+}
+
+// IdentityProviderAzureADRoleModel describes the role_mapping blocks of the
+// azure_ad block.
+type IdentityProviderAzureADRoleModel struct {
+	Group types.String `tfsdk:"group"`
+	Role  types.String `tfsdk:"role"`
+}
+
+// IdentityProviderGoogleModel describes the google block.
+type IdentityProviderGoogleModel struct {
+	HostedDomains types.Set `tfsdk:"hosted_domains"`
+}
+
+func (r *IdentityProviderResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_identity_provider"
+}
+
+func (r *IdentityProviderResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	httpURL := validURI("http", "https")
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages an OpenID Connect identity provider users sign in to the box with. " +
+			"The endpoints, scopes and claim mapping of well-known providers are derived from a preset block, such as `okta`, and can still be overridden. " +
+			"Without a preset block, `authorize_endpoint`, `token_endpoint`, and `jwks_uri` or `userinfo_endpoint` must be set.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the identity provider, part of the callback URL of the box. Changing it forces a new identity provider to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validFHIRID(),
+				},
+			},
+			"title": schema.StringAttribute{
+				MarkdownDescription: "Title of the sign in button of the identity provider",
+				Optional:            true,
+			},
+			"active": schema.BoolAttribute{
+				MarkdownDescription: "Whether users can sign in with the identity provider. Defaults to `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"client_id": schema.StringAttribute{
+				MarkdownDescription: "Client ID of the box registered with the identity provider",
+				Required:            true,
+			},
+			"client_secret": schema.StringAttribute{
+				MarkdownDescription: "Client secret of the box registered with the identity provider, stored in the Terraform state. Prefer `client_secret_wo` with Terraform 1.11 and later.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"client_secret_wo": schema.StringAttribute{
+				MarkdownDescription: "Client secret of the box registered with the identity provider, never stored in the Terraform state. Requires Terraform 1.11 or later. Increment `client_secret_wo_version` to update the secret.",
+				Optional:            true,
+				Sensitive:           true,
+				WriteOnly:           true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("client_secret")),
+				},
+			},
+			"client_secret_wo_version": schema.Int64Attribute{
+				MarkdownDescription: "Version of `client_secret_wo`. As write-only values are not stored in state, changing this version is what triggers an update of the secret.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot("client_secret_wo")),
+				},
+			},
+			"redirect_uri": schema.StringAttribute{
+				MarkdownDescription: "Callback URL registered with the identity provider. Defaults to the callback endpoint of the box.",
+				Optional:            true,
+				Validators: []validator.String{
+					httpURL,
+				},
+			},
+			"authorize_endpoint": schema.StringAttribute{
+				MarkdownDescription: "Authorization endpoint of the identity provider. Derived from the preset block when not set.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					httpURL,
+				},
+			},
+			"token_endpoint": schema.StringAttribute{
+				MarkdownDescription: "Token endpoint of the identity provider. Derived from the preset block when not set.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					httpURL,
+				},
+			},
+			"userinfo_endpoint": schema.StringAttribute{
+				MarkdownDescription: "Userinfo endpoint of the identity provider. Derived from the preset block when not set. Without it, the claims are read from the ID token.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					httpURL,
+				},
+			},
+			"jwks_uri": schema.StringAttribute{
+				MarkdownDescription: "URL of the JSON Web Key Set the ID tokens are verified with. Derived from the preset block when not set.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					httpURL,
+				},
+			},
+			"scopes": schema.SetAttribute{
+				MarkdownDescription: "Scopes requested from the identity provider, which must include `openid`. Derived from the preset block when not set.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Computed:            true,
+			},
+			"claim_mapping": schema.MapAttribute{
+				MarkdownDescription: "Attributes of the Aidbox user, such as `email` or `name.givenName`, mapped to the claims they are set from. Derived from the preset block when not set.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(
+						stringvalidator.RegexMatches(userAttributeRegexp, "must be an attribute of the Aidbox user, such as email or name.givenName"),
+					),
+				},
+			},
+			"version_id": schema.StringAttribute{
+				MarkdownDescription: "Version of the identity provider, incremented by the box on every change",
+				Computed:            true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"okta": schema.SingleNestedBlock{
+				MarkdownDescription: "Derive the configuration of an Okta organization.",
+				Attributes: map[string]schema.Attribute{
+					"domain": schema.StringAttribute{
+						MarkdownDescription: "Domain of the Okta organization, such as `example.okta.com`. Required in this block.",
+						Optional:            true,
+						Validators: []validator.String{
+							stringvalidator.RegexMatches(hostnameRegexp, "must be a domain name, such as example.okta.com"),
+						},
+					},
+					"authorization_server_id": schema.StringAttribute{
+						MarkdownDescription: "ID of the custom authorization server, such as `default`. Defaults to the org authorization server.",
+						Optional:            true,
+					},
+				},
+				Validators: []validator.Object{
+					objectvalidator.AlsoRequires(
+						path.MatchRelative().AtName("domain"),
+					),
+				},
+			},
+			"auth0": schema.SingleNestedBlock{
+				MarkdownDescription: "Derive the configuration of an Auth0 tenant.",
+				Attributes: map[string]schema.Attribute{
+					"domain": schema.StringAttribute{
+						MarkdownDescription: "Domain of the Auth0 tenant, such as `example.us.auth0.com`, or its custom domain. Required in this block.",
+						Optional:            true,
+						Validators: []validator.String{
+							stringvalidator.RegexMatches(hostnameRegexp, "must be a domain name, such as example.us.auth0.com"),
+						},
+					},
+					"connection": schema.StringAttribute{
+						MarkdownDescription: "Name of the Auth0 connection users sign in with, such as an enterprise connection, skipping the Auth0 login page. Defaults to letting users choose.",
+						Optional:            true,
+					},
+				},
+				Validators: []validator.Object{
+					objectvalidator.AlsoRequires(
+						path.MatchRelative().AtName("domain"),
+					),
+				},
+			},
+			"keycloak": schema.SingleNestedBlock{
+				MarkdownDescription: "Derive the configuration of a Keycloak realm.",
+				Attributes: map[string]schema.Attribute{
+					"base_url": schema.StringAttribute{
+						MarkdownDescription: "URL of the Keycloak server, such as `https://sso.example.com`, including the `/auth` path of Keycloak 16 and earlier. Required in this block.",
+						Optional:            true,
+						Validators: []validator.String{
+							httpURL,
+						},
+					},
+					"realm": schema.StringAttribute{
+						MarkdownDescription: "Name of the realm. Required in this block.",
+						Optional:            true,
+					},
+				},
+				Blocks: map[string]schema.Block{
+					"role_mapping": schema.ListNestedBlock{
+						MarkdownDescription: "Grant an Aidbox role to the users with a realm role.",
+						NestedObject: schema.NestedBlockObject{
+							Attributes: map[string]schema.Attribute{
+								"realm_role": schema.StringAttribute{
+									MarkdownDescription: "Realm role of the users",
+									Required:            true,
+								},
+								"role": schema.StringAttribute{
+									MarkdownDescription: "Aidbox role granted to the users",
+									Required:            true,
+								},
+							},
+						},
+					},
+				},
+				Validators: []validator.Object{
+					objectvalidator.AlsoRequires(
+						path.MatchRelative().AtName("base_url"),
+						path.MatchRelative().AtName("realm"),
+					),
+				},
+			},
+			"azure_ad": schema.SingleNestedBlock{
+				MarkdownDescription: "Derive the configuration of an Azure AD, now Entra ID, tenant. The app registration must include the `groups` claim in ID tokens to use `allowed_groups` or `role_mapping`.",
+				Attributes: map[string]schema.Attribute{
+					"tenant_id": schema.StringAttribute{
+						MarkdownDescription: "ID of the tenant. Required in this block.",
+						Optional:            true,
+						Validators: []validator.String{
+							stringvalidator.RegexMatches(uuidRegexp, "must be a tenant ID"),
+						},
+					},
+					"allowed_groups": schema.SetAttribute{
+						MarkdownDescription: "Object IDs of the groups users must be a member of, directly, to sign in. Defaults to every user of the tenant.",
+						ElementType:         types.StringType,
+						Optional:            true,
+						Validators: []validator.Set{
+							setvalidator.ValueStringsAre(
+								stringvalidator.RegexMatches(uuidRegexp, "must be a group object ID"),
+							),
+						},
+					},
+				},
+				Blocks: map[string]schema.Block{
+					"role_mapping": schema.ListNestedBlock{
+						MarkdownDescription: "Grant an Aidbox role to the members of a group.",
+						NestedObject: schema.NestedBlockObject{
+							Attributes: map[string]schema.Attribute{
+								"group": schema.StringAttribute{
+									MarkdownDescription: "Object ID of the group",
+									Required:            true,
+									Validators: []validator.String{
+										stringvalidator.RegexMatches(uuidRegexp, "must be a group object ID"),
+									},
+								},
+								"role": schema.StringAttribute{
+									MarkdownDescription: "Aidbox role granted to the members of the group",
+									Required:            true,
+								},
+							},
+						},
+					},
+				},
+				Validators: []validator.Object{
+					objectvalidator.AlsoRequires(
+						path.MatchRelative().AtName("tenant_id"),
+					),
+				},
+			},
+			"google": schema.SingleNestedBlock{
+				MarkdownDescription: "Derive the configuration of Google. The block may be empty to let any Google account sign in.",
+				Attributes: map[string]schema.Attribute{
+					"hosted_domains": schema.SetAttribute{
+						MarkdownDescription: "Google Workspace domains users must belong to, such as `example.com`, to sign in. Defaults to any Google account.",
+						ElementType:         types.StringType,
+						Optional:            true,
+						Validators: []validator.Set{
+							setvalidator.ValueStringsAre(
+								stringvalidator.RegexMatches(hostnameRegexp, "must be a domain name, such as example.com"),
+							),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *IdentityProviderResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.Conflicting(
+			path.MatchRoot("okta"),
+			path.MatchRoot("auth0"),
+			path.MatchRoot("keycloak"),
+			path.MatchRoot("azure_ad"),
+			path.MatchRoot("google"),
+		),
+	}
+}
+
+func (r *IdentityProviderResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var model IdentityProviderResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Without the openid scope, the identity provider returns no ID token.
+	if !model.Scopes.IsNull() && !model.Scopes.IsUnknown() {
+		var scopes []types.String
+		resp.Diagnostics.Append(model.Scopes.ElementsAs(ctx, &scopes, false)...)
+		if !slices.ContainsFunc(scopes, func(scope types.String) bool { return scope.IsUnknown() || scope.ValueString() == "openid" }) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("scopes"),
+				"Missing OpenID Scope",
+				"The scopes attribute must include the openid scope for the identity provider to return an ID token.",
+			)
+		}
+	}
+
+	if preset, known := identityProviderPresetFor(model); preset != nil || !known {
+		return
+	}
+
+	for name, value := range map[string]types.String{"authorize_endpoint": model.AuthorizeEndpoint, "token_endpoint": model.TokenEndpoint} {
+		if value.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(name),
+				"Missing Identity Provider Endpoint",
+				fmt.Sprintf("The %s attribute must be set when no preset block is set.", name),
+			)
+		}
+	}
+	if model.UserinfoEndpoint.IsNull() && model.JWKSURI.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("jwks_uri"),
+			"Missing Identity Provider Endpoint",
+			"The jwks_uri attribute, verifying the ID tokens the claims are read from, or the userinfo_endpoint attribute must be set when no preset block is set.",
+		)
+	}
+}
+
+// ModifyPlan derives the endpoints, scopes and claim mapping not set in the
+// configuration from the preset block. Derived values are planned on every
+// change of the identity provider, so that changes made outside of Terraform
+// are reverted.
+func (r *IdentityProviderResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to derive on destroy.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var config, plan IdentityProviderResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	preset, known := identityProviderPresetFor(config)
+	if !known {
+		return
+	}
+	if preset == nil {
+		preset = &identityProviderPreset{}
+	}
+
+	derived := plan
+	derive := func(configured types.String, value string) types.String {
+		if !configured.IsNull() {
+			return configured
+		}
+		return stringValueOrNull(value)
+	}
+	derived.AuthorizeEndpoint = derive(config.AuthorizeEndpoint, preset.AuthorizeEndpoint)
+	derived.TokenEndpoint = derive(config.TokenEndpoint, preset.TokenEndpoint)
+	derived.UserinfoEndpoint = derive(config.UserinfoEndpoint, preset.UserinfoEndpoint)
+	derived.JWKSURI = derive(config.JWKSURI, preset.JWKSURI)
+
+	if config.Scopes.IsNull() {
+		derived.Scopes = types.SetNull(types.StringType)
+		if preset.Scopes != nil {
+			scopes, diags := types.SetValueFrom(ctx, types.StringType, preset.Scopes)
+			resp.Diagnostics.Append(diags...)
+			derived.Scopes = scopes
+		}
+	}
+	if config.ClaimMapping.IsNull() {
+		derived.ClaimMapping = types.MapNull(types.StringType)
+		if preset.ClaimMapping != nil {
+			claimMapping, diags := types.MapValueFrom(ctx, types.StringType, preset.ClaimMapping)
+			resp.Diagnostics.Append(diags...)
+			derived.ClaimMapping = claimMapping
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Reverting a change made outside of Terraform updates the identity
+	// provider, even when the configuration did not change.
+	if !derived.AuthorizeEndpoint.Equal(plan.AuthorizeEndpoint) || !derived.TokenEndpoint.Equal(plan.TokenEndpoint) ||
+		!derived.UserinfoEndpoint.Equal(plan.UserinfoEndpoint) || !derived.JWKSURI.Equal(plan.JWKSURI) ||
+		!derived.Scopes.Equal(plan.Scopes) || !derived.ClaimMapping.Equal(plan.ClaimMapping) {
+		derived.VersionID = types.StringUnknown()
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &derived)...)
+}
+
+func (r *IdentityProviderResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var model IdentityProviderResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	clientSecret, diags := identityProviderClientSecret(ctx, req.Config, model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stored, err := r.client.PutResource(ctx, identityProviderToResource(model, clientSecret), "")
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostic("Failed to Create Identity Provider", "Unable to create identity provider", err))
+		return
+	}
+
+	mapIdentityProviderFromResource(&model, stored)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *IdentityProviderResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var model IdentityProviderResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stored, err := r.client.GetResourceIfModified(ctx, identityProviderResourceType, model.ID.ValueString(), model.VersionID.ValueString())
+	if aidbox.IsNotModified(err) {
+		return
+	}
+	if aidbox.IsNotFound(err) {
+		tflog.Warn(ctx, "Identity provider not found, removing from state", map[string]interface{}{"id": model.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostic("Failed to Fetch Identity Provider", "Unable to fetch identity provider", err))
+		return
+	}
+
+	mapIdentityProviderFromResource(&model, stored)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *IdentityProviderResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var model IdentityProviderResourceModel
+	var versionID types.String
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("version_id"), &versionID)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	clientSecret, diags := identityProviderClientSecret(ctx, req.Config, model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stored, err := r.client.PutResource(ctx, identityProviderToResource(model, clientSecret), versionID.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(updateErrorDiagnostic("identity provider "+model.ID.ValueString(), "Failed to Update Identity Provider", "Unable to update identity provider", err))
+		return
+	}
+
+	mapIdentityProviderFromResource(&model, stored)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *IdentityProviderResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var model IdentityProviderResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteResource(ctx, identityProviderResourceType, model.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(deleteErrorDiagnostic(
+			"identity provider "+model.ID.ValueString(),
+			"Failed to Delete Identity Provider",
+			fmt.Sprintf("Error while trying to delete the identity provider with ID %s", model.ID.ValueString()),
+			err,
+		))
+	}
+}
+
+func (r *IdentityProviderResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// identityProviderClientSecret returns the configured client secret.
+// Write-only values are only available in the configuration, never in the
+// plan.
+func identityProviderClientSecret(ctx context.Context, config tfsdk.Config, model IdentityProviderResourceModel) (types.String, diag.Diagnostics) {
+	if !model.ClientSecret.IsNull() {
+		return model.ClientSecret, nil
+	}
+
+	var clientSecretWO types.String
+	diags := config.GetAttribute(ctx, path.Root("client_secret_wo"), &clientSecretWO)
+	return clientSecretWO, diags
+}
+
+func identityProviderToResource(model IdentityProviderResourceModel, clientSecret types.String) aidbox.Resource {
+	preset, _ := identityProviderPresetFor(model)
+	if preset == nil {
+		preset = &identityProviderPreset{Type: "oidc"}
+	}
+
+	client := map[string]interface{}{}
+	setJSONValue(client, "id", model.ClientID)
+	setJSONValue(client, "secret", clientSecret)
+	setJSONValue(client, "redirect_uri", model.RedirectURI)
+
+	idp := aidbox.Resource{
+		"resourceType": identityProviderResourceType,
+		"id":           model.ID.ValueString(),
+		"type":         preset.Type,
+		"client":       client,
+	}
+	setJSONValue(idp, "title", model.Title)
+	setJSONValue(idp, "active", model.Active)
+	setJSONValue(idp, "authorize_endpoint", model.AuthorizeEndpoint)
+	setJSONValue(idp, "token_endpoint", model.TokenEndpoint)
+	setJSONValue(idp, "userinfo_endpoint", model.UserinfoEndpoint)
+	setJSONValue(idp, "jwks_uri", model.JWKSURI)
+	setJSONValue(idp, "scopes", model.Scopes)
+	setJSONValue(idp, "claim-mapping", model.ClaimMapping)
+	if model.UserinfoEndpoint.IsNull() {
+		idp["userinfo-source"] = "id-token"
+	}
+	if len(preset.AuthorizeParams) > 0 {
+		params := map[string]interface{}{}
+		for name, value := range preset.AuthorizeParams {
+			params[name] = value
+		}
+		idp["authorize-params"] = params
+	}
+	if len(preset.RoleMappings) > 0 {
+		mappings := make([]interface{}, 0, len(preset.RoleMappings))
+		for _, mapping := range preset.RoleMappings {
+			mappings = append(mappings, map[string]interface{}{"value": mapping.Value, "role": mapping.Role})
+		}
+		idp["role-mapping"] = map[string]interface{}{
+			"claim":    preset.RoleClaim,
+			"mappings": mappings,
+		}
+	}
+	if len(preset.AllowedValues) > 0 {
+		values := make([]interface{}, 0, len(preset.AllowedValues))
+		for _, value := range preset.AllowedValues {
+			values = append(values, value)
+		}
+		idp["allowed"] = map[string]interface{}{
+			"claim":  preset.AllowedClaim,
+			"values": values,
+		}
+	}
+
+	return idp
+}
+
+// mapIdentityProviderFromResource maps the IdentityProvider resource stored
+// by the box to model. Preset blocks are not stored by the box and are kept
+// as is. The client secret is only read back when it is stored in state.
+func mapIdentityProviderFromResource(model *IdentityProviderResourceModel, stored aidbox.Resource) {
+	client := jsonObject(stored, "client")
+
+	model.ID = types.StringValue(stored.ID())
+	model.Title = jsonStringValue(stored, "title")
+	model.Active = types.BoolValue(jsonBoolValue(stored, "active").ValueBool())
+	model.ClientID = jsonStringValue(client, "id")
+	model.ClientSecretWO = types.StringNull()
+	if model.ClientSecretWOVersion.IsNull() {
+		model.ClientSecret = secretValueOrPrior(client, "secret", model.ClientSecret)
+	} else {
+		model.ClientSecret = types.StringNull()
+	}
+	model.RedirectURI = jsonStringValue(client, "redirect_uri")
+	model.AuthorizeEndpoint = jsonStringValue(stored, "authorize_endpoint")
+	model.TokenEndpoint = jsonStringValue(stored, "token_endpoint")
+	model.UserinfoEndpoint = jsonStringValue(stored, "userinfo_endpoint")
+	model.JWKSURI = jsonStringValue(stored, "jwks_uri")
+	model.Scopes = jsonStringSet(stored, "scopes")
+	model.ClaimMapping = jsonStringMap(stored, "claim-mapping")
+	model.VersionID = stringValueOrNull(stored.VersionID())
+}
@@ -0,0 +1,75 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccAidboxIdentityProviderResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheckBox(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_5_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccAidboxIdentityProviderResourceConfig("Okta"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("aidbox_identity_provider.test", "id", "tf-acc-okta"),
+					resource.TestCheckResourceAttr("aidbox_identity_provider.test", "authorize_endpoint", "https://example.okta.com/oauth2/default/v1/authorize"),
+					resource.TestCheckResourceAttr("aidbox_identity_provider.test", "token_endpoint", "https://example.okta.com/oauth2/default/v1/token"),
+					resource.TestCheckResourceAttr("aidbox_identity_provider.test", "jwks_uri", "https://example.okta.com/oauth2/default/v1/keys"),
+					resource.TestCheckResourceAttr("aidbox_identity_provider.test", "scopes.#", "3"),
+					resource.TestCheckResourceAttr("aidbox_identity_provider.test", "claim_mapping.email", "email"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "aidbox_identity_provider.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"client_secret", "okta"},
+			},
+			// Import block testing, as with terraform plan -generate-config-out
+			{
+				// The client secret is not read back from the box
+				ResourceName:       "aidbox_identity_provider.test",
+				ImportState:        true,
+				ImportStateKind:    resource.ImportBlockWithID,
+				ExpectNonEmptyPlan: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccAidboxIdentityProviderResourceConfig("Sign in with Okta"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("aidbox_identity_provider.test", "title", "Sign in with Okta"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccAidboxIdentityProviderResourceConfig(title string) string {
+	return fmt.Sprintf(`
+resource "aidbox_identity_provider" "test" {
+  id            = "tf-acc-okta"
+  title         = %[1]q
+  client_id     = "0oa1b2c3d4e5f6g7h8i9"
+  client_secret = "tf-acc-secret"
+
+  okta {
+    domain                  = "example.okta.com"
+    authorization_server_id = "default"
+  }
+}
+`, title)
+}
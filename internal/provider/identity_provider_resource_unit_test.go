@@ -0,0 +1,373 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox/fake"
+)
+
+// identityProviderPlan runs ModifyPlan on an identity provider configured
+// with config and planned with plan, and returns the modified plan.
+func identityProviderPlan(t *testing.T, config, plan IdentityProviderResourceModel) IdentityProviderResourceModel {
+	t.Helper()
+	ctx := context.Background()
+	r := &IdentityProviderResource{}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	null := tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)
+
+	req := resource.ModifyPlanRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: null},
+		Plan:   tfsdk.Plan{Schema: schemaResp.Schema, Raw: null},
+		State:  tfsdk.State{Schema: schemaResp.Schema, Raw: null},
+	}
+	if diags := req.Plan.Set(ctx, &plan); diags.HasError() {
+		t.Fatalf("unable to build plan: %v", diags)
+	}
+	configPlan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: null}
+	if diags := configPlan.Set(ctx, &config); diags.HasError() {
+		t.Fatalf("unable to build config: %v", diags)
+	}
+	req.Config.Raw = configPlan.Raw
+
+	resp := &resource.ModifyPlanResponse{Plan: req.Plan}
+	r.ModifyPlan(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var got IdentityProviderResourceModel
+	if diags := resp.Plan.Get(ctx, &got); diags.HasError() {
+		t.Fatalf("unable to read plan: %v", diags)
+	}
+	return got
+}
+
+func TestIdentityProviderResource_okta(t *testing.T) {
+	config := IdentityProviderResourceModel{
+		ID:                types.StringValue("okta"),
+		Title:             types.StringNull(),
+		Active:            types.BoolNull(),
+		ClientID:          types.StringValue("0oa1"),
+		ClientSecret:      types.StringNull(),
+		RedirectURI:       types.StringNull(),
+		AuthorizeEndpoint: types.StringNull(),
+		TokenEndpoint:     types.StringNull(),
+		UserinfoEndpoint:  types.StringNull(),
+		JWKSURI:           types.StringValue("https://keys.example.com/jwks.json"),
+		Scopes:            types.SetNull(types.StringType),
+		ClaimMapping:      types.MapNull(types.StringType),
+		Okta: &IdentityProviderOktaModel{
+			Domain:                types.StringValue("example.okta.com"),
+			AuthorizationServerID: types.StringValue("default"),
+		},
+		VersionID: types.StringNull(),
+	}
+
+	// The state drifted from the derived token endpoint
+	plan := config
+	plan.Active = types.BoolValue(true)
+	plan.AuthorizeEndpoint = types.StringValue("https://example.okta.com/oauth2/default/v1/authorize")
+	plan.TokenEndpoint = types.StringValue("https://example.okta.com/oauth2/v1/token")
+	plan.UserinfoEndpoint = types.StringValue("https://example.okta.com/oauth2/default/v1/userinfo")
+	plan.VersionID = types.StringValue("1")
+
+	got := identityProviderPlan(t, config, plan)
+	if got.TokenEndpoint.ValueString() != "https://example.okta.com/oauth2/default/v1/token" {
+		t.Errorf("expected the token endpoint to be derived from the preset, got: %s", got.TokenEndpoint)
+	}
+	if got.JWKSURI.ValueString() != "https://keys.example.com/jwks.json" {
+		t.Errorf("expected the configured JWKS URI to be kept, got: %s", got.JWKSURI)
+	}
+	if len(got.Scopes.Elements()) != 3 || got.ClaimMapping.Elements()["email"] != types.StringValue("email") {
+		t.Errorf("unexpected scopes or claim mapping: %s, %s", got.Scopes, got.ClaimMapping)
+	}
+	if !got.VersionID.IsUnknown() {
+		t.Errorf("expected reverting the drift to change the version, got: %s", got.VersionID)
+	}
+}
+
+func TestIdentityProviderResource_auth0(t *testing.T) {
+	model := IdentityProviderResourceModel{
+		ID:       types.StringValue("auth0"),
+		ClientID: types.StringValue("aidbox"),
+		Auth0: &IdentityProviderAuth0Model{
+			Domain:     types.StringValue("example.us.auth0.com"),
+			Connection: types.StringValue("hospital-ad"),
+		},
+	}
+
+	preset, known := identityProviderPresetFor(model)
+	if !known || preset == nil {
+		t.Fatalf("expected the auth0 preset, got: %v", preset)
+	}
+	if preset.TokenEndpoint != "https://example.us.auth0.com/oauth/token" || preset.JWKSURI != "https://example.us.auth0.com/.well-known/jwks.json" {
+		t.Errorf("unexpected endpoints: %+v", preset)
+	}
+	if preset.ClaimMapping["userName"] != "nickname" || oidcClaimMapping["userName"] != "preferred_username" {
+		t.Errorf("expected the nickname to be mapped to the user name, got: %v", preset.ClaimMapping)
+	}
+
+	idp := identityProviderToResource(model, model.ClientSecret)
+	if idp["type"] != "auth0" || jsonObject(idp, "authorize-params")["connection"] != "hospital-ad" {
+		t.Errorf("expected the connection to be passed to the authorize endpoint, got: %v", idp)
+	}
+}
+
+func TestIdentityProviderResource_keycloak(t *testing.T) {
+	model := IdentityProviderResourceModel{
+		ID:       types.StringValue("keycloak"),
+		ClientID: types.StringValue("aidbox"),
+		Keycloak: &IdentityProviderKeycloakModel{
+			BaseURL: types.StringValue("https://sso.example.com/"),
+			Realm:   types.StringValue("clinic"),
+			RoleMappings: []IdentityProviderKeycloakRoleModel{
+				{RealmRole: types.StringValue("physician"), Role: types.StringValue("practitioner")},
+			},
+		},
+	}
+
+	preset, known := identityProviderPresetFor(model)
+	if !known || preset == nil {
+		t.Fatalf("expected the keycloak preset, got: %v", preset)
+	}
+	if preset.AuthorizeEndpoint != "https://sso.example.com/realms/clinic/protocol/openid-connect/auth" {
+		t.Errorf("unexpected authorize endpoint: %s", preset.AuthorizeEndpoint)
+	}
+
+	idp := identityProviderToResource(model, model.ClientSecret)
+	roleMapping := jsonObject(idp, "role-mapping")
+	mappings, ok := roleMapping["mappings"].([]interface{})
+	if roleMapping["claim"] != "realm_access.roles" || !ok || len(mappings) != 1 {
+		t.Fatalf("expected the realm roles to be mapped, got: %v", roleMapping)
+	}
+	if mapping, ok := mappings[0].(map[string]interface{}); !ok || mapping["value"] != "physician" || mapping["role"] != "practitioner" {
+		t.Errorf("unexpected role mapping: %v", mappings[0])
+	}
+}
+
+func TestIdentityProviderResource_azureAD(t *testing.T) {
+	const group = "8f3c1c2e-5b7a-4a37-9d55-0c3d0f1b2a61"
+	model := IdentityProviderResourceModel{
+		ID:               types.StringValue("entra"),
+		ClientID:         types.StringValue("aidbox"),
+		UserinfoEndpoint: types.StringNull(),
+		AzureAD: &IdentityProviderAzureADModel{
+			TenantID:      types.StringValue("0b4f6c5d-2a1e-4c3b-8d7f-9e6a5b4c3d2e"),
+			AllowedGroups: types.SetValueMust(types.StringType, []attr.Value{types.StringValue(group)}),
+			RoleMappings: []IdentityProviderAzureADRoleModel{
+				{Group: types.StringValue(group), Role: types.StringValue("admin")},
+			},
+		},
+	}
+
+	preset, known := identityProviderPresetFor(model)
+	if !known || preset == nil {
+		t.Fatalf("expected the azure_ad preset, got: %v", preset)
+	}
+	if preset.TokenEndpoint != "https://login.microsoftonline.com/0b4f6c5d-2a1e-4c3b-8d7f-9e6a5b4c3d2e/oauth2/v2.0/token" || preset.UserinfoEndpoint != "" {
+		t.Errorf("unexpected endpoints: %+v", preset)
+	}
+
+	idp := identityProviderToResource(model, model.ClientSecret)
+	if idp["userinfo-source"] != "id-token" {
+		t.Errorf("expected the groups to be read from the ID token, got: %v", idp["userinfo-source"])
+	}
+	allowed := jsonObject(idp, "allowed")
+	if values, ok := allowed["values"].([]interface{}); allowed["claim"] != "groups" || !ok || len(values) != 1 || values[0] != group {
+		t.Errorf("expected sign in to be restricted to the group, got: %v", allowed)
+	}
+	if jsonObject(idp, "role-mapping")["claim"] != "groups" {
+		t.Errorf("expected the groups to be mapped to roles, got: %v", idp["role-mapping"])
+	}
+}
+
+func TestIdentityProviderResource_google(t *testing.T) {
+	model := IdentityProviderResourceModel{
+		ID:       types.StringValue("google"),
+		ClientID: types.StringValue("aidbox.apps.googleusercontent.com"),
+		Google: &IdentityProviderGoogleModel{
+			HostedDomains: types.SetValueMust(types.StringType, []attr.Value{types.StringValue("example.com")}),
+		},
+	}
+
+	preset, known := identityProviderPresetFor(model)
+	if !known || preset == nil {
+		t.Fatalf("expected the google preset, got: %v", preset)
+	}
+	if preset.ClaimMapping["userName"] != "email" {
+		t.Errorf("expected the email to be mapped to the user name, got: %v", preset.ClaimMapping)
+	}
+
+	idp := identityProviderToResource(model, model.ClientSecret)
+	if jsonObject(idp, "allowed")["claim"] != "hd" || jsonObject(idp, "authorize-params")["hd"] != "example.com" {
+		t.Errorf("expected sign in to be restricted to the hosted domain, got: %v", idp)
+	}
+
+	// An empty block lets any Google account sign in
+	model.Google.HostedDomains = types.SetNull(types.StringType)
+	idp = identityProviderToResource(model, model.ClientSecret)
+	if _, ok := idp["allowed"]; ok {
+		t.Errorf("expected no restriction, got: %v", idp["allowed"])
+	}
+}
+
+func TestIdentityProviderResource_writeOnlyClientSecret(t *testing.T) {
+	ctx := context.Background()
+	r := &IdentityProviderResource{}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	model := IdentityProviderResourceModel{
+		ID:                    types.StringValue("okta"),
+		ClientID:              types.StringValue("box"),
+		ClientSecret:          types.StringNull(),
+		ClientSecretWO:        types.StringValue("secret"),
+		ClientSecretWOVersion: types.Int64Value(1),
+		Scopes:                types.SetNull(types.StringType),
+		ClaimMapping:          types.MapNull(types.StringType),
+		Okta:                  &IdentityProviderOktaModel{Domain: types.StringValue("example.okta.com")},
+	}
+	plan := tfsdk.Plan{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	if diags := plan.Set(ctx, &model); diags.HasError() {
+		t.Fatalf("unable to build config: %v", diags)
+	}
+	config := tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw}
+
+	// Write-only values are null in the plan
+	model.ClientSecretWO = types.StringNull()
+	clientSecret, diags := identityProviderClientSecret(ctx, config, model)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if clientSecret.ValueString() != "secret" {
+		t.Fatalf("expected the write-only client secret, got: %s", clientSecret)
+	}
+
+	client := fake.NewBoxClient()
+	stored, err := client.PutResource(ctx, identityProviderToResource(model, clientSecret), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if jsonObject(stored, "client")["secret"] != "secret" {
+		t.Errorf("expected the client secret to be sent to the box, got: %v", stored)
+	}
+
+	mapIdentityProviderFromResource(&model, stored)
+	if !model.ClientSecret.IsNull() || !model.ClientSecretWO.IsNull() {
+		t.Errorf("expected no client secret in state, got: %+v", model)
+	}
+	if model.ClientSecretWOVersion.ValueInt64() != 1 || model.ClientID.ValueString() != "box" {
+		t.Errorf("unexpected model: %+v", model)
+	}
+}
+
+func TestIdentityProviderResource_validateConfig(t *testing.T) {
+	ctx := context.Background()
+	r := &IdentityProviderResource{}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	custom := func() IdentityProviderResourceModel {
+		return IdentityProviderResourceModel{
+			ID:                types.StringValue("sso"),
+			ClientID:          types.StringValue("aidbox"),
+			AuthorizeEndpoint: types.StringValue("https://sso.example.com/authorize"),
+			TokenEndpoint:     types.StringValue("https://sso.example.com/token"),
+			JWKSURI:           types.StringValue("https://sso.example.com/jwks.json"),
+			Scopes:            types.SetNull(types.StringType),
+			ClaimMapping:      types.MapNull(types.StringType),
+		}
+	}
+
+	for name, testCase := range map[string]struct {
+		model          func(model *IdentityProviderResourceModel)
+		expectedErrors []string
+	}{
+		"valid": {
+			model: func(model *IdentityProviderResourceModel) {},
+		},
+		"userinfo endpoint": {
+			model: func(model *IdentityProviderResourceModel) {
+				model.JWKSURI = types.StringNull()
+				model.UserinfoEndpoint = types.StringValue("https://sso.example.com/userinfo")
+			},
+		},
+		"preset": {
+			model: func(model *IdentityProviderResourceModel) {
+				model.AuthorizeEndpoint = types.StringNull()
+				model.TokenEndpoint = types.StringNull()
+				model.JWKSURI = types.StringNull()
+				model.Okta = &IdentityProviderOktaModel{Domain: types.StringValue("example.okta.com")}
+			},
+		},
+		"missing endpoints": {
+			model: func(model *IdentityProviderResourceModel) {
+				model.TokenEndpoint = types.StringNull()
+				model.JWKSURI = types.StringNull()
+			},
+			expectedErrors: []string{"token_endpoint", "jwks_uri"},
+		},
+		"scopes": {
+			model: func(model *IdentityProviderResourceModel) {
+				model.Scopes = types.SetValueMust(types.StringType, []attr.Value{types.StringValue("openid"), types.StringValue("email")})
+			},
+		},
+		"unknown scope": {
+			model: func(model *IdentityProviderResourceModel) {
+				model.Scopes = types.SetValueMust(types.StringType, []attr.Value{types.StringUnknown(), types.StringValue("email")})
+			},
+		},
+		"missing openid scope": {
+			model: func(model *IdentityProviderResourceModel) {
+				model.Scopes = types.SetValueMust(types.StringType, []attr.Value{types.StringValue("profile"), types.StringValue("email")})
+			},
+			expectedErrors: []string{"scopes"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			model := custom()
+			testCase.model(&model)
+
+			config := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+			if diags := config.Set(ctx, &model); diags.HasError() {
+				t.Fatalf("unable to build config: %v", diags)
+			}
+
+			resp := &resource.ValidateConfigResponse{}
+			r.ValidateConfig(ctx, resource.ValidateConfigRequest{
+				Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config.Raw},
+			}, resp)
+
+			var got []string
+			for _, d := range resp.Diagnostics.Errors() {
+				if d, ok := d.(diag.DiagnosticWithPath); ok {
+					got = append(got, d.Path().String())
+				}
+			}
+			sort.Strings(got)
+			sort.Strings(testCase.expectedErrors)
+			if strings.Join(got, ",") != strings.Join(testCase.expectedErrors, ",") {
+				t.Errorf("expected errors on %v, got: %v", testCase.expectedErrors, resp.Diagnostics)
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &IGPackagesDataSource{}
+var _ datasource.DataSourceWithConfigure = &IGPackagesDataSource{}
+
+// Installed implementation guides are stored as FHIRPackage resources, one
+// per package version.
+const igPackageResourceType = "FHIRPackage"
+
+func NewIGPackagesDataSource() datasource.DataSource {
+	return &IGPackagesDataSource{}
+}
+
+// IGPackagesDataSource defines the data source implementation.
+type IGPackagesDataSource struct {
+	boxDataSource
+}
+
+// IGPackagesDataSourceModel describes the data source data model.
+type IGPackagesDataSourceModel struct {
+	PageSize types.Int64                        `tfsdk:"page_size"`
+	MaxItems types.Int64                        `tfsdk:"max_items"`
+	Packages []IGPackagesDataSourcePackageModel `tfsdk:"packages"`
+	Versions types.Map                          `tfsdk:"versions"`
+}
+
+// IGPackagesDataSourcePackageModel describes a package of the packages
+// attribute.
+type IGPackagesDataSourcePackageModel struct {
+	Name    types.String `tfsdk:"name"`
+	Version types.String `tfsdk:"version"`
+}
+
+func (d *IGPackagesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ig_packages"
+}
+
+func (d *IGPackagesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the FHIR implementation guide packages installed on the box, such as to only create the resources depending on the profiles of an implementation guide when it is installed.",
+		Attributes: map[string]schema.Attribute{
+			"page_size": pageSizeAttribute(),
+			"max_items": maxItemsAttribute("packages"),
+			"packages": schema.ListAttribute{
+				MarkdownDescription: "Installed packages, ordered by name and version. Each package has a `name`, such as `hl7.fhir.us.core`, and a `version`.",
+				ElementType: types.ObjectType{
+					AttrTypes: map[string]attr.Type{
+						"name":    types.StringType,
+						"version": types.StringType,
+					},
+				},
+				Computed: true,
+			},
+			"versions": schema.MapAttribute{
+				MarkdownDescription: "Installed packages by name, with their version. When several versions of a package are installed, the last one in the order of `packages` is kept.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *IGPackagesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model IGPackagesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	packages, err := d.client.SearchResources(ctx, igPackageResourceType, searchParams(model.PageSize), searchLimit(model.MaxItems))
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostic("Failed to List IG Packages", "Unable to search installed packages", err))
+		return
+	}
+
+	mapIGPackages(&model, packages)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+// mapIGPackages maps the packages installed on the box to model, ordered by
+// name and version.
+func mapIGPackages(model *IGPackagesDataSourceModel, packages []aidbox.Resource) {
+	model.Packages = make([]IGPackagesDataSourcePackageModel, 0, len(packages))
+	for _, pkg := range packages {
+		model.Packages = append(model.Packages, IGPackagesDataSourcePackageModel{
+			Name:    jsonStringValue(pkg, "name"),
+			Version: jsonStringValue(pkg, "version"),
+		})
+	}
+
+	sort.Slice(model.Packages, func(i, j int) bool {
+		if model.Packages[i].Name != model.Packages[j].Name {
+			return model.Packages[i].Name.ValueString() < model.Packages[j].Name.ValueString()
+		}
+		return compareVersions(model.Packages[i].Version.ValueString(), model.Packages[j].Version.ValueString()) < 0
+	})
+
+	versions := make(map[string]attr.Value, len(model.Packages))
+	for _, pkg := range model.Packages {
+		if !pkg.Name.IsNull() {
+			versions[pkg.Name.ValueString()] = pkg.Version
+		}
+	}
+	model.Versions = types.MapValueMust(types.StringType, versions)
+}
+
+// compareVersions compares package versions such as 6.1.0 or 1.0.0-ballot,
+// comparing numeric parts numerically.
+func compareVersions(a, b string) int {
+	aParts, bParts := strings.FieldsFunc(a, isVersionSeparator), strings.FieldsFunc(b, isVersionSeparator)
+	for i := 0; i < len(aParts) && i < len(bParts); i++ {
+		aNumber, aErr := strconv.Atoi(aParts[i])
+		bNumber, bErr := strconv.Atoi(bParts[i])
+		switch {
+		case aErr == nil && bErr == nil && aNumber != bNumber:
+			return aNumber - bNumber
+		case (aErr != nil || bErr != nil) && aParts[i] != bParts[i]:
+			return strings.Compare(aParts[i], bParts[i])
+		}
+	}
+	return len(aParts) - len(bParts)
+}
+
+func isVersionSeparator(r rune) bool {
+	return r == '.' || r == '-'
+}
@@ -0,0 +1,33 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccAidboxIGPackagesDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckBox(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccAidboxIGPackagesDataSourceConfig(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.aidbox_ig_packages.test", "packages.#"),
+					resource.TestCheckResourceAttrSet("data.aidbox_ig_packages.test", "versions.%"),
+				),
+			},
+		},
+	})
+}
+
+func testAccAidboxIGPackagesDataSourceConfig() string {
+	return `
+data "aidbox_ig_packages" "test" {}
+`
+}
@@ -0,0 +1,52 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox"
+)
+
+func TestMapIGPackages(t *testing.T) {
+	var model IGPackagesDataSourceModel
+	mapIGPackages(&model, []aidbox.Resource{
+		{"resourceType": "FHIRPackage", "id": "us-core-10", "name": "hl7.fhir.us.core", "version": "10.0.0"},
+		{"resourceType": "FHIRPackage", "id": "r4-core", "name": "hl7.fhir.r4.core", "version": "4.0.1"},
+		{"resourceType": "FHIRPackage", "id": "us-core-6", "name": "hl7.fhir.us.core", "version": "6.1.0"},
+	})
+
+	expected := []string{"hl7.fhir.r4.core@4.0.1", "hl7.fhir.us.core@6.1.0", "hl7.fhir.us.core@10.0.0"}
+	if len(model.Packages) != len(expected) {
+		t.Fatalf("expected packages %v, got: %+v", expected, model.Packages)
+	}
+	for i, pkg := range model.Packages {
+		if got := pkg.Name.ValueString() + "@" + pkg.Version.ValueString(); got != expected[i] {
+			t.Errorf("expected package %s at %d, got: %s", expected[i], i, got)
+		}
+	}
+
+	if version := model.Versions.Elements()["hl7.fhir.us.core"]; version == nil || version.String() != `"10.0.0"` {
+		t.Errorf("expected the last version of US Core, got: %v", model.Versions)
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	testCases := []struct {
+		a, b     string
+		expected int
+	}{
+		{"6.1.0", "6.1.0", 0},
+		{"6.1.0", "10.0.0", -1},
+		{"4.0.1", "4.0", 1},
+		{"1.0.0-ballot", "1.0.0-snapshot", -1},
+	}
+
+	for _, testCase := range testCases {
+		got := compareVersions(testCase.a, testCase.b)
+		if (got < 0 && testCase.expected >= 0) || (got > 0 && testCase.expected <= 0) || (got == 0 && testCase.expected != 0) {
+			t.Errorf("compareVersions(%q, %q) = %d, expected the sign of %d", testCase.a, testCase.b, got, testCase.expected)
+		}
+	}
+}
@@ -0,0 +1,268 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ImportBlocksDataSource{}
+var _ datasource.DataSourceWithConfigure = &ImportBlocksDataSource{}
+
+// importableResource describes where the resources managed by a resource
+// type of the provider are stored in the box.
+type importableResource struct {
+	resourceType string
+	// id is the ID of the single resource of configuration resource types,
+	// such as AidboxConfig/sms-provider. The resources of other types are
+	// searched.
+	id string
+	// match selects the resources of resourceType managed by the resource
+	// type, when several resource types share resourceType.
+	match func(aidbox.Resource) bool
+}
+
+// importableResources are the resource types of the provider whose resources
+// can be listed, by Terraform resource type.
+var importableResources = map[string]importableResource{
+	"aidbox_access_policy":          {resourceType: accessPolicyResourceType, match: isAccessPolicy},
+	"aidbox_archive_policy":         {resourceType: archivePolicyResourceType},
+	"aidbox_audit_config":           {resourceType: auditConfigResourceType, id: auditConfigID},
+	"aidbox_consent_policy":         {resourceType: consentPolicyResourceType, match: fieldEquals("engine", consentPolicyEngine)},
+	"aidbox_db_settings":            {resourceType: dbSettingsResourceType, id: dbSettingsID},
+	"aidbox_email_provider":         {resourceType: emailProviderResourceType, id: emailProviderID},
+	"aidbox_identity_provider":      {resourceType: identityProviderResourceType, match: isOIDCIdentityProvider},
+	"aidbox_job":                    {resourceType: jobResourceType},
+	"aidbox_jwks_config":            {resourceType: jwksConfigResourceType, match: fieldEquals("type", jwksConfigType)},
+	"aidbox_ldap_identity_provider": {resourceType: identityProviderResourceType, match: fieldEquals("type", ldapIdentityProviderType)},
+	"aidbox_notification_template":  {resourceType: notificationTemplateResourceType},
+	"aidbox_patient_access_config":  {resourceType: patientAccessConfigResourceType, id: patientAccessConfigID},
+	"aidbox_saml_identity_provider": {resourceType: identityProviderResourceType, match: fieldEquals("type", samlIdentityProviderType)},
+	"aidbox_scim_config":            {resourceType: scimConfigResourceType, id: scimConfigID},
+	"aidbox_security_labels_config": {resourceType: securityLabelsConfigResourceType, id: securityLabelsConfigID},
+	"aidbox_smart_app":              {resourceType: smartAppResourceType, match: fieldEquals("type", smartAppClientType)},
+	"aidbox_smart_config":           {resourceType: smartConfigResourceType, id: smartConfigID},
+	"aidbox_sms_provider":           {resourceType: smsProviderResourceType, id: smsProviderID},
+	"aidbox_task_definition":        {resourceType: taskDefinitionResourceType},
+	"aidbox_webhook":                {resourceType: webhookResourceType},
+	"aidbox_workflow_definition":    {resourceType: workflowDefinitionResourceType},
+}
+
+// fieldEquals returns a match of the resources whose field is value.
+func fieldEquals(field, value string) func(aidbox.Resource) bool {
+	return func(resource aidbox.Resource) bool {
+		fieldValue, _ := resource[field].(string)
+		return fieldValue == value
+	}
+}
+
+// isAccessPolicy matches the access policies which are not managed by
+// aidbox_consent_policy.
+func isAccessPolicy(resource aidbox.Resource) bool {
+	engine, _ := resource["engine"].(string)
+	return engine != consentPolicyEngine
+}
+
+// isOIDCIdentityProvider matches the identity providers which are not
+// managed by aidbox_ldap_identity_provider or aidbox_saml_identity_provider.
+func isOIDCIdentityProvider(resource aidbox.Resource) bool {
+	providerType, _ := resource["type"].(string)
+	return providerType != ldapIdentityProviderType && providerType != samlIdentityProviderType
+}
+
+// importableResourceTypes returns the Terraform resource types of
+// importableResources, sorted.
+func importableResourceTypes() []string {
+	resourceTypes := make([]string, 0, len(importableResources))
+	for resourceType := range importableResources {
+		resourceTypes = append(resourceTypes, resourceType)
+	}
+	sort.Strings(resourceTypes)
+	return resourceTypes
+}
+
+func NewImportBlocksDataSource() datasource.DataSource {
+	return &ImportBlocksDataSource{}
+}
+
+// ImportBlocksDataSource defines the data source implementation.
+type ImportBlocksDataSource struct {
+	boxDataSource
+}
+
+// ImportBlocksDataSourceModel describes the data source data model.
+type ImportBlocksDataSourceModel struct {
+	ResourceTypes types.Set                           `tfsdk:"resource_types"`
+	Imports       []ImportBlocksDataSourceImportModel `tfsdk:"imports"`
+	HCL           types.String                        `tfsdk:"hcl"`
+}
+
+// ImportBlocksDataSourceImportModel describes an import of the imports
+// attribute.
+type ImportBlocksDataSourceImportModel struct {
+	To types.String `tfsdk:"to"`
+	ID types.String `tfsdk:"id"`
+}
+
+func (d *ImportBlocksDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_import_blocks"
+}
+
+func (d *ImportBlocksDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the resources of the box managed by resources of the provider, with the `import` blocks importing them, such as to adopt Terraform on a box configured by hand. The generated blocks can be written to a file and completed with `terraform plan -generate-config-out`.",
+		Attributes: map[string]schema.Attribute{
+			"resource_types": schema.SetAttribute{
+				MarkdownDescription: fmt.Sprintf("Resource types of the provider to list the resources of. Without it, the resources of all the supported resource types are listed: `%s`.", strings.Join(importableResourceTypes(), "`, `")),
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(stringvalidator.OneOf(importableResourceTypes()...)),
+				},
+			},
+			"imports": schema.ListAttribute{
+				MarkdownDescription: "Resources to import, ordered by resource type and ID. Each has the address it is imported `to`, named after its ID, and the `id` to import.",
+				ElementType: types.ObjectType{
+					AttrTypes: map[string]attr.Type{
+						"to": types.StringType,
+						"id": types.StringType,
+					},
+				},
+				Computed: true,
+			},
+			"hcl": schema.StringAttribute{
+				MarkdownDescription: "`import` blocks of the resources to import, in the order of `imports`",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *ImportBlocksDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model ImportBlocksDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resourceTypes := importableResourceTypes()
+	if !model.ResourceTypes.IsNull() {
+		resourceTypes = nil
+		resp.Diagnostics.Append(model.ResourceTypes.ElementsAs(ctx, &resourceTypes, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		sort.Strings(resourceTypes)
+	}
+
+	ids, err := listImportableResources(ctx, d.client, resourceTypes)
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostic("Failed to List Resources", "Unable to list the resources of the box", err))
+		return
+	}
+
+	model.Imports, model.HCL = importBlocks(resourceTypes, ids)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+// listImportableResources returns the IDs of the resources of the box
+// managed by resourceTypes, by Terraform resource type.
+func listImportableResources(ctx context.Context, client BoxClient, resourceTypes []string) (map[string][]string, error) {
+	// Several resource types of the provider share the same type of
+	// resources, such as the Client resources of API keys and SMART apps.
+	searched := map[string][]aidbox.Resource{}
+	ids := map[string][]string{}
+	for _, resourceType := range resourceTypes {
+		importable, ok := importableResources[resourceType]
+		if !ok {
+			return nil, fmt.Errorf("unsupported resource type %s, expected one of: %s", resourceType, strings.Join(importableResourceTypes(), ", "))
+		}
+
+		if importable.id != "" {
+			_, err := client.GetResource(ctx, importable.resourceType, importable.id)
+			if aidbox.IsNotFound(err) {
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("unable to fetch the %s resource: %w", resourceType, err)
+			}
+			ids[resourceType] = []string{importable.id}
+			continue
+		}
+
+		resources, ok := searched[importable.resourceType]
+		if !ok {
+			var err error
+			resources, err = client.SearchResources(ctx, importable.resourceType, searchParams(types.Int64Null()), 0)
+			if err != nil {
+				return nil, fmt.Errorf("unable to search %s resources: %w", importable.resourceType, err)
+			}
+			searched[importable.resourceType] = resources
+		}
+
+		for _, resource := range resources {
+			if importable.match == nil || importable.match(resource) {
+				ids[resourceType] = append(ids[resourceType], resource.ID())
+			}
+		}
+	}
+	return ids, nil
+}
+
+// importBlocks returns the imports of the resources with the given IDs, by
+// Terraform resource type, and their import blocks.
+func importBlocks(resourceTypes []string, ids map[string][]string) ([]ImportBlocksDataSourceImportModel, types.String) {
+	imports := []ImportBlocksDataSourceImportModel{}
+	var hcl strings.Builder
+	for _, resourceType := range resourceTypes {
+		resourceIDs := append([]string(nil), ids[resourceType]...)
+		sort.Strings(resourceIDs)
+
+		names := map[string]bool{}
+		for _, id := range resourceIDs {
+			to := resourceType + "." + importResourceName(id, names)
+			imports = append(imports, ImportBlocksDataSourceImportModel{
+				To: types.StringValue(to),
+				ID: types.StringValue(id),
+			})
+
+			if hcl.Len() > 0 {
+				hcl.WriteString("\n")
+			}
+			fmt.Fprintf(&hcl, "import {\n  to = %s\n  id = %q\n}\n", to, id)
+		}
+	}
+	return imports, types.StringValue(hcl.String())
+}
+
+var invalidNameCharacters = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// importResourceName returns a valid Terraform resource name for the
+// resource with the given ID, such as my_hook for My-Hook, not in names.
+func importResourceName(id string, names map[string]bool) string {
+	name := strings.Trim(invalidNameCharacters.ReplaceAllString(strings.ToLower(id), "_"), "_")
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = "r_" + name
+	}
+
+	unique := name
+	for i := 2; names[unique]; i++ {
+		unique = fmt.Sprintf("%s_%d", name, i)
+	}
+	names[unique] = true
+	return unique
+}
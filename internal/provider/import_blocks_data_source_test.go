@@ -0,0 +1,47 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccAidboxImportBlocksDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckBox(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccAidboxImportBlocksDataSourceConfig(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs("data.aidbox_import_blocks.test", "imports.*", map[string]string{
+						"to": "aidbox_webhook.tf_acc_import_blocks",
+						"id": "tf-acc-import-blocks",
+					}),
+					resource.TestMatchResourceAttr("data.aidbox_import_blocks.test", "hcl", regexp.MustCompile(`id = "tf-acc-import-blocks"`)),
+				),
+			},
+		},
+	})
+}
+
+func testAccAidboxImportBlocksDataSourceConfig() string {
+	return `
+resource "aidbox_webhook" "test" {
+  id     = "tf-acc-import-blocks"
+  url    = "https://example.com/hooks/terraform"
+  events = ["User/create"]
+}
+
+data "aidbox_import_blocks" "test" {
+  resource_types = ["aidbox_webhook"]
+
+  depends_on = [aidbox_webhook.test]
+}
+`
+}
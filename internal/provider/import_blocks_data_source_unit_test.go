@@ -0,0 +1,69 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+)
+
+func TestImportBlocks(t *testing.T) {
+	imports, hcl := importBlocks(
+		[]string{"aidbox_sms_provider", "aidbox_webhook"},
+		map[string][]string{
+			"aidbox_sms_provider": {smsProviderID},
+			"aidbox_webhook":      {"notify-ci", "Notify_CI", "1st"},
+		},
+	)
+
+	expected := []struct{ to, id string }{
+		{"aidbox_sms_provider.sms_provider", "sms-provider"},
+		{"aidbox_webhook.r_1st", "1st"},
+		{"aidbox_webhook.notify_ci", "Notify_CI"},
+		{"aidbox_webhook.notify_ci_2", "notify-ci"},
+	}
+	if len(imports) != len(expected) {
+		t.Fatalf("expected %d imports, got: %v", len(expected), imports)
+	}
+	for i, e := range expected {
+		if imports[i].To.ValueString() != e.to || imports[i].ID.ValueString() != e.id {
+			t.Errorf("expected import of %s to %s, got: %v", e.id, e.to, imports[i])
+		}
+	}
+
+	expectedHCL := `import {
+  to = aidbox_sms_provider.sms_provider
+  id = "sms-provider"
+}
+
+import {
+  to = aidbox_webhook.r_1st
+  id = "1st"
+}
+
+import {
+  to = aidbox_webhook.notify_ci
+  id = "Notify_CI"
+}
+
+import {
+  to = aidbox_webhook.notify_ci_2
+  id = "notify-ci"
+}
+`
+	if hcl.ValueString() != expectedHCL {
+		t.Errorf("unexpected import blocks:\n%s", hcl.ValueString())
+	}
+}
+
+func TestImportableResources(t *testing.T) {
+	identityProviders := importableResources["aidbox_identity_provider"]
+	if !identityProviders.match(map[string]interface{}{"type": "okta"}) || identityProviders.match(map[string]interface{}{"type": ldapIdentityProviderType}) {
+		t.Errorf("expected the LDAP identity providers to be excluded from aidbox_identity_provider")
+	}
+
+	smartApps := importableResources["aidbox_smart_app"]
+	if smartApps.match(map[string]interface{}{"type": apiKeyClientType}) {
+		t.Errorf("expected API keys to be excluded from aidbox_smart_app")
+	}
+}
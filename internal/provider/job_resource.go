@@ -0,0 +1,223 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox"
+	"regexp"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &JobResource{}
+var _ resource.ResourceWithConfigValidators = &JobResource{}
+var _ resource.ResourceWithImportState = &JobResource{}
+
+const jobResourceType = "AidboxJob"
+
+// timeOfDayRegexp matches a time of the day in the HH:MM format, as used by
+// the box to schedule daily runs.
+var timeOfDayRegexp = regexp.MustCompile(`^([01][0-9]|2[0-3]):[0-5][0-9]$`)
+
+func NewJobResource() resource.Resource {
+	return &JobResource{}
+}
+
+// JobResource defines the resource implementation.
+type JobResource struct {
+	boxResource
+}
+
+// JobResourceModel describes the resource data model.
+type JobResourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	Module    types.String `tfsdk:"module"`
+	Every     types.Int64  `tfsdk:"every"`
+	At        types.String `tfsdk:"at"`
+	Status    types.String `tfsdk:"status"`
+	VersionID types.String `tfsdk:"version_id"`
+}
+
+func (r *JobResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_job"
+}
+
+func (r *JobResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages an AidboxJob, a background job the box runs periodically or at a given time of the day, such as a nightly deidentification run.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the job. Changing it forces a new job to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validFHIRID(),
+				},
+			},
+			"module": schema.StringAttribute{
+				MarkdownDescription: "Module implementing the job, such as `aidbox.deidentification/run`",
+				Required:            true,
+			},
+			"every": schema.Int64Attribute{
+				MarkdownDescription: "Interval, in seconds, between two runs of the job. Exactly one of `every` or `at` must be set.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"at": schema.StringAttribute{
+				MarkdownDescription: "Time of the day the job runs at, in the `HH:MM` format and the UTC time zone. Exactly one of `every` or `at` must be set.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(timeOfDayRegexp, "must be a time of the day in the HH:MM format"),
+				},
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "Status of the job, `active` or `stop` to pause it. Defaults to `active`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("active"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("active", "stop"),
+				},
+			},
+			"version_id": schema.StringAttribute{
+				MarkdownDescription: "Version of the job, incremented by the box on every change",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *JobResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("every"),
+			path.MatchRoot("at"),
+		),
+	}
+}
+
+func (r *JobResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var model JobResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stored, err := r.client.PutResource(ctx, jobToResource(model), "")
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostic("Failed to Create Job", "Unable to create job", err))
+		return
+	}
+
+	mapJobFromResource(&model, stored)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *JobResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var model JobResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stored, err := r.client.GetResourceIfModified(ctx, jobResourceType, model.ID.ValueString(), model.VersionID.ValueString())
+	if aidbox.IsNotModified(err) {
+		return
+	}
+	if aidbox.IsNotFound(err) {
+		tflog.Warn(ctx, "Job not found, removing from state", map[string]interface{}{"id": model.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostic("Failed to Fetch Job", "Unable to fetch job", err))
+		return
+	}
+
+	mapJobFromResource(&model, stored)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *JobResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var model JobResourceModel
+	var versionID types.String
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("version_id"), &versionID)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stored, err := r.client.PutResource(ctx, jobToResource(model), versionID.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(updateErrorDiagnostic("job "+model.ID.ValueString(), "Failed to Update Job", "Unable to update job", err))
+		return
+	}
+
+	mapJobFromResource(&model, stored)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *JobResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var model JobResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteResource(ctx, jobResourceType, model.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(deleteErrorDiagnostic(
+			"job "+model.ID.ValueString(),
+			"Failed to Delete Job",
+			fmt.Sprintf("Error while trying to delete the job with ID %s", model.ID.ValueString()),
+			err,
+		))
+	}
+}
+
+func (r *JobResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+func jobToResource(model JobResourceModel) aidbox.Resource {
+	job := aidbox.Resource{
+		"resourceType": jobResourceType,
+		"id":           model.ID.ValueString(),
+	}
+	setJSONValue(job, "module", model.Module)
+	setJSONValue(job, "every", model.Every)
+	setJSONValue(job, "at", model.At)
+	setJSONValue(job, "status", model.Status)
+
+	return job
+}
+
+func mapJobFromResource(model *JobResourceModel, stored aidbox.Resource) {
+	model.ID = types.StringValue(stored.ID())
+	model.Module = jsonStringValue(stored, "module")
+	model.Every = jsonInt64Value(stored, "every")
+	model.At = jsonStringValue(stored, "at")
+	model.Status = jsonStringValue(stored, "status")
+	if model.Status.IsNull() {
+		model.Status = types.StringValue("active")
+	}
+	model.VersionID = stringValueOrNull(stored.VersionID())
+}
@@ -0,0 +1,66 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccAidboxJobResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheckBox(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_5_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccAidboxJobResourceConfig("02:00", "active"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("aidbox_job.test", "id", "tf-acc-nightly"),
+					resource.TestCheckResourceAttr("aidbox_job.test", "at", "02:00"),
+					resource.TestCheckResourceAttr("aidbox_job.test", "status", "active"),
+					resource.TestCheckResourceAttrSet("aidbox_job.test", "version_id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "aidbox_job.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Import block testing, as with terraform plan -generate-config-out
+			{
+				ResourceName:    "aidbox_job.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithID,
+			},
+			// Update and Read testing
+			{
+				Config: testAccAidboxJobResourceConfig("03:30", "stop"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("aidbox_job.test", "at", "03:30"),
+					resource.TestCheckResourceAttr("aidbox_job.test", "status", "stop"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccAidboxJobResourceConfig(at, status string) string {
+	return fmt.Sprintf(`
+resource "aidbox_job" "test" {
+  id     = "tf-acc-nightly"
+  module = "aidbox.deidentification/run"
+  at     = %[1]q
+  status = %[2]q
+}
+`, at, status)
+}
@@ -0,0 +1,84 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"math/big"
+)
+
+// decodeJSONValue decodes a JSON document into a framework value suitable for
+// returning from functions with a dynamic return type. Objects become object
+// values, arrays become tuples and numbers keep their full precision.
+func decodeJSONValue(ctx context.Context, data []byte) (attr.Value, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var raw interface{}
+	if err := decoder.Decode(&raw); err != nil {
+		return nil, err
+	}
+
+	return jsonToValue(ctx, raw)
+}
+
+// jsonToValue converts a value produced by encoding/json (decoded with
+// UseNumber) into the equivalent framework value.
+func jsonToValue(ctx context.Context, raw interface{}) (attr.Value, error) {
+	switch v := raw.(type) {
+	case nil:
+		return types.DynamicNull(), nil
+	case bool:
+		return types.BoolValue(v), nil
+	case string:
+		return types.StringValue(v), nil
+	case json.Number:
+		n, ok := new(big.Float).SetString(v.String())
+		if !ok {
+			return nil, fmt.Errorf("invalid number %q", v.String())
+		}
+		return types.NumberValue(n), nil
+	case float64:
+		return types.NumberValue(big.NewFloat(v)), nil
+	case []interface{}:
+		elemTypes := make([]attr.Type, len(v))
+		elems := make([]attr.Value, len(v))
+		for i, item := range v {
+			elem, err := jsonToValue(ctx, item)
+			if err != nil {
+				return nil, err
+			}
+			elemTypes[i] = elem.Type(ctx)
+			elems[i] = elem
+		}
+		tuple, diags := types.TupleValue(elemTypes, elems)
+		if diags.HasError() {
+			return nil, fmt.Errorf("unable to build tuple value: %v", diags)
+		}
+		return tuple, nil
+	case map[string]interface{}:
+		attrTypes := make(map[string]attr.Type, len(v))
+		attrs := make(map[string]attr.Value, len(v))
+		for key, item := range v {
+			elem, err := jsonToValue(ctx, item)
+			if err != nil {
+				return nil, err
+			}
+			attrTypes[key] = elem.Type(ctx)
+			attrs[key] = elem
+		}
+		object, diags := types.ObjectValue(attrTypes, attrs)
+		if diags.HasError() {
+			return nil, fmt.Errorf("unable to build object value: %v", diags)
+		}
+		return object, nil
+	default:
+		return nil, fmt.Errorf("unsupported JSON value of type %T", raw)
+	}
+}
@@ -0,0 +1,287 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox"
+	"regexp"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &JWKSConfigResource{}
+var _ resource.ResourceWithImportState = &JWKSConfigResource{}
+var _ resource.ResourceWithValidateConfig = &JWKSConfigResource{}
+
+// Trusted issuers are stored as TokenIntrospector resources of the jwt type.
+const (
+	jwksConfigResourceType = "TokenIntrospector"
+	jwksConfigType         = "jwt"
+)
+
+// jwksConfigAlgorithms are the signing algorithms of the static keys.
+var jwksConfigAlgorithms = []string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512", "PS256", "PS384", "PS512"}
+
+func NewJWKSConfigResource() resource.Resource {
+	return &JWKSConfigResource{}
+}
+
+// JWKSConfigResource defines the resource implementation.
+type JWKSConfigResource struct {
+	boxResource
+}
+
+// JWKSConfigResourceModel describes the resource data model.
+type JWKSConfigResourceModel struct {
+	ID        types.String         `tfsdk:"id"`
+	Issuer    types.String         `tfsdk:"issuer"`
+	Audiences types.Set            `tfsdk:"audiences"`
+	JWKSURI   types.String         `tfsdk:"jwks_uri"`
+	Keys      []JWKSConfigKeyModel `tfsdk:"key"`
+	VersionID types.String         `tfsdk:"version_id"`
+}
+
+// JWKSConfigKeyModel describes the key blocks.
+type JWKSConfigKeyModel struct {
+	KID       types.String `tfsdk:"kid"`
+	Algorithm types.String `tfsdk:"algorithm"`
+	PublicKey types.String `tfsdk:"public_key"`
+}
+
+func (r *JWKSConfigResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_jwks_config"
+}
+
+func (r *JWKSConfigResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages an external issuer of JWT access tokens the box trusts, such as another authorization server or a partner platform. " +
+			"Tokens are validated with the keys published by the issuer at `jwks_uri`, or with static `key` blocks. Declare one resource per issuer to trust several issuers.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the TokenIntrospector resource holding the issuer. Changing it forces a new issuer to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validFHIRID(),
+				},
+			},
+			"issuer": schema.StringAttribute{
+				MarkdownDescription: "Issuer of the tokens, matched against their `iss` claim",
+				Required:            true,
+			},
+			"audiences": schema.SetAttribute{
+				MarkdownDescription: "Audiences accepted in the `aud` claim of the tokens. Without it, the audience of the tokens is not checked.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+			"jwks_uri": schema.StringAttribute{
+				MarkdownDescription: "URL of the JSON Web Key Set the issuer publishes its signing keys at. Exactly one of `jwks_uri` or `key` blocks must be set.",
+				Optional:            true,
+				Validators: []validator.String{
+					validURI("https"),
+				},
+			},
+			"version_id": schema.StringAttribute{
+				MarkdownDescription: "Version of the TokenIntrospector resource, incremented by the box on every change",
+				Computed:            true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"key": schema.ListNestedBlock{
+				MarkdownDescription: "Static key the issuer signs tokens with, for issuers not publishing a JSON Web Key Set. Keys can be rotated by adding the new key before removing the old one.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"kid": schema.StringAttribute{
+							MarkdownDescription: "ID of the key, matched against the `kid` header of the tokens",
+							Required:            true,
+						},
+						"algorithm": schema.StringAttribute{
+							MarkdownDescription: "Algorithm the tokens are signed with. Defaults to `RS256`.",
+							Optional:            true,
+							Computed:            true,
+							Default:             stringdefault.StaticString("RS256"),
+							Validators: []validator.String{
+								stringvalidator.OneOf(jwksConfigAlgorithms...),
+							},
+						},
+						"public_key": schema.StringAttribute{
+							MarkdownDescription: "PEM encoded public key",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.RegexMatches(regexp.MustCompile(`^\s*-----BEGIN PUBLIC KEY-----`), "must be a PEM encoded public key"),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *JWKSConfigResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var model JWKSConfigResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() || model.JWKSURI.IsUnknown() {
+		return
+	}
+
+	if model.JWKSURI.IsNull() == (len(model.Keys) == 0) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("jwks_uri"),
+			"Invalid Key Set",
+			"Exactly one of the jwks_uri attribute or key blocks must be set.",
+		)
+	}
+}
+
+func (r *JWKSConfigResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var model JWKSConfigResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stored, err := r.client.PutResource(ctx, jwksConfigToResource(model), "")
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostic("Failed to Create JWKS Config", "Unable to create JWKS config", err))
+		return
+	}
+
+	mapJWKSConfigFromResource(&model, stored)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *JWKSConfigResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var model JWKSConfigResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stored, err := r.client.GetResourceIfModified(ctx, jwksConfigResourceType, model.ID.ValueString(), model.VersionID.ValueString())
+	if aidbox.IsNotModified(err) {
+		return
+	}
+	if aidbox.IsNotFound(err) {
+		tflog.Warn(ctx, "JWKS config not found, removing from state", map[string]interface{}{"id": model.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostic("Failed to Fetch JWKS Config", "Unable to fetch JWKS config", err))
+		return
+	}
+
+	mapJWKSConfigFromResource(&model, stored)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *JWKSConfigResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var model JWKSConfigResourceModel
+	var versionID types.String
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("version_id"), &versionID)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stored, err := r.client.PutResource(ctx, jwksConfigToResource(model), versionID.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(updateErrorDiagnostic("JWKS config "+model.ID.ValueString(), "Failed to Update JWKS Config", "Unable to update JWKS config", err))
+		return
+	}
+
+	mapJWKSConfigFromResource(&model, stored)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *JWKSConfigResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var model JWKSConfigResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteResource(ctx, jwksConfigResourceType, model.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(deleteErrorDiagnostic(
+			"JWKS config "+model.ID.ValueString(),
+			"Failed to Delete JWKS Config",
+			fmt.Sprintf("Error while trying to delete the JWKS config with ID %s", model.ID.ValueString()),
+			err,
+		))
+	}
+}
+
+func (r *JWKSConfigResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+func jwksConfigToResource(model JWKSConfigResourceModel) aidbox.Resource {
+	jwt := map[string]interface{}{}
+	setJSONValue(jwt, "iss", model.Issuer)
+	setJSONValue(jwt, "aud", model.Audiences)
+	if len(model.Keys) > 0 {
+		keys := make([]interface{}, 0, len(model.Keys))
+		for _, key := range model.Keys {
+			k := map[string]interface{}{}
+			setJSONValue(k, "kid", key.KID)
+			setJSONValue(k, "alg", key.Algorithm)
+			setJSONValue(k, "pem", key.PublicKey)
+			keys = append(keys, k)
+		}
+		jwt["keys"] = keys
+	}
+
+	introspector := aidbox.Resource{
+		"resourceType": jwksConfigResourceType,
+		"id":           model.ID.ValueString(),
+		"type":         jwksConfigType,
+		"jwt":          jwt,
+	}
+	setJSONValue(introspector, "jwks_uri", model.JWKSURI)
+
+	return introspector
+}
+
+func mapJWKSConfigFromResource(model *JWKSConfigResourceModel, stored aidbox.Resource) {
+	jwt := jsonObject(stored, "jwt")
+
+	model.ID = types.StringValue(stored.ID())
+	model.Issuer = jsonStringValue(jwt, "iss")
+	model.Audiences = jsonStringSet(jwt, "aud")
+	model.JWKSURI = jsonStringValue(stored, "jwks_uri")
+	model.VersionID = stringValueOrNull(stored.VersionID())
+
+	model.Keys = nil
+	keys, _ := jwt["keys"].([]interface{})
+	for _, key := range keys {
+		k, ok := key.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		model.Keys = append(model.Keys, JWKSConfigKeyModel{
+			KID:       jsonStringValue(k, "kid"),
+			Algorithm: jsonStringValue(k, "alg"),
+			PublicKey: jsonStringValue(k, "pem"),
+		})
+	}
+}
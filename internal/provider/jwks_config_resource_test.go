@@ -0,0 +1,66 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccAidboxJWKSConfigResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheckBox(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_5_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccAidboxJWKSConfigResourceConfig("https://box.example.com"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("aidbox_jwks_config.test", "id", "tf-acc-jwks"),
+					resource.TestCheckResourceAttr("aidbox_jwks_config.test", "issuer", "https://tf-acc.example.com"),
+					resource.TestCheckResourceAttr("aidbox_jwks_config.test", "audiences.#", "1"),
+					resource.TestCheckTypeSetElemAttr("aidbox_jwks_config.test", "audiences.*", "https://box.example.com"),
+					resource.TestCheckResourceAttrSet("aidbox_jwks_config.test", "version_id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "aidbox_jwks_config.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Import block testing, as with terraform plan -generate-config-out
+			{
+				ResourceName:    "aidbox_jwks_config.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithID,
+			},
+			// Update and Read testing
+			{
+				Config: testAccAidboxJWKSConfigResourceConfig("https://api.example.com"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckTypeSetElemAttr("aidbox_jwks_config.test", "audiences.*", "https://api.example.com"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccAidboxJWKSConfigResourceConfig(audience string) string {
+	return fmt.Sprintf(`
+resource "aidbox_jwks_config" "test" {
+  id        = "tf-acc-jwks"
+  issuer    = "https://tf-acc.example.com"
+  audiences = [%[1]q]
+  jwks_uri  = "https://tf-acc.example.com/.well-known/jwks.json"
+}
+`, audience)
+}
@@ -0,0 +1,111 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox/fake"
+)
+
+func TestJWKSConfigResource_staticKeys(t *testing.T) {
+	ctx := context.Background()
+	model := JWKSConfigResourceModel{
+		ID:        types.StringValue("partner"),
+		Issuer:    types.StringValue("https://partner.example.com"),
+		Audiences: types.SetValueMust(types.StringType, []attr.Value{types.StringValue("https://box.example.com")}),
+		JWKSURI:   types.StringNull(),
+		Keys: []JWKSConfigKeyModel{
+			{KID: types.StringValue("2024"), Algorithm: types.StringValue("RS256"), PublicKey: types.StringValue("-----BEGIN PUBLIC KEY-----\n2024")},
+			{KID: types.StringValue("2025"), Algorithm: types.StringValue("ES256"), PublicKey: types.StringValue("-----BEGIN PUBLIC KEY-----\n2025")},
+		},
+		VersionID: types.StringUnknown(),
+	}
+
+	client := fake.NewBoxClient()
+	stored, err := client.PutResource(ctx, jwksConfigToResource(model), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored["type"] != "jwt" || stored["jwks_uri"] != nil {
+		t.Errorf("unexpected token introspector: %v", stored)
+	}
+
+	var mapped JWKSConfigResourceModel
+	mapJWKSConfigFromResource(&mapped, stored)
+	if mapped.Issuer != model.Issuer || !mapped.Audiences.Equal(model.Audiences) || !mapped.JWKSURI.IsNull() || mapped.VersionID.ValueString() != "1" {
+		t.Errorf("unexpected model: %+v", mapped)
+	}
+	if len(mapped.Keys) != 2 || mapped.Keys[1] != model.Keys[1] {
+		t.Errorf("expected the keys in order, got: %+v", mapped.Keys)
+	}
+}
+
+func TestJWKSConfigResource_validateConfig(t *testing.T) {
+	ctx := context.Background()
+	r := &JWKSConfigResource{}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	key := JWKSConfigKeyModel{KID: types.StringValue("2025"), Algorithm: types.StringNull(), PublicKey: types.StringValue("-----BEGIN PUBLIC KEY-----")}
+	testCases := map[string]struct {
+		jwksURI     types.String
+		keys        []JWKSConfigKeyModel
+		expectError bool
+	}{
+		"jwks_uri": {
+			jwksURI: types.StringValue("https://partner.example.com/.well-known/jwks.json"),
+		},
+		"keys": {
+			jwksURI: types.StringNull(),
+			keys:    []JWKSConfigKeyModel{key},
+		},
+		"unknown-jwks_uri": {
+			jwksURI: types.StringUnknown(),
+			keys:    []JWKSConfigKeyModel{key},
+		},
+		"both": {
+			jwksURI:     types.StringValue("https://partner.example.com/.well-known/jwks.json"),
+			keys:        []JWKSConfigKeyModel{key},
+			expectError: true,
+		},
+		"neither": {
+			jwksURI:     types.StringNull(),
+			expectError: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			model := JWKSConfigResourceModel{
+				ID:        types.StringValue("partner"),
+				Issuer:    types.StringValue("https://partner.example.com"),
+				Audiences: types.SetNull(types.StringType),
+				JWKSURI:   testCase.jwksURI,
+				Keys:      testCase.keys,
+				VersionID: types.StringNull(),
+			}
+			plan := tfsdk.Plan{
+				Schema: schemaResp.Schema,
+				Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+			}
+			if diags := plan.Set(ctx, &model); diags.HasError() {
+				t.Fatalf("unable to build config: %v", diags)
+			}
+
+			resp := resource.ValidateConfigResponse{}
+			r.ValidateConfig(ctx, resource.ValidateConfigRequest{Config: tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw}}, &resp)
+			if resp.Diagnostics.HasError() != testCase.expectError {
+				t.Errorf("expected error %t, got: %v", testCase.expectError, resp.Diagnostics)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"strings"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &JWTDecodeFunction{}
+
+func NewJWTDecodeFunction() function.Function {
+	return &JWTDecodeFunction{}
+}
+
+// JWTDecodeFunction defines the function implementation.
+type JWTDecodeFunction struct{}
+
+func (f *JWTDecodeFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "jwt_decode"
+}
+
+func (f *JWTDecodeFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Decode the claims of a JWT",
+		MarkdownDescription: "Decodes the payload of a JSON Web Token, such as the `jwt` of an `aidbox_license` or an access token, and returns its claims as an object. The signature is **not** verified.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "jwt",
+				MarkdownDescription: "JWT to decode",
+			},
+		},
+		Return: function.DynamicReturn{},
+	}
+}
+
+func (f *JWTDecodeFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var jwt string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &jwt))
+	if resp.Error != nil {
+		return
+	}
+
+	payload, err := decodeJWTPayload(jwt)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	claims, err := decodeJSONValue(ctx, payload)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("JWT payload is not valid JSON: %s", err))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, types.DynamicValue(claims)))
+}
+
+// decodeJWTPayload returns the raw JSON payload segment of a compact JWT.
+func decodeJWTPayload(jwt string) ([]byte, error) {
+	parts := strings.Split(strings.TrimSpace(jwt), ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("JWT must have 3 dot-separated segments, got %d", len(parts))
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return nil, fmt.Errorf("JWT payload is not valid base64url: %s", err)
+	}
+
+	return payload, nil
+}
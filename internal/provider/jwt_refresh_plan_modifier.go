@@ -0,0 +1,122 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox"
+	"time"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ planmodifier.String = jwtRefreshPlanModifier{}
+
+// jwtRefreshPlanModifier marks the planned value unknown when the license
+// expiration stored in state falls within the configured refresh window and
+// the portal has a JWT or an expiration for the license other than the ones
+// in state, so that they are fetched again during apply. Once fetched, plans
+// converge until the license is renewed again.
+type jwtRefreshPlanModifier struct {
+	expirationPath path.Path
+	windowPath     path.Path
+	// fetch reads the license with the given ID from the portal. Without it,
+	// the value is refreshed on every plan within the window.
+	fetch func(ctx context.Context, id string) (aidbox.LicenseResponse, error)
+	now   func() time.Time
+}
+
+// refreshJWTBeforeExpiration returns a plan modifier which refreshes the
+// value when the expiration attribute is within the window attribute and
+// the license fetched with fetch has changed.
+func refreshJWTBeforeExpiration(expirationPath, windowPath path.Path, fetch func(ctx context.Context, id string) (aidbox.LicenseResponse, error)) planmodifier.String {
+	return jwtRefreshPlanModifier{
+		expirationPath: expirationPath,
+		windowPath:     windowPath,
+		fetch:          fetch,
+		now:            time.Now,
+	}
+}
+
+func (m jwtRefreshPlanModifier) Description(ctx context.Context) string {
+	return "Refreshes the value when the license expires within the configured refresh window and the portal has a newer JWT."
+}
+
+func (m jwtRefreshPlanModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m jwtRefreshPlanModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	// Nothing to refresh on create or destroy.
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var window types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, m.windowPath, &window)...)
+	if resp.Diagnostics.HasError() || window.IsNull() || window.IsUnknown() {
+		return
+	}
+
+	duration, err := time.ParseDuration(window.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			m.windowPath,
+			"Invalid Refresh Window",
+			fmt.Sprintf("Unable to parse %q as a duration: %s", window.ValueString(), err),
+		)
+		return
+	}
+
+	var expiration types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, m.expirationPath, &expiration)...)
+	if resp.Diagnostics.HasError() || expiration.IsNull() || expiration.IsUnknown() {
+		return
+	}
+
+	expiresAt, ok := parseLicenseExpiration(expiration.ValueString())
+	if !ok {
+		return
+	}
+
+	if !m.now().Add(duration).After(expiresAt) {
+		return
+	}
+
+	if m.fetch != nil {
+		var id, jwt types.String
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("id"), &id)...)
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("jwt"), &jwt)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		current, err := m.fetch(ctx, id.ValueString())
+		if err != nil {
+			resp.Diagnostics.Append(apiErrorDiagnostic("Failed to Fetch License", "Unable to check the license for a newer JWT", err))
+			return
+		}
+		// The JWT in state is the latest one
+		if current.JWT == jwt.ValueString() && current.License.Expiration == expiration.ValueString() {
+			return
+		}
+	}
+
+	resp.PlanValue = types.StringUnknown()
+}
+
+// parseLicenseExpiration parses the expiration reported by the portal, which
+// is either a timestamp or a date.
+func parseLicenseExpiration(value string) (time.Time, bool) {
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
@@ -0,0 +1,147 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox"
+)
+
+func TestJWTRefreshPlanModifier(t *testing.T) {
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	(&LicenseResource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	objectType, ok := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	if !ok {
+		t.Fatal("expected the schema type to be an object")
+	}
+
+	licenseValue := func(expiration string, window interface{}) tftypes.Value {
+		values := map[string]tftypes.Value{}
+		for name, attrType := range objectType.AttributeTypes {
+			values[name] = tftypes.NewValue(attrType, nil)
+		}
+		values["id"] = tftypes.NewValue(tftypes.String, "license-1")
+		values["expiration"] = tftypes.NewValue(tftypes.String, expiration)
+		values["jwt"] = tftypes.NewValue(tftypes.String, "header.payload.signature")
+		values["jwt_refresh_window"] = tftypes.NewValue(tftypes.String, window)
+		return tftypes.NewValue(objectType, values)
+	}
+
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// fetched returns the license the portal has, with its JWT and
+	// expiration.
+	fetched := func(jwt, expiration string) func(context.Context, string) (aidbox.LicenseResponse, error) {
+		return func(ctx context.Context, id string) (aidbox.LicenseResponse, error) {
+			if id != "license-1" {
+				t.Errorf("unexpected license ID: %s", id)
+			}
+			return aidbox.LicenseResponse{License: aidbox.License{ID: id, Expiration: expiration}, JWT: jwt}, nil
+		}
+	}
+
+	testCases := map[string]struct {
+		expiration    string
+		window        interface{}
+		fetch         func(context.Context, string) (aidbox.LicenseResponse, error)
+		expectUnknown bool
+		expectError   bool
+	}{
+		"within-window": {
+			expiration:    "2025-01-15T00:00:00Z",
+			window:        "720h",
+			expectUnknown: true,
+		},
+		"outside-window": {
+			expiration: "2025-03-01T00:00:00Z",
+			window:     "720h",
+		},
+		"date-within-window": {
+			expiration:    "2025-01-15",
+			window:        "720h",
+			expectUnknown: true,
+		},
+		"no-window": {
+			expiration: "2025-01-15T00:00:00Z",
+			window:     nil,
+		},
+		"unparseable-expiration": {
+			expiration: "soon",
+			window:     "720h",
+		},
+		"within-window-up-to-date": {
+			expiration: "2025-01-15T00:00:00Z",
+			window:     "720h",
+			fetch:      fetched("header.payload.signature", "2025-01-15T00:00:00Z"),
+		},
+		"within-window-renewed": {
+			expiration:    "2025-01-15T00:00:00Z",
+			window:        "720h",
+			fetch:         fetched("header.renewed.signature", "2026-01-15T00:00:00Z"),
+			expectUnknown: true,
+		},
+		"outside-window-not-fetched": {
+			expiration: "2025-03-01T00:00:00Z",
+			window:     "720h",
+			fetch: func(ctx context.Context, id string) (aidbox.LicenseResponse, error) {
+				t.Error("unexpected fetch of a license outside of the window")
+				return aidbox.LicenseResponse{}, nil
+			},
+		},
+		"fetch-error": {
+			expiration: "2025-01-15T00:00:00Z",
+			window:     "720h",
+			fetch: func(ctx context.Context, id string) (aidbox.LicenseResponse, error) {
+				return aidbox.LicenseResponse{}, errors.New("connection refused")
+			},
+			expectError: true,
+		},
+		"invalid-window": {
+			expiration:  "2025-01-15T00:00:00Z",
+			window:      "a month",
+			expectError: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			raw := licenseValue(testCase.expiration, testCase.window)
+			req := planmodifier.StringRequest{
+				Path:       path.Root("jwt"),
+				PlanValue:  types.StringValue("header.payload.signature"),
+				StateValue: types.StringValue("header.payload.signature"),
+				Plan:       tfsdk.Plan{Schema: schemaResp.Schema, Raw: raw},
+				State:      tfsdk.State{Schema: schemaResp.Schema, Raw: raw},
+			}
+			resp := planmodifier.StringResponse{PlanValue: req.PlanValue}
+
+			modifier := jwtRefreshPlanModifier{
+				expirationPath: path.Root("expiration"),
+				windowPath:     path.Root("jwt_refresh_window"),
+				fetch:          testCase.fetch,
+				now:            func() time.Time { return now },
+			}
+			modifier.PlanModifyString(ctx, req, &resp)
+
+			if resp.Diagnostics.HasError() != testCase.expectError {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+			if resp.PlanValue.IsUnknown() != testCase.expectUnknown {
+				t.Errorf("expected unknown to be %t, got %s", testCase.expectUnknown, resp.PlanValue)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"regexp"
+	"strings"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &K8sLicenseSecretFunction{}
+
+// k8sLicenseSecretKey is the key of the license in the secret, the
+// environment variable Aidbox reads its license from, so that the secret can
+// be mounted with envFrom.
+const k8sLicenseSecretKey = "AIDBOX_LICENSE"
+
+var (
+	// k8sNamePattern matches DNS subdomain names, such as secret names.
+	k8sNamePattern = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`)
+	// k8sNamespacePattern matches DNS label names, such as namespaces.
+	k8sNamespacePattern = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+)
+
+func NewK8sLicenseSecretFunction() function.Function {
+	return &K8sLicenseSecretFunction{}
+}
+
+// K8sLicenseSecretFunction defines the function implementation.
+type K8sLicenseSecretFunction struct{}
+
+func (f *K8sLicenseSecretFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "k8s_license_secret"
+}
+
+func (f *K8sLicenseSecretFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Render a Kubernetes Secret holding a license",
+		MarkdownDescription: "Returns the JSON manifest of a Kubernetes `Secret` holding the license JWT under the `" + k8sLicenseSecretKey + "` key, " +
+			"ready to be applied with `kubectl apply -f` or decoded into a `kubernetes_manifest`, and mounted into the Aidbox container with `envFrom`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "name",
+				MarkdownDescription: "Name of the secret",
+			},
+			function.StringParameter{
+				Name:                "namespace",
+				MarkdownDescription: "Namespace of the secret",
+			},
+			function.StringParameter{
+				Name:                "jwt",
+				MarkdownDescription: "License JWT, such as the `jwt` attribute of an `aidbox_license`",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *K8sLicenseSecretFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var name, namespace, jwt string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &name, &namespace, &jwt))
+	if resp.Error != nil {
+		return
+	}
+
+	if len(name) > 253 || !k8sNamePattern.MatchString(name) {
+		resp.Error = function.NewArgumentFuncError(0, "name must be a DNS subdomain name, of lowercase alphanumeric characters, '-' and '.', of at most 253 characters")
+		return
+	}
+	if len(namespace) > 63 || !k8sNamespacePattern.MatchString(namespace) {
+		resp.Error = function.NewArgumentFuncError(1, "namespace must be a DNS label name, of lowercase alphanumeric characters and '-', of at most 63 characters")
+		return
+	}
+	jwt = strings.TrimSpace(jwt)
+	if _, err := decodeJWTPayload(jwt); err != nil {
+		resp.Error = function.NewArgumentFuncError(2, err.Error())
+		return
+	}
+
+	manifest, err := json.Marshal(map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Secret",
+		"metadata": map[string]interface{}{
+			"name":      name,
+			"namespace": namespace,
+			"labels": map[string]string{
+				"app.kubernetes.io/managed-by": "terraform",
+			},
+		},
+		"type": "Opaque",
+		"data": map[string]string{
+			k8sLicenseSecretKey: base64.StdEncoding.EncodeToString([]byte(jwt)),
+		},
+	})
+	if err != nil {
+		resp.Error = function.NewFuncError(fmt.Sprintf("Unable to render the secret: %s", err))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, string(manifest)))
+}
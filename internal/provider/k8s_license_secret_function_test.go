@@ -0,0 +1,67 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccK8sLicenseSecretFunction(t *testing.T) {
+	// {"alg":"none"}.{"sub":"license"}
+	jwt := "eyJhbGciOiJub25lIn0.eyJzdWIiOiJsaWNlbnNlIn0.sig"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+locals {
+  secret = jsondecode(provider::aidbox::k8s_license_secret("aidbox-license", "aidbox", "` + jwt + `"))
+}
+
+output "kind" {
+  value = local.secret.kind
+}
+
+output "namespace" {
+  value = local.secret.metadata.namespace
+}
+
+output "license" {
+  value = base64decode(local.secret.data.AIDBOX_LICENSE)
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("kind", "Secret"),
+					resource.TestCheckOutput("namespace", "aidbox"),
+					resource.TestCheckOutput("license", jwt),
+				),
+			},
+			{
+				Config: `
+output "test" {
+  value = provider::aidbox::k8s_license_secret("Aidbox_License", "aidbox", "` + jwt + `")
+}
+`,
+				ExpectError: regexp.MustCompile(`name must be a DNS subdomain name`),
+			},
+			{
+				Config: `
+output "test" {
+  value = provider::aidbox::k8s_license_secret("aidbox-license", "aidbox", "not-a-jwt")
+}
+`,
+				ExpectError: regexp.MustCompile(`JWT must have 3 dot-separated segments`),
+			},
+		},
+	})
+}
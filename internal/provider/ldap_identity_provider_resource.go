@@ -0,0 +1,280 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox"
+	"regexp"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &LDAPIdentityProviderResource{}
+var _ resource.ResourceWithImportState = &LDAPIdentityProviderResource{}
+
+// LDAP identity providers are stored as IdentityProvider resources of the
+// ldap type.
+const ldapIdentityProviderType = "ldap"
+
+func NewLDAPIdentityProviderResource() resource.Resource {
+	return &LDAPIdentityProviderResource{}
+}
+
+// LDAPIdentityProviderResource defines the resource implementation.
+type LDAPIdentityProviderResource struct {
+	boxResource
+}
+
+// LDAPIdentityProviderResourceModel describes the resource data model.
+type LDAPIdentityProviderResourceModel struct {
+	ID                    types.String `tfsdk:"id"`
+	Title                 types.String `tfsdk:"title"`
+	Active                types.Bool   `tfsdk:"active"`
+	URL                   types.String `tfsdk:"url"`
+	BindDN                types.String `tfsdk:"bind_dn"`
+	BindPasswordWO        types.String `tfsdk:"bind_password_wo"`
+	BindPasswordWOVersion types.Int64  `tfsdk:"bind_password_wo_version"`
+	UserSearchBase        types.String `tfsdk:"user_search_base"`
+	UserSearchFilter      types.String `tfsdk:"user_search_filter"`
+	AttributeMapping      types.Map    `tfsdk:"attribute_mapping"`
+	VersionID             types.String `tfsdk:"version_id"`
+}
+
+func (r *LDAPIdentityProviderResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ldap_identity_provider"
+}
+
+func (r *LDAPIdentityProviderResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages an LDAP directory, such as Active Directory, that users sign in to the box with their directory credentials. " +
+			"The box binds to the directory with a service account to search for users, whose password is never stored in the Terraform state. Requires Terraform 1.11 or later.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the identity provider. Changing it forces a new identity provider to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validFHIRID(),
+				},
+			},
+			"title": schema.StringAttribute{
+				MarkdownDescription: "Title of the identity provider on the sign in page",
+				Optional:            true,
+			},
+			"active": schema.BoolAttribute{
+				MarkdownDescription: "Whether users can sign in with the identity provider. Defaults to `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"url": schema.StringAttribute{
+				MarkdownDescription: "URL of the directory, such as `ldaps://ldap.example.com:636`",
+				Required:            true,
+				Validators: []validator.String{
+					validURI("ldap", "ldaps"),
+				},
+			},
+			"bind_dn": schema.StringAttribute{
+				MarkdownDescription: "Distinguished name of the service account the box binds with, such as `cn=aidbox,ou=services,dc=example,dc=com`",
+				Required:            true,
+			},
+			"bind_password_wo": schema.StringAttribute{
+				MarkdownDescription: "Password of the service account, never stored in the Terraform state. Increment `bind_password_wo_version` to update the password.",
+				Required:            true,
+				Sensitive:           true,
+				WriteOnly:           true,
+			},
+			"bind_password_wo_version": schema.Int64Attribute{
+				MarkdownDescription: "Version of `bind_password_wo`. As write-only values are not stored in state, changing this version is what triggers an update of the password.",
+				Optional:            true,
+			},
+			"user_search_base": schema.StringAttribute{
+				MarkdownDescription: "Distinguished name users are searched under, such as `ou=people,dc=example,dc=com`",
+				Required:            true,
+			},
+			"user_search_filter": schema.StringAttribute{
+				MarkdownDescription: "LDAP filter finding a user, where `{username}` is replaced by the username entered on the sign in page, such as `(sAMAccountName={username})` for Active Directory. Defaults to `(uid={username})`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("(uid={username})"),
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^\(.*\{username\}.*\)$`), "must be an LDAP filter using {username}"),
+				},
+			},
+			"attribute_mapping": schema.MapAttribute{
+				MarkdownDescription: "Attributes of the Aidbox user, such as `email` or `name.givenName`, mapped to the LDAP attributes they are set from, such as `mail` or `givenName`",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(
+						stringvalidator.RegexMatches(userAttributeRegexp, "must be an attribute of the Aidbox user, such as email or name.givenName"),
+					),
+				},
+			},
+			"version_id": schema.StringAttribute{
+				MarkdownDescription: "Version of the identity provider, incremented by the box on every change",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *LDAPIdentityProviderResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var model LDAPIdentityProviderResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	bindPassword, diags := ldapIdentityProviderBindPassword(ctx, req.Config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stored, err := r.client.PutResource(ctx, ldapIdentityProviderToResource(model, bindPassword), "")
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostic("Failed to Create LDAP Identity Provider", "Unable to create LDAP identity provider", err))
+		return
+	}
+
+	mapLDAPIdentityProviderFromResource(&model, stored)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *LDAPIdentityProviderResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var model LDAPIdentityProviderResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stored, err := r.client.GetResourceIfModified(ctx, identityProviderResourceType, model.ID.ValueString(), model.VersionID.ValueString())
+	if aidbox.IsNotModified(err) {
+		return
+	}
+	if aidbox.IsNotFound(err) {
+		tflog.Warn(ctx, "LDAP identity provider not found, removing from state", map[string]interface{}{"id": model.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostic("Failed to Fetch LDAP Identity Provider", "Unable to fetch LDAP identity provider", err))
+		return
+	}
+
+	mapLDAPIdentityProviderFromResource(&model, stored)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *LDAPIdentityProviderResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var model LDAPIdentityProviderResourceModel
+	var versionID types.String
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("version_id"), &versionID)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	bindPassword, diags := ldapIdentityProviderBindPassword(ctx, req.Config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stored, err := r.client.PutResource(ctx, ldapIdentityProviderToResource(model, bindPassword), versionID.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(updateErrorDiagnostic("LDAP identity provider "+model.ID.ValueString(), "Failed to Update LDAP Identity Provider", "Unable to update LDAP identity provider", err))
+		return
+	}
+
+	mapLDAPIdentityProviderFromResource(&model, stored)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *LDAPIdentityProviderResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var model LDAPIdentityProviderResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteResource(ctx, identityProviderResourceType, model.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(deleteErrorDiagnostic(
+			"LDAP identity provider "+model.ID.ValueString(),
+			"Failed to Delete LDAP Identity Provider",
+			fmt.Sprintf("Error while trying to delete the LDAP identity provider with ID %s", model.ID.ValueString()),
+			err,
+		))
+	}
+}
+
+func (r *LDAPIdentityProviderResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// ldapIdentityProviderBindPassword returns the configured bind password.
+// Write-only values are only available in the configuration, never in the
+// plan.
+func ldapIdentityProviderBindPassword(ctx context.Context, config tfsdk.Config) (types.String, diag.Diagnostics) {
+	var bindPassword types.String
+	diags := config.GetAttribute(ctx, path.Root("bind_password_wo"), &bindPassword)
+	return bindPassword, diags
+}
+
+func ldapIdentityProviderToResource(model LDAPIdentityProviderResourceModel, bindPassword types.String) aidbox.Resource {
+	ldap := map[string]interface{}{}
+	setJSONValue(ldap, "url", model.URL)
+	setJSONValue(ldap, "bind-dn", model.BindDN)
+	setJSONValue(ldap, "bind-password", bindPassword)
+	setJSONValue(ldap, "user-search-base", model.UserSearchBase)
+	setJSONValue(ldap, "user-search-filter", model.UserSearchFilter)
+	setJSONValue(ldap, "attribute-mapping", model.AttributeMapping)
+
+	idp := aidbox.Resource{
+		"resourceType": identityProviderResourceType,
+		"id":           model.ID.ValueString(),
+		"type":         ldapIdentityProviderType,
+		"ldap":         ldap,
+	}
+	setJSONValue(idp, "title", model.Title)
+	setJSONValue(idp, "active", model.Active)
+
+	return idp
+}
+
+// mapLDAPIdentityProviderFromResource maps the IdentityProvider resource
+// stored by the box to model. The bind password is never read back.
+func mapLDAPIdentityProviderFromResource(model *LDAPIdentityProviderResourceModel, stored aidbox.Resource) {
+	ldap := jsonObject(stored, "ldap")
+
+	model.ID = types.StringValue(stored.ID())
+	model.Title = jsonStringValue(stored, "title")
+	model.Active = types.BoolValue(jsonBoolValue(stored, "active").ValueBool())
+	model.URL = jsonStringValue(ldap, "url")
+	model.BindDN = jsonStringValue(ldap, "bind-dn")
+	model.BindPasswordWO = types.StringNull()
+	model.UserSearchBase = jsonStringValue(ldap, "user-search-base")
+	model.UserSearchFilter = jsonStringValue(ldap, "user-search-filter")
+	model.AttributeMapping = jsonStringMap(ldap, "attribute-mapping")
+	model.VersionID = stringValueOrNull(stored.VersionID())
+}
@@ -0,0 +1,81 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccAidboxLDAPIdentityProviderResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckBox(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccAidboxLDAPIdentityProviderResourceConfig("(uid={username})", 1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("aidbox_ldap_identity_provider.test", "id", "tf-acc-ldap"),
+					resource.TestCheckResourceAttr("aidbox_ldap_identity_provider.test", "active", "true"),
+					resource.TestCheckResourceAttr("aidbox_ldap_identity_provider.test", "user_search_filter", "(uid={username})"),
+					resource.TestCheckResourceAttr("aidbox_ldap_identity_provider.test", "attribute_mapping.email", "mail"),
+					resource.TestCheckNoResourceAttr("aidbox_ldap_identity_provider.test", "bind_password_wo"),
+					resource.TestCheckResourceAttrSet("aidbox_ldap_identity_provider.test", "version_id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "aidbox_ldap_identity_provider.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"bind_password_wo_version"},
+			},
+			// Import block testing, as with terraform plan -generate-config-out
+			{
+				// The version of the write-only bind password is not stored in the box
+				ResourceName:       "aidbox_ldap_identity_provider.test",
+				ImportState:        true,
+				ImportStateKind:    resource.ImportBlockWithID,
+				ExpectNonEmptyPlan: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccAidboxLDAPIdentityProviderResourceConfig("(sAMAccountName={username})", 2),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("aidbox_ldap_identity_provider.test", "user_search_filter", "(sAMAccountName={username})"),
+					resource.TestCheckResourceAttr("aidbox_ldap_identity_provider.test", "bind_password_wo_version", "2"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccAidboxLDAPIdentityProviderResourceConfig(userSearchFilter string, bindPasswordVersion int) string {
+	return fmt.Sprintf(`
+resource "aidbox_ldap_identity_provider" "test" {
+  id    = "tf-acc-ldap"
+  title = "Directory"
+  url   = "ldaps://ldap.example.com:636"
+
+  bind_dn                  = "cn=aidbox,ou=services,dc=example,dc=com"
+  bind_password_wo         = "tf-acc-password-%[2]d"
+  bind_password_wo_version = %[2]d
+
+  user_search_base   = "ou=people,dc=example,dc=com"
+  user_search_filter = %[1]q
+
+  attribute_mapping = {
+    email = "mail"
+  }
+}
+`, userSearchFilter, bindPasswordVersion)
+}
@@ -0,0 +1,73 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox/fake"
+)
+
+func TestLDAPIdentityProviderResource_writeOnlyBindPassword(t *testing.T) {
+	ctx := context.Background()
+	r := &LDAPIdentityProviderResource{}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	model := LDAPIdentityProviderResourceModel{
+		ID:                    types.StringValue("ldap"),
+		Title:                 types.StringNull(),
+		Active:                types.BoolValue(true),
+		URL:                   types.StringValue("ldaps://ldap.example.com"),
+		BindDN:                types.StringValue("cn=aidbox,dc=example,dc=com"),
+		BindPasswordWO:        types.StringValue("password"),
+		BindPasswordWOVersion: types.Int64Value(1),
+		UserSearchBase:        types.StringValue("ou=people,dc=example,dc=com"),
+		UserSearchFilter:      types.StringValue("(uid={username})"),
+		AttributeMapping:      types.MapValueMust(types.StringType, map[string]attr.Value{"email": types.StringValue("mail")}),
+		VersionID:             types.StringUnknown(),
+	}
+	plan := tfsdk.Plan{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	if diags := plan.Set(ctx, &model); diags.HasError() {
+		t.Fatalf("unable to build config: %v", diags)
+	}
+	config := tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw}
+
+	// Write-only values are null in the plan
+	model.BindPasswordWO = types.StringNull()
+	bindPassword, diags := ldapIdentityProviderBindPassword(ctx, config)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if bindPassword.ValueString() != "password" {
+		t.Fatalf("expected the write-only bind password, got: %s", bindPassword)
+	}
+
+	client := fake.NewBoxClient()
+	stored, err := client.PutResource(ctx, ldapIdentityProviderToResource(model, bindPassword), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored["type"] != "ldap" || jsonObject(stored, "ldap")["bind-password"] != "password" {
+		t.Errorf("expected the bind password to be sent to the box, got: %v", stored)
+	}
+
+	mapLDAPIdentityProviderFromResource(&model, stored)
+	if !model.BindPasswordWO.IsNull() {
+		t.Errorf("expected no bind password in state, got: %+v", model)
+	}
+	if model.BindPasswordWOVersion.ValueInt64() != 1 || model.AttributeMapping.Elements()["email"] != types.StringValue("mail") || model.VersionID.ValueString() != "1" {
+		t.Errorf("unexpected model: %+v", model)
+	}
+}
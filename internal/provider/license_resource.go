@@ -6,20 +6,30 @@ package provider
 import (
 	"context"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
-	"terraform-provider-aidbox/internal/aidbox"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox"
+	"time"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &LicenseResource{}
 var _ resource.ResourceWithImportState = &LicenseResource{}
+var _ resource.ResourceWithIdentity = &LicenseResource{}
+var _ resource.ResourceWithUpgradeState = &LicenseResource{}
+var _ resource.ResourceWithModifyPlan = &LicenseResource{}
 
 func NewLicenseResource() resource.Resource {
 	return &LicenseResource{}
@@ -28,29 +38,43 @@ func NewLicenseResource() resource.Resource {
 // LicenseResource defines the resource implementation.
 type LicenseResource struct {
 	client   Client
+	licenses *licenseSnapshot
 	endpoint string
 	token    string
 }
 
 // LicenseResourceModel describes the resource data model.
 type LicenseResourceModel struct {
-	ID              types.String `tfsdk:"id"`
-	Name            types.String `tfsdk:"name"`
-	Product         types.String `tfsdk:"product"`
-	Type            types.String `tfsdk:"type"`
-	Expiration      types.String `tfsdk:"expiration"`
-	Status          types.String `tfsdk:"status"`
-	MaxInstances    types.Int64  `tfsdk:"max_instances"`
-	CreatorID       types.String `tfsdk:"creator_id"`
-	ProjectID       types.String `tfsdk:"project_id"`
-	Offline         types.Bool   `tfsdk:"offline"`
-	Created         types.String `tfsdk:"created"`
-	MetaLastUpdated types.String `tfsdk:"meta_last_updated"`
-	MetaCreatedAt   types.String `tfsdk:"meta_created_at"`
-	MetaVersionID   types.String `tfsdk:"meta_version_id"`
-	Issuer          types.String `tfsdk:"issuer"`
-	InfoHosting     types.String `tfsdk:"info_hosting"`
-	JWT             types.String `tfsdk:"jwt"`
+	ID                 types.String   `tfsdk:"id"`
+	Name               types.String   `tfsdk:"name"`
+	Product            types.String   `tfsdk:"product"`
+	Type               types.String   `tfsdk:"type"`
+	Expiration         types.String   `tfsdk:"expiration"`
+	Status             types.String   `tfsdk:"status"`
+	MaxInstances       types.Int64    `tfsdk:"max_instances"`
+	CreatorID          types.String   `tfsdk:"creator_id"`
+	ProjectID          types.String   `tfsdk:"project_id"`
+	Offline            types.Bool     `tfsdk:"offline"`
+	Created            types.String   `tfsdk:"created"`
+	Meta               types.Object   `tfsdk:"meta"`
+	Issuer             types.String   `tfsdk:"issuer"`
+	InfoHosting        types.String   `tfsdk:"info_hosting"`
+	JWT                types.String   `tfsdk:"jwt"`
+	JWTRefreshWindow   types.String   `tfsdk:"jwt_refresh_window"`
+	DeletionProtection types.Bool     `tfsdk:"deletion_protection"`
+	Timeouts           timeouts.Value `tfsdk:"timeouts"`
+}
+
+// licenseMetaAttrTypes describes the attributes of the nested meta object.
+var licenseMetaAttrTypes = map[string]attr.Type{
+	"last_updated": types.StringType,
+	"created_at":   types.StringType,
+	"version_id":   types.StringType,
+}
+
+// LicenseResourceIdentityModel describes the resource identity data model.
+type LicenseResourceIdentityModel struct {
+	ID types.String `tfsdk:"id"`
 }
 
 func (r *LicenseResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -60,6 +84,7 @@ func (r *LicenseResource) Metadata(ctx context.Context, req resource.MetadataReq
 func (r *LicenseResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Manages an Aidbox license",
+		Version:             1,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed: true,
@@ -86,6 +111,9 @@ func (r *LicenseResource) Schema(ctx context.Context, req resource.SchemaRequest
 			},
 			"expiration": schema.StringAttribute{
 				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					refreshJWTBeforeExpiration(path.Root("expiration"), path.Root("jwt_refresh_window"), r.fetchLicense),
+				},
 			},
 			"status": schema.StringAttribute{
 				Computed: true,
@@ -105,14 +133,12 @@ func (r *LicenseResource) Schema(ctx context.Context, req resource.SchemaRequest
 			"created": schema.StringAttribute{
 				Computed: true,
 			},
-			"meta_last_updated": schema.StringAttribute{
-				Computed: true,
-			},
-			"meta_created_at": schema.StringAttribute{
-				Computed: true,
-			},
-			"meta_version_id": schema.StringAttribute{
-				Computed: true,
+			// An object attribute rather than a nested attribute, as the
+			// protocol 5 server can't describe nested attributes.
+			"meta": schema.ObjectAttribute{
+				MarkdownDescription: "Resource metadata maintained by the portal: `version_id`, the version of the license incremented by the portal on every change, and the `created_at` and `last_updated` timestamps",
+				Computed:            true,
+				AttributeTypes:      licenseMetaAttrTypes,
 			},
 			"issuer": schema.StringAttribute{
 				Computed: true,
@@ -122,6 +148,49 @@ func (r *LicenseResource) Schema(ctx context.Context, req resource.SchemaRequest
 			},
 			"jwt": schema.StringAttribute{
 				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					refreshJWTBeforeExpiration(path.Root("expiration"), path.Root("jwt_refresh_window"), r.fetchLicense),
+				},
+			},
+			"deletion_protection": schema.BoolAttribute{
+				MarkdownDescription: "Whether Terraform is prevented from deleting the license. When `true`, destroying or replacing the license fails until this is set to `false` and applied. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"jwt_refresh_window": schema.StringAttribute{
+				MarkdownDescription: "Duration, such as `720h`, before `expiration` within which plans check the portal for a newer `jwt`, such as after the license is renewed, and the next apply fetches it without replacing the license",
+				Optional:            true,
+				Validators: []validator.String{
+					validDuration(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// Default durations the provider waits for portal operations on licenses.
+const (
+	licenseDefaultCreateTimeout = 5 * time.Minute
+	licenseDefaultReadTimeout   = 2 * time.Minute
+	licenseDefaultUpdateTimeout = 5 * time.Minute
+	licenseDefaultDeleteTimeout = 5 * time.Minute
+)
+
+func (r *LicenseResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"id": identityschema.StringAttribute{
+				Description:       "ID of the license",
+				RequiredForImport: true,
 			},
 		},
 	}
@@ -145,6 +214,7 @@ func (r *LicenseResource) Configure(ctx context.Context, req resource.ConfigureR
 	}
 
 	r.client = data.Client
+	r.licenses = data.Licenses
 	r.endpoint = data.Endpoint
 	r.token = data.Token
 }
@@ -157,14 +227,24 @@ func (r *LicenseResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
+	createTimeout, diags := model.Timeouts.Create(ctx, licenseDefaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	apiResp, err := r.client.CreateLicense(ctx, model.Name.ValueString(), model.Product.ValueString(), model.Type.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("API Call Failed", err.Error())
+		resp.Diagnostics.Append(apiErrorDiagnostic("Failed to Create License", "Unable to create license", err))
 		return
 	}
 
 	mapModelFromAPIResponse(&model, apiResp)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, LicenseResourceIdentityModel{ID: model.ID})...)
 }
 
 func (r *LicenseResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -186,41 +266,92 @@ func (r *LicenseResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
+	readTimeout, diags := model.Timeouts.Read(ctx, licenseDefaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	// Use the client to fetch the license data from the API
-	apiResp, err := r.client.GetLicense(ctx, model.ID.ValueString())
+	apiResp, err := r.readLicense(ctx, model)
+	if aidbox.IsNotFound(err) {
+		// The license was deleted outside of Terraform, remove it from state
+		// so that it is planned for creation again
+		tflog.Warn(ctx, "License not found, removing from state", map[string]interface{}{"id": model.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to Fetch License", fmt.Sprintf("Unable to fetch license: %s", err))
+		resp.Diagnostics.Append(apiErrorDiagnostic("Failed to Fetch License", "Unable to fetch license", err))
 		return
 	}
 
+	// Report changes made outside of Terraform, e.g. a license suspended or
+	// renewed in the portal
+	logLicenseDrift(ctx, model, apiResp)
+
 	// Map the API response back to the Terraform model
 	mapModelFromAPIResponse(&model, apiResp)
 
+	// deletion_protection is null in the state of imported licenses and of
+	// licenses created before it had a default, which would plan an update
+	if model.DeletionProtection.IsNull() {
+		model.DeletionProtection = types.BoolValue(false)
+	}
+
 	// Save the updated model back into the Terraform state
 	diags = resp.State.Set(ctx, &model)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, LicenseResourceIdentityModel{ID: model.ID})...)
 }
 
 func (r *LicenseResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data LicenseResourceModel
+	var data, prior LicenseResourceModel
 
-	// Read Terraform plan data into the model
+	// Read Terraform plan and prior state data into the models
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
+	updateTimeout, diags := data.Timeouts.Update(ctx, licenseDefaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// If applicable, this is a great opportunity to initialize any necessary
-	// provider client data and make a call using it.
-	// httpResp, err := r.client.Do(httpReq)
-	// if err != nil {
-	//     resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update example, got error: %s", err))
-	//     return
-	// }
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	// Licenses cannot be modified in place, so fetch the license again to
+	// resolve computed values, such as a JWT planned for a refresh.
+	apiResp, err := r.client.GetLicense(ctx, data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostic("Failed to Fetch License", "Unable to fetch license", err))
+		return
+	}
+
+	// Refuse to apply a plan made against an older version of the license,
+	// unless the plan only refreshes the JWT: renewing the license in the
+	// portal bumps its version, and the refresh is planned to pick it up.
+	if priorVersion := licenseVersionID(prior); priorVersion != "" && priorVersion != apiResp.License.Meta.VersionID && !licenseRefreshOnly(data, prior) {
+		resp.Diagnostics.AddError(
+			"License Modified Concurrently",
+			fmt.Sprintf("The license %s was modified outside of Terraform since the plan was made (version %s, now %s). Please plan again to review the changes.", data.ID.ValueString(), priorVersion, apiResp.License.Meta.VersionID),
+		)
+		return
+	}
+
+	mapModelFromAPIResponse(&data, apiResp)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, LicenseResourceIdentityModel{ID: data.ID})...)
 }
 
 func (r *LicenseResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -242,13 +373,32 @@ func (r *LicenseResource) Delete(ctx context.Context, req resource.DeleteRequest
 		return
 	}
 
+	if model.DeletionProtection.ValueBool() {
+		resp.Diagnostics.AddError(
+			"License Deletion Protected",
+			fmt.Sprintf("Cannot delete the License with ID %s while deletion_protection is enabled. Set deletion_protection to false and apply before deleting it.", model.ID.ValueString()),
+		)
+		return
+	}
+
+	deleteTimeout, diags := model.Timeouts.Delete(ctx, licenseDefaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	// Call the DeleteLicense method from the AidboxHTTPClient with the ID from the model
 	err := r.client.DeleteLicense(ctx, model.ID.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError(
+		resp.Diagnostics.Append(deleteErrorDiagnostic(
+			"license "+model.ID.ValueString(),
 			"Failed to Delete License",
-			fmt.Sprintf("Error while trying to delete the License with ID %s: %s", model.ID.ValueString(), err.Error()),
-		)
+			fmt.Sprintf("Error while trying to delete the License with ID %s", model.ID.ValueString()),
+			err,
+		))
 		return
 	}
 
@@ -256,8 +406,101 @@ func (r *LicenseResource) Delete(ctx context.Context, req resource.DeleteRequest
 	resp.State.RemoveResource(ctx)
 }
 
+func (r *LicenseResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to report on create or destroy.
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var status types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("status"), &status)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !status.IsNull() && !status.IsUnknown() && status.ValueString() != "" && status.ValueString() != "active" {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("status"),
+			"License Not Active",
+			fmt.Sprintf("The license status is %q, it was likely changed outside of Terraform. Aidbox instances using this license may stop working.", status.ValueString()),
+		)
+	}
+}
+
 func (r *LicenseResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
+}
+
+// logLicenseDrift logs the attributes of the license that changed outside of
+// Terraform since the last refresh.
+func logLicenseDrift(ctx context.Context, model LicenseResourceModel, apiResp aidbox.LicenseResponse) {
+	drift := map[string][2]string{
+		"name":       {model.Name.ValueString(), apiResp.License.Name},
+		"type":       {model.Type.ValueString(), apiResp.License.Type},
+		"status":     {model.Status.ValueString(), apiResp.License.Status},
+		"expiration": {model.Expiration.ValueString(), apiResp.License.Expiration},
+	}
+
+	for attribute, values := range drift {
+		if values[0] == "" || values[0] == values[1] {
+			continue
+		}
+
+		tflog.Info(ctx, "License changed outside of Terraform", map[string]interface{}{
+			"id":        model.ID.ValueString(),
+			"attribute": attribute,
+			"previous":  values[0],
+			"current":   values[1],
+		})
+	}
+}
+
+// fetchLicense fetches the license with the given ID from the portal,
+// bypassing the license snapshot.
+func (r *LicenseResource) fetchLicense(ctx context.Context, id string) (aidbox.LicenseResponse, error) {
+	if r.client == nil {
+		return aidbox.LicenseResponse{}, fmt.Errorf("the provider is not configured")
+	}
+	return r.client.GetLicense(ctx, id)
+}
+
+// readLicense fetches the license of model. A license listed in the license
+// snapshot with the version stored in state is unchanged, so it is served
+// from the snapshot with the JWT stored in state, which the listing doesn't
+// include.
+func (r *LicenseResource) readLicense(ctx context.Context, model LicenseResourceModel) (aidbox.LicenseResponse, error) {
+	if r.licenses != nil && !model.JWT.IsNull() && !model.JWT.IsUnknown() {
+		license, ok := r.licenses.get(ctx, model.ID.ValueString())
+		if versionID := licenseVersionID(model); ok && versionID != "" && license.Meta.VersionID == versionID {
+			return aidbox.LicenseResponse{License: license, JWT: model.JWT.ValueString()}, nil
+		}
+	}
+
+	return r.client.GetLicense(ctx, model.ID.ValueString())
+}
+
+// licenseRefreshOnly reports whether the only change planned for the license
+// is the refresh of its JWT and expiration.
+func licenseRefreshOnly(plan, prior LicenseResourceModel) bool {
+	return plan.JWT.IsUnknown() &&
+		plan.DeletionProtection.Equal(prior.DeletionProtection) &&
+		plan.JWTRefreshWindow.Equal(prior.JWTRefreshWindow) &&
+		plan.Timeouts.Equal(prior.Timeouts)
+}
+
+// licenseVersionID returns the meta.version_id stored in the model, or an
+// empty string when it is not known.
+func licenseVersionID(model LicenseResourceModel) string {
+	if model.Meta.IsNull() || model.Meta.IsUnknown() {
+		return ""
+	}
+
+	versionID, ok := model.Meta.Attributes()["version_id"].(types.String)
+	if !ok {
+		return ""
+	}
+
+	return versionID.ValueString()
 }
 
 func mapModelFromAPIResponse(model *LicenseResourceModel, apiResp aidbox.LicenseResponse) {
@@ -265,17 +508,19 @@ func mapModelFromAPIResponse(model *LicenseResourceModel, apiResp aidbox.License
 	model.Name = basetypes.NewStringValue(apiResp.License.Name)
 	model.Product = basetypes.NewStringValue(apiResp.License.Product)
 	model.Type = basetypes.NewStringValue(apiResp.License.Type)
-	model.Expiration = basetypes.NewStringValue(apiResp.License.Expiration)
-	model.Status = basetypes.NewStringValue(apiResp.License.Status)
-	model.MaxInstances = basetypes.NewInt64Value(int64(apiResp.License.MaxInstances))
-	model.CreatorID = basetypes.NewStringValue(apiResp.License.Creator.ID)
-	model.ProjectID = basetypes.NewStringValue(apiResp.License.Project.ID)
-	model.Offline = basetypes.NewBoolValue(apiResp.License.Offline)
-	model.Created = basetypes.NewStringValue(apiResp.License.Created)
-	model.MetaLastUpdated = basetypes.NewStringValue(apiResp.License.Meta.LastUpdated)
-	model.MetaCreatedAt = basetypes.NewStringValue(apiResp.License.Meta.CreatedAt)
-	model.MetaVersionID = basetypes.NewStringValue(apiResp.License.Meta.VersionID)
-	model.Issuer = basetypes.NewStringValue(apiResp.License.Issuer)
-	model.InfoHosting = basetypes.NewStringValue(apiResp.License.Info.Hosting)
-	model.JWT = basetypes.NewStringValue(apiResp.JWT)
+	model.Expiration = stringValueOrNull(apiResp.License.Expiration)
+	model.Status = stringValueOrNull(apiResp.License.Status)
+	model.MaxInstances = int64PointerValueOrNull(apiResp.License.MaxInstances)
+	model.CreatorID = stringValueOrNull(apiResp.License.Creator.ID)
+	model.ProjectID = stringValueOrNull(apiResp.License.Project.ID)
+	model.Offline = boolPointerValueOrNull(apiResp.License.Offline)
+	model.Created = stringValueOrNull(apiResp.License.Created)
+	model.Meta = basetypes.NewObjectValueMust(licenseMetaAttrTypes, map[string]attr.Value{
+		"last_updated": stringValueOrNull(apiResp.License.Meta.LastUpdated),
+		"created_at":   stringValueOrNull(apiResp.License.Meta.CreatedAt),
+		"version_id":   stringValueOrNull(apiResp.License.Meta.VersionID),
+	})
+	model.Issuer = stringValueOrNull(apiResp.License.Issuer)
+	model.InfoHosting = stringValueOrNull(apiResp.License.Info.Hosting)
+	model.JWT = stringValueOrNull(apiResp.JWT)
 }
@@ -4,22 +4,34 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"regexp"
+	"strconv"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 )
 
 func TestAccAidboxLicenseResource(t *testing.T) {
 	resource.Test(t, resource.TestCase{
-		PreCheck:                 func() { testAccPreCheck(t) },
+		PreCheck: func() { testAccPreCheck(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_5_0),
+		},
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			// Create and Read testing
 			{
-				Config: testAccAidboxLicenseResourceConfig("license-one", "development"),
+				Config: testAccAidboxLicenseResourceConfig("tf-acc-license-one", "development"),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("aidbox_license.test", "name", "license-one"),
+					resource.TestCheckResourceAttr("aidbox_license.test", "name", "tf-acc-license-one"),
 					resource.TestCheckResourceAttr("aidbox_license.test", "type", "development"),
 				),
 			},
@@ -29,11 +41,17 @@ func TestAccAidboxLicenseResource(t *testing.T) {
 				ImportState:       true,
 				ImportStateVerify: true,
 			},
+			// Import block testing, as with terraform plan -generate-config-out
+			{
+				ResourceName:    "aidbox_license.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithID,
+			},
 			// Update and Read testing
 			{
-				Config: testAccAidboxLicenseResourceConfig("license-two", "development"),
+				Config: testAccAidboxLicenseResourceConfig("tf-acc-license-two", "development"),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("aidbox_license.test", "name", "license-two"),
+					resource.TestCheckResourceAttr("aidbox_license.test", "name", "tf-acc-license-two"),
 					resource.TestCheckResourceAttr("aidbox_license.test", "type", "development"),
 				),
 			},
@@ -42,6 +60,149 @@ func TestAccAidboxLicenseResource(t *testing.T) {
 	})
 }
 
+func TestAccAidboxLicenseResource_protocol5(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAidboxLicenseResourceConfig("tf-acc-license-protocol5", "development"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("aidbox_license.test", "name", "tf-acc-license-protocol5"),
+					resource.TestCheckResourceAttrSet("aidbox_license.test", "meta.version_id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAidboxLicenseResource_identity(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_12_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAidboxLicenseResourceConfig("tf-acc-license-identity", "development"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectIdentity("aidbox_license.test", map[string]knownvalue.Check{
+						"id": knownvalue.NotNull(),
+					}),
+					statecheck.ExpectIdentityValueMatchesState("aidbox_license.test", tfjsonpath.New("id")),
+				},
+			},
+			// Import by identity testing
+			{
+				ResourceName:    "aidbox_license.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithResourceIdentity,
+			},
+		},
+	})
+}
+
+func TestAccAidboxLicenseResource_disappears(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAidboxLicenseResourceConfig("tf-acc-license-disappears", "development"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckAidboxLicenseDisappears("aidbox_license.test"),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+// testAccCheckAidboxLicenseDisappears deletes the license outside of
+// Terraform to simulate a license removed in the portal.
+func testAccCheckAidboxLicenseDisappears(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", resourceName)
+		}
+
+		return testAccClient().DeleteLicense(context.Background(), rs.Primary.ID)
+	}
+}
+
+func TestAccAidboxLicenseResource_deletionProtection(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAidboxLicenseResourceConfigDeletionProtection("tf-acc-license-protected", true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("aidbox_license.test", "deletion_protection", "true"),
+				),
+			},
+			{
+				Config:      testAccAidboxLicenseResourceConfigDeletionProtection("tf-acc-license-protected", true),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(`deletion_protection is enabled`),
+			},
+			// Disable protection so that the license can be destroyed
+			{
+				Config: testAccAidboxLicenseResourceConfigDeletionProtection("tf-acc-license-protected", false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("aidbox_license.test", "deletion_protection", "false"),
+				),
+			},
+		},
+	})
+}
+
+// TestAccAidboxLicenseResource_scale plans and applies many licenses at
+// once. It only runs against the fake portal with AIDBOX_ACC_SCALE set to the
+// number of licenses, e.g. AIDBOX_ACC_SCALE=300.
+func TestAccAidboxLicenseResource_scale(t *testing.T) {
+	count, err := strconv.Atoi(os.Getenv("AIDBOX_ACC_SCALE"))
+	if err != nil || count <= 0 {
+		t.Skip("AIDBOX_ACC_SCALE must be set to a number of licenses to run the scale test")
+	}
+	if os.Getenv("AIDBOX_ACC_MOCK_PORTAL") == "" {
+		t.Skip("AIDBOX_ACC_MOCK_PORTAL must be set to run the scale test")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "aidbox_license" "test" {
+  count = %[1]d
+
+  name = "tf-acc-license-scale-${count.index}"
+  type = "development"
+}
+`, count),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("aidbox_license.test.0", "status", "active"),
+					resource.TestCheckResourceAttr(fmt.Sprintf("aidbox_license.test.%d", count-1), "status", "active"),
+				),
+			},
+		},
+	})
+}
+
+func testAccAidboxLicenseResourceConfigDeletionProtection(name string, deletionProtection bool) string {
+	return fmt.Sprintf(`
+resource "aidbox_license" "test" {
+  name                = %[1]q
+  type                = "development"
+  deletion_protection = %[2]t
+}
+`, name, deletionProtection)
+}
+
 func testAccAidboxLicenseResourceConfig(name string, licenseType string) string {
 	return fmt.Sprintf(`
 resource "aidbox_license" "test" {
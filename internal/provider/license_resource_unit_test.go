@@ -0,0 +1,401 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox/aidboxtest"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox/fake"
+)
+
+// Ensure the fake client satisfies the client interface of the provider.
+var _ Client = &fake.Client{}
+
+// newTestLicenseResource returns a license resource configured with client.
+func newTestLicenseResource(t *testing.T, client Client) (*LicenseResource, resource.SchemaResponse) {
+	t.Helper()
+
+	r := &LicenseResource{}
+	var configureResp resource.ConfigureResponse
+	r.Configure(context.Background(), resource.ConfigureRequest{ProviderData: &ProviderData{Client: client}}, &configureResp)
+	if configureResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", configureResp.Diagnostics)
+	}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+
+	return r, schemaResp
+}
+
+// testLicenseModel maps license to a model as stored in state.
+func testLicenseModel(license aidbox.LicenseResponse) LicenseResourceModel {
+	var model LicenseResourceModel
+	mapModelFromAPIResponse(&model, license)
+	model.DeletionProtection = types.BoolValue(false)
+	model.Timeouts = timeouts.Value{
+		Object: types.ObjectNull(map[string]attr.Type{
+			"create": types.StringType,
+			"read":   types.StringType,
+			"update": types.StringType,
+			"delete": types.StringType,
+		}),
+	}
+	return model
+}
+
+func testLicenseState(t *testing.T, schemaResp resource.SchemaResponse, model LicenseResourceModel) tfsdk.State {
+	t.Helper()
+
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(context.Background()), nil),
+	}
+	if diags := state.Set(context.Background(), &model); diags.HasError() {
+		t.Fatalf("unable to build state: %v", diags)
+	}
+	return state
+}
+
+func TestLicenseResourceRead_removedOutsideTerraform(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewClient()
+	r, schemaResp := newTestLicenseResource(t, client)
+
+	created, err := client.CreateLicense(ctx, "tf-acc-license", "aidbox", "development")
+	if err != nil {
+		t.Fatal(err)
+	}
+	state := testLicenseState(t, schemaResp, testLicenseModel(created))
+
+	if err := client.DeleteLicense(ctx, created.License.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	resp := resource.ReadResponse{State: state}
+	r.Read(ctx, resource.ReadRequest{State: state}, &resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	if !resp.State.Raw.IsNull() {
+		t.Error("expected the license to be removed from state")
+	}
+}
+
+func TestLicenseResourceRead_removedOutsideTerraformPortal(t *testing.T) {
+	ctx := context.Background()
+	portal := aidboxtest.NewPortalServer("token")
+	defer portal.Close()
+	client := aidbox.NewClient(portal.Endpoint(), "token")
+	r, schemaResp := newTestLicenseResource(t, client)
+
+	created, err := client.CreateLicense(ctx, "tf-acc-license", "aidbox", "development")
+	if err != nil {
+		t.Fatal(err)
+	}
+	state := testLicenseState(t, schemaResp, testLicenseModel(created))
+
+	// The portal answers that the token is not a member of the project of
+	// deleted licenses
+	if err := client.DeleteLicense(ctx, created.License.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	resp := resource.ReadResponse{State: state}
+	r.Read(ctx, resource.ReadRequest{State: state}, &resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	if !resp.State.Raw.IsNull() {
+		t.Error("expected the license to be removed from state")
+	}
+}
+
+func TestLicenseResourceRead_authError(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewClient()
+	r, schemaResp := newTestLicenseResource(t, client)
+
+	created, err := client.CreateLicense(ctx, "tf-acc-license", "aidbox", "development")
+	if err != nil {
+		t.Fatal(err)
+	}
+	state := testLicenseState(t, schemaResp, testLicenseModel(created))
+
+	client.Err = &aidbox.APIError{Code: aidbox.ErrorCodeUnauthorized, StatusCode: 401}
+
+	resp := resource.ReadResponse{State: state}
+	r.Read(ctx, resource.ReadRequest{State: state}, &resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected an error")
+	}
+	if resp.State.Raw.IsNull() {
+		t.Error("expected the license to be kept in state")
+	}
+}
+
+func TestLicenseResourceRead_nullDeletionProtection(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewClient()
+	r, schemaResp := newTestLicenseResource(t, client)
+
+	var identitySchemaResp resource.IdentitySchemaResponse
+	r.IdentitySchema(ctx, resource.IdentitySchemaRequest{}, &identitySchemaResp)
+
+	created, err := client.CreateLicense(ctx, "tf-acc-license", "aidbox", "development")
+	if err != nil {
+		t.Fatal(err)
+	}
+	model := testLicenseModel(created)
+	model.DeletionProtection = types.BoolNull()
+	state := testLicenseState(t, schemaResp, model)
+
+	identity := &tfsdk.ResourceIdentity{
+		Schema: identitySchemaResp.IdentitySchema,
+		Raw:    tftypes.NewValue(identitySchemaResp.IdentitySchema.Type().TerraformType(ctx), nil),
+	}
+	resp := resource.ReadResponse{State: state, Identity: identity}
+	r.Read(ctx, resource.ReadRequest{State: state}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	resp.Diagnostics.Append(resp.State.Get(ctx, &model)...)
+	if model.DeletionProtection.IsNull() || model.DeletionProtection.ValueBool() {
+		t.Errorf("expected deletion_protection to be read as false, got: %s", model.DeletionProtection)
+	}
+}
+
+func TestLicenseResourceDelete(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := map[string]struct {
+		deletionProtection bool
+		deletedOutside     bool
+		expectError        bool
+		expectWarning      bool
+	}{
+		"deleted":             {},
+		"deletion-protection": {deletionProtection: true, expectError: true},
+		"deleted-outside":     {deletedOutside: true, expectWarning: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client := fake.NewClient()
+			r, schemaResp := newTestLicenseResource(t, client)
+
+			created, err := client.CreateLicense(ctx, "tf-acc-license", "aidbox", "development")
+			if err != nil {
+				t.Fatal(err)
+			}
+			model := testLicenseModel(created)
+			model.DeletionProtection = types.BoolValue(testCase.deletionProtection)
+			state := testLicenseState(t, schemaResp, model)
+
+			if testCase.deletedOutside {
+				if err := client.DeleteLicense(ctx, created.License.ID); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			resp := resource.DeleteResponse{State: state}
+			r.Delete(ctx, resource.DeleteRequest{State: state}, &resp)
+
+			if resp.Diagnostics.HasError() != testCase.expectError {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+			if (resp.Diagnostics.WarningsCount() > 0) != testCase.expectWarning {
+				t.Errorf("unexpected warnings: %v", resp.Diagnostics)
+			}
+
+			_, err = client.GetLicense(ctx, created.License.ID)
+			if exists := err == nil; exists != testCase.deletionProtection {
+				t.Errorf("expected license to exist to be %t", testCase.deletionProtection)
+			}
+		})
+	}
+}
+
+func TestLicenseResourceDelete_deletedOutsideTerraformPortal(t *testing.T) {
+	ctx := context.Background()
+	portal := aidboxtest.NewPortalServer("token")
+	defer portal.Close()
+	client := aidbox.NewClient(portal.Endpoint(), "token")
+	r, schemaResp := newTestLicenseResource(t, client)
+
+	created, err := client.CreateLicense(ctx, "tf-acc-license", "aidbox", "development")
+	if err != nil {
+		t.Fatal(err)
+	}
+	state := testLicenseState(t, schemaResp, testLicenseModel(created))
+
+	if err := client.DeleteLicense(ctx, created.License.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	// remove-license answers that the token is not a member of the project
+	resp := resource.DeleteResponse{State: state}
+	r.Delete(ctx, resource.DeleteRequest{State: state}, &resp)
+
+	if resp.Diagnostics.HasError() || resp.Diagnostics.WarningsCount() != 1 {
+		t.Errorf("expected a single warning, got: %v", resp.Diagnostics)
+	}
+}
+
+func TestLicenseResourceUpdate_modifiedConcurrently(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewClient()
+	r, schemaResp := newTestLicenseResource(t, client)
+
+	created, err := client.CreateLicense(ctx, "tf-acc-license", "aidbox", "development")
+	if err != nil {
+		t.Fatal(err)
+	}
+	state := testLicenseState(t, schemaResp, testLicenseModel(created))
+
+	client.UpdateLicense(created.License.ID, func(license *aidbox.License) {
+		license.Status = "suspended"
+	})
+
+	plan := tfsdk.Plan{Schema: state.Schema, Raw: state.Raw}
+	resp := resource.UpdateResponse{State: state}
+	r.Update(ctx, resource.UpdateRequest{Plan: plan, State: state}, &resp)
+
+	if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != "License Modified Concurrently" {
+		t.Fatalf("expected a concurrent modification error, got: %v", resp.Diagnostics)
+	}
+}
+
+func TestLicenseResourceUpdate_jwtRefreshAfterRenewal(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewClient()
+	r, schemaResp := newTestLicenseResource(t, client)
+
+	created, err := client.CreateLicense(ctx, "tf-acc-license", "aidbox", "development")
+	if err != nil {
+		t.Fatal(err)
+	}
+	prior := testLicenseModel(created)
+	state := testLicenseState(t, schemaResp, prior)
+	var identitySchemaResp resource.IdentitySchemaResponse
+	r.IdentitySchema(ctx, resource.IdentitySchemaRequest{}, &identitySchemaResp)
+	identity := &tfsdk.ResourceIdentity{
+		Schema: identitySchemaResp.IdentitySchema,
+		Raw:    tftypes.NewValue(identitySchemaResp.IdentitySchema.Type().TerraformType(ctx), nil),
+	}
+
+	// Renewing the license in the portal bumps its version, which is what
+	// the planned refresh of the JWT picks up
+	client.UpdateLicense(created.License.ID, func(license *aidbox.License) {
+		license.Expiration = "2030-01-01T00:00:00Z"
+	})
+
+	planned := prior
+	planned.JWT = types.StringUnknown()
+	planned.Expiration = types.StringUnknown()
+	plan := tfsdk.Plan{Schema: state.Schema, Raw: testLicenseState(t, schemaResp, planned).Raw}
+	resp := resource.UpdateResponse{State: state, Identity: identity}
+	r.Update(ctx, resource.UpdateRequest{Plan: plan, State: state}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var got LicenseResourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &got)...)
+	if got.Expiration.ValueString() != "2030-01-01T00:00:00Z" || got.JWT.ValueString() == created.JWT || licenseVersionID(got) != "2" {
+		t.Errorf("expected the renewed license, got: %+v", got)
+	}
+
+	// Other changes are still refused against an older version
+	client.UpdateLicense(created.License.ID, func(license *aidbox.License) {
+		license.Status = "suspended"
+	})
+	planned.DeletionProtection = types.BoolValue(true)
+	plan = tfsdk.Plan{Schema: state.Schema, Raw: testLicenseState(t, schemaResp, planned).Raw}
+	resp = resource.UpdateResponse{State: state, Identity: identity}
+	r.Update(ctx, resource.UpdateRequest{Plan: plan, State: state}, &resp)
+	if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != "License Modified Concurrently" {
+		t.Fatalf("expected a concurrent modification error, got: %v", resp.Diagnostics)
+	}
+}
+
+// countingClient counts the license reads made through the client.
+type countingClient struct {
+	*fake.Client
+	gets, lists int
+}
+
+func (c *countingClient) GetLicense(ctx context.Context, licenseID string) (aidbox.LicenseResponse, error) {
+	c.gets++
+	return c.Client.GetLicense(ctx, licenseID)
+}
+
+func (c *countingClient) ListLicenses(ctx context.Context) ([]aidbox.License, error) {
+	c.lists++
+	return c.Client.ListLicenses(ctx)
+}
+
+func TestLicenseResourceRead_snapshot(t *testing.T) {
+	ctx := context.Background()
+	client := &countingClient{Client: fake.NewClient()}
+	r, schemaResp := newTestLicenseResource(t, client)
+	r.licenses = newLicenseSnapshot(client)
+
+	var identitySchemaResp resource.IdentitySchemaResponse
+	r.IdentitySchema(ctx, resource.IdentitySchemaRequest{}, &identitySchemaResp)
+
+	var states []tfsdk.State
+	for i := 0; i < 3; i++ {
+		created, err := client.CreateLicense(ctx, "tf-acc-license", "aidbox", "development")
+		if err != nil {
+			t.Fatal(err)
+		}
+		states = append(states, testLicenseState(t, schemaResp, testLicenseModel(created)))
+	}
+
+	// A license modified outside of Terraform is fetched again, to read its
+	// new JWT
+	var suspended LicenseResourceModel
+	if diags := states[0].Get(ctx, &suspended); diags.HasError() {
+		t.Fatal(diags)
+	}
+	client.UpdateLicense(suspended.ID.ValueString(), func(license *aidbox.License) {
+		license.Status = "suspended"
+	})
+
+	for _, state := range states {
+		identity := &tfsdk.ResourceIdentity{
+			Schema: identitySchemaResp.IdentitySchema,
+			Raw:    tftypes.NewValue(identitySchemaResp.IdentitySchema.Type().TerraformType(ctx), nil),
+		}
+		resp := resource.ReadResponse{State: state, Identity: identity}
+		r.Read(ctx, resource.ReadRequest{State: state}, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+
+		var model LicenseResourceModel
+		resp.Diagnostics.Append(resp.State.Get(ctx, &model)...)
+		if model.ID == suspended.ID && (model.Status.ValueString() != "suspended" || model.JWT == suspended.JWT) {
+			t.Errorf("expected the suspended license with a new JWT, got: %+v", model)
+		}
+	}
+
+	if client.lists != 1 || client.gets != 1 {
+		t.Errorf("expected 1 listing and 1 fetch, got: %d listings and %d fetches", client.lists, client.gets)
+	}
+}
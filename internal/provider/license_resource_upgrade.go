@@ -0,0 +1,117 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// LicenseResourceModelV0 describes the version 0 data model, which exposed
+// the license metadata as flat meta_* attributes.
+type LicenseResourceModelV0 struct {
+	ID              types.String `tfsdk:"id"`
+	Name            types.String `tfsdk:"name"`
+	Product         types.String `tfsdk:"product"`
+	Type            types.String `tfsdk:"type"`
+	Expiration      types.String `tfsdk:"expiration"`
+	Status          types.String `tfsdk:"status"`
+	MaxInstances    types.Int64  `tfsdk:"max_instances"`
+	CreatorID       types.String `tfsdk:"creator_id"`
+	ProjectID       types.String `tfsdk:"project_id"`
+	Offline         types.Bool   `tfsdk:"offline"`
+	Created         types.String `tfsdk:"created"`
+	MetaLastUpdated types.String `tfsdk:"meta_last_updated"`
+	MetaCreatedAt   types.String `tfsdk:"meta_created_at"`
+	MetaVersionID   types.String `tfsdk:"meta_version_id"`
+	Issuer          types.String `tfsdk:"issuer"`
+	InfoHosting     types.String `tfsdk:"info_hosting"`
+	JWT             types.String `tfsdk:"jwt"`
+}
+
+func (r *LicenseResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	schemaV0 := licenseSchemaV0()
+
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema:   &schemaV0,
+			StateUpgrader: upgradeLicenseStateV0toV1,
+		},
+	}
+}
+
+func upgradeLicenseStateV0toV1(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var prior LicenseResourceModelV0
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	upgraded := LicenseResourceModel{
+		ID:           prior.ID,
+		Name:         prior.Name,
+		Product:      prior.Product,
+		Type:         prior.Type,
+		Expiration:   prior.Expiration,
+		Status:       prior.Status,
+		MaxInstances: prior.MaxInstances,
+		CreatorID:    prior.CreatorID,
+		ProjectID:    prior.ProjectID,
+		Offline:      prior.Offline,
+		Created:      prior.Created,
+		Meta: basetypes.NewObjectValueMust(licenseMetaAttrTypes, map[string]attr.Value{
+			"last_updated": prior.MetaLastUpdated,
+			"created_at":   prior.MetaCreatedAt,
+			"version_id":   prior.MetaVersionID,
+		}),
+		Issuer:      prior.Issuer,
+		InfoHosting: prior.InfoHosting,
+		JWT:         prior.JWT,
+		// Attributes added in version 1
+		DeletionProtection: types.BoolValue(false),
+		Timeouts: timeouts.Value{
+			Object: types.ObjectNull(map[string]attr.Type{
+				"create": types.StringType,
+				"read":   types.StringType,
+				"update": types.StringType,
+				"delete": types.StringType,
+			}),
+		},
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, upgraded)...)
+}
+
+// licenseSchemaV0 returns the version 0 schema of aidbox_license.
+func licenseSchemaV0() schema.Schema {
+	computedString := schema.StringAttribute{Computed: true}
+
+	return schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id":                computedString,
+			"name":              schema.StringAttribute{Required: true},
+			"product":           schema.StringAttribute{Optional: true, Computed: true},
+			"type":              schema.StringAttribute{Required: true},
+			"expiration":        computedString,
+			"status":            computedString,
+			"max_instances":     schema.Int64Attribute{Computed: true},
+			"creator_id":        computedString,
+			"project_id":        computedString,
+			"offline":           schema.BoolAttribute{Computed: true},
+			"created":           computedString,
+			"meta_last_updated": computedString,
+			"meta_created_at":   computedString,
+			"meta_version_id":   computedString,
+			"issuer":            computedString,
+			"info_hosting":      computedString,
+			"jwt":               computedString,
+		},
+	}
+}
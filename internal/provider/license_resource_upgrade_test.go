@@ -0,0 +1,83 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestLicenseResourceUpgradeStateV0(t *testing.T) {
+	ctx := context.Background()
+	r := &LicenseResource{}
+
+	upgrader, ok := r.UpgradeState(ctx)[0]
+	if !ok {
+		t.Fatal("expected a state upgrader for version 0")
+	}
+
+	priorType := upgrader.PriorSchema.Type().TerraformType(ctx)
+	priorValues := map[string]tftypes.Value{}
+	priorObjectType, ok := priorType.(tftypes.Object)
+	if !ok {
+		t.Fatal("expected the prior schema type to be an object")
+	}
+	for name, attrType := range priorObjectType.AttributeTypes {
+		priorValues[name] = tftypes.NewValue(attrType, nil)
+	}
+	priorValues["id"] = tftypes.NewValue(tftypes.String, "license-one")
+	priorValues["name"] = tftypes.NewValue(tftypes.String, "license-one")
+	priorValues["type"] = tftypes.NewValue(tftypes.String, "development")
+	priorValues["meta_last_updated"] = tftypes.NewValue(tftypes.String, "2024-01-02T00:00:00Z")
+	priorValues["meta_created_at"] = tftypes.NewValue(tftypes.String, "2024-01-01T00:00:00Z")
+	priorValues["meta_version_id"] = tftypes.NewValue(tftypes.String, "7")
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	req := resource.UpgradeStateRequest{
+		State: &tfsdk.State{
+			Schema: *upgrader.PriorSchema,
+			Raw:    tftypes.NewValue(priorType, priorValues),
+		},
+	}
+	resp := resource.UpgradeStateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	upgrader.StateUpgrader(ctx, req, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var upgraded LicenseResourceModel
+	if diags := resp.State.Get(ctx, &upgraded); diags.HasError() {
+		t.Fatalf("unable to read upgraded state: %v", diags)
+	}
+
+	if upgraded.ID.ValueString() != "license-one" {
+		t.Errorf("expected id license-one, got %s", upgraded.ID)
+	}
+
+	meta := upgraded.Meta.Attributes()
+	expected := map[string]string{
+		"last_updated": "2024-01-02T00:00:00Z",
+		"created_at":   "2024-01-01T00:00:00Z",
+		"version_id":   "7",
+	}
+	for name, want := range expected {
+		got, ok := meta[name].(types.String)
+		if !ok {
+			t.Fatalf("expected meta.%s to be a string, got %T", name, meta[name])
+		}
+		if got.ValueString() != want {
+			t.Errorf("expected meta.%s %q, got %q", name, want, got.ValueString())
+		}
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox"
+	"sync"
+)
+
+// licenseSnapshot lists the licenses of the project once, on the first read,
+// so that refreshing many licenses doesn't call the portal for each of them.
+// It is shared by the license resources of a provider instance.
+type licenseSnapshot struct {
+	client Client
+
+	mu       sync.Mutex
+	loaded   bool
+	licenses map[string]aidbox.License
+}
+
+func newLicenseSnapshot(client Client) *licenseSnapshot {
+	return &licenseSnapshot{client: client}
+}
+
+// get returns the license with the given ID as listed in the snapshot. It
+// returns false when the snapshot can't tell, such as for a license created
+// after the snapshot was taken or when listing the licenses failed, in which
+// case the license must be fetched on its own.
+func (s *licenseSnapshot) get(ctx context.Context, id string) (aidbox.License, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.loaded {
+		s.loaded = true
+
+		licenses, err := s.client.ListLicenses(ctx)
+		if err != nil {
+			tflog.Debug(ctx, "Failed to list licenses, fetching them one at a time", map[string]interface{}{"error": err.Error()})
+			return aidbox.License{}, false
+		}
+
+		s.licenses = make(map[string]aidbox.License, len(licenses))
+		for _, license := range licenses {
+			s.licenses[license.ID] = license
+		}
+	}
+
+	license, ok := s.licenses[id]
+	return license, ok
+}
@@ -0,0 +1,63 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/petalmd/terraform-provider-aidbox/internal/matcho"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &MatchoValidateFunction{}
+
+func NewMatchoValidateFunction() function.Function {
+	return &MatchoValidateFunction{}
+}
+
+// MatchoValidateFunction defines the function implementation.
+type MatchoValidateFunction struct{}
+
+func (f *MatchoValidateFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "matcho_validate"
+}
+
+func (f *MatchoValidateFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Check the syntax of a matcho access policy",
+		MarkdownDescription: "Parses a matcho access-policy document written as JSON or YAML and checks its structure and operators (`$contains`, `$enum`, `$one-of`). Returns the list of problems found, which is empty when the document is valid.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "document",
+				MarkdownDescription: "Matcho document as JSON or YAML",
+			},
+		},
+		Return: function.ListReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+func (f *MatchoValidateFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var document string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &document))
+	if resp.Error != nil {
+		return
+	}
+
+	problems := []string{}
+
+	pattern, err := matcho.Parse(document)
+	if err != nil {
+		problems = append(problems, err.Error())
+	} else {
+		for _, problem := range matcho.Validate(pattern) {
+			problems = append(problems, problem.Error())
+		}
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, problems))
+}
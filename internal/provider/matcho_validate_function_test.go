@@ -0,0 +1,43 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccMatchoValidateFunction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "valid" {
+  value = length(provider::aidbox::matcho_validate(jsonencode({
+    request-method = { "$enum" = ["get", "post"] }
+    user           = { data = { roles = { "$contains" = "admin" } } }
+  })))
+}
+
+output "invalid" {
+  value = provider::aidbox::matcho_validate(jsonencode({
+    user = { "$contain" = "admin" }
+  }))[0]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("valid", "0"),
+					resource.TestCheckOutput("invalid", `user.$contain: unknown matcho operator "$contain", expected one of $contains, $enum, $one-of`),
+				),
+			},
+		},
+	})
+}
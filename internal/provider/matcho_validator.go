@@ -0,0 +1,51 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/petalmd/terraform-provider-aidbox/internal/matcho"
+)
+
+var _ validator.String = matchoValidator{}
+
+// matchoValidator validates that a string attribute is a matcho document,
+// reporting every problem found by the matcho package, such as an unknown
+// operator or a `$one-of` without patterns.
+type matchoValidator struct{}
+
+// validMatcho returns a validator for the matcho documents of access
+// policies, written as JSON or YAML.
+func validMatcho() validator.String {
+	return matchoValidator{}
+}
+
+func (v matchoValidator) Description(ctx context.Context) string {
+	return "value must be a matcho document, written as JSON or YAML"
+}
+
+func (v matchoValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v matchoValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	pattern, err := matcho.Parse(req.ConfigValue.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Matcho Document", err.Error())
+		return
+	}
+	for _, problem := range matcho.Validate(pattern) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Matcho Document",
+			fmt.Sprintf("The matcho document is invalid: %s", problem),
+		)
+	}
+}
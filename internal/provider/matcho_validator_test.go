@@ -0,0 +1,44 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestMatchoValidator(t *testing.T) {
+	testCases := map[string]struct {
+		value          types.String
+		expectedErrors int
+	}{
+		"json":           {value: types.StringValue(`{"request-method": {"$enum": ["get", "post"]}, "user": {"roles": {"$contains": {"value": "admin"}}}}`)},
+		"yaml":           {value: types.StringValue("client:\n  id: portal\nparams:\n  $one-of:\n    - resource/type: Patient\n    - resource/type: Encounter\n")},
+		"null":           {value: types.StringNull()},
+		"unknown":        {value: types.StringUnknown()},
+		"syntax":         {value: types.StringValue(`{"user": `), expectedErrors: 1},
+		"not an object":  {value: types.StringValue(`["get"]`), expectedErrors: 1},
+		"empty one-of":   {value: types.StringValue(`{"params": {"$one-of": []}}`), expectedErrors: 1},
+		"null contains":  {value: types.StringValue(`{"user": {"roles": {"$contains": null}}}`), expectedErrors: 1},
+		"several issues": {value: types.StringValue(`{"user": {"$in": ["a"]}, "params": {"$one-of": "a"}}`), expectedErrors: 2},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resp := &validator.StringResponse{}
+			validMatcho().ValidateString(context.Background(), validator.StringRequest{
+				Path:        path.Root("matcho"),
+				ConfigValue: testCase.value,
+			}, resp)
+
+			if resp.Diagnostics.ErrorsCount() != testCase.expectedErrors {
+				t.Errorf("expected %d errors, got: %v", testCase.expectedErrors, resp.Diagnostics)
+			}
+		})
+	}
+}
@@ -0,0 +1,285 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox"
+	"regexp"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &MultiboxResource{}
+var _ resource.ResourceWithImportState = &MultiboxResource{}
+
+func NewMultiboxResource() resource.Resource {
+	return &MultiboxResource{}
+}
+
+// MultiboxResource defines the aidbox_box resource implementation. It is
+// named after the multibox RPC it uses, to tell it apart from boxResource,
+// embedded by the resources managing the configuration of a box.
+type MultiboxResource struct {
+	client Client
+}
+
+// MultiboxResourceModel describes the resource data model.
+type MultiboxResourceModel struct {
+	ID                 types.String `tfsdk:"id"`
+	Name               types.String `tfsdk:"name"`
+	FHIRVersion        types.String `tfsdk:"fhir_version"`
+	Description        types.String `tfsdk:"description"`
+	Env                types.Map    `tfsdk:"env"`
+	BoxURL             types.String `tfsdk:"box_url"`
+	ClientID           types.String `tfsdk:"client_id"`
+	ClientSecret       types.String `tfsdk:"client_secret"`
+	DeletionProtection types.Bool   `tfsdk:"deletion_protection"`
+}
+
+func (r *MultiboxResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_box"
+}
+
+func (r *MultiboxResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a box of a multibox deployment. The credentials of the root client of the box are only returned when the box is created, so they are not set for imported boxes.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the box, the same as `name`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the box, used in its URL. Changing it forces a new box to be created.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^[a-z][a-z0-9-]*$`), "must start with a lowercase letter and only contain lowercase letters, digits and dashes"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"fhir_version": schema.StringAttribute{
+				MarkdownDescription: "FHIR version of the box, such as `4.0.1`. Changing it forces a new box to be created.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("3.0.1", "4.0.0", "4.0.1", "4.3.0", "5.0.0"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Description of the box",
+				Optional:            true,
+			},
+			"env": schema.MapAttribute{
+				MarkdownDescription: "Environment variables of the box, such as `BOX_SEARCH_FHIR__COMPARISONS`. The box restarts when they change.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"box_url": schema.StringAttribute{
+				MarkdownDescription: "Base URL of the box, to configure the `box_url` of another provider instance with",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"client_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the root client of the box",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"client_secret": schema.StringAttribute{
+				MarkdownDescription: "Secret of the root client of the box",
+				Computed:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"deletion_protection": schema.BoolAttribute{
+				MarkdownDescription: "Whether Terraform is prevented from deleting the box, with all of its data. When `true`, destroying or replacing the box fails until this is set to `false` and applied. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+		},
+	}
+}
+
+func (r *MultiboxResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*ProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = data.Client
+}
+
+func (r *MultiboxResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var model MultiboxResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	box, diags := multiboxFromModel(ctx, model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	apiResp, err := r.client.CreateBox(ctx, box)
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostic("Failed to Create Box", "Unable to create box", err))
+		return
+	}
+
+	model.ClientID = types.StringValue(apiResp.Credentials.ClientID)
+	model.ClientSecret = types.StringValue(apiResp.Credentials.ClientSecret)
+	resp.Diagnostics.Append(mapMultiboxFromBox(ctx, &model, apiResp.Box)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *MultiboxResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var model MultiboxResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	apiResp, err := r.client.GetBox(ctx, model.ID.ValueString())
+	if aidbox.IsNotFound(err) {
+		tflog.Warn(ctx, "Box not found, removing from state", map[string]interface{}{"id": model.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostic("Failed to Fetch Box", "Unable to fetch box", err))
+		return
+	}
+
+	// Credentials are only returned on creation, keep the ones in state.
+	resp.Diagnostics.Append(mapMultiboxFromBox(ctx, &model, apiResp.Box)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *MultiboxResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var model MultiboxResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	box, diags := multiboxFromModel(ctx, model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	apiResp, err := r.client.UpdateBox(ctx, box)
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostic("Failed to Update Box", "Unable to update box", err))
+		return
+	}
+
+	resp.Diagnostics.Append(mapMultiboxFromBox(ctx, &model, apiResp.Box)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *MultiboxResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var model MultiboxResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if model.DeletionProtection.ValueBool() {
+		resp.Diagnostics.AddError(
+			"Box Deletion Protected",
+			fmt.Sprintf("Cannot delete the box %s while deletion_protection is enabled. Set deletion_protection to false and apply before deleting it.", model.ID.ValueString()),
+		)
+		return
+	}
+
+	err := r.client.DeleteBox(ctx, model.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(deleteErrorDiagnostic(
+			"box "+model.ID.ValueString(),
+			"Failed to Delete Box",
+			fmt.Sprintf("Error while trying to delete the box %s", model.ID.ValueString()),
+			err,
+		))
+	}
+}
+
+func (r *MultiboxResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("deletion_protection"), false)...)
+}
+
+func multiboxFromModel(ctx context.Context, model MultiboxResourceModel) (aidbox.Box, diag.Diagnostics) {
+	box := aidbox.Box{
+		ID:          model.Name.ValueString(),
+		FHIRVersion: model.FHIRVersion.ValueString(),
+		Description: model.Description.ValueString(),
+	}
+
+	var diags diag.Diagnostics
+	if !model.Env.IsNull() && !model.Env.IsUnknown() {
+		diags = model.Env.ElementsAs(ctx, &box.Env, false)
+	}
+	return box, diags
+}
+
+// mapMultiboxFromBox maps box to model. The portal omits an empty description
+// and env, so an empty description or env in model is kept as is, rather
+// than read back as null.
+func mapMultiboxFromBox(ctx context.Context, model *MultiboxResourceModel, box aidbox.Box) diag.Diagnostics {
+	model.ID = types.StringValue(box.ID)
+	model.Name = types.StringValue(box.ID)
+	model.FHIRVersion = types.StringValue(box.FHIRVersion)
+	if box.Description != "" || model.Description.ValueString() != "" {
+		model.Description = stringValueOrNull(box.Description)
+	}
+	model.BoxURL = types.StringValue(box.BoxURL)
+
+	var diags diag.Diagnostics
+	switch {
+	case len(box.Env) > 0:
+		model.Env, diags = types.MapValueFrom(ctx, types.StringType, box.Env)
+	case len(model.Env.Elements()) > 0:
+		model.Env = types.MapNull(types.StringType)
+	}
+	return diags
+}
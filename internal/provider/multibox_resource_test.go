@@ -0,0 +1,71 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccAidboxBoxResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_5_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccAidboxBoxResourceConfig("Acceptance tests"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("aidbox_box.test", "id", "tf-acc-box"),
+					resource.TestCheckResourceAttr("aidbox_box.test", "fhir_version", "4.0.1"),
+					resource.TestCheckResourceAttrSet("aidbox_box.test", "box_url"),
+					resource.TestCheckResourceAttrSet("aidbox_box.test", "client_id"),
+					resource.TestCheckResourceAttrSet("aidbox_box.test", "client_secret"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "aidbox_box.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"client_id", "client_secret"},
+			},
+			// Import block testing, as with terraform plan -generate-config-out
+			{
+				ResourceName:    "aidbox_box.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithID,
+			},
+			// Update and Read testing
+			{
+				Config: testAccAidboxBoxResourceConfig("Updated acceptance tests"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("aidbox_box.test", "description", "Updated acceptance tests"),
+					resource.TestCheckResourceAttrSet("aidbox_box.test", "client_secret"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccAidboxBoxResourceConfig(description string) string {
+	return fmt.Sprintf(`
+resource "aidbox_box" "test" {
+  name         = "tf-acc-box"
+  fhir_version = "4.0.1"
+  description  = %[1]q
+
+  env = {
+    BOX_SEARCH_FHIR__COMPARISONS = "true"
+  }
+}
+`, description)
+}
@@ -0,0 +1,168 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox/aidboxtest"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox/fake"
+)
+
+func TestMultiboxResourceRead_keepsCredentials(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewClient()
+
+	r := &MultiboxResource{}
+	r.Configure(ctx, resource.ConfigureRequest{ProviderData: &ProviderData{Client: client}}, &resource.ConfigureResponse{})
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	created, err := client.CreateBox(ctx, aidbox.Box{ID: "staging", FHIRVersion: "4.0.1", Env: map[string]string{"BOX_ID": "staging"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	model := MultiboxResourceModel{
+		ClientID:           types.StringValue(created.Credentials.ClientID),
+		ClientSecret:       types.StringValue(created.Credentials.ClientSecret),
+		DeletionProtection: types.BoolValue(false),
+	}
+	if diags := mapMultiboxFromBox(ctx, &model, created.Box); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	if diags := state.Set(ctx, &model); diags.HasError() {
+		t.Fatalf("unable to build state: %v", diags)
+	}
+
+	resp := resource.ReadResponse{State: state}
+	r.Read(ctx, resource.ReadRequest{State: state}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var got MultiboxResourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &got)...)
+	if got.ClientSecret.ValueString() != created.Credentials.ClientSecret {
+		t.Errorf("expected the client secret to be kept, got: %s", got.ClientSecret)
+	}
+	if got.Env.Elements()["BOX_ID"] != types.StringValue("staging") || got.BoxURL.IsNull() {
+		t.Errorf("unexpected model: %+v", got)
+	}
+}
+
+func TestMultiboxResourceUpdate_clearsDescriptionAndEnv(t *testing.T) {
+	ctx := context.Background()
+	portal := aidboxtest.NewPortalServer("token")
+	defer portal.Close()
+	client := aidbox.NewClient(portal.Endpoint(), "token")
+
+	r := &MultiboxResource{}
+	r.Configure(ctx, resource.ConfigureRequest{ProviderData: &ProviderData{Client: client}}, &resource.ConfigureResponse{})
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	created, err := client.CreateBox(ctx, aidbox.Box{ID: "staging", FHIRVersion: "4.0.1", Description: "Staging", Env: map[string]string{"BOX_ID": "staging"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	model := MultiboxResourceModel{
+		ClientID:           types.StringValue(created.Credentials.ClientID),
+		ClientSecret:       types.StringValue(created.Credentials.ClientSecret),
+		DeletionProtection: types.BoolValue(false),
+	}
+	if diags := mapMultiboxFromBox(ctx, &model, created.Box); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	if diags := state.Set(ctx, &model); diags.HasError() {
+		t.Fatalf("unable to build state: %v", diags)
+	}
+
+	model.Description = types.StringNull()
+	model.Env = types.MapNull(types.StringType)
+	plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: state.Raw}
+	if diags := plan.Set(ctx, &model); diags.HasError() {
+		t.Fatalf("unable to build plan: %v", diags)
+	}
+
+	resp := resource.UpdateResponse{State: state}
+	r.Update(ctx, resource.UpdateRequest{Plan: plan, State: state}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var got MultiboxResourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &got)...)
+	if !got.Description.IsNull() || !got.Env.IsNull() {
+		t.Errorf("expected the description and env to be cleared, got: %s and %s", got.Description, got.Env)
+	}
+
+	fetched, err := client.GetBox(ctx, "staging")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fetched.Box.Description != "" || len(fetched.Box.Env) > 0 {
+		t.Errorf("expected the box to have no description and env, got: %+v", fetched.Box)
+	}
+}
+
+func TestMultiboxResourceCreate_emptyDescriptionAndEnv(t *testing.T) {
+	ctx := context.Background()
+	portal := aidboxtest.NewPortalServer("token")
+	defer portal.Close()
+	client := aidbox.NewClient(portal.Endpoint(), "token")
+
+	r := &MultiboxResource{}
+	r.Configure(ctx, resource.ConfigureRequest{ProviderData: &ProviderData{Client: client}}, &resource.ConfigureResponse{})
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	model := MultiboxResourceModel{
+		ID:                 types.StringUnknown(),
+		Name:               types.StringValue("staging"),
+		FHIRVersion:        types.StringValue("4.0.1"),
+		Description:        types.StringValue(""),
+		Env:                types.MapValueMust(types.StringType, map[string]attr.Value{}),
+		BoxURL:             types.StringUnknown(),
+		ClientID:           types.StringUnknown(),
+		ClientSecret:       types.StringUnknown(),
+		DeletionProtection: types.BoolValue(false),
+	}
+	plan := tfsdk.Plan{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	if diags := plan.Set(ctx, &model); diags.HasError() {
+		t.Fatalf("unable to build plan: %v", diags)
+	}
+
+	resp := resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: plan.Raw}}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	// The configured empty values are kept, as the portal omits them
+	var got MultiboxResourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &got)...)
+	if !got.Description.Equal(model.Description) || !got.Env.Equal(model.Env) {
+		t.Errorf("expected the empty description and env to be kept, got: %s and %s", got.Description, got.Env)
+	}
+}
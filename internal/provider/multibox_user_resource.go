@@ -0,0 +1,205 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox"
+	"strings"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &MultiboxUserResource{}
+var _ resource.ResourceWithImportState = &MultiboxUserResource{}
+
+func NewMultiboxUserResource() resource.Resource {
+	return &MultiboxUserResource{}
+}
+
+// MultiboxUserResource defines the aidbox_box_user resource implementation.
+type MultiboxUserResource struct {
+	client Client
+}
+
+// MultiboxUserResourceModel describes the resource data model.
+type MultiboxUserResourceModel struct {
+	ID    types.String `tfsdk:"id"`
+	BoxID types.String `tfsdk:"box_id"`
+	Email types.String `tfsdk:"email"`
+	Role  types.String `tfsdk:"role"`
+}
+
+func (r *MultiboxUserResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_box_user"
+}
+
+func (r *MultiboxUserResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Grants a portal user access to a box of a multibox deployment with a role.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the access, in the `<box_id>/<email>` format",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"box_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the box, such as the `id` of an `aidbox_box` resource. Changing it forces a new access to be granted.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"email": schema.StringAttribute{
+				MarkdownDescription: "Email of the portal user. Changing it forces a new access to be granted.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"role": schema.StringAttribute{
+				MarkdownDescription: "Role of the user on the box, `admin`, `developer` or `viewer`",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("admin", "developer", "viewer"),
+				},
+			},
+		},
+	}
+}
+
+func (r *MultiboxUserResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*ProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = data.Client
+}
+
+func (r *MultiboxUserResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var model MultiboxUserResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	user, err := r.client.GrantBoxAccess(ctx, multiboxUserFromModel(model))
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostic("Failed to Grant Box Access", "Unable to grant access to the box", err))
+		return
+	}
+
+	mapMultiboxUserFromBoxUser(&model, user)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *MultiboxUserResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var model MultiboxUserResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	user, err := r.client.GetBoxUser(ctx, model.BoxID.ValueString(), model.Email.ValueString())
+	if aidbox.IsNotFound(err) {
+		tflog.Warn(ctx, "Box user not found, removing from state", map[string]interface{}{"id": model.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostic("Failed to Fetch Box User", "Unable to fetch the access to the box", err))
+		return
+	}
+
+	mapMultiboxUserFromBoxUser(&model, user)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *MultiboxUserResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var model MultiboxUserResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	user, err := r.client.GrantBoxAccess(ctx, multiboxUserFromModel(model))
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostic("Failed to Update Box Access", "Unable to change the role of the user on the box", err))
+		return
+	}
+
+	mapMultiboxUserFromBoxUser(&model, user)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *MultiboxUserResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var model MultiboxUserResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.RevokeBoxAccess(ctx, model.BoxID.ValueString(), model.Email.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(deleteErrorDiagnostic(
+			fmt.Sprintf("access of %s to the box %s", model.Email.ValueString(), model.BoxID.ValueString()),
+			"Failed to Revoke Box Access",
+			fmt.Sprintf("Error while trying to revoke the access of %s to the box %s", model.Email.ValueString(), model.BoxID.ValueString()),
+			err,
+		))
+	}
+}
+
+func (r *MultiboxUserResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	boxID, email, ok := strings.Cut(req.ID, "/")
+	if !ok || boxID == "" || email == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("The access of a user to a box is imported with an ID in the <box_id>/<email> format, got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("box_id"), boxID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("email"), email)...)
+}
+
+func multiboxUserFromModel(model MultiboxUserResourceModel) aidbox.BoxUser {
+	return aidbox.BoxUser{
+		BoxID: model.BoxID.ValueString(),
+		Email: model.Email.ValueString(),
+		Role:  model.Role.ValueString(),
+	}
+}
+
+func mapMultiboxUserFromBoxUser(model *MultiboxUserResourceModel, user aidbox.BoxUser) {
+	model.ID = types.StringValue(user.BoxID + "/" + user.Email)
+	model.BoxID = types.StringValue(user.BoxID)
+	model.Email = types.StringValue(user.Email)
+	model.Role = types.StringValue(user.Role)
+}
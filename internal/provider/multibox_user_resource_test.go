@@ -0,0 +1,67 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccAidboxBoxUserResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_5_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccAidboxBoxUserResourceConfig("developer"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("aidbox_box_user.test", "id", "tf-acc-box-users/tf-acc@example.com"),
+					resource.TestCheckResourceAttr("aidbox_box_user.test", "role", "developer"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "aidbox_box_user.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Import block testing, as with terraform plan -generate-config-out
+			{
+				ResourceName:    "aidbox_box_user.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithID,
+			},
+			// Update and Read testing
+			{
+				Config: testAccAidboxBoxUserResourceConfig("admin"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("aidbox_box_user.test", "role", "admin"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccAidboxBoxUserResourceConfig(role string) string {
+	return fmt.Sprintf(`
+resource "aidbox_box" "test" {
+  name         = "tf-acc-box-users"
+  fhir_version = "4.0.1"
+}
+
+resource "aidbox_box_user" "test" {
+  box_id = aidbox_box.test.id
+  email  = "tf-acc@example.com"
+  role   = %[1]q
+}
+`, role)
+}
@@ -0,0 +1,175 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &NotificationTemplateResource{}
+var _ resource.ResourceWithImportState = &NotificationTemplateResource{}
+
+const notificationTemplateResourceType = "NotificationTemplate"
+
+func NewNotificationTemplateResource() resource.Resource {
+	return &NotificationTemplateResource{}
+}
+
+// NotificationTemplateResource defines the resource implementation.
+type NotificationTemplateResource struct {
+	boxResource
+}
+
+// NotificationTemplateResourceModel describes the resource data model.
+type NotificationTemplateResourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	Subject   types.String `tfsdk:"subject"`
+	Template  types.String `tfsdk:"template"`
+	VersionID types.String `tfsdk:"version_id"`
+}
+
+func (r *NotificationTemplateResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_notification_template"
+}
+
+func (r *NotificationTemplateResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a NotificationTemplate, the subject and body of an email sent by a box, such as the password reset or account activation email",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the template, such as `reset-password`. Changing it forces a new template to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validFHIRID(),
+				},
+			},
+			"subject": schema.StringAttribute{
+				MarkdownDescription: "Template of the email subject",
+				Optional:            true,
+			},
+			"template": schema.StringAttribute{
+				MarkdownDescription: "Template of the email body, rendered with the values of the notification",
+				Required:            true,
+			},
+			"version_id": schema.StringAttribute{
+				MarkdownDescription: "Version of the template, incremented by the box on every change",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *NotificationTemplateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var model NotificationTemplateResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stored, err := r.client.PutResource(ctx, notificationTemplateToResource(model), "")
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostic("Failed to Create Notification Template", "Unable to create notification template", err))
+		return
+	}
+
+	mapNotificationTemplateFromResource(&model, stored)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *NotificationTemplateResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var model NotificationTemplateResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stored, err := r.client.GetResourceIfModified(ctx, notificationTemplateResourceType, model.ID.ValueString(), model.VersionID.ValueString())
+	if aidbox.IsNotModified(err) {
+		return
+	}
+	if aidbox.IsNotFound(err) {
+		tflog.Warn(ctx, "Notification template not found, removing from state", map[string]interface{}{"id": model.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostic("Failed to Fetch Notification Template", "Unable to fetch notification template", err))
+		return
+	}
+
+	mapNotificationTemplateFromResource(&model, stored)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *NotificationTemplateResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var model NotificationTemplateResourceModel
+	var versionID types.String
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("version_id"), &versionID)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stored, err := r.client.PutResource(ctx, notificationTemplateToResource(model), versionID.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(updateErrorDiagnostic("notification template "+model.ID.ValueString(), "Failed to Update Notification Template", "Unable to update notification template", err))
+		return
+	}
+
+	mapNotificationTemplateFromResource(&model, stored)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *NotificationTemplateResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var model NotificationTemplateResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteResource(ctx, notificationTemplateResourceType, model.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(deleteErrorDiagnostic(
+			"notification template "+model.ID.ValueString(),
+			"Failed to Delete Notification Template",
+			fmt.Sprintf("Error while trying to delete the notification template with ID %s", model.ID.ValueString()),
+			err,
+		))
+	}
+}
+
+func (r *NotificationTemplateResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+func notificationTemplateToResource(model NotificationTemplateResourceModel) aidbox.Resource {
+	template := aidbox.Resource{
+		"resourceType": notificationTemplateResourceType,
+		"id":           model.ID.ValueString(),
+	}
+	setJSONValue(template, "subject", model.Subject)
+	setJSONValue(template, "template", model.Template)
+	return template
+}
+
+func mapNotificationTemplateFromResource(model *NotificationTemplateResourceModel, stored aidbox.Resource) {
+	model.ID = types.StringValue(stored.ID())
+	model.Subject = jsonStringValue(stored, "subject")
+	model.Template = jsonStringValue(stored, "template")
+	model.VersionID = stringValueOrNull(stored.VersionID())
+}
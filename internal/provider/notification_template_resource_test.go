@@ -0,0 +1,63 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccAidboxNotificationTemplateResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheckBox(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_5_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccAidboxNotificationTemplateResourceConfig("Reset your password"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("aidbox_notification_template.test", "id", "tf-acc-reset-password"),
+					resource.TestCheckResourceAttr("aidbox_notification_template.test", "subject", "Reset your password"),
+					resource.TestCheckResourceAttrSet("aidbox_notification_template.test", "version_id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "aidbox_notification_template.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Import block testing, as with terraform plan -generate-config-out
+			{
+				ResourceName:    "aidbox_notification_template.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithID,
+			},
+			// Update and Read testing
+			{
+				Config: testAccAidboxNotificationTemplateResourceConfig("Password reset requested"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("aidbox_notification_template.test", "subject", "Password reset requested"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccAidboxNotificationTemplateResourceConfig(subject string) string {
+	return fmt.Sprintf(`
+resource "aidbox_notification_template" "test" {
+  id       = "tf-acc-reset-password"
+  subject  = %[1]q
+  template = "Follow {{link}} to reset your password."
+}
+`, subject)
+}
@@ -0,0 +1,112 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ ephemeral.EphemeralResource = &OfflineLicensePayloadEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithConfigure = &OfflineLicensePayloadEphemeralResource{}
+
+func NewOfflineLicensePayloadEphemeralResource() ephemeral.EphemeralResource {
+	return &OfflineLicensePayloadEphemeralResource{}
+}
+
+// OfflineLicensePayloadEphemeralResource defines the ephemeral resource implementation.
+type OfflineLicensePayloadEphemeralResource struct {
+	client Client
+}
+
+// OfflineLicensePayloadEphemeralResourceModel describes the ephemeral resource data model.
+type OfflineLicensePayloadEphemeralResourceModel struct {
+	LicenseID  types.String `tfsdk:"license_id"`
+	JWT        types.String `tfsdk:"jwt"`
+	Expiration types.String `tfsdk:"expiration"`
+	Offline    types.Bool   `tfsdk:"offline"`
+}
+
+func (r *OfflineLicensePayloadEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_offline_license_payload"
+}
+
+func (r *OfflineLicensePayloadEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Fetches the license payload (JWT) of an Aidbox license at apply time without storing it in state",
+		Attributes: map[string]schema.Attribute{
+			"license_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the license, such as `aidbox_license.example.id`",
+				Required:            true,
+			},
+			"jwt": schema.StringAttribute{
+				MarkdownDescription: "License payload to provide to the box, for example through the `AIDBOX_LICENSE` environment variable",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"expiration": schema.StringAttribute{
+				MarkdownDescription: "License expiration",
+				Computed:            true,
+			},
+			"offline": schema.BoolAttribute{
+				MarkdownDescription: "Whether the license is an offline license",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *OfflineLicensePayloadEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*ProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = data.Client
+}
+
+func (r *OfflineLicensePayloadEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var model OfflineLicensePayloadEphemeralResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	apiResp, err := r.client.GetLicense(ctx, model.LicenseID.ValueString())
+	if aidbox.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"License Not Found",
+			fmt.Sprintf("No license with ID %s was found in the project.", model.LicenseID.ValueString()),
+		)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostic("Failed to Fetch License", "Unable to fetch license", err))
+		return
+	}
+
+	model.JWT = basetypes.NewStringValue(apiResp.JWT)
+	model.Expiration = basetypes.NewStringValue(apiResp.License.Expiration)
+	model.Offline = boolPointerValueOrNull(apiResp.License.Offline)
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &model)...)
+}
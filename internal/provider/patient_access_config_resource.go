@@ -0,0 +1,209 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/petalmd/terraform-provider-aidbox/internal/fhir"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &PatientAccessConfigResource{}
+var _ resource.ResourceWithImportState = &PatientAccessConfigResource{}
+
+// The patient access configuration is stored in the
+// AidboxConfig/patient-access resource.
+const (
+	patientAccessConfigResourceType = "AidboxConfig"
+	patientAccessConfigID           = "patient-access"
+
+	patientCompartmentDefinition = "http://hl7.org/fhir/CompartmentDefinition/patient"
+)
+
+func NewPatientAccessConfigResource() resource.Resource {
+	return &PatientAccessConfigResource{}
+}
+
+// PatientAccessConfigResource defines the resource implementation.
+type PatientAccessConfigResource struct {
+	boxResource
+}
+
+// PatientAccessConfigResourceModel describes the resource data model.
+type PatientAccessConfigResourceModel struct {
+	ID                    types.String `tfsdk:"id"`
+	Enabled               types.Bool   `tfsdk:"enabled"`
+	CompartmentDefinition types.String `tfsdk:"compartment_definition"`
+	ResourceTypes         types.Set    `tfsdk:"resource_types"`
+	VersionID             types.String `tfsdk:"version_id"`
+}
+
+func (r *PatientAccessConfigResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_patient_access_config"
+}
+
+func (r *PatientAccessConfigResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the patient access API of a box, which lets patients, such as the users of a patient portal, access the resources of their Patient compartment. " +
+			"A box has a single patient access configuration. Destroying the resource disables the patient access API.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the AidboxConfig resource holding the patient access configuration, always `patient-access`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"version_id": schema.StringAttribute{
+				MarkdownDescription: "Version of the patient access configuration, incremented by the box on every change",
+				Computed:            true,
+			},
+			"enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether the box serves the patient access API. Defaults to `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"compartment_definition": schema.StringAttribute{
+				MarkdownDescription: "Canonical URL of the CompartmentDefinition resource defining which resources belong to a patient. Defaults to the FHIR Patient compartment, `" + patientCompartmentDefinition + "`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(patientCompartmentDefinition),
+				Validators: []validator.String{
+					validURI("http", "https"),
+				},
+			},
+			"resource_types": schema.SetAttribute{
+				MarkdownDescription: "Resource types of the compartment patients are allowed to access, such as `Observation`",
+				ElementType:         types.StringType,
+				Required:            true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(
+						stringvalidator.OneOf(fhir.ResourceTypes...),
+					),
+				},
+			},
+		},
+	}
+}
+
+func (r *PatientAccessConfigResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var model PatientAccessConfigResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.checkConfigurationAbsent(ctx, "patient access configuration", "aidbox_patient_access_config", patientAccessConfigResourceType, patientAccessConfigID)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stored, err := r.client.PutResource(ctx, patientAccessConfigToResource(model), "")
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostic("Failed to Create Patient Access Configuration", "Unable to configure patient access", err))
+		return
+	}
+
+	mapPatientAccessConfigFromResource(&model, stored)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *PatientAccessConfigResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var model PatientAccessConfigResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stored, err := r.client.GetResource(ctx, patientAccessConfigResourceType, patientAccessConfigID)
+	if aidbox.IsNotFound(err) {
+		tflog.Warn(ctx, "Patient access configuration not found, removing from state")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostic("Failed to Fetch Patient Access Configuration", "Unable to fetch the patient access configuration", err))
+		return
+	}
+
+	mapPatientAccessConfigFromResource(&model, stored)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *PatientAccessConfigResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var model PatientAccessConfigResourceModel
+	var versionID types.String
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("version_id"), &versionID)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stored, err := r.client.PutResource(ctx, patientAccessConfigToResource(model), versionID.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(updateErrorDiagnostic("patient access configuration", "Failed to Update Patient Access Configuration", "Unable to configure patient access", err))
+		return
+	}
+
+	mapPatientAccessConfigFromResource(&model, stored)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *PatientAccessConfigResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	err := r.client.DeleteResource(ctx, patientAccessConfigResourceType, patientAccessConfigID)
+	if err != nil {
+		resp.Diagnostics.Append(deleteErrorDiagnostic("patient access configuration", "Failed to Delete Patient Access Configuration", "Unable to disable patient access", err))
+	}
+}
+
+func (r *PatientAccessConfigResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID != patientAccessConfigID {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("The patient access configuration of a box is imported with the ID %q, got: %q", patientAccessConfigID, req.ID),
+		)
+		return
+	}
+
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+func patientAccessConfigToResource(model PatientAccessConfigResourceModel) aidbox.Resource {
+	access := map[string]interface{}{}
+	setJSONValue(access, "enabled", model.Enabled)
+	setJSONValue(access, "compartment-definition", model.CompartmentDefinition)
+	setJSONValue(access, "resource-types", model.ResourceTypes)
+
+	return aidbox.Resource{
+		"resourceType":   patientAccessConfigResourceType,
+		"id":             patientAccessConfigID,
+		"patient-access": access,
+	}
+}
+
+func mapPatientAccessConfigFromResource(model *PatientAccessConfigResourceModel, stored aidbox.Resource) {
+	access := jsonObject(stored, "patient-access")
+
+	model.ID = types.StringValue(patientAccessConfigID)
+	model.VersionID = stringValueOrNull(stored.VersionID())
+	model.Enabled = types.BoolValue(jsonBoolValue(access, "enabled").ValueBool())
+	model.CompartmentDefinition = jsonStringValue(access, "compartment-definition")
+	model.ResourceTypes = jsonStringSet(access, "resource-types")
+}
@@ -0,0 +1,63 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccAidboxPatientAccessConfigResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheckBox(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_5_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccAidboxPatientAccessConfigResourceConfig(`"Observation", "Condition"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("aidbox_patient_access_config.test", "id", "patient-access"),
+					resource.TestCheckResourceAttr("aidbox_patient_access_config.test", "enabled", "true"),
+					resource.TestCheckResourceAttr("aidbox_patient_access_config.test", "compartment_definition", "http://hl7.org/fhir/CompartmentDefinition/patient"),
+					resource.TestCheckResourceAttr("aidbox_patient_access_config.test", "resource_types.#", "2"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "aidbox_patient_access_config.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Import block testing, as with terraform plan -generate-config-out
+			{
+				ResourceName:    "aidbox_patient_access_config.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithID,
+			},
+			// Update and Read testing
+			{
+				Config: testAccAidboxPatientAccessConfigResourceConfig(`"Observation", "Condition", "Immunization"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("aidbox_patient_access_config.test", "resource_types.#", "3"),
+					resource.TestCheckTypeSetElemAttr("aidbox_patient_access_config.test", "resource_types.*", "Immunization"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccAidboxPatientAccessConfigResourceConfig(resourceTypes string) string {
+	return fmt.Sprintf(`
+resource "aidbox_patient_access_config" "test" {
+  resource_types = [%[1]s]
+}
+`, resourceTypes)
+}
@@ -0,0 +1,183 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &PGSequenceResource{}
+var _ resource.ResourceWithImportState = &PGSequenceResource{}
+
+func NewPGSequenceResource() resource.Resource {
+	return &PGSequenceResource{}
+}
+
+// PGSequenceResource defines the resource implementation.
+type PGSequenceResource struct {
+	boxResource
+}
+
+// PGSequenceResourceModel describes the resource data model.
+type PGSequenceResourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	Start     types.Int64  `tfsdk:"start"`
+	Increment types.Int64  `tfsdk:"increment"`
+	Cycle     types.Bool   `tfsdk:"cycle"`
+}
+
+func (r *PGSequenceResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_pg_sequence"
+}
+
+func (r *PGSequenceResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a PostgreSQL sequence created through the sequences API of a box, such as the sequence medical record or accession numbers are generated from. " +
+			"Sequences can't be altered, so changing any attribute forces a new sequence to be created, restarting its numbering.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Name of the sequence, a lowercase PostgreSQL identifier",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(pgIdentifierRegexp, "must be a lowercase PostgreSQL identifier"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"start": schema.Int64Attribute{
+				MarkdownDescription: "First value of the sequence. Defaults to `1`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(1),
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"increment": schema.Int64Attribute{
+				MarkdownDescription: "Value added to the sequence on every call, negative for a descending sequence. Defaults to `1`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(1),
+				Validators: []validator.Int64{
+					int64validator.NoneOf(0),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"cycle": schema.BoolAttribute{
+				MarkdownDescription: "Whether the sequence wraps around when it reaches its limit, rather than failing. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *PGSequenceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var model PGSequenceResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	created, err := r.client.CreateSequence(ctx, aidbox.Sequence{
+		ID:        model.ID.ValueString(),
+		Start:     model.Start.ValueInt64(),
+		Increment: model.Increment.ValueInt64(),
+		Cycle:     model.Cycle.ValueBool(),
+	})
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostic("Failed to Create Sequence", "Unable to create sequence", err))
+		return
+	}
+
+	mapPGSequenceFromSequence(&model, created)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *PGSequenceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var model PGSequenceResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	sequence, err := r.client.GetSequence(ctx, model.ID.ValueString())
+	if aidbox.IsNotFound(err) {
+		tflog.Warn(ctx, "Sequence not found, removing from state", map[string]interface{}{"id": model.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostic("Failed to Fetch Sequence", "Unable to fetch sequence", err))
+		return
+	}
+
+	mapPGSequenceFromSequence(&model, sequence)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+// Update is never called with changes, as every attribute requires the
+// sequence to be replaced.
+func (r *PGSequenceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var model PGSequenceResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *PGSequenceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var model PGSequenceResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteSequence(ctx, model.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(deleteErrorDiagnostic(
+			"sequence "+model.ID.ValueString(),
+			"Failed to Delete Sequence",
+			fmt.Sprintf("Error while trying to delete the sequence %s", model.ID.ValueString()),
+			err,
+		))
+	}
+}
+
+func (r *PGSequenceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+func mapPGSequenceFromSequence(model *PGSequenceResourceModel, sequence aidbox.Sequence) {
+	model.ID = types.StringValue(sequence.ID)
+	model.Start = types.Int64Value(sequence.Start)
+	model.Increment = types.Int64Value(sequence.Increment)
+	model.Cycle = types.BoolValue(sequence.Cycle)
+}
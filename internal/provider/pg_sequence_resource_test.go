@@ -0,0 +1,69 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccAidboxPGSequenceResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheckBox(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_5_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccAidboxPGSequenceResourceConfig(1000),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("aidbox_pg_sequence.test", "id", "tf_acc_mrn"),
+					resource.TestCheckResourceAttr("aidbox_pg_sequence.test", "start", "1000"),
+					resource.TestCheckResourceAttr("aidbox_pg_sequence.test", "increment", "1"),
+					resource.TestCheckResourceAttr("aidbox_pg_sequence.test", "cycle", "false"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "aidbox_pg_sequence.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Import block testing, as with terraform plan -generate-config-out
+			{
+				ResourceName:    "aidbox_pg_sequence.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithID,
+			},
+			// Changes replace the sequence
+			{
+				Config: testAccAidboxPGSequenceResourceConfig(5000),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("aidbox_pg_sequence.test", plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("aidbox_pg_sequence.test", "start", "5000"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccAidboxPGSequenceResourceConfig(start int) string {
+	return fmt.Sprintf(`
+resource "aidbox_pg_sequence" "test" {
+  id    = "tf_acc_mrn"
+  start = %[1]d
+}
+`, start)
+}
@@ -4,42 +4,112 @@ package provider
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox"
+	"net"
 	"net/http"
+	"net/url"
 	"os" // Import for environment variables
-	"terraform-provider-aidbox/internal/aidbox"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // Ensure AidboxProvider satisfies various provider interfaces.
 var _ provider.Provider = &AidboxProvider{}
 var _ provider.ProviderWithFunctions = &AidboxProvider{}
+var _ provider.ProviderWithEphemeralResources = &AidboxProvider{}
 
 type AidboxProvider struct {
 	version string
 }
 
 type AidboxProviderModel struct {
-	Endpoint types.String `tfsdk:"endpoint"`
-	Token    types.String `tfsdk:"token"`
+	Endpoint            types.String `tfsdk:"endpoint"`
+	Token               types.String `tfsdk:"token"`
+	PortalRPCPath       types.String `tfsdk:"portal_rpc_path"`
+	PortalRPCNamespace  types.String `tfsdk:"portal_rpc_namespace"`
+	ValidateCredentials types.Bool   `tfsdk:"validate_credentials"`
+	BoxURL              types.String `tfsdk:"box_url"`
+	BoxClientID         types.String `tfsdk:"box_client_id"`
+	BoxClientSecret     types.String `tfsdk:"box_client_secret"`
+	BoxCompressRequests types.Bool   `tfsdk:"box_compress_requests"`
+	BoxFHIRAPI          types.Bool   `tfsdk:"box_fhir_api"`
+	BoxAccessTokens     types.Bool   `tfsdk:"box_access_tokens"`
+	BoxTokenCacheDir    types.String `tfsdk:"box_token_cache_dir"`
+	MaxIdleConns        types.Int64  `tfsdk:"max_idle_conns"`
+	MaxConnsPerHost     types.Int64  `tfsdk:"max_conns_per_host"`
+	IdleConnTimeout     types.String `tfsdk:"idle_conn_timeout"`
+	KeepAlive           types.String `tfsdk:"keep_alive"`
 }
 
 type Client interface {
 	CreateLicense(cxt context.Context, name, product, licenseType string) (aidbox.LicenseResponse, error)
 	GetLicense(ctx context.Context, licenseID string) (aidbox.LicenseResponse, error)
+	ListLicenses(ctx context.Context) ([]aidbox.License, error)
 	DeleteLicense(ctx context.Context, licenseID string) error
+	OpenSession(ctx context.Context) (aidbox.Session, error)
+	CloseSession(ctx context.Context, sessionID string) error
+	CreateBox(ctx context.Context, box aidbox.Box) (aidbox.BoxResponse, error)
+	GetBox(ctx context.Context, boxID string) (aidbox.BoxResponse, error)
+	UpdateBox(ctx context.Context, box aidbox.Box) (aidbox.BoxResponse, error)
+	DeleteBox(ctx context.Context, boxID string) error
+	GrantBoxAccess(ctx context.Context, user aidbox.BoxUser) (aidbox.BoxUser, error)
+	GetBoxUser(ctx context.Context, boxID, email string) (aidbox.BoxUser, error)
+	RevokeBoxAccess(ctx context.Context, boxID, email string) error
+}
+
+// BoxClient manages the resources of an Aidbox box.
+type BoxClient interface {
+	GetResource(ctx context.Context, resourceType, id string) (aidbox.Resource, error)
+	GetResourceIfModified(ctx context.Context, resourceType, id, versionID string) (aidbox.Resource, error)
+	PutResource(ctx context.Context, resource aidbox.Resource, versionID string) (aidbox.Resource, error)
+	DeleteResource(ctx context.Context, resourceType, id string) error
+	SearchResources(ctx context.Context, resourceType string, params url.Values, limit int) ([]aidbox.Resource, error)
+	RunOperation(ctx context.Context, path string, params url.Values) (aidbox.Resource, error)
+	CreateSequence(ctx context.Context, sequence aidbox.Sequence) (aidbox.Sequence, error)
+	GetSequence(ctx context.Context, id string) (aidbox.Sequence, error)
+	DeleteSequence(ctx context.Context, id string) error
+	GetSetting(ctx context.Context, name, scope string) (aidbox.Setting, error)
+	ListSettings(ctx context.Context, scope string) ([]aidbox.Setting, error)
+	PutSetting(ctx context.Context, setting aidbox.Setting) (aidbox.Setting, error)
+	DeleteSetting(ctx context.Context, name, scope string) error
+	Health(ctx context.Context) (aidbox.Health, error)
+	ListTableStats(ctx context.Context) ([]aidbox.TableStats, error)
 }
 
 type ProviderData struct {
 	Endpoint string
 	Token    string
 	Client   Client
+
+	// BoxClient is nil when no box is configured.
+	BoxClient BoxClient
+	// BoxMetadata caches the capability statement of the box. It is nil when
+	// no box is configured.
+	BoxMetadata *boxMetadata
+
+	// Licenses serves license reads from a single listing of the licenses.
+	// Configure always sets it; license reads only fall back to fetching each
+	// license when it is nil, as with provider data built by unit tests.
+	Licenses *licenseSnapshot
 }
 
 func (p *AidboxProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -51,13 +121,88 @@ func (p *AidboxProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"endpoint": schema.StringAttribute{
-				MarkdownDescription: "Aidbox RPC API endpoint",
+				MarkdownDescription: "Aidbox RPC API endpoint. Can also be set with the `AIDBOX_ENDPOINT` environment variable. Defaults to `https://aidbox.app/rpc`.",
 				Optional:            true,
 			},
 			"token": schema.StringAttribute{
 				MarkdownDescription: "Aidbox API token",
 				Optional:            true,
 			},
+			"portal_rpc_path": schema.StringAttribute{
+				MarkdownDescription: "Path of the RPC API on the host of `endpoint`, replacing the path of `endpoint`, for license servers proxied or self-hosted under another path, such as `/licensing/rpc`. Can also be set with the `AIDBOX_PORTAL_RPC_PATH` environment variable.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^/`), "must be an absolute path"),
+				},
+			},
+			"portal_rpc_namespace": schema.StringAttribute{
+				MarkdownDescription: "Namespace of the license and session RPC methods, for license servers exposing them under another namespace. Can also be set with the `AIDBOX_PORTAL_RPC_NAMESPACE` environment variable. Defaults to `portal.portal`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"validate_credentials": schema.BoolAttribute{
+				MarkdownDescription: "Make an authenticated call to the endpoint while configuring the provider, reporting an invalid token or an unreachable endpoint before any resource is planned. Defaults to `false`.",
+				Optional:            true,
+			},
+			"box_url": schema.StringAttribute{
+				MarkdownDescription: "Base URL of the Aidbox box managed by box-level resources, such as `aidbox_email_provider`. Can also be set with the `AIDBOX_BOX_URL` environment variable.",
+				Optional:            true,
+			},
+			"box_client_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the Aidbox Client used to authenticate to the box. Can also be set with the `AIDBOX_BOX_CLIENT_ID` environment variable.",
+				Optional:            true,
+			},
+			"box_client_secret": schema.StringAttribute{
+				MarkdownDescription: "Secret of the Aidbox Client used to authenticate to the box. Can also be set with the `AIDBOX_BOX_CLIENT_SECRET` environment variable.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"box_compress_requests": schema.BoolAttribute{
+				MarkdownDescription: "Compress request bodies sent to the box with gzip, such as large FHIR resources. The box, or the proxy in front of it, must accept gzip encoded requests. Responses are always accepted compressed. Defaults to `false`.",
+				Optional:            true,
+			},
+			"box_fhir_api": schema.BoolAttribute{
+				MarkdownDescription: "Manage the resources of the box through its FHIR API, under `/fhir`, instead of the Aidbox API. Resources are then exchanged in their FHIR format, as `application/fhir+json`. Defaults to `false`.",
+				Optional:            true,
+			},
+			"box_access_tokens": schema.BoolAttribute{
+				MarkdownDescription: "Authenticate to the box with access tokens obtained with the OAuth client credentials grant, instead of basic authentication. The Aidbox Client must allow the `client_credentials` grant type. Defaults to `false`.",
+				Optional:            true,
+			},
+			"box_token_cache_dir": schema.StringAttribute{
+				MarkdownDescription: "Directory where the access tokens of the box are cached until they expire, such as `~/.cache/terraform-provider-aidbox`, so that providers targeting the same box and client, such as in several workspaces, don't obtain a token on every plan. Only used with `box_access_tokens`. Can also be set with the `AIDBOX_BOX_TOKEN_CACHE_DIR` environment variable.",
+				Optional:            true,
+			},
+			"max_idle_conns": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of idle connections kept open to the portal and the box, reused by later calls. Raise it for applies with a high `-parallelism`. Defaults to `100`.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"max_conns_per_host": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of connections to the portal or the box, including connections in use. Calls wait for a connection once the limit is reached, such as to avoid being throttled. Defaults to no limit.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"idle_conn_timeout": schema.StringAttribute{
+				MarkdownDescription: "How long an idle connection is kept open, such as `90s`. Defaults to `90s`.",
+				Optional:            true,
+				Validators: []validator.String{
+					validDuration(),
+				},
+			},
+			"keep_alive": schema.StringAttribute{
+				MarkdownDescription: "Interval of the TCP keep-alive probes of open connections, such as `30s`. Defaults to `30s`.",
+				Optional:            true,
+				Validators: []validator.String{
+					validDuration(),
+				},
+			},
 		},
 	}
 }
@@ -71,18 +216,77 @@ func (p *AidboxProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		return
 	}
 
-	// Set default endpoint if not provided
+	// Defer everything until apply when the configuration depends on values
+	// that are not known yet, e.g. a token created by another resource.
+	if data.Endpoint.IsUnknown() || data.Token.IsUnknown() || data.BoxURL.IsUnknown() || data.BoxClientID.IsUnknown() || data.BoxClientSecret.IsUnknown() {
+		if req.ClientCapabilities.DeferralAllowed {
+			resp.Deferred = &provider.Deferred{
+				Reason: provider.DeferredReasonProviderConfigUnknown,
+			}
+			return
+		}
+	}
+
+	// Set endpoint from the environment variable or the default if not provided
 	if data.Endpoint.IsNull() || data.Endpoint.IsUnknown() || data.Endpoint.ValueString() == "" {
 		defaultEndpoint := basetypes.NewStringValue("https://aidbox.app/rpc")
+		if endpointEnv := os.Getenv("AIDBOX_ENDPOINT"); endpointEnv != "" {
+			defaultEndpoint = basetypes.NewStringValue(endpointEnv)
+		}
 		data.Endpoint = defaultEndpoint
 	}
+	if rpcPath := stringValueOrEnv(data.PortalRPCPath, "AIDBOX_PORTAL_RPC_PATH"); rpcPath != "" {
+		endpoint, err := url.Parse(data.Endpoint.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("endpoint"), "Invalid Aidbox Endpoint", fmt.Sprintf("Unable to parse the endpoint %s: %s", data.Endpoint.ValueString(), err))
+			return
+		}
+		endpoint.Path = "/" + strings.TrimLeft(rpcPath, "/")
+		endpoint.RawPath = ""
+		data.Endpoint = types.StringValue(endpoint.String())
+	}
+
+	// The portal and the box clients share connections, so that parallel
+	// operations reuse them instead of opening new ones
+	httpClient := newHTTPClient(data)
 
-	// Handle token; get from environment variable if not provided
+	// Box-level resources are managed through the box API, configured
+	// separately from the portal
+	var boxClient BoxClient
+	boxURL := stringValueOrEnv(data.BoxURL, "AIDBOX_BOX_URL")
+	if boxURL != "" {
+		boxClientID := stringValueOrEnv(data.BoxClientID, "AIDBOX_BOX_CLIENT_ID")
+		boxClientSecret := stringValueOrEnv(data.BoxClientSecret, "AIDBOX_BOX_CLIENT_SECRET")
+		if boxClientID == "" || boxClientSecret == "" {
+			resp.Diagnostics.AddError(
+				"No Box Client Credentials Provided",
+				fmt.Sprintf("Please provide 'box_client_id' and 'box_client_secret' in the provider configuration or through the 'AIDBOX_BOX_CLIENT_ID' and 'AIDBOX_BOX_CLIENT_SECRET' environment variables to manage the box at %s.", boxURL),
+			)
+			return
+		}
+		box := aidbox.NewBoxClient(boxURL, boxClientID, boxClientSecret)
+		box.Client = httpClient
+		box.Compress = data.BoxCompressRequests.ValueBool()
+		box.FHIR = data.BoxFHIRAPI.ValueBool()
+		box.UseTokens = data.BoxAccessTokens.ValueBool()
+		if box.UseTokens {
+			tokenCacheDir, err := expandHome(stringValueOrEnv(data.BoxTokenCacheDir, "AIDBOX_BOX_TOKEN_CACHE_DIR"))
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(path.Root("box_token_cache_dir"), "Invalid Token Cache Directory", err.Error())
+				return
+			}
+			box.TokenCacheDir = tokenCacheDir
+		}
+		boxClient = box
+	}
+
+	// Handle token; get from environment variable if not provided. The token
+	// is only optional when the provider manages a box.
 	if data.Token.IsNull() || data.Token.IsUnknown() || data.Token.ValueString() == "" {
 		tokenEnv := os.Getenv("AIDBOX_API_TOKEN")
 		if tokenEnv != "" {
 			data.Token = basetypes.NewStringValue(tokenEnv)
-		} else {
+		} else if boxClient == nil {
 			resp.Diagnostics.AddError(
 				"No API Token Provided",
 				"Please provide a 'token' in the provider configuration or through the 'AIDBOX_API_TOKEN' environment variable.",
@@ -91,28 +295,191 @@ func (p *AidboxProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		}
 	}
 
-	// Example client configuration for data sources and resources
-	client := http.DefaultClient
-	resp.DataSourceData = client
-	resp.ResourceData = &ProviderData{
-		Endpoint: data.Endpoint.ValueString(),
-		Token:    data.Token.ValueString(),
-		Client:   aidbox.NewClient(data.Endpoint.ValueString(), data.Token.ValueString()),
+	client := aidbox.NewClient(data.Endpoint.ValueString(), data.Token.ValueString())
+	client.Client = httpClient
+	client.MethodNamespace = stringValueOrEnv(data.PortalRPCNamespace, "AIDBOX_PORTAL_RPC_NAMESPACE")
+	providerData := &ProviderData{
+		Endpoint:  data.Endpoint.ValueString(),
+		Token:     data.Token.ValueString(),
+		Client:    client,
+		BoxClient: boxClient,
+		Licenses:  newLicenseSnapshot(client),
+	}
+	if boxClient != nil {
+		providerData.BoxMetadata = newBoxMetadata(boxClient, boxMetadataTTL)
+	}
+
+	if data.ValidateCredentials.ValueBool() && providerData.Token != "" {
+		resp.Diagnostics.Append(validateCredentials(ctx, providerData)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	resp.DataSourceData = providerData
+	resp.ResourceData = providerData
+	resp.EphemeralResourceData = providerData
+}
+
+// stringValueOrEnv returns the configured value, or the value of the
+// environment variable when it is not configured.
+func stringValueOrEnv(value types.String, name string) string {
+	if value.IsNull() || value.IsUnknown() || value.ValueString() == "" {
+		return os.Getenv(name)
+	}
+	return value.ValueString()
+}
+
+// expandHome replaces a leading ~ of dir with the home directory of the
+// current user.
+func expandHome(dir string) (string, error) {
+	if dir != "~" && !strings.HasPrefix(dir, "~/") {
+		return dir, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("unable to expand %s: %w", dir, err)
+	}
+	return filepath.Join(home, dir[1:]), nil
+}
+
+// newHTTPClient returns the HTTP client of the provider, with the connection
+// pooling configured in data. Durations are validated by the schema. The
+// defaults are the ones of http.DefaultTransport.
+func newHTTPClient(data AidboxProviderModel) *http.Client {
+	dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+	transport := &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+
+	if !data.MaxIdleConns.IsNull() {
+		transport.MaxIdleConns = int(data.MaxIdleConns.ValueInt64())
+	}
+	// The provider only calls the portal and the box, so every idle
+	// connection may be kept for the same host. The default of 2 makes
+	// parallel operations open a new connection for almost every call.
+	transport.MaxIdleConnsPerHost = transport.MaxIdleConns
+	if !data.MaxConnsPerHost.IsNull() {
+		transport.MaxConnsPerHost = int(data.MaxConnsPerHost.ValueInt64())
+	}
+	if idleConnTimeout, err := time.ParseDuration(data.IdleConnTimeout.ValueString()); err == nil {
+		transport.IdleConnTimeout = idleConnTimeout
+	}
+	if keepAlive, err := time.ParseDuration(data.KeepAlive.ValueString()); err == nil {
+		dialer.KeepAlive = keepAlive
+	}
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{Transport: transport}
+}
+
+// validateCredentials opens and closes a portal session to check that the
+// endpoint is reachable and accepts the token.
+func validateCredentials(ctx context.Context, data *ProviderData) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	session, err := data.Client.OpenSession(ctx)
+	if err != nil {
+		var apiErr *aidbox.APIError
+		switch {
+		case aidbox.IsAuthError(err):
+			diags.AddAttributeError(
+				path.Root("token"),
+				"Invalid API Token",
+				fmt.Sprintf("The endpoint %s rejected the API token. Please check the 'token' in the provider configuration or the 'AIDBOX_API_TOKEN' environment variable: %s", data.Endpoint, err),
+			)
+		case errors.As(err, &apiErr):
+			diags.AddError(
+				"Failed to Validate Credentials",
+				fmt.Sprintf("The endpoint %s returned an error while validating the API token: %s", data.Endpoint, err),
+			)
+		default:
+			diags.AddAttributeError(
+				path.Root("endpoint"),
+				"Aidbox Endpoint Unreachable",
+				fmt.Sprintf("Unable to reach the endpoint %s. Please check the 'endpoint' in the provider configuration and your network: %s", data.Endpoint, err),
+			)
+		}
+		return diags
+	}
+
+	if err := data.Client.CloseSession(ctx, session.ID); err != nil {
+		tflog.Warn(ctx, "Failed to close the session used to validate credentials", map[string]interface{}{"error": err})
 	}
+
+	return diags
 }
 
 func (p *AidboxProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
+		NewAccessPolicyResource,
+		NewAPIKeyResource,
+		NewArchivePolicyResource,
+		NewAuditConfigResource,
+		NewConsentPolicyResource,
+		NewDBSettingsResource,
+		NewEmailProviderResource,
+		NewIdentityProviderResource,
+		NewJobResource,
+		NewJWKSConfigResource,
+		NewLDAPIdentityProviderResource,
 		NewLicenseResource,
+		NewMultiboxResource,
+		NewMultiboxUserResource,
+		NewNotificationTemplateResource,
+		NewPatientAccessConfigResource,
+		NewPGSequenceResource,
+		NewSAMLIdentityProviderResource,
+		NewSCIMConfigResource,
+		NewSecurityLabelsConfigResource,
+		NewSettingResource,
+		NewSMARTAppResource,
+		NewSMARTConfigResource,
+		NewSMSProviderResource,
+		NewTaskDefinitionResource,
+		NewWebhookResource,
+		NewWorkflowDefinitionResource,
+	}
+}
+
+func (p *AidboxProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewOfflineLicensePayloadEphemeralResource,
+		NewSessionEphemeralResource,
 	}
 }
 
 func (p *AidboxProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
-	return []func() datasource.DataSource{}
+	return []func() datasource.DataSource{
+		NewAccessPoliciesDataSource,
+		NewClientDataSource,
+		NewConceptLookupDataSource,
+		NewDBStatsDataSource,
+		NewHealthDataSource,
+		NewIGPackagesDataSource,
+		NewImportBlocksDataSource,
+		NewSearchParametersDataSource,
+		NewSettingsDataSource,
+		NewUsersDataSource,
+		NewValueSetExpansionDataSource,
+	}
 }
 
 func (p *AidboxProvider) Functions(ctx context.Context) []func() function.Function {
-	return []func() function.Function{}
+	return []func() function.Function{
+		NewBasicAuthHeaderFunction,
+		NewEDNToJSONFunction,
+		NewFHIRCanonicalizeFunction,
+		NewFHIRPathExtractFunction,
+		NewJWTDecodeFunction,
+		NewK8sLicenseSecretFunction,
+		NewMatchoValidateFunction,
+		NewSMARTScopeFunction,
+	}
 }
 
 func New(version string) func() provider.Provider {
@@ -3,18 +3,316 @@
 package provider
 
 import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-mux/tf6to5server"
+	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox/aidboxtest"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"sync"
 	"testing"
+	"time"
 )
 
 var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
 	"aidbox": providerserver.NewProtocol6WithError(New("test")()),
 }
 
+// testAccProtoV6ProviderFactoriesWithEcho includes the echo provider, which
+// is used to assert the values of ephemeral resources.
+var testAccProtoV6ProviderFactoriesWithEcho = map[string]func() (tfprotov6.ProviderServer, error){
+	"aidbox": providerserver.NewProtocol6WithError(New("test")()),
+	"echo":   echoprovider.NewProviderServer(),
+}
+
+// testAccProtoV5ProviderFactories serve the provider downgraded to protocol 5,
+// as it is served to Terraform CLI versions without protocol 6 support.
+var testAccProtoV5ProviderFactories = map[string]func() (tfprotov5.ProviderServer, error){
+	"aidbox": func() (tfprotov5.ProviderServer, error) {
+		return tf6to5server.DowngradeServer(context.Background(), providerserver.NewProtocol6(New("test")()))
+	},
+}
+
+var (
+	testAccPortalServer     *aidboxtest.PortalServer
+	testAccPortalServerOnce sync.Once
+)
+
+// testAccPreCheck validates the environment of acceptance tests. When
+// AIDBOX_ACC_MOCK_PORTAL is set, the provider is pointed at an in-process
+// fake of the portal instead, so no AIDBOX_API_TOKEN is required.
 func testAccPreCheck(t *testing.T) {
+	if os.Getenv("AIDBOX_ACC_MOCK_PORTAL") != "" {
+		testAccPortalServerOnce.Do(func() {
+			testAccPortalServer = aidboxtest.NewPortalServer("tf-acc-mock-token")
+		})
+		t.Setenv("AIDBOX_ENDPOINT", testAccPortalServer.Endpoint())
+		t.Setenv("AIDBOX_API_TOKEN", testAccPortalServer.Token)
+		return
+	}
+
 	if v := os.Getenv("AIDBOX_API_TOKEN"); v == "" {
 		t.Fatal("AIDBOX_API_TOKEN must be set for acceptance tests")
 	}
 }
+
+// testAccPreCheckBox skips acceptance tests of box-level resources unless
+// AIDBOX_ACC_BOX is set, as they require a running Aidbox box such as the
+// one started by `make testacc-box`.
+func testAccPreCheckBox(t *testing.T) {
+	if os.Getenv("AIDBOX_ACC_BOX") == "" {
+		t.Skip("AIDBOX_ACC_BOX must be set to run acceptance tests of box-level resources")
+	}
+
+	for _, name := range []string{"AIDBOX_BOX_URL", "AIDBOX_BOX_CLIENT_ID", "AIDBOX_BOX_CLIENT_SECRET"} {
+		if os.Getenv(name) == "" {
+			t.Fatalf("%s must be set for acceptance tests of box-level resources", name)
+		}
+	}
+}
+
+// testAccClient returns a client for the portal used by acceptance tests,
+// to act on it outside of Terraform.
+func testAccClient() *aidbox.HTTPClient {
+	endpoint := os.Getenv("AIDBOX_ENDPOINT")
+	if endpoint == "" {
+		endpoint = "https://aidbox.app/rpc"
+	}
+	return aidbox.NewClient(endpoint, os.Getenv("AIDBOX_API_TOKEN"))
+}
+
+func TestProvider_protocol5(t *testing.T) {
+	// Downgrading fails when a schema uses features missing from protocol 5,
+	// such as nested attributes.
+	if _, err := testAccProtoV5ProviderFactories["aidbox"](); err != nil {
+		t.Fatalf("unable to downgrade the provider to protocol 5: %s", err)
+	}
+}
+
+// testProviderConfigure configures the provider with the given attribute
+// values, leaving the other attributes null.
+func testProviderConfigure(t *testing.T, values map[string]tftypes.Value, deferralAllowed bool) provider.ConfigureResponse {
+	t.Helper()
+
+	ctx := context.Background()
+	p := New("test")()
+
+	var schemaResp provider.SchemaResponse
+	p.Schema(ctx, provider.SchemaRequest{}, &schemaResp)
+
+	configType, ok := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	if !ok {
+		t.Fatalf("unexpected provider schema type: %T", schemaResp.Schema.Type().TerraformType(ctx))
+	}
+	attributes := map[string]tftypes.Value{}
+	for name, attributeType := range configType.AttributeTypes {
+		attributes[name] = tftypes.NewValue(attributeType, nil)
+		if value, ok := values[name]; ok {
+			attributes[name] = value
+		}
+	}
+
+	resp := provider.ConfigureResponse{}
+	p.Configure(ctx, provider.ConfigureRequest{
+		Config: tfsdk.Config{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(configType, attributes),
+		},
+		ClientCapabilities: provider.ConfigureProviderClientCapabilities{DeferralAllowed: deferralAllowed},
+	}, &resp)
+
+	return resp
+}
+
+func TestProviderConfigure_deferredUnknownToken(t *testing.T) {
+	resp := testProviderConfigure(t, map[string]tftypes.Value{
+		"token": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+	}, true)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	if resp.Deferred == nil || resp.Deferred.Reason != provider.DeferredReasonProviderConfigUnknown {
+		t.Fatalf("expected configuration to be deferred, got: %v", resp.Deferred)
+	}
+	if resp.ResourceData != nil {
+		t.Errorf("expected no resource data when deferred, got: %T", resp.ResourceData)
+	}
+}
+
+func TestProviderConfigure_box(t *testing.T) {
+	t.Setenv("AIDBOX_API_TOKEN", "")
+	t.Setenv("AIDBOX_BOX_CLIENT_ID", "")
+	t.Setenv("AIDBOX_BOX_CLIENT_SECRET", "")
+
+	resp := testProviderConfigure(t, map[string]tftypes.Value{
+		"box_url":           tftypes.NewValue(tftypes.String, "http://localhost:8888"),
+		"box_client_id":     tftypes.NewValue(tftypes.String, "root"),
+		"box_client_secret": tftypes.NewValue(tftypes.String, "secret"),
+	}, false)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	data, ok := resp.ResourceData.(*ProviderData)
+	if !ok || data.BoxClient == nil {
+		t.Fatalf("expected a box client, got: %#v", resp.ResourceData)
+	}
+
+	resp = testProviderConfigure(t, map[string]tftypes.Value{
+		"box_url": tftypes.NewValue(tftypes.String, "http://localhost:8888"),
+	}, false)
+	if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != "No Box Client Credentials Provided" {
+		t.Errorf("expected missing credentials error, got: %v", resp.Diagnostics)
+	}
+}
+
+func TestProviderConfigure_portalRPC(t *testing.T) {
+	t.Setenv("AIDBOX_API_TOKEN", "token")
+	t.Setenv("AIDBOX_ENDPOINT", "")
+	t.Setenv("AIDBOX_PORTAL_RPC_PATH", "")
+	t.Setenv("AIDBOX_PORTAL_RPC_NAMESPACE", "licensing.api")
+
+	resp := testProviderConfigure(t, map[string]tftypes.Value{
+		"endpoint":        tftypes.NewValue(tftypes.String, "https://licenses.example.com/rpc?tenant=one"),
+		"portal_rpc_path": tftypes.NewValue(tftypes.String, "/licensing/rpc"),
+	}, false)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	data, ok := resp.ResourceData.(*ProviderData)
+	if !ok {
+		t.Fatalf("unexpected resource data: %#v", resp.ResourceData)
+	}
+	client, ok := data.Client.(*aidbox.HTTPClient)
+	if !ok {
+		t.Fatalf("unexpected client: %T", data.Client)
+	}
+	if client.Endpoint != "https://licenses.example.com/licensing/rpc?tenant=one" || data.Endpoint != client.Endpoint {
+		t.Errorf("expected the path of the endpoint to be replaced, got: %s", client.Endpoint)
+	}
+	if client.MethodNamespace != "licensing.api" {
+		t.Errorf("expected the namespace of the environment, got: %s", client.MethodNamespace)
+	}
+}
+
+func TestValidateCredentials(t *testing.T) {
+	testCases := map[string]struct {
+		handler      http.HandlerFunc
+		unreachable  bool
+		expectError  string
+		expectNoDiag bool
+	}{
+		"valid": {
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, "result:\n  session:\n    id: session-one\n")
+			},
+			expectNoDiag: true,
+		},
+		"invalid-token": {
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusUnauthorized)
+			},
+			expectError: "Invalid API Token",
+		},
+		"server-error": {
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			},
+			expectError: "Failed to Validate Credentials",
+		},
+		"unreachable": {
+			handler:     func(w http.ResponseWriter, r *http.Request) {},
+			unreachable: true,
+			expectError: "Aidbox Endpoint Unreachable",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(testCase.handler)
+			if testCase.unreachable {
+				server.Close()
+			} else {
+				defer server.Close()
+			}
+
+			diags := validateCredentials(context.Background(), &ProviderData{
+				Endpoint: server.URL,
+				Token:    "token",
+				Client:   aidbox.NewClient(server.URL, "token"),
+			})
+
+			if testCase.expectNoDiag {
+				if diags.HasError() {
+					t.Fatalf("unexpected diagnostics: %v", diags)
+				}
+				return
+			}
+
+			if !diags.HasError() || diags.Errors()[0].Summary() != testCase.expectError {
+				t.Fatalf("expected %q error, got: %v", testCase.expectError, diags)
+			}
+		})
+	}
+}
+
+func TestNewHTTPClient(t *testing.T) {
+	client := newHTTPClient(AidboxProviderModel{
+		MaxIdleConns:    types.Int64Value(30),
+		MaxConnsPerHost: types.Int64Value(10),
+		IdleConnTimeout: types.StringValue("2m"),
+		KeepAlive:       types.StringNull(),
+	})
+
+	transport, ok := client.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("unexpected transport: %T", client.Transport)
+	}
+	if transport.MaxIdleConns != 30 || transport.MaxIdleConnsPerHost != 30 || transport.MaxConnsPerHost != 10 {
+		t.Errorf("unexpected connection limits: %d idle, %d idle per host, %d per host", transport.MaxIdleConns, transport.MaxIdleConnsPerHost, transport.MaxConnsPerHost)
+	}
+	if transport.IdleConnTimeout != 2*time.Minute {
+		t.Errorf("unexpected idle connection timeout: %s", transport.IdleConnTimeout)
+	}
+}
+
+func TestProviderConfigure_boxAccessTokens(t *testing.T) {
+	t.Setenv("AIDBOX_API_TOKEN", "")
+	t.Setenv("AIDBOX_BOX_TOKEN_CACHE_DIR", "")
+	t.Setenv("HOME", "/home/terraform")
+
+	resp := testProviderConfigure(t, map[string]tftypes.Value{
+		"box_url":             tftypes.NewValue(tftypes.String, "http://localhost:8888"),
+		"box_client_id":       tftypes.NewValue(tftypes.String, "root"),
+		"box_client_secret":   tftypes.NewValue(tftypes.String, "secret"),
+		"box_access_tokens":   tftypes.NewValue(tftypes.Bool, true),
+		"box_token_cache_dir": tftypes.NewValue(tftypes.String, "~/.cache/aidbox"),
+	}, false)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	data, ok := resp.ResourceData.(*ProviderData)
+	if !ok {
+		t.Fatalf("unexpected resource data: %#v", resp.ResourceData)
+	}
+	box, ok := data.BoxClient.(*aidbox.BoxClient)
+	if !ok {
+		t.Fatalf("unexpected box client: %T", data.BoxClient)
+	}
+	if !box.UseTokens || box.TokenCacheDir != "/home/terraform/.cache/aidbox" {
+		t.Errorf("expected access tokens cached in the expanded directory, got: %t, %q", box.UseTokens, box.TokenCacheDir)
+	}
+}
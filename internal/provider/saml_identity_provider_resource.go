@@ -0,0 +1,306 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox"
+	"regexp"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &SAMLIdentityProviderResource{}
+var _ resource.ResourceWithConfigValidators = &SAMLIdentityProviderResource{}
+var _ resource.ResourceWithImportState = &SAMLIdentityProviderResource{}
+
+// SAML identity providers are stored as IdentityProvider resources of the
+// saml type.
+const samlIdentityProviderType = "saml"
+
+// samlNameIDFormats are the NameID formats the box accepts in assertions.
+var samlNameIDFormats = []string{
+	"urn:oasis:names:tc:SAML:1.1:nameid-format:emailAddress",
+	"urn:oasis:names:tc:SAML:1.1:nameid-format:unspecified",
+	"urn:oasis:names:tc:SAML:2.0:nameid-format:persistent",
+	"urn:oasis:names:tc:SAML:2.0:nameid-format:transient",
+}
+
+func NewSAMLIdentityProviderResource() resource.Resource {
+	return &SAMLIdentityProviderResource{}
+}
+
+// SAMLIdentityProviderResource defines the resource implementation.
+type SAMLIdentityProviderResource struct {
+	boxResource
+}
+
+// SAMLIdentityProviderResourceModel describes the resource data model.
+type SAMLIdentityProviderResourceModel struct {
+	ID                   types.String `tfsdk:"id"`
+	Title                types.String `tfsdk:"title"`
+	Active               types.Bool   `tfsdk:"active"`
+	MetadataURL          types.String `tfsdk:"metadata_url"`
+	EntityID             types.String `tfsdk:"entity_id"`
+	SSOURL               types.String `tfsdk:"sso_url"`
+	Certificate          types.String `tfsdk:"certificate"`
+	SPEntityID           types.String `tfsdk:"sp_entity_id"`
+	ACSURL               types.String `tfsdk:"acs_url"`
+	NameIDFormat         types.String `tfsdk:"name_id_format"`
+	WantAssertionsSigned types.Bool   `tfsdk:"want_assertions_signed"`
+	AttributeMapping     types.Map    `tfsdk:"attribute_mapping"`
+	VersionID            types.String `tfsdk:"version_id"`
+}
+
+func (r *SAMLIdentityProviderResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_saml_identity_provider"
+}
+
+func (r *SAMLIdentityProviderResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	httpsURL := validURI("https")
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a SAML 2.0 identity provider, such as ADFS or a hospital single sign-on, that users sign in to the box with. " +
+			"The identity provider is either described by its metadata URL, or by its entity ID, single sign-on URL and signing certificate.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the identity provider. Changing it forces a new identity provider to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validFHIRID(),
+				},
+			},
+			"title": schema.StringAttribute{
+				MarkdownDescription: "Title of the identity provider on the sign in page",
+				Optional:            true,
+			},
+			"active": schema.BoolAttribute{
+				MarkdownDescription: "Whether users can sign in with the identity provider. Defaults to `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"metadata_url": schema.StringAttribute{
+				MarkdownDescription: "URL of the SAML metadata of the identity provider, which the box periodically fetches the entity ID, single sign-on URL and certificates from. Exactly one of `metadata_url` or `certificate` must be set.",
+				Optional:            true,
+				Validators: []validator.String{
+					httpsURL,
+				},
+			},
+			"entity_id": schema.StringAttribute{
+				MarkdownDescription: "Entity ID of the identity provider, the issuer of the assertions. Required with `certificate`.",
+				Optional:            true,
+			},
+			"sso_url": schema.StringAttribute{
+				MarkdownDescription: "URL of the single sign-on service of the identity provider, users are redirected to. Required with `certificate`.",
+				Optional:            true,
+				Validators: []validator.String{
+					httpsURL,
+				},
+			},
+			"certificate": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded certificate the identity provider signs assertions with",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^\s*-----BEGIN CERTIFICATE-----`), "must be a PEM encoded certificate"),
+				},
+			},
+			"sp_entity_id": schema.StringAttribute{
+				MarkdownDescription: "Entity ID of the box as a service provider, the audience of the assertions, as registered in the identity provider",
+				Required:            true,
+			},
+			"acs_url": schema.StringAttribute{
+				MarkdownDescription: "Assertion consumer service URL of the box, the identity provider posts assertions to, as registered in the identity provider, such as `https://box.example.com/auth/saml/acs`",
+				Required:            true,
+				Validators: []validator.String{
+					httpsURL,
+				},
+			},
+			"name_id_format": schema.StringAttribute{
+				MarkdownDescription: "Format of the NameID requested from the identity provider, identifying the user. Defaults to `urn:oasis:names:tc:SAML:1.1:nameid-format:emailAddress`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(samlNameIDFormats[0]),
+				Validators: []validator.String{
+					stringvalidator.OneOf(samlNameIDFormats...),
+				},
+			},
+			"want_assertions_signed": schema.BoolAttribute{
+				MarkdownDescription: "Whether the box rejects assertions which are not signed. Defaults to `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"attribute_mapping": schema.MapAttribute{
+				MarkdownDescription: "Attributes of the Aidbox user, such as `email` or `name.givenName`, mapped to the SAML attributes of the assertion they are set from",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(
+						stringvalidator.RegexMatches(userAttributeRegexp, "must be an attribute of the Aidbox user, such as email or name.givenName"),
+					),
+				},
+			},
+			"version_id": schema.StringAttribute{
+				MarkdownDescription: "Version of the identity provider, incremented by the box on every change",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *SAMLIdentityProviderResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("metadata_url"),
+			path.MatchRoot("certificate"),
+		),
+		resourcevalidator.RequiredTogether(
+			path.MatchRoot("certificate"),
+			path.MatchRoot("entity_id"),
+			path.MatchRoot("sso_url"),
+		),
+	}
+}
+
+func (r *SAMLIdentityProviderResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var model SAMLIdentityProviderResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stored, err := r.client.PutResource(ctx, samlIdentityProviderToResource(model), "")
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostic("Failed to Create SAML Identity Provider", "Unable to create SAML identity provider", err))
+		return
+	}
+
+	mapSAMLIdentityProviderFromResource(&model, stored)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *SAMLIdentityProviderResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var model SAMLIdentityProviderResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stored, err := r.client.GetResourceIfModified(ctx, identityProviderResourceType, model.ID.ValueString(), model.VersionID.ValueString())
+	if aidbox.IsNotModified(err) {
+		return
+	}
+	if aidbox.IsNotFound(err) {
+		tflog.Warn(ctx, "SAML identity provider not found, removing from state", map[string]interface{}{"id": model.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostic("Failed to Fetch SAML Identity Provider", "Unable to fetch SAML identity provider", err))
+		return
+	}
+
+	mapSAMLIdentityProviderFromResource(&model, stored)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *SAMLIdentityProviderResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var model SAMLIdentityProviderResourceModel
+	var versionID types.String
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("version_id"), &versionID)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stored, err := r.client.PutResource(ctx, samlIdentityProviderToResource(model), versionID.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(updateErrorDiagnostic("SAML identity provider "+model.ID.ValueString(), "Failed to Update SAML Identity Provider", "Unable to update SAML identity provider", err))
+		return
+	}
+
+	mapSAMLIdentityProviderFromResource(&model, stored)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *SAMLIdentityProviderResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var model SAMLIdentityProviderResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteResource(ctx, identityProviderResourceType, model.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(deleteErrorDiagnostic(
+			"SAML identity provider "+model.ID.ValueString(),
+			"Failed to Delete SAML Identity Provider",
+			fmt.Sprintf("Error while trying to delete the SAML identity provider with ID %s", model.ID.ValueString()),
+			err,
+		))
+	}
+}
+
+func (r *SAMLIdentityProviderResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+func samlIdentityProviderToResource(model SAMLIdentityProviderResourceModel) aidbox.Resource {
+	saml := map[string]interface{}{}
+	setJSONValue(saml, "metadata-url", model.MetadataURL)
+	setJSONValue(saml, "entity-id", model.EntityID)
+	setJSONValue(saml, "sso-url", model.SSOURL)
+	setJSONValue(saml, "certificate", model.Certificate)
+	setJSONValue(saml, "sp-entity-id", model.SPEntityID)
+	setJSONValue(saml, "acs-url", model.ACSURL)
+	setJSONValue(saml, "name-id-format", model.NameIDFormat)
+	setJSONValue(saml, "want-assertions-signed", model.WantAssertionsSigned)
+	setJSONValue(saml, "attribute-mapping", model.AttributeMapping)
+
+	idp := aidbox.Resource{
+		"resourceType": identityProviderResourceType,
+		"id":           model.ID.ValueString(),
+		"type":         samlIdentityProviderType,
+		"saml":         saml,
+	}
+	setJSONValue(idp, "title", model.Title)
+	setJSONValue(idp, "active", model.Active)
+
+	return idp
+}
+
+func mapSAMLIdentityProviderFromResource(model *SAMLIdentityProviderResourceModel, stored aidbox.Resource) {
+	saml := jsonObject(stored, "saml")
+
+	model.ID = types.StringValue(stored.ID())
+	model.Title = jsonStringValue(stored, "title")
+	model.Active = types.BoolValue(jsonBoolValue(stored, "active").ValueBool())
+	model.MetadataURL = jsonStringValue(saml, "metadata-url")
+	model.EntityID = jsonStringValue(saml, "entity-id")
+	model.SSOURL = jsonStringValue(saml, "sso-url")
+	model.Certificate = jsonStringValue(saml, "certificate")
+	model.SPEntityID = jsonStringValue(saml, "sp-entity-id")
+	model.ACSURL = jsonStringValue(saml, "acs-url")
+	model.NameIDFormat = jsonStringValue(saml, "name-id-format")
+	model.WantAssertionsSigned = types.BoolValue(jsonBoolValue(saml, "want-assertions-signed").ValueBool())
+	model.AttributeMapping = jsonStringMap(saml, "attribute-mapping")
+	model.VersionID = stringValueOrNull(stored.VersionID())
+}
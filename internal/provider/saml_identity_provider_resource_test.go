@@ -0,0 +1,73 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccAidboxSAMLIdentityProviderResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheckBox(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_5_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccAidboxSAMLIdentityProviderResourceConfig("email"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("aidbox_saml_identity_provider.test", "id", "tf-acc-saml"),
+					resource.TestCheckResourceAttr("aidbox_saml_identity_provider.test", "active", "true"),
+					resource.TestCheckResourceAttr("aidbox_saml_identity_provider.test", "name_id_format", "urn:oasis:names:tc:SAML:1.1:nameid-format:emailAddress"),
+					resource.TestCheckResourceAttr("aidbox_saml_identity_provider.test", "want_assertions_signed", "true"),
+					resource.TestCheckResourceAttr("aidbox_saml_identity_provider.test", "attribute_mapping.email", "email"),
+					resource.TestCheckResourceAttrSet("aidbox_saml_identity_provider.test", "version_id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "aidbox_saml_identity_provider.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Import block testing, as with terraform plan -generate-config-out
+			{
+				ResourceName:    "aidbox_saml_identity_provider.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithID,
+			},
+			// Update and Read testing
+			{
+				Config: testAccAidboxSAMLIdentityProviderResourceConfig("http://schemas.xmlsoap.org/ws/2005/05/identity/claims/emailaddress"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("aidbox_saml_identity_provider.test", "attribute_mapping.email", "http://schemas.xmlsoap.org/ws/2005/05/identity/claims/emailaddress"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccAidboxSAMLIdentityProviderResourceConfig(emailAttribute string) string {
+	return fmt.Sprintf(`
+resource "aidbox_saml_identity_provider" "test" {
+  id           = "tf-acc-saml"
+  title        = "Hospital"
+  metadata_url = "https://idp.example.com/saml/metadata"
+
+  sp_entity_id = "https://box.example.com"
+  acs_url      = "https://box.example.com/auth/saml/acs"
+
+  attribute_mapping = {
+    email = %[1]q
+  }
+}
+`, emailAttribute)
+}
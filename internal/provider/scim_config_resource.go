@@ -0,0 +1,256 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &SCIMConfigResource{}
+var _ resource.ResourceWithConfigValidators = &SCIMConfigResource{}
+var _ resource.ResourceWithImportState = &SCIMConfigResource{}
+
+// The SCIM configuration is stored in the AidboxConfig/scim resource.
+const (
+	scimConfigResourceType = "AidboxConfig"
+	scimConfigID           = "scim"
+)
+
+func NewSCIMConfigResource() resource.Resource {
+	return &SCIMConfigResource{}
+}
+
+// SCIMConfigResource defines the resource implementation.
+type SCIMConfigResource struct {
+	boxResource
+}
+
+// SCIMConfigResourceModel describes the resource data model.
+type SCIMConfigResourceModel struct {
+	ID                   types.String `tfsdk:"id"`
+	Enabled              types.Bool   `tfsdk:"enabled"`
+	BearerToken          types.String `tfsdk:"bearer_token"`
+	BearerTokenWO        types.String `tfsdk:"bearer_token_wo"`
+	BearerTokenWOVersion types.Int64  `tfsdk:"bearer_token_wo_version"`
+	AttributeMapping     types.Map    `tfsdk:"attribute_mapping"`
+	VersionID            types.String `tfsdk:"version_id"`
+}
+
+func (r *SCIMConfigResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_scim_config"
+}
+
+func (r *SCIMConfigResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the SCIM module of a box, which lets an identity provider, such as Entra ID or Okta, provision the users of the box through the SCIM 2.0 API. " +
+			"A box has a single SCIM configuration. Destroying the resource disables the SCIM API.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the AidboxConfig resource holding the SCIM configuration, always `scim`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"version_id": schema.StringAttribute{
+				MarkdownDescription: "Version of the SCIM configuration, incremented by the box on every change",
+				Computed:            true,
+			},
+			"enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether the box serves the SCIM API. Defaults to `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"bearer_token": schema.StringAttribute{
+				MarkdownDescription: "Bearer token the identity provider authenticates to the SCIM API with, stored in the Terraform state. Prefer `bearer_token_wo` with Terraform 1.11 and later. Exactly one of `bearer_token` or `bearer_token_wo` must be set.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"bearer_token_wo": schema.StringAttribute{
+				MarkdownDescription: "Bearer token the identity provider authenticates to the SCIM API with, never stored in the Terraform state. Requires Terraform 1.11 or later. Increment `bearer_token_wo_version` to update the token.",
+				Optional:            true,
+				Sensitive:           true,
+				WriteOnly:           true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("bearer_token")),
+				},
+			},
+			"bearer_token_wo_version": schema.Int64Attribute{
+				MarkdownDescription: "Version of `bearer_token_wo`. As write-only values are not stored in state, changing this version is what triggers an update of the token.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot("bearer_token_wo")),
+				},
+			},
+			"attribute_mapping": schema.MapAttribute{
+				MarkdownDescription: "Attributes of the SCIM User resource, such as `userName` or `emails[primary].value`, mapped to the path of the attribute of the Aidbox User they are stored in, such as `email`. Defaults to the mapping of the box.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func (r *SCIMConfigResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.AtLeastOneOf(
+			path.MatchRoot("bearer_token"),
+			path.MatchRoot("bearer_token_wo"),
+		),
+	}
+}
+
+func (r *SCIMConfigResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var model SCIMConfigResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.checkConfigurationAbsent(ctx, "SCIM configuration", "aidbox_scim_config", scimConfigResourceType, scimConfigID)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	bearerToken, diags := scimConfigBearerToken(ctx, req.Config, model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stored, err := r.client.PutResource(ctx, scimConfigToResource(model, bearerToken), "")
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostic("Failed to Create SCIM Configuration", "Unable to configure the SCIM module", err))
+		return
+	}
+
+	mapSCIMConfigFromResource(&model, stored)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *SCIMConfigResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var model SCIMConfigResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stored, err := r.client.GetResource(ctx, scimConfigResourceType, scimConfigID)
+	if aidbox.IsNotFound(err) {
+		tflog.Warn(ctx, "SCIM configuration not found, removing from state")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostic("Failed to Fetch SCIM Configuration", "Unable to fetch the SCIM configuration", err))
+		return
+	}
+
+	mapSCIMConfigFromResource(&model, stored)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *SCIMConfigResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var model SCIMConfigResourceModel
+	var versionID types.String
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("version_id"), &versionID)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	bearerToken, diags := scimConfigBearerToken(ctx, req.Config, model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stored, err := r.client.PutResource(ctx, scimConfigToResource(model, bearerToken), versionID.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(updateErrorDiagnostic("SCIM configuration", "Failed to Update SCIM Configuration", "Unable to configure the SCIM module", err))
+		return
+	}
+
+	mapSCIMConfigFromResource(&model, stored)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *SCIMConfigResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	err := r.client.DeleteResource(ctx, scimConfigResourceType, scimConfigID)
+	if err != nil {
+		resp.Diagnostics.Append(deleteErrorDiagnostic("SCIM configuration", "Failed to Delete SCIM Configuration", "Unable to disable the SCIM module", err))
+	}
+}
+
+func (r *SCIMConfigResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID != scimConfigID {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("The SCIM configuration of a box is imported with the ID %q, got: %q", scimConfigID, req.ID),
+		)
+		return
+	}
+
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// scimConfigBearerToken returns the configured bearer token. Write-only
+// values are only available in the configuration, never in the plan.
+func scimConfigBearerToken(ctx context.Context, config tfsdk.Config, model SCIMConfigResourceModel) (types.String, diag.Diagnostics) {
+	if !model.BearerToken.IsNull() {
+		return model.BearerToken, nil
+	}
+
+	var bearerTokenWO types.String
+	diags := config.GetAttribute(ctx, path.Root("bearer_token_wo"), &bearerTokenWO)
+	return bearerTokenWO, diags
+}
+
+func scimConfigToResource(model SCIMConfigResourceModel, bearerToken types.String) aidbox.Resource {
+	scim := map[string]interface{}{}
+	setJSONValue(scim, "enabled", model.Enabled)
+	setJSONValue(scim, "bearer-token", bearerToken)
+	setJSONValue(scim, "attribute-mapping", model.AttributeMapping)
+
+	return aidbox.Resource{
+		"resourceType": scimConfigResourceType,
+		"id":           scimConfigID,
+		"scim":         scim,
+	}
+}
+
+// mapSCIMConfigFromResource maps the AidboxConfig resource stored by the box
+// to model. The bearer token is only read back when it is stored in state.
+func mapSCIMConfigFromResource(model *SCIMConfigResourceModel, stored aidbox.Resource) {
+	scim := jsonObject(stored, "scim")
+
+	model.ID = types.StringValue(scimConfigID)
+	model.VersionID = stringValueOrNull(stored.VersionID())
+	model.Enabled = types.BoolValue(jsonBoolValue(scim, "enabled").ValueBool())
+	model.AttributeMapping = jsonStringMap(scim, "attribute-mapping")
+	model.BearerTokenWO = types.StringNull()
+	if model.BearerTokenWOVersion.IsNull() {
+		model.BearerToken = secretValueOrPrior(scim, "bearer-token", model.BearerToken)
+	} else {
+		model.BearerToken = types.StringNull()
+	}
+}
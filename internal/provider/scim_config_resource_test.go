@@ -0,0 +1,68 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccAidboxSCIMConfigResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheckBox(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_5_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccAidboxSCIMConfigResourceConfig("email"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("aidbox_scim_config.test", "id", "scim"),
+					resource.TestCheckResourceAttr("aidbox_scim_config.test", "enabled", "true"),
+					resource.TestCheckResourceAttr("aidbox_scim_config.test", "attribute_mapping.userName", "email"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "aidbox_scim_config.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"bearer_token"},
+			},
+			// Import block testing, as with terraform plan -generate-config-out
+			{
+				// The bearer token is not read back from the box
+				ResourceName:       "aidbox_scim_config.test",
+				ImportState:        true,
+				ImportStateKind:    resource.ImportBlockWithID,
+				ExpectNonEmptyPlan: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccAidboxSCIMConfigResourceConfig("login"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("aidbox_scim_config.test", "attribute_mapping.userName", "login"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccAidboxSCIMConfigResourceConfig(userName string) string {
+	return fmt.Sprintf(`
+resource "aidbox_scim_config" "test" {
+  bearer_token = "tf-acc-scim-token"
+
+  attribute_mapping = {
+    userName = %[1]q
+  }
+}
+`, userName)
+}
@@ -0,0 +1,68 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox/fake"
+)
+
+func TestSCIMConfigResource_writeOnlyBearerToken(t *testing.T) {
+	ctx := context.Background()
+	r := &SCIMConfigResource{}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	model := SCIMConfigResourceModel{
+		ID:                   types.StringUnknown(),
+		Enabled:              types.BoolValue(true),
+		BearerToken:          types.StringNull(),
+		BearerTokenWO:        types.StringValue("token"),
+		BearerTokenWOVersion: types.Int64Value(1),
+		AttributeMapping:     types.MapValueMust(types.StringType, map[string]attr.Value{"userName": types.StringValue("email")}),
+	}
+	plan := tfsdk.Plan{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	if diags := plan.Set(ctx, &model); diags.HasError() {
+		t.Fatalf("unable to build config: %v", diags)
+	}
+	config := tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw}
+
+	// Write-only values are null in the plan
+	model.BearerTokenWO = types.StringNull()
+	bearerToken, diags := scimConfigBearerToken(ctx, config, model)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if bearerToken.ValueString() != "token" {
+		t.Fatalf("expected the write-only bearer token, got: %s", bearerToken)
+	}
+
+	client := fake.NewBoxClient()
+	stored, err := client.PutResource(ctx, scimConfigToResource(model, bearerToken), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if jsonObject(stored, "scim")["bearer-token"] != "token" {
+		t.Errorf("expected the bearer token to be sent to the box, got: %v", stored)
+	}
+
+	mapSCIMConfigFromResource(&model, stored)
+	if !model.BearerToken.IsNull() || !model.BearerTokenWO.IsNull() {
+		t.Errorf("expected no bearer token in state, got: %+v", model)
+	}
+	if model.BearerTokenWOVersion.ValueInt64() != 1 || model.AttributeMapping.Elements()["userName"] != types.StringValue("email") {
+		t.Errorf("unexpected model: %+v", model)
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"net/url"
+	"strconv"
+)
+
+// searchPageSize is the default number of resources fetched per page of
+// search results.
+const searchPageSize = 100
+
+// pageSizeAttribute returns the page_size attribute of the data sources
+// listing resources of the box.
+func pageSizeAttribute() schema.Int64Attribute {
+	return schema.Int64Attribute{
+		MarkdownDescription: fmt.Sprintf("Number of resources read per request to the box. Defaults to `%d`.", searchPageSize),
+		Optional:            true,
+		Validators: []validator.Int64{
+			int64validator.Between(1, 1000),
+		},
+	}
+}
+
+// maxItemsAttribute returns the max_items attribute of the data sources
+// listing resources of the box, listing items.
+func maxItemsAttribute(items string) schema.Int64Attribute {
+	return schema.Int64Attribute{
+		MarkdownDescription: fmt.Sprintf("Maximum number of %s listed. Without it, all of them are listed.", items),
+		Optional:            true,
+		Validators: []validator.Int64{
+			int64validator.AtLeast(1),
+		},
+	}
+}
+
+// searchParams returns the search parameters reading pageSize resources per
+// page, sorted by ID so that the resources listed, and the ones left out by
+// max_items, are the same on every read.
+func searchParams(pageSize types.Int64) url.Values {
+	count := int64(searchPageSize)
+	if !pageSize.IsNull() && !pageSize.IsUnknown() {
+		count = pageSize.ValueInt64()
+	}
+	return url.Values{
+		"_count": {strconv.FormatInt(count, 10)},
+		"_sort":  {"_id"},
+	}
+}
+
+// searchLimit returns the limit of a search for max_items, 0 when all the
+// resources are listed.
+func searchLimit(maxItems types.Int64) int {
+	if maxItems.IsNull() || maxItems.IsUnknown() {
+		return 0
+	}
+	return int(maxItems.ValueInt64())
+}
+
+// limitItems returns the first max_items items, or all of them when
+// max_items is not set.
+func limitItems[T any](items []T, maxItems types.Int64) []T {
+	if limit := searchLimit(maxItems); limit > 0 && len(items) > limit {
+		return items[:limit]
+	}
+	return items
+}
@@ -0,0 +1,36 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestSearchParams(t *testing.T) {
+	params := searchParams(types.Int64Null())
+	if params.Get("_count") != "100" || params.Get("_sort") != "_id" {
+		t.Fatalf("expected default search params, got: %v", params)
+	}
+
+	params = searchParams(types.Int64Value(25))
+	if params.Get("_count") != "25" {
+		t.Fatalf("expected _count 25, got: %v", params)
+	}
+}
+
+func TestLimitItems(t *testing.T) {
+	items := []string{"a", "b", "c"}
+
+	if limited := limitItems(items, types.Int64Null()); len(limited) != 3 {
+		t.Fatalf("expected all items, got: %v", limited)
+	}
+	if limited := limitItems(items, types.Int64Value(2)); len(limited) != 2 || limited[1] != "b" {
+		t.Fatalf("expected the first 2 items, got: %v", limited)
+	}
+	if limited := limitItems(items, types.Int64Value(5)); len(limited) != 3 {
+		t.Fatalf("expected all items, got: %v", limited)
+	}
+}
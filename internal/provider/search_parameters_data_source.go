@@ -0,0 +1,157 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox"
+	"sort"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &SearchParametersDataSource{}
+var _ datasource.DataSourceWithConfigure = &SearchParametersDataSource{}
+
+const searchParameterResourceType = "SearchParameter"
+
+func NewSearchParametersDataSource() datasource.DataSource {
+	return &SearchParametersDataSource{}
+}
+
+// SearchParametersDataSource defines the data source implementation.
+type SearchParametersDataSource struct {
+	boxDataSource
+}
+
+// SearchParametersDataSourceModel describes the data source data model.
+type SearchParametersDataSourceModel struct {
+	ResourceType     types.String                                 `tfsdk:"resource_type"`
+	PageSize         types.Int64                                  `tfsdk:"page_size"`
+	MaxItems         types.Int64                                  `tfsdk:"max_items"`
+	SearchParameters []SearchParametersDataSourceSearchParamModel `tfsdk:"search_parameters"`
+}
+
+// SearchParametersDataSourceSearchParamModel describes a search parameter of
+// the search_parameters attribute.
+type SearchParametersDataSourceSearchParamModel struct {
+	ID         types.String `tfsdk:"id"`
+	Code       types.String `tfsdk:"code"`
+	Base       types.List   `tfsdk:"base"`
+	Type       types.String `tfsdk:"type"`
+	Expression types.String `tfsdk:"expression"`
+	URL        types.String `tfsdk:"url"`
+}
+
+func (d *SearchParametersDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_search_parameters"
+}
+
+func (d *SearchParametersDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the SearchParameter resources stored in the box, such as its custom search parameters, for example to create a search parameter, or an index, only when it is missing.",
+		Attributes: map[string]schema.Attribute{
+			"resource_type": schema.StringAttribute{
+				MarkdownDescription: "Resource type the search parameters must apply to, such as `Patient`. Without it, the search parameters of all resource types are listed.",
+				Optional:            true,
+			},
+			"page_size": pageSizeAttribute(),
+			"max_items": maxItemsAttribute("search parameters"),
+			"search_parameters": schema.ListAttribute{
+				MarkdownDescription: "Search parameters, ordered by ID. Each search parameter has an `id`, the `code` used in search requests, the resource types it applies to as `base`, its `type`, such as `token` or `reference`, its FHIRPath `expression` and its canonical `url`.",
+				ElementType: types.ObjectType{
+					AttrTypes: map[string]attr.Type{
+						"id":         types.StringType,
+						"code":       types.StringType,
+						"base":       types.ListType{ElemType: types.StringType},
+						"type":       types.StringType,
+						"expression": types.StringType,
+						"url":        types.StringType,
+					},
+				},
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (d *SearchParametersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model SearchParametersDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The search parameters are filtered by resource type after the search,
+	// so max_items can only limit the search itself without it.
+	limit := searchLimit(model.MaxItems)
+	if !model.ResourceType.IsNull() {
+		limit = 0
+	}
+
+	searchParameters, err := d.client.SearchResources(ctx, searchParameterResourceType, searchParams(model.PageSize), limit)
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostic("Failed to List Search Parameters", "Unable to search search parameters", err))
+		return
+	}
+
+	model.SearchParameters = limitItems(mapSearchParameters(searchParameters, model.ResourceType.ValueString()), model.MaxItems)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+// mapSearchParameters maps the SearchParameter resources stored by the box
+// applying to resourceType, or all of them when empty, ordered by ID. Search
+// parameters in the Aidbox format, naming a single resource, are mapped to the
+// FHIR format.
+func mapSearchParameters(searchParameters []aidbox.Resource, resourceType string) []SearchParametersDataSourceSearchParamModel {
+	mapped := []SearchParametersDataSourceSearchParamModel{}
+	for _, searchParameter := range searchParameters {
+		code := jsonStringValue(searchParameter, "code")
+		if code.IsNull() {
+			code = jsonStringValue(searchParameter, "name")
+		}
+
+		bases := jsonStringList(searchParameter, "base")
+		if bases.IsNull() {
+			base := []attr.Value{}
+			if id, ok := jsonObject(searchParameter, "resource")["id"].(string); ok {
+				base = append(base, types.StringValue(id))
+			}
+			bases = types.ListValueMust(types.StringType, base)
+		}
+		if resourceType != "" && !listContains(bases, resourceType) {
+			continue
+		}
+
+		// Aidbox format expressions are paths, not FHIRPath expressions.
+		expression, _ := searchParameter["expression"].(string)
+
+		mapped = append(mapped, SearchParametersDataSourceSearchParamModel{
+			ID:         types.StringValue(searchParameter.ID()),
+			Code:       code,
+			Base:       bases,
+			Type:       jsonStringValue(searchParameter, "type"),
+			Expression: stringValueOrNull(expression),
+			URL:        jsonStringValue(searchParameter, "url"),
+		})
+	}
+
+	sort.Slice(mapped, func(i, j int) bool {
+		return mapped[i].ID.ValueString() < mapped[j].ID.ValueString()
+	})
+	return mapped
+}
+
+// listContains reports whether list contains the string value.
+func listContains(list types.List, value string) bool {
+	for _, element := range list.Elements() {
+		if element.Equal(types.StringValue(value)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,36 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccAidboxSearchParametersDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckBox(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccAidboxSearchParametersDataSourceConfig("Patient"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.aidbox_search_parameters.test", "resource_type", "Patient"),
+					resource.TestCheckResourceAttrSet("data.aidbox_search_parameters.test", "search_parameters.#"),
+				),
+			},
+		},
+	})
+}
+
+func testAccAidboxSearchParametersDataSourceConfig(resourceType string) string {
+	return fmt.Sprintf(`
+data "aidbox_search_parameters" "test" {
+  resource_type = %[1]q
+}
+`, resourceType)
+}
@@ -0,0 +1,50 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox"
+)
+
+func TestMapSearchParameters(t *testing.T) {
+	searchParameters := []aidbox.Resource{
+		{
+			"resourceType": "SearchParameter",
+			"id":           "patient-mrn",
+			"code":         "mrn",
+			"base":         []interface{}{"Patient"},
+			"type":         "token",
+			"expression":   "Patient.identifier.where(type.coding.code = 'MR')",
+			"url":          "https://fhir.example.com/SearchParameter/patient-mrn",
+		},
+		{
+			"resourceType": "SearchParameter",
+			"id":           "encounter-ward",
+			"name":         "ward",
+			"resource":     map[string]interface{}{"id": "Encounter", "resourceType": "Entity"},
+			"type":         "reference",
+			"expression":   []interface{}{[]interface{}{"location", "location"}},
+		},
+	}
+
+	all := mapSearchParameters(searchParameters, "")
+	if len(all) != 2 || all[0].ID.ValueString() != "encounter-ward" || all[1].ID.ValueString() != "patient-mrn" {
+		t.Fatalf("expected the search parameters ordered by ID, got: %+v", all)
+	}
+	if all[0].Code.ValueString() != "ward" || !all[0].Base.Equal(types.ListValueMust(types.StringType, []attr.Value{types.StringValue("Encounter")})) || !all[0].Expression.IsNull() {
+		t.Errorf("unexpected Aidbox format search parameter: %+v", all[0])
+	}
+	if all[1].Code.ValueString() != "mrn" || all[1].Type.ValueString() != "token" || all[1].URL.IsNull() {
+		t.Errorf("unexpected FHIR format search parameter: %+v", all[1])
+	}
+
+	patient := mapSearchParameters(searchParameters, "Patient")
+	if len(patient) != 1 || patient[0].ID.ValueString() != "patient-mrn" {
+		t.Errorf("expected the Patient search parameters, got: %+v", patient)
+	}
+}
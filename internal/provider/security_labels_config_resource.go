@@ -0,0 +1,215 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &SecurityLabelsConfigResource{}
+var _ resource.ResourceWithImportState = &SecurityLabelsConfigResource{}
+
+// The security labels configuration is stored in the
+// AidboxConfig/security-labels resource.
+const (
+	securityLabelsConfigResourceType = "AidboxConfig"
+	securityLabelsConfigID           = "security-labels"
+)
+
+func NewSecurityLabelsConfigResource() resource.Resource {
+	return &SecurityLabelsConfigResource{}
+}
+
+// SecurityLabelsConfigResource defines the resource implementation.
+type SecurityLabelsConfigResource struct {
+	boxResource
+}
+
+// SecurityLabelsConfigResourceModel describes the resource data model.
+type SecurityLabelsConfigResourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	Enabled       types.Bool   `tfsdk:"enabled"`
+	ResourceTypes types.Set    `tfsdk:"resource_types"`
+	LabelSource   types.String `tfsdk:"label_source"`
+	Masking       types.String `tfsdk:"masking"`
+	VersionID     types.String `tfsdk:"version_id"`
+}
+
+func (r *SecurityLabelsConfigResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_security_labels_config"
+}
+
+func (r *SecurityLabelsConfigResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the security labels access control of a box, which restricts access to resources and elements according to their security labels and the labels granted to the client. " +
+			"A box has a single security labels configuration. Destroying the resource disables security labels.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the AidboxConfig resource holding the security labels configuration, always `security-labels`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"version_id": schema.StringAttribute{
+				MarkdownDescription: "Version of the security labels configuration, incremented by the box on every change",
+				Computed:            true,
+			},
+			"enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether the box enforces security labels. Defaults to `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"resource_types": schema.SetAttribute{
+				MarkdownDescription: "Resource types security labels are enforced on, such as `Observation`",
+				ElementType:         types.StringType,
+				Required:            true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+			"label_source": schema.StringAttribute{
+				MarkdownDescription: "FHIRPath expression selecting the security labels of a resource. Defaults to `meta.security`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("meta.security"),
+				Validators: []validator.String{
+					validFHIRPath(),
+				},
+			},
+			"masking": schema.StringAttribute{
+				MarkdownDescription: "How elements the client is not granted access to are returned, `redact` to replace them with a data absent reason or `remove` to leave them out. Defaults to `redact`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("redact"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("redact", "remove"),
+				},
+			},
+		},
+	}
+}
+
+func (r *SecurityLabelsConfigResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var model SecurityLabelsConfigResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.checkConfigurationAbsent(ctx, "security labels configuration", "aidbox_security_labels_config", securityLabelsConfigResourceType, securityLabelsConfigID)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stored, err := r.client.PutResource(ctx, securityLabelsConfigToResource(model), "")
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostic("Failed to Create Security Labels Configuration", "Unable to configure security labels", err))
+		return
+	}
+
+	mapSecurityLabelsConfigFromResource(&model, stored)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *SecurityLabelsConfigResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var model SecurityLabelsConfigResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stored, err := r.client.GetResource(ctx, securityLabelsConfigResourceType, securityLabelsConfigID)
+	if aidbox.IsNotFound(err) {
+		tflog.Warn(ctx, "Security labels configuration not found, removing from state")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostic("Failed to Fetch Security Labels Configuration", "Unable to fetch the security labels configuration", err))
+		return
+	}
+
+	mapSecurityLabelsConfigFromResource(&model, stored)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *SecurityLabelsConfigResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var model SecurityLabelsConfigResourceModel
+	var versionID types.String
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("version_id"), &versionID)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stored, err := r.client.PutResource(ctx, securityLabelsConfigToResource(model), versionID.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(updateErrorDiagnostic("security labels configuration", "Failed to Update Security Labels Configuration", "Unable to configure security labels", err))
+		return
+	}
+
+	mapSecurityLabelsConfigFromResource(&model, stored)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *SecurityLabelsConfigResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	err := r.client.DeleteResource(ctx, securityLabelsConfigResourceType, securityLabelsConfigID)
+	if err != nil {
+		resp.Diagnostics.Append(deleteErrorDiagnostic("security labels configuration", "Failed to Delete Security Labels Configuration", "Unable to disable security labels", err))
+	}
+}
+
+func (r *SecurityLabelsConfigResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID != securityLabelsConfigID {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("The security labels configuration of a box is imported with the ID %q, got: %q", securityLabelsConfigID, req.ID),
+		)
+		return
+	}
+
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+func securityLabelsConfigToResource(model SecurityLabelsConfigResourceModel) aidbox.Resource {
+	labels := map[string]interface{}{}
+	setJSONValue(labels, "enabled", model.Enabled)
+	setJSONValue(labels, "resource-types", model.ResourceTypes)
+	setJSONValue(labels, "label-source", model.LabelSource)
+	setJSONValue(labels, "masking", model.Masking)
+
+	return aidbox.Resource{
+		"resourceType":    securityLabelsConfigResourceType,
+		"id":              securityLabelsConfigID,
+		"security-labels": labels,
+	}
+}
+
+func mapSecurityLabelsConfigFromResource(model *SecurityLabelsConfigResourceModel, stored aidbox.Resource) {
+	labels := jsonObject(stored, "security-labels")
+
+	model.ID = types.StringValue(securityLabelsConfigID)
+	model.VersionID = stringValueOrNull(stored.VersionID())
+	model.Enabled = types.BoolValue(jsonBoolValue(labels, "enabled").ValueBool())
+	model.ResourceTypes = jsonStringSet(labels, "resource-types")
+	model.LabelSource = jsonStringValue(labels, "label-source")
+	model.Masking = jsonStringValue(labels, "masking")
+}
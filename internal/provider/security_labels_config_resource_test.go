@@ -0,0 +1,64 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccAidboxSecurityLabelsConfigResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheckBox(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_5_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccAidboxSecurityLabelsConfigResourceConfig("redact"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("aidbox_security_labels_config.test", "id", "security-labels"),
+					resource.TestCheckResourceAttr("aidbox_security_labels_config.test", "enabled", "true"),
+					resource.TestCheckResourceAttr("aidbox_security_labels_config.test", "resource_types.#", "2"),
+					resource.TestCheckResourceAttr("aidbox_security_labels_config.test", "label_source", "meta.security"),
+					resource.TestCheckResourceAttr("aidbox_security_labels_config.test", "masking", "redact"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "aidbox_security_labels_config.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Import block testing, as with terraform plan -generate-config-out
+			{
+				ResourceName:    "aidbox_security_labels_config.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithID,
+			},
+			// Update and Read testing
+			{
+				Config: testAccAidboxSecurityLabelsConfigResourceConfig("remove"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("aidbox_security_labels_config.test", "masking", "remove"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccAidboxSecurityLabelsConfigResourceConfig(masking string) string {
+	return fmt.Sprintf(`
+resource "aidbox_security_labels_config" "test" {
+  resource_types = ["Observation", "Condition"]
+  masking        = %[1]q
+}
+`, masking)
+}
@@ -0,0 +1,136 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ ephemeral.EphemeralResource = &SessionEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithConfigure = &SessionEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithClose = &SessionEphemeralResource{}
+
+// sessionPrivateKey is the private data key holding the ID of the session to
+// close once Terraform no longer needs it.
+const sessionPrivateKey = "session_id"
+
+func NewSessionEphemeralResource() ephemeral.EphemeralResource {
+	return &SessionEphemeralResource{}
+}
+
+// SessionEphemeralResource defines the ephemeral resource implementation.
+type SessionEphemeralResource struct {
+	client Client
+}
+
+// SessionEphemeralResourceModel describes the ephemeral resource data model.
+type SessionEphemeralResourceModel struct {
+	ID      types.String `tfsdk:"id"`
+	Token   types.String `tfsdk:"token"`
+	Expires types.String `tfsdk:"expires"`
+}
+
+func (r *SessionEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_session"
+}
+
+func (r *SessionEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Opens a temporary admin session for the duration of a Terraform operation and closes it afterwards",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Session ID",
+				Computed:            true,
+			},
+			"token": schema.StringAttribute{
+				MarkdownDescription: "Session token",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"expires": schema.StringAttribute{
+				MarkdownDescription: "Time at which the session expires if it is not closed",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *SessionEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*ProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = data.Client
+}
+
+func (r *SessionEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	session, err := r.client.OpenSession(ctx)
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostic("Failed to Open Session", "Unable to open session", err))
+		return
+	}
+
+	model := SessionEphemeralResourceModel{
+		ID:      basetypes.NewStringValue(session.ID),
+		Token:   basetypes.NewStringValue(session.Token),
+		Expires: basetypes.NewStringValue(session.Expires),
+	}
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &model)...)
+
+	// Private data must be valid JSON.
+	privateData, err := json.Marshal(session.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to Store Session ID", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, sessionPrivateKey, privateData)...)
+}
+
+func (r *SessionEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	value, diags := req.Private.GetKey(ctx, sessionPrivateKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || value == nil {
+		return
+	}
+
+	var sessionID string
+	if err := json.Unmarshal(value, &sessionID); err != nil {
+		resp.Diagnostics.AddError("Invalid Session Private Data", fmt.Sprintf("Unable to read the session ID: %s", err))
+		return
+	}
+
+	err := r.client.CloseSession(ctx, sessionID)
+	if aidbox.IsNotFound(err) {
+		// The session already expired or was closed
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostic(
+			"Failed to Close Session",
+			fmt.Sprintf("Error while trying to close the session with ID %s", sessionID),
+			err,
+		))
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ planmodifier.String = settingDefaultPlanModifier{}
+
+// settingDefaultPlanModifier plans the value of a setting left unset in the
+// configuration. The value in state is kept when the box already uses its
+// default, so that unset settings don't show a perpetual diff, and is marked
+// unknown otherwise, as the setting is reset to its default during apply.
+type settingDefaultPlanModifier struct {
+	sourcePath path.Path
+}
+
+// useSettingDefault returns a plan modifier for the value of settings, with
+// the source of the value stored in state at sourcePath.
+func useSettingDefault(sourcePath path.Path) planmodifier.String {
+	return settingDefaultPlanModifier{sourcePath: sourcePath}
+}
+
+func (m settingDefaultPlanModifier) Description(ctx context.Context) string {
+	return "Keeps the value in state when the setting is not configured and the box uses its default."
+}
+
+func (m settingDefaultPlanModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m settingDefaultPlanModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	// Configured values are planned as is, and there is nothing to keep on
+	// create or destroy.
+	if !req.ConfigValue.IsNull() || req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var source types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, m.sourcePath, &source)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if source.ValueString() == aidbox.SettingSourceDefault {
+		resp.PlanValue = req.StateValue
+	} else {
+		resp.PlanValue = types.StringUnknown()
+	}
+}
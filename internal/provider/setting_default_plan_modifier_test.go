@@ -0,0 +1,82 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestSettingDefaultPlanModifier(t *testing.T) {
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	(&SettingResource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	objectType, ok := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	if !ok {
+		t.Fatal("expected the schema type to be an object")
+	}
+
+	settingValue := func(source string) tftypes.Value {
+		values := map[string]tftypes.Value{}
+		for name, attrType := range objectType.AttributeTypes {
+			values[name] = tftypes.NewValue(attrType, nil)
+		}
+		values["name"] = tftypes.NewValue(tftypes.String, "fhir.search.default-params.count")
+		values["value"] = tftypes.NewValue(tftypes.String, "100")
+		values["source"] = tftypes.NewValue(tftypes.String, source)
+		return tftypes.NewValue(objectType, values)
+	}
+
+	testCases := map[string]struct {
+		config        types.String
+		source        string
+		expectUnknown bool
+	}{
+		"unset-default": {
+			config: types.StringNull(),
+			source: "default",
+		},
+		"unset-api": {
+			config:        types.StringNull(),
+			source:        "api",
+			expectUnknown: true,
+		},
+		"configured": {
+			config: types.StringValue("100"),
+			source: "api",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			raw := settingValue(testCase.source)
+			req := planmodifier.StringRequest{
+				Path:        path.Root("value"),
+				ConfigValue: testCase.config,
+				PlanValue:   types.StringValue("100"),
+				StateValue:  types.StringValue("100"),
+				Plan:        tfsdk.Plan{Schema: schemaResp.Schema, Raw: raw},
+				State:       tfsdk.State{Schema: schemaResp.Schema, Raw: raw},
+			}
+			resp := planmodifier.StringResponse{PlanValue: req.PlanValue}
+
+			useSettingDefault(path.Root("source")).PlanModifyString(ctx, req, &resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+			if resp.PlanValue.IsUnknown() != testCase.expectUnknown {
+				t.Errorf("expected unknown to be %t, got %s", testCase.expectUnknown, resp.PlanValue)
+			}
+		})
+	}
+}
@@ -0,0 +1,234 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox"
+	"strings"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &SettingResource{}
+var _ resource.ResourceWithImportState = &SettingResource{}
+var _ resource.ResourceWithModifyPlan = &SettingResource{}
+
+func NewSettingResource() resource.Resource {
+	return &SettingResource{}
+}
+
+// SettingResource defines the resource implementation.
+type SettingResource struct {
+	boxResource
+}
+
+// SettingResourceModel describes the resource data model.
+type SettingResourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	Name         types.String `tfsdk:"name"`
+	Scope        types.String `tfsdk:"scope"`
+	Value        types.String `tfsdk:"value"`
+	DefaultValue types.String `tfsdk:"default_value"`
+	Source       types.String `tfsdk:"source"`
+}
+
+func (r *SettingResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_setting"
+}
+
+func (r *SettingResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a setting of a box through the settings API of Aidbox 2408 and later. " +
+			"A setting without a configured `value` is reset to the default of the box, and destroying the resource restores the default.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the setting, its name, prefixed with its scope and a slash for scoped settings",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the setting, such as `fhir.search.default-params.count`. Changing it forces a new setting to be managed.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"scope": schema.StringAttribute{
+				MarkdownDescription: "Scope of the setting, such as an organization of a multitenant box. Defaults to the whole box. Changing it forces a new setting to be managed.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"value": schema.StringAttribute{
+				MarkdownDescription: "Value of the setting. When not set, the setting is reset to `default_value`.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					useSettingDefault(path.Root("source")),
+				},
+			},
+			"default_value": schema.StringAttribute{
+				MarkdownDescription: "Value of the setting when it is not set",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"source": schema.StringAttribute{
+				MarkdownDescription: "Where the value of the setting comes from, `default` when the box uses its default",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// ModifyPlan reports boxes without the settings API while planning, rather
+// than failing the apply.
+func (r *SettingResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Settings are reset on destroy whatever the version, the box being
+	// already configured through them.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(requireBoxVersion(ctx, r.metadata, "The aidbox_setting resource", settingsAPIVersion)...)
+}
+
+func (r *SettingResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var model SettingResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	setting, err := r.applySetting(ctx, req.Config, model)
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostic("Failed to Create Setting", "Unable to configure setting", err))
+		return
+	}
+
+	mapSettingFromSetting(&model, setting)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *SettingResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var model SettingResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	setting, err := r.client.GetSetting(ctx, model.Name.ValueString(), model.Scope.ValueString())
+	if aidbox.IsNotFound(err) {
+		tflog.Warn(ctx, "Setting not found, removing from state", map[string]interface{}{"id": model.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostic("Failed to Fetch Setting", "Unable to fetch setting", err))
+		return
+	}
+
+	mapSettingFromSetting(&model, setting)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *SettingResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var model SettingResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	setting, err := r.applySetting(ctx, req.Config, model)
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostic("Failed to Update Setting", "Unable to configure setting", err))
+		return
+	}
+
+	mapSettingFromSetting(&model, setting)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *SettingResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var model SettingResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteSetting(ctx, model.Name.ValueString(), model.Scope.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(deleteErrorDiagnostic(
+			"setting "+model.ID.ValueString(),
+			"Failed to Delete Setting",
+			fmt.Sprintf("Error while trying to restore the default of the setting %s", model.ID.ValueString()),
+			err,
+		))
+	}
+}
+
+func (r *SettingResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	name, scope := req.ID, ""
+	if before, after, ok := strings.Cut(req.ID, "/"); ok {
+		scope, name = before, after
+	}
+	if name == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("A setting is imported with its name, or with an ID in the <scope>/<name> format for scoped settings, got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("scope"), stringValueOrNull(scope))...)
+}
+
+// applySetting sets the configured value of the setting, or resets the
+// setting to its default when no value is configured, and returns the
+// setting as reported by the box.
+func (r *SettingResource) applySetting(ctx context.Context, config tfsdk.Config, model SettingResourceModel) (aidbox.Setting, error) {
+	var value types.String
+	if diags := config.GetAttribute(ctx, path.Root("value"), &value); diags.HasError() {
+		return aidbox.Setting{}, fmt.Errorf("unable to read the configured value: %v", diags)
+	}
+
+	name, scope := model.Name.ValueString(), model.Scope.ValueString()
+	if !value.IsNull() {
+		return r.client.PutSetting(ctx, aidbox.Setting{Name: name, Value: value.ValueString(), Scope: scope})
+	}
+
+	if err := r.client.DeleteSetting(ctx, name, scope); err != nil {
+		return aidbox.Setting{}, err
+	}
+	return r.client.GetSetting(ctx, name, scope)
+}
+
+func mapSettingFromSetting(model *SettingResourceModel, setting aidbox.Setting) {
+	id := setting.Name
+	if setting.Scope != "" {
+		id = setting.Scope + "/" + setting.Name
+	}
+
+	model.ID = types.StringValue(id)
+	model.Name = types.StringValue(setting.Name)
+	model.Scope = stringValueOrNull(setting.Scope)
+	model.Value = types.StringValue(setting.Value)
+	model.DefaultValue = types.StringValue(setting.Default)
+	model.Source = types.StringValue(setting.Source)
+}
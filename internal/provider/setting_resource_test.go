@@ -0,0 +1,73 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccAidboxSettingResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheckBox(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_5_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccAidboxSettingResourceConfig(`value = "50"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("aidbox_setting.test", "id", "fhir.search.default-params.count"),
+					resource.TestCheckResourceAttr("aidbox_setting.test", "value", "50"),
+					resource.TestCheckResourceAttr("aidbox_setting.test", "source", "api"),
+					resource.TestCheckResourceAttrSet("aidbox_setting.test", "default_value"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "aidbox_setting.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Import block testing, as with terraform plan -generate-config-out
+			{
+				ResourceName:    "aidbox_setting.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithID,
+			},
+			// Unsetting the value resets the setting to its default
+			{
+				Config: testAccAidboxSettingResourceConfig(""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("aidbox_setting.test", "source", "default"),
+					resource.TestCheckResourceAttrPair("aidbox_setting.test", "value", "aidbox_setting.test", "default_value"),
+				),
+			},
+			// Settings using their default don't drift
+			{
+				Config: testAccAidboxSettingResourceConfig(""),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccAidboxSettingResourceConfig(value string) string {
+	return `
+resource "aidbox_setting" "test" {
+  name = "fhir.search.default-params.count"
+  ` + value + `
+}
+`
+}
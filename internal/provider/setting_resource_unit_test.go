@@ -0,0 +1,61 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox/fake"
+)
+
+func TestSettingResource_resetToDefault(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewBoxClient()
+	client.SetSettingDefault("fhir.search.default-params.count", "100")
+
+	r := &SettingResource{boxResource{client: client}}
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	objectType, ok := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	if !ok {
+		t.Fatal("expected the schema type to be an object")
+	}
+
+	config := func(value interface{}) tfsdk.Config {
+		values := map[string]tftypes.Value{}
+		for name, attrType := range objectType.AttributeTypes {
+			values[name] = tftypes.NewValue(attrType, nil)
+		}
+		values["name"] = tftypes.NewValue(tftypes.String, "fhir.search.default-params.count")
+		values["value"] = tftypes.NewValue(tftypes.String, value)
+		return tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)}
+	}
+	model := SettingResourceModel{Name: types.StringValue("fhir.search.default-params.count")}
+
+	setting, err := r.applySetting(ctx, config("50"), model)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mapSettingFromSetting(&model, setting)
+	if model.Value.ValueString() != "50" || model.Source.ValueString() != "api" || model.DefaultValue.ValueString() != "100" {
+		t.Errorf("unexpected model: %+v", model)
+	}
+
+	setting, err = r.applySetting(ctx, config(nil), model)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mapSettingFromSetting(&model, setting)
+	if model.Value.ValueString() != "100" || model.Source.ValueString() != "default" {
+		t.Errorf("expected the setting to be reset to its default, got: %+v", model)
+	}
+	if model.ID.ValueString() != "fhir.search.default-params.count" || !model.Scope.IsNull() {
+		t.Errorf("unexpected ID or scope: %+v", model)
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &SettingsDataSource{}
+var _ datasource.DataSourceWithConfigure = &SettingsDataSource{}
+
+func NewSettingsDataSource() datasource.DataSource {
+	return &SettingsDataSource{}
+}
+
+// SettingsDataSource defines the data source implementation.
+type SettingsDataSource struct {
+	boxDataSource
+}
+
+// SettingsDataSourceModel describes the data source data model.
+type SettingsDataSourceModel struct {
+	Scope   types.String `tfsdk:"scope"`
+	Values  types.Map    `tfsdk:"values"`
+	Sources types.Map    `tfsdk:"sources"`
+}
+
+func (d *SettingsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_settings"
+}
+
+func (d *SettingsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads the effective settings of a box through the settings API of Aidbox 2408 and later, such as to compare the live configuration of the box with the environment variables declared for it.",
+		Attributes: map[string]schema.Attribute{
+			"scope": schema.StringAttribute{
+				MarkdownDescription: "Scope of the settings, such as an organization of a multitenant box. Defaults to the whole box.",
+				Optional:            true,
+			},
+			"values": schema.MapAttribute{
+				MarkdownDescription: "Effective value of each setting, by name",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"sources": schema.MapAttribute{
+				MarkdownDescription: "Where the value of each setting comes from, by name, `default` when the box uses its default",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *SettingsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model SettingsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(requireBoxVersion(ctx, d.metadata, "The aidbox_settings data source", settingsAPIVersion)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	settings, err := d.client.ListSettings(ctx, model.Scope.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostic("Failed to List Settings", "Unable to list the settings of the box", err))
+		return
+	}
+
+	mapSettings(&model, settings)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func mapSettings(model *SettingsDataSourceModel, settings []aidbox.Setting) {
+	values := make(map[string]attr.Value, len(settings))
+	sources := make(map[string]attr.Value, len(settings))
+	for _, setting := range settings {
+		values[setting.Name] = types.StringValue(setting.Value)
+		sources[setting.Name] = types.StringValue(setting.Source)
+	}
+
+	model.Values = types.MapValueMust(types.StringType, values)
+	model.Sources = types.MapValueMust(types.StringType, sources)
+}
@@ -0,0 +1,40 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccAidboxSettingsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckBox(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccAidboxSettingsDataSourceConfig(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.aidbox_settings.test", "values.fhir.search.default-params.count", "42"),
+					resource.TestCheckResourceAttr("data.aidbox_settings.test", "sources.fhir.search.default-params.count", "api"),
+				),
+			},
+		},
+	})
+}
+
+func testAccAidboxSettingsDataSourceConfig() string {
+	return `
+resource "aidbox_setting" "test" {
+  name  = "fhir.search.default-params.count"
+  value = "42"
+}
+
+data "aidbox_settings" "test" {
+  depends_on = [aidbox_setting.test]
+}
+`
+}
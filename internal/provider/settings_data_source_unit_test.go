@@ -0,0 +1,43 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox/fake"
+)
+
+func TestMapSettings(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewBoxClient()
+	client.SetSettingDefault("fhir.search.default-params.count", "100")
+	client.SetSettingDefault("security.audit.enabled", "false")
+	if _, err := client.PutSetting(ctx, aidbox.Setting{Name: "fhir.search.default-params.count", Value: "50"}); err != nil {
+		t.Fatal(err)
+	}
+
+	settings, err := client.ListSettings(ctx, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var model SettingsDataSourceModel
+	mapSettings(&model, settings)
+
+	expected := types.MapValueMust(types.StringType, map[string]attr.Value{
+		"fhir.search.default-params.count": types.StringValue("50"),
+		"security.audit.enabled":           types.StringValue("false"),
+	})
+	if !model.Values.Equal(expected) {
+		t.Errorf("expected values %v, got: %v", expected, model.Values)
+	}
+	if source := model.Sources.Elements()["security.audit.enabled"]; source == nil || !source.Equal(types.StringValue(aidbox.SettingSourceDefault)) {
+		t.Errorf("expected the default source, got: %v", model.Sources)
+	}
+}
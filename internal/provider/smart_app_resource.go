@@ -0,0 +1,351 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &SMARTAppResource{}
+var _ resource.ResourceWithImportState = &SMARTAppResource{}
+var _ resource.ResourceWithModifyPlan = &SMARTAppResource{}
+var _ resource.ResourceWithValidateConfig = &SMARTAppResource{}
+
+// SMART apps are stored as Client resources of the smart-app type.
+const (
+	smartAppResourceType = "Client"
+	smartAppClientType   = "smart-app"
+)
+
+// Token endpoint authentication methods of SMART apps: public apps don't
+// authenticate, confidential apps authenticate with a JWT signed with one of
+// their registered keys.
+const (
+	smartAppAuthMethodNone          = "none"
+	smartAppAuthMethodPrivateKeyJWT = "private_key_jwt"
+)
+
+func NewSMARTAppResource() resource.Resource {
+	return &SMARTAppResource{}
+}
+
+// SMARTAppResource defines the resource implementation.
+type SMARTAppResource struct {
+	boxResource
+}
+
+// SMARTAppResourceModel describes the resource data model.
+type SMARTAppResourceModel struct {
+	ID          types.String         `tfsdk:"id"`
+	Name        types.String         `tfsdk:"name"`
+	Description types.String         `tfsdk:"description"`
+	LaunchURI   types.String         `tfsdk:"launch_uri"`
+	RedirectURI types.String         `tfsdk:"redirect_uri"`
+	Scopes      types.Set            `tfsdk:"scopes"`
+	JWKSURL     types.String         `tfsdk:"jwks_url"`
+	JWKS        jsontypes.Normalized `tfsdk:"jwks"`
+	AuthMethod  types.String         `tfsdk:"token_endpoint_auth_method"`
+	LogoURL     types.String         `tfsdk:"logo_url"`
+	VersionID   types.String         `tfsdk:"version_id"`
+}
+
+func (r *SMARTAppResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_smart_app"
+}
+
+func (r *SMARTAppResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	httpURL := validURI("http", "https")
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a SMART app, a Client of the box authorized with the authorization code grant and launched from an EHR or standalone. " +
+			"Apps registering public keys with `jwks_url` or `jwks` are confidential clients authenticating with a signed JWT, other apps are public clients required to use PKCE.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the Client, used as the `client_id` of the app. Changing it forces a new app to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validFHIRID(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the app, displayed to users when they authorize it",
+				Required:            true,
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Description of the app, displayed to users when they authorize it",
+				Optional:            true,
+			},
+			"launch_uri": schema.StringAttribute{
+				MarkdownDescription: "URL the EHR opens to launch the app",
+				Required:            true,
+				Validators: []validator.String{
+					httpURL,
+				},
+			},
+			"redirect_uri": schema.StringAttribute{
+				MarkdownDescription: "URL the box redirects to with the authorization code",
+				Required:            true,
+				Validators: []validator.String{
+					httpURL,
+				},
+			},
+			"scopes": schema.SetAttribute{
+				MarkdownDescription: "Scopes the app may request, such as `launch` or `patient/Observation.rs`. The `smart_scope` function builds resource scopes.",
+				ElementType:         types.StringType,
+				Required:            true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(validSMARTScope()),
+				},
+			},
+			"jwks_url": schema.StringAttribute{
+				MarkdownDescription: "URL of the JSON Web Key Set of the app, making it a confidential client authenticating with a JWT signed with one of its keys",
+				Optional:            true,
+				Validators: []validator.String{
+					httpURL,
+					stringvalidator.ConflictsWith(path.MatchRoot("jwks")),
+				},
+			},
+			"jwks": schema.StringAttribute{
+				MarkdownDescription: "JSON Web Key Set of the app, as a JSON document with the public keys of the app, for apps not publishing their keys at a URL. Use the `jsonencode` or `file` functions to build it.",
+				CustomType:          jsontypes.NormalizedType{},
+				Optional:            true,
+			},
+			"token_endpoint_auth_method": schema.StringAttribute{
+				MarkdownDescription: "How the app authenticates to the token endpoint, `none` for public apps or `private_key_jwt` for confidential apps. Defaults to `private_key_jwt` when `jwks_url` or `jwks` is set, `none` otherwise.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(smartAppAuthMethodNone, smartAppAuthMethodPrivateKeyJWT),
+				},
+			},
+			"logo_url": schema.StringAttribute{
+				MarkdownDescription: "URL of the logo of the app, displayed to users when they authorize it",
+				Optional:            true,
+				Validators: []validator.String{
+					httpURL,
+				},
+			},
+			"version_id": schema.StringAttribute{
+				MarkdownDescription: "Version of the Client, incremented by the box on every change",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *SMARTAppResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var model SMARTAppResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() || model.AuthMethod.IsNull() || model.AuthMethod.IsUnknown() || model.JWKSURL.IsUnknown() || model.JWKS.IsUnknown() {
+		return
+	}
+
+	hasKeys := !model.JWKSURL.IsNull() || !model.JWKS.IsNull()
+	switch model.AuthMethod.ValueString() {
+	case smartAppAuthMethodPrivateKeyJWT:
+		if !hasKeys {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("token_endpoint_auth_method"),
+				"Missing Public Keys",
+				"Apps authenticating with private_key_jwt must register their public keys with the jwks_url or jwks attribute.",
+			)
+		}
+	case smartAppAuthMethodNone:
+		if hasKeys {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("token_endpoint_auth_method"),
+				"Unexpected Public Keys",
+				"Public apps, authenticating with none, can't register public keys with the jwks_url or jwks attribute.",
+			)
+		}
+	}
+}
+
+// ModifyPlan derives the token endpoint authentication method not set in the
+// configuration from the registered public keys.
+func (r *SMARTAppResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to derive on destroy.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var config SMARTAppResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() || !config.AuthMethod.IsNull() {
+		return
+	}
+
+	authMethod := types.StringUnknown()
+	if !config.JWKSURL.IsUnknown() && !config.JWKS.IsUnknown() {
+		authMethod = types.StringValue(smartAppAuthMethod(config))
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("token_endpoint_auth_method"), authMethod)...)
+}
+
+func (r *SMARTAppResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var model SMARTAppResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stored, err := r.client.PutResource(ctx, smartAppToResource(model), "")
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostic("Failed to Create SMART App", "Unable to create SMART app", err))
+		return
+	}
+
+	mapSMARTAppFromResource(&model, stored)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *SMARTAppResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var model SMARTAppResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stored, err := r.client.GetResourceIfModified(ctx, smartAppResourceType, model.ID.ValueString(), model.VersionID.ValueString())
+	if aidbox.IsNotModified(err) {
+		return
+	}
+	if aidbox.IsNotFound(err) {
+		tflog.Warn(ctx, "SMART app not found, removing from state", map[string]interface{}{"id": model.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostic("Failed to Fetch SMART App", "Unable to fetch SMART app", err))
+		return
+	}
+
+	mapSMARTAppFromResource(&model, stored)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *SMARTAppResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var model SMARTAppResourceModel
+	var versionID types.String
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("version_id"), &versionID)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stored, err := r.client.PutResource(ctx, smartAppToResource(model), versionID.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(updateErrorDiagnostic("SMART app "+model.ID.ValueString(), "Failed to Update SMART App", "Unable to update SMART app", err))
+		return
+	}
+
+	mapSMARTAppFromResource(&model, stored)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *SMARTAppResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var model SMARTAppResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteResource(ctx, smartAppResourceType, model.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(deleteErrorDiagnostic(
+			"SMART app "+model.ID.ValueString(),
+			"Failed to Delete SMART App",
+			fmt.Sprintf("Error while trying to delete the SMART app with ID %s", model.ID.ValueString()),
+			err,
+		))
+	}
+}
+
+func (r *SMARTAppResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+func smartAppToResource(model SMARTAppResourceModel) aidbox.Resource {
+	authorizationCode := map[string]interface{}{
+		"token_format":  "jwt",
+		"refresh_token": true,
+	}
+	setJSONValue(authorizationCode, "redirect_uri", model.RedirectURI)
+	if smartAppAuthMethod(model) == smartAppAuthMethodPrivateKeyJWT {
+		authorizationCode["secret_required"] = false
+		authorizationCode["token_endpoint_auth_method"] = smartAppAuthMethodPrivateKeyJWT
+	} else {
+		authorizationCode["pkce"] = true
+	}
+
+	smart := map[string]interface{}{}
+	setJSONValue(smart, "name", model.Name)
+	setJSONValue(smart, "description", model.Description)
+	setJSONValue(smart, "launch_uri", model.LaunchURI)
+	setJSONValue(smart, "logo_url", model.LogoURL)
+
+	client := aidbox.Resource{
+		"resourceType": smartAppResourceType,
+		"id":           model.ID.ValueString(),
+		"type":         smartAppClientType,
+		"grant_types":  []interface{}{"authorization_code"},
+		"auth":         map[string]interface{}{"authorization_code": authorizationCode},
+		"smart":        smart,
+	}
+	setJSONValue(client, "scope", model.Scopes)
+	setJSONValue(client, "jwks_uri", model.JWKSURL)
+	setJSONValue(client, "jwks", model.JWKS)
+
+	return client
+}
+
+func mapSMARTAppFromResource(model *SMARTAppResourceModel, stored aidbox.Resource) {
+	smart := jsonObject(stored, "smart")
+	authorizationCode := jsonObject(jsonObject(stored, "auth"), "authorization_code")
+
+	model.ID = types.StringValue(stored.ID())
+	model.Name = jsonStringValue(smart, "name")
+	model.Description = jsonStringValue(smart, "description")
+	model.LaunchURI = jsonStringValue(smart, "launch_uri")
+	model.LogoURL = jsonStringValue(smart, "logo_url")
+	model.RedirectURI = jsonStringValue(authorizationCode, "redirect_uri")
+	model.Scopes = jsonStringSet(stored, "scope")
+	model.JWKSURL = jsonStringValue(stored, "jwks_uri")
+	model.JWKS = jsonNormalizedValue(stored, "jwks")
+	model.AuthMethod = types.StringValue(smartAppAuthMethodNone)
+	if jsonStringValue(authorizationCode, "token_endpoint_auth_method").ValueString() == smartAppAuthMethodPrivateKeyJWT {
+		model.AuthMethod = types.StringValue(smartAppAuthMethodPrivateKeyJWT)
+	}
+	model.VersionID = stringValueOrNull(stored.VersionID())
+}
+
+// smartAppAuthMethod returns the token endpoint authentication method of the
+// app, derived from its public keys when not set.
+func smartAppAuthMethod(model SMARTAppResourceModel) string {
+	if !model.AuthMethod.IsNull() && !model.AuthMethod.IsUnknown() {
+		return model.AuthMethod.ValueString()
+	}
+	if !model.JWKSURL.IsNull() || !model.JWKS.IsNull() {
+		return smartAppAuthMethodPrivateKeyJWT
+	}
+	return smartAppAuthMethodNone
+}
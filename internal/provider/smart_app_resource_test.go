@@ -0,0 +1,68 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccAidboxSMARTAppResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheckBox(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_5_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccAidboxSMARTAppResourceConfig("Growth Chart"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("aidbox_smart_app.test", "id", "tf-acc-growth-chart"),
+					resource.TestCheckResourceAttr("aidbox_smart_app.test", "name", "Growth Chart"),
+					resource.TestCheckResourceAttr("aidbox_smart_app.test", "scopes.#", "2"),
+					resource.TestCheckNoResourceAttr("aidbox_smart_app.test", "jwks_url"),
+					resource.TestCheckResourceAttr("aidbox_smart_app.test", "token_endpoint_auth_method", "none"),
+					resource.TestCheckResourceAttrSet("aidbox_smart_app.test", "version_id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "aidbox_smart_app.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Import block testing, as with terraform plan -generate-config-out
+			{
+				ResourceName:    "aidbox_smart_app.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithID,
+			},
+			// Update and Read testing
+			{
+				Config: testAccAidboxSMARTAppResourceConfig("Pediatric Growth Chart"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("aidbox_smart_app.test", "name", "Pediatric Growth Chart"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccAidboxSMARTAppResourceConfig(name string) string {
+	return fmt.Sprintf(`
+resource "aidbox_smart_app" "test" {
+  id           = "tf-acc-growth-chart"
+  name         = %[1]q
+  launch_uri   = "https://apps.example.com/growth-chart/launch"
+  redirect_uri = "https://apps.example.com/growth-chart/"
+  scopes       = ["launch", "patient/Observation.rs"]
+}
+`, name)
+}
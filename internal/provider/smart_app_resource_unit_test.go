@@ -0,0 +1,84 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox/fake"
+)
+
+func TestSMARTAppResource_clientAuthentication(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewBoxClient()
+
+	model := SMARTAppResourceModel{
+		ID:          types.StringValue("growth-chart"),
+		Name:        types.StringValue("Growth Chart"),
+		Description: types.StringNull(),
+		LaunchURI:   types.StringValue("https://apps.example.com/growth-chart/launch"),
+		RedirectURI: types.StringValue("https://apps.example.com/growth-chart/"),
+		Scopes:      types.SetValueMust(types.StringType, []attr.Value{types.StringValue("launch"), types.StringValue("patient/Observation.rs")}),
+		JWKSURL:     types.StringNull(),
+		LogoURL:     types.StringNull(),
+	}
+
+	testCases := map[string]struct {
+		jwksURL     types.String
+		jwks        jsontypes.Normalized
+		expectPKCE  bool
+		expectJWKS  bool
+		authnMethod interface{}
+	}{
+		"public": {
+			jwksURL:    types.StringNull(),
+			jwks:       jsontypes.NewNormalizedNull(),
+			expectPKCE: true,
+		},
+		"confidential": {
+			jwksURL:     types.StringValue("https://apps.example.com/growth-chart/jwks.json"),
+			jwks:        jsontypes.NewNormalizedNull(),
+			expectJWKS:  true,
+			authnMethod: "private_key_jwt",
+		},
+		"confidential-inline-keys": {
+			jwksURL:     types.StringNull(),
+			jwks:        jsontypes.NewNormalizedValue(`{"keys":[{"kty":"EC","crv":"P-256","kid":"2025","x":"x","y":"y"}]}`),
+			expectJWKS:  true,
+			authnMethod: "private_key_jwt",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			model.JWKSURL = testCase.jwksURL
+			model.JWKS = testCase.jwks
+			stored, err := client.PutResource(ctx, smartAppToResource(model), "")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			authorizationCode := jsonObject(jsonObject(stored, "auth"), "authorization_code")
+			if (authorizationCode["pkce"] == true) != testCase.expectPKCE {
+				t.Errorf("expected PKCE to be required %t, got: %v", testCase.expectPKCE, authorizationCode)
+			}
+			if authorizationCode["token_endpoint_auth_method"] != testCase.authnMethod {
+				t.Errorf("unexpected authentication method: %v", authorizationCode["token_endpoint_auth_method"])
+			}
+
+			var got SMARTAppResourceModel
+			mapSMARTAppFromResource(&got, stored)
+			if (got.JWKSURL.IsNull() && got.JWKS.IsNull()) == testCase.expectJWKS || got.RedirectURI != model.RedirectURI || !got.Scopes.Equal(model.Scopes) {
+				t.Errorf("unexpected model: %+v", got)
+			}
+			if expected := smartAppAuthMethod(model); got.AuthMethod.ValueString() != expected {
+				t.Errorf("expected the %s authentication method, got: %s", expected, got.AuthMethod)
+			}
+		})
+	}
+}
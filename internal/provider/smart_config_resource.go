@@ -0,0 +1,221 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &SMARTConfigResource{}
+var _ resource.ResourceWithImportState = &SMARTConfigResource{}
+
+// The SMART configuration is stored in the AidboxConfig/smart resource.
+const (
+	smartConfigResourceType = "AidboxConfig"
+	smartConfigID           = "smart"
+)
+
+// smartCapabilities lists the capabilities defined by SMART App Launch 2.0.
+var smartCapabilities = []string{
+	"launch-ehr", "launch-standalone", "authorize-post", "client-public",
+	"client-confidential-symmetric", "client-confidential-asymmetric", "sso-openid-connect",
+	"context-banner", "context-style", "context-ehr-patient", "context-ehr-encounter",
+	"context-standalone-patient", "context-standalone-encounter", "permission-offline",
+	"permission-online", "permission-patient", "permission-user", "permission-v1", "permission-v2",
+}
+
+func NewSMARTConfigResource() resource.Resource {
+	return &SMARTConfigResource{}
+}
+
+// SMARTConfigResource defines the resource implementation.
+type SMARTConfigResource struct {
+	boxResource
+}
+
+// SMARTConfigResourceModel describes the resource data model.
+type SMARTConfigResourceModel struct {
+	ID                    types.String `tfsdk:"id"`
+	AuthorizationEndpoint types.String `tfsdk:"authorization_endpoint"`
+	TokenEndpoint         types.String `tfsdk:"token_endpoint"`
+	ScopesSupported       types.Set    `tfsdk:"scopes_supported"`
+	Capabilities          types.Set    `tfsdk:"capabilities"`
+	VersionID             types.String `tfsdk:"version_id"`
+}
+
+func (r *SMARTConfigResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_smart_config"
+}
+
+func (r *SMARTConfigResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the SMART on FHIR configuration a box advertises in its `.well-known/smart-configuration` document, used by SMART apps to discover how to launch and authorize. " +
+			"A box has a single SMART configuration. Destroying the resource restores the defaults of the box.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the AidboxConfig resource holding the SMART configuration, always `smart`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"version_id": schema.StringAttribute{
+				MarkdownDescription: "Version of the SMART configuration, incremented by the box on every change",
+				Computed:            true,
+			},
+			"authorization_endpoint": schema.StringAttribute{
+				MarkdownDescription: "Authorization endpoint advertised to SMART apps, such as the endpoint of an external authorization server. Defaults to the endpoint of the box.",
+				Optional:            true,
+				Validators: []validator.String{
+					validURI("http", "https"),
+				},
+			},
+			"token_endpoint": schema.StringAttribute{
+				MarkdownDescription: "Token endpoint advertised to SMART apps. Defaults to the endpoint of the box.",
+				Optional:            true,
+				Validators: []validator.String{
+					validURI("http", "https"),
+				},
+			},
+			"scopes_supported": schema.SetAttribute{
+				MarkdownDescription: "Scopes advertised to SMART apps, such as `launch/patient` or `patient/Observation.rs`. The `smart_scope` function builds resource scopes.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(validSMARTScope()),
+				},
+			},
+			"capabilities": schema.SetAttribute{
+				MarkdownDescription: "SMART capabilities advertised to SMART apps, such as the `launch-ehr` and `launch-standalone` launch modes, or the `context-ehr-patient` and `context-standalone-patient` launch contexts",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(
+						stringvalidator.OneOf(smartCapabilities...),
+					),
+				},
+			},
+		},
+	}
+}
+
+func (r *SMARTConfigResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var model SMARTConfigResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.checkConfigurationAbsent(ctx, "SMART configuration", "aidbox_smart_config", smartConfigResourceType, smartConfigID)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stored, err := r.client.PutResource(ctx, smartConfigToResource(model), "")
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostic("Failed to Create SMART Configuration", "Unable to configure SMART on FHIR", err))
+		return
+	}
+
+	mapSMARTConfigFromResource(&model, stored)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *SMARTConfigResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var model SMARTConfigResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stored, err := r.client.GetResource(ctx, smartConfigResourceType, smartConfigID)
+	if aidbox.IsNotFound(err) {
+		tflog.Warn(ctx, "SMART configuration not found, removing from state")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostic("Failed to Fetch SMART Configuration", "Unable to fetch the SMART configuration", err))
+		return
+	}
+
+	mapSMARTConfigFromResource(&model, stored)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *SMARTConfigResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var model SMARTConfigResourceModel
+	var versionID types.String
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("version_id"), &versionID)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stored, err := r.client.PutResource(ctx, smartConfigToResource(model), versionID.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(updateErrorDiagnostic("SMART configuration", "Failed to Update SMART Configuration", "Unable to configure SMART on FHIR", err))
+		return
+	}
+
+	mapSMARTConfigFromResource(&model, stored)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *SMARTConfigResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	err := r.client.DeleteResource(ctx, smartConfigResourceType, smartConfigID)
+	if err != nil {
+		resp.Diagnostics.Append(deleteErrorDiagnostic("SMART configuration", "Failed to Delete SMART Configuration", "Unable to restore the default SMART configuration", err))
+	}
+}
+
+func (r *SMARTConfigResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID != smartConfigID {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("The SMART configuration of a box is imported with the ID %q, got: %q", smartConfigID, req.ID),
+		)
+		return
+	}
+
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+func smartConfigToResource(model SMARTConfigResourceModel) aidbox.Resource {
+	smart := map[string]interface{}{}
+	setJSONValue(smart, "authorization-endpoint", model.AuthorizationEndpoint)
+	setJSONValue(smart, "token-endpoint", model.TokenEndpoint)
+	setJSONValue(smart, "scopes-supported", model.ScopesSupported)
+	setJSONValue(smart, "capabilities", model.Capabilities)
+
+	return aidbox.Resource{
+		"resourceType": smartConfigResourceType,
+		"id":           smartConfigID,
+		"smart":        smart,
+	}
+}
+
+func mapSMARTConfigFromResource(model *SMARTConfigResourceModel, stored aidbox.Resource) {
+	smart := jsonObject(stored, "smart")
+
+	model.ID = types.StringValue(smartConfigID)
+	model.VersionID = stringValueOrNull(stored.VersionID())
+	model.AuthorizationEndpoint = jsonStringValue(smart, "authorization-endpoint")
+	model.TokenEndpoint = jsonStringValue(smart, "token-endpoint")
+	model.ScopesSupported = jsonStringSet(smart, "scopes-supported")
+	model.Capabilities = jsonStringSet(smart, "capabilities")
+}
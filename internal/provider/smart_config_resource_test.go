@@ -0,0 +1,63 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccAidboxSMARTConfigResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheckBox(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_5_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccAidboxSMARTConfigResourceConfig(`"launch-ehr"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("aidbox_smart_config.test", "id", "smart"),
+					resource.TestCheckResourceAttr("aidbox_smart_config.test", "scopes_supported.#", "2"),
+					resource.TestCheckTypeSetElemAttr("aidbox_smart_config.test", "scopes_supported.*", "patient/Observation.rs"),
+					resource.TestCheckResourceAttr("aidbox_smart_config.test", "capabilities.#", "1"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "aidbox_smart_config.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Import block testing, as with terraform plan -generate-config-out
+			{
+				ResourceName:    "aidbox_smart_config.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithID,
+			},
+			// Update and Read testing
+			{
+				Config: testAccAidboxSMARTConfigResourceConfig(`"launch-ehr", "launch-standalone"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("aidbox_smart_config.test", "capabilities.#", "2"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccAidboxSMARTConfigResourceConfig(capabilities string) string {
+	return fmt.Sprintf(`
+resource "aidbox_smart_config" "test" {
+  scopes_supported = ["launch/patient", "patient/Observation.rs"]
+  capabilities     = [%[1]s]
+}
+`, capabilities)
+}
@@ -0,0 +1,104 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/petalmd/terraform-provider-aidbox/internal/fhir"
+	"strings"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &SMARTScopeFunction{}
+
+func NewSMARTScopeFunction() function.Function {
+	return &SMARTScopeFunction{}
+}
+
+// SMARTScopeFunction defines the function implementation.
+type SMARTScopeFunction struct{}
+
+func (f *SMARTScopeFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "smart_scope"
+}
+
+func (f *SMARTScopeFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Build a SMART on FHIR scope",
+		MarkdownDescription: "Builds a SMART on FHIR scope such as `patient/Observation.rs`. " +
+			"The context must be one of `patient`, `user` or `system`, the resource type must be a FHIR R4 resource type or `*`, " +
+			"and the permissions must be SMART v2 letters in `cruds` order or one of the SMART v1 values `read`, `write` and `*`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "context",
+				MarkdownDescription: "Scope context: `patient`, `user` or `system`",
+			},
+			function.StringParameter{
+				Name:                "resource_type",
+				MarkdownDescription: "FHIR resource type, or `*` for all types",
+			},
+			function.StringParameter{
+				Name:                "permissions",
+				MarkdownDescription: "Permissions, such as `rs` or `cruds`",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *SMARTScopeFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var scopeContext, resourceType, permissions string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &scopeContext, &resourceType, &permissions))
+	if resp.Error != nil {
+		return
+	}
+
+	switch scopeContext {
+	case "patient", "user", "system":
+	default:
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("context must be one of patient, user or system, got %q", scopeContext))
+		return
+	}
+
+	if resourceType != "*" && !fhir.IsResourceType(resourceType) {
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("%q is not a FHIR resource type", resourceType))
+		return
+	}
+
+	if err := validateSMARTPermissions(permissions); err != nil {
+		resp.Error = function.NewArgumentFuncError(2, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, fmt.Sprintf("%s/%s.%s", scopeContext, resourceType, permissions)))
+}
+
+// validateSMARTPermissions accepts SMART v1 permissions and SMART v2
+// permission letters, which must appear at most once and in `cruds` order.
+func validateSMARTPermissions(permissions string) error {
+	switch permissions {
+	case "read", "write", "*":
+		return nil
+	case "":
+		return fmt.Errorf("permissions must not be empty")
+	}
+
+	const order = "cruds"
+	last := -1
+	for _, letter := range permissions {
+		position := strings.IndexRune(order, letter)
+		if position == -1 {
+			return fmt.Errorf("invalid permission %q, expected letters from %q, read, write or *", letter, order)
+		}
+		if position <= last {
+			return fmt.Errorf("permissions %q must list each letter once, in %q order", permissions, order)
+		}
+		last = position
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccSMARTScopeFunction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "v2" {
+  value = provider::aidbox::smart_scope("patient", "Observation", "rs")
+}
+
+output "v1" {
+  value = provider::aidbox::smart_scope("system", "*", "read")
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("v2", "patient/Observation.rs"),
+					resource.TestCheckOutput("v1", "system/*.read"),
+				),
+			},
+			{
+				Config: `
+output "test" {
+  value = provider::aidbox::smart_scope("patient", "Observations", "rs")
+}
+`,
+				ExpectError: regexp.MustCompile(`"Observations" is not a FHIR resource type`),
+			},
+			{
+				Config: `
+output "test" {
+  value = provider::aidbox::smart_scope("patient", "Observation", "sr")
+}
+`,
+				ExpectError: regexp.MustCompile(`must list each letter once, in "cruds" order`),
+			},
+		},
+	})
+}
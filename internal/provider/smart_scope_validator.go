@@ -0,0 +1,84 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/petalmd/terraform-provider-aidbox/internal/fhir"
+	"strings"
+)
+
+var _ validator.String = smartScopeValidator{}
+
+// smartLaunchScopes lists the SMART on FHIR scopes not granting access to
+// resources.
+var smartLaunchScopes = []string{
+	"openid", "fhirUser", "profile", "launch", "launch/patient", "launch/encounter",
+	"offline_access", "online_access",
+}
+
+// smartScopeValidator validates that a string attribute is a SMART on FHIR
+// scope, such as `patient/Observation.rs` or `launch/patient`.
+type smartScopeValidator struct{}
+
+// validSMARTScope returns a validator for SMART on FHIR scopes.
+func validSMARTScope() validator.String {
+	return smartScopeValidator{}
+}
+
+func (v smartScopeValidator) Description(ctx context.Context) string {
+	return "value must be a SMART on FHIR scope, such as `patient/Observation.rs` or `launch/patient`"
+}
+
+func (v smartScopeValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v smartScopeValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if err := validateSMARTScope(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid SMART Scope",
+			fmt.Sprintf("The value must be a SMART on FHIR scope: %s", err),
+		)
+	}
+}
+
+// validateSMARTScope accepts the SMART launch scopes and resource scopes
+// built as the smart_scope function does, optionally followed by the query
+// parameters SMART v2 allows.
+func validateSMARTScope(scope string) error {
+	for _, launchScope := range smartLaunchScopes {
+		if scope == launchScope {
+			return nil
+		}
+	}
+
+	scopeContext, rest, ok := strings.Cut(scope, "/")
+	if !ok {
+		return fmt.Errorf("%q is neither a launch scope nor a resource scope", scope)
+	}
+	switch scopeContext {
+	case "patient", "user", "system":
+	default:
+		return fmt.Errorf("context must be one of patient, user or system, got %q", scopeContext)
+	}
+
+	rest, _, _ = strings.Cut(rest, "?")
+	resourceType, permissions, ok := strings.Cut(rest, ".")
+	if !ok {
+		return fmt.Errorf("%q must have permissions after the resource type, such as %s/%s.rs", scope, scopeContext, resourceType)
+	}
+	if resourceType != "*" && !fhir.IsResourceType(resourceType) {
+		return fmt.Errorf("%q is not a FHIR resource type", resourceType)
+	}
+
+	return validateSMARTPermissions(permissions)
+}
@@ -0,0 +1,46 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestSMARTScopeValidator(t *testing.T) {
+	testCases := map[string]struct {
+		value       types.String
+		expectError bool
+	}{
+		"launch":           {value: types.StringValue("launch/patient")},
+		"v2":               {value: types.StringValue("patient/Observation.rs")},
+		"v2 query":         {value: types.StringValue("patient/Observation.rs?category=laboratory")},
+		"v1":               {value: types.StringValue("user/*.read")},
+		"null":             {value: types.StringNull()},
+		"unknown":          {value: types.StringUnknown()},
+		"unknown scope":    {value: types.StringValue("admin"), expectError: true},
+		"invalid context":  {value: types.StringValue("admin/Patient.rs"), expectError: true},
+		"no permissions":   {value: types.StringValue("patient/Observation"), expectError: true},
+		"invalid resource": {value: types.StringValue("patient/Observations.rs"), expectError: true},
+		"invalid order":    {value: types.StringValue("patient/Observation.sr"), expectError: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resp := &validator.StringResponse{}
+			validSMARTScope().ValidateString(context.Background(), validator.StringRequest{
+				Path:        path.Root("scopes"),
+				ConfigValue: testCase.value,
+			}, resp)
+
+			if resp.Diagnostics.HasError() != testCase.expectError {
+				t.Errorf("expected error %t, got: %v", testCase.expectError, resp.Diagnostics)
+			}
+		})
+	}
+}
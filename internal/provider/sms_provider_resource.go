@@ -0,0 +1,284 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &SMSProviderResource{}
+var _ resource.ResourceWithImportState = &SMSProviderResource{}
+
+// The SMS provider is stored in the AidboxConfig/sms-provider resource.
+const (
+	smsProviderResourceType = "AidboxConfig"
+	smsProviderID           = "sms-provider"
+)
+
+func NewSMSProviderResource() resource.Resource {
+	return &SMSProviderResource{}
+}
+
+// SMSProviderResource defines the resource implementation.
+type SMSProviderResource struct {
+	boxResource
+}
+
+// SMSProviderResourceModel describes the resource data model.
+type SMSProviderResourceModel struct {
+	ID        types.String            `tfsdk:"id"`
+	Twilio    *SMSProviderTwilioModel `tfsdk:"twilio"`
+	VersionID types.String            `tfsdk:"version_id"`
+}
+
+// SMSProviderTwilioModel describes the twilio block.
+type SMSProviderTwilioModel struct {
+	AccountSID         types.String `tfsdk:"account_sid"`
+	From               types.String `tfsdk:"from"`
+	AuthToken          types.String `tfsdk:"auth_token"`
+	AuthTokenWO        types.String `tfsdk:"auth_token_wo"`
+	AuthTokenWOVersion types.Int64  `tfsdk:"auth_token_wo_version"`
+}
+
+func (r *SMSProviderResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_sms_provider"
+}
+
+func (r *SMSProviderResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the SMS provider a box uses to send two-factor authentication codes and notifications. A box has a single SMS provider.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the AidboxConfig resource holding the SMS provider, always `sms-provider`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"version_id": schema.StringAttribute{
+				MarkdownDescription: "Version of the SMS provider, incremented by the box on every change",
+				Computed:            true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"twilio": schema.SingleNestedBlock{
+				MarkdownDescription: "Send SMS through Twilio. Required.",
+				Attributes: map[string]schema.Attribute{
+					"account_sid": schema.StringAttribute{
+						MarkdownDescription: "Twilio account SID. Required in this block.",
+						Optional:            true,
+					},
+					"from": schema.StringAttribute{
+						MarkdownDescription: "Phone number, in E.164 format, or messaging service SID the SMS are sent from. Required in this block.",
+						Optional:            true,
+					},
+					"auth_token": schema.StringAttribute{
+						MarkdownDescription: "Twilio auth token, stored in the Terraform state. Prefer `auth_token_wo` with Terraform 1.11 and later. Exactly one of `auth_token` or `auth_token_wo` must be set.",
+						Optional:            true,
+						Sensitive:           true,
+					},
+					"auth_token_wo": schema.StringAttribute{
+						MarkdownDescription: "Twilio auth token, never stored in the Terraform state. Requires Terraform 1.11 or later. Increment `auth_token_wo_version` to update the token.",
+						Optional:            true,
+						Sensitive:           true,
+						WriteOnly:           true,
+						Validators: []validator.String{
+							stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("auth_token")),
+						},
+					},
+					"auth_token_wo_version": schema.Int64Attribute{
+						MarkdownDescription: "Version of `auth_token_wo`. As write-only values are not stored in state, changing this version is what triggers an update of the token.",
+						Optional:            true,
+						Validators: []validator.Int64{
+							int64validator.AlsoRequires(path.MatchRelative().AtParent().AtName("auth_token_wo")),
+						},
+					},
+				},
+				Validators: []validator.Object{
+					objectvalidator.IsRequired(),
+					objectvalidator.AlsoRequires(
+						path.MatchRelative().AtName("account_sid"),
+						path.MatchRelative().AtName("from"),
+					),
+					objectvalidator.AtLeastOneOf(
+						path.MatchRelative().AtName("auth_token"),
+						path.MatchRelative().AtName("auth_token_wo"),
+					),
+				},
+			},
+		},
+	}
+}
+
+func (r *SMSProviderResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var model SMSProviderResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.checkConfigurationAbsent(ctx, "SMS provider", "aidbox_sms_provider", smsProviderResourceType, smsProviderID)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	authToken, diags := smsProviderAuthToken(ctx, req.Config, model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stored, err := r.client.PutResource(ctx, smsProviderToResource(model, authToken), "")
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostic("Failed to Create SMS Provider", "Unable to configure the SMS provider", err))
+		return
+	}
+
+	mapSMSProviderFromResource(&model, stored)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *SMSProviderResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var model SMSProviderResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stored, err := r.client.GetResource(ctx, smsProviderResourceType, smsProviderID)
+	if aidbox.IsNotFound(err) {
+		tflog.Warn(ctx, "SMS provider not found, removing from state")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostic("Failed to Fetch SMS Provider", "Unable to fetch the SMS provider", err))
+		return
+	}
+
+	mapSMSProviderFromResource(&model, stored)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *SMSProviderResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var model SMSProviderResourceModel
+	var versionID types.String
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("version_id"), &versionID)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	authToken, diags := smsProviderAuthToken(ctx, req.Config, model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stored, err := r.client.PutResource(ctx, smsProviderToResource(model, authToken), versionID.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(updateErrorDiagnostic("SMS provider", "Failed to Update SMS Provider", "Unable to configure the SMS provider", err))
+		return
+	}
+
+	mapSMSProviderFromResource(&model, stored)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *SMSProviderResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	err := r.client.DeleteResource(ctx, smsProviderResourceType, smsProviderID)
+	if err != nil {
+		resp.Diagnostics.Append(deleteErrorDiagnostic("SMS provider", "Failed to Delete SMS Provider", "Unable to remove the SMS provider", err))
+	}
+}
+
+func (r *SMSProviderResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID != smsProviderID {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("The SMS provider of a box is imported with the ID %q, got: %q", smsProviderID, req.ID),
+		)
+		return
+	}
+
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// smsProviderAuthToken returns the configured auth token. Write-only values
+// are only available in the configuration, never in the plan.
+func smsProviderAuthToken(ctx context.Context, config tfsdk.Config, model SMSProviderResourceModel) (types.String, diag.Diagnostics) {
+	if model.Twilio == nil || !model.Twilio.AuthToken.IsNull() {
+		var authToken types.String
+		if model.Twilio != nil {
+			authToken = model.Twilio.AuthToken
+		}
+		return authToken, nil
+	}
+
+	var authTokenWO types.String
+	diags := config.GetAttribute(ctx, path.Root("twilio").AtName("auth_token_wo"), &authTokenWO)
+	return authTokenWO, diags
+}
+
+func smsProviderToResource(model SMSProviderResourceModel, authToken types.String) aidbox.Resource {
+	sms := map[string]interface{}{}
+	if model.Twilio != nil {
+		sms["type"] = "twilio"
+		setJSONValue(sms, "account-sid", model.Twilio.AccountSID)
+		setJSONValue(sms, "from", model.Twilio.From)
+		setJSONValue(sms, "auth-token", authToken)
+	}
+
+	return aidbox.Resource{
+		"resourceType": smsProviderResourceType,
+		"id":           smsProviderID,
+		"sms":          sms,
+	}
+}
+
+// mapSMSProviderFromResource maps the AidboxConfig resource stored by the box
+// to model. The auth token is only read back when it is stored in state.
+func mapSMSProviderFromResource(model *SMSProviderResourceModel, stored aidbox.Resource) {
+	sms := jsonObject(stored, "sms")
+
+	model.ID = types.StringValue(smsProviderID)
+	model.VersionID = stringValueOrNull(stored.VersionID())
+	if jsonStringValue(sms, "type").ValueString() != "twilio" {
+		model.Twilio = nil
+		return
+	}
+
+	prior := model.Twilio
+	if prior == nil {
+		prior = &SMSProviderTwilioModel{}
+	}
+
+	twilio := &SMSProviderTwilioModel{
+		AccountSID:         jsonStringValue(sms, "account-sid"),
+		From:               jsonStringValue(sms, "from"),
+		AuthToken:          types.StringNull(),
+		AuthTokenWO:        types.StringNull(),
+		AuthTokenWOVersion: prior.AuthTokenWOVersion,
+	}
+	if prior.AuthTokenWOVersion.IsNull() {
+		twilio.AuthToken = secretValueOrPrior(sms, "auth-token", prior.AuthToken)
+	}
+	model.Twilio = twilio
+}
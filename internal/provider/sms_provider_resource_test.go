@@ -0,0 +1,106 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccAidboxSMSProviderResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheckBox(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_5_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccAidboxSMSProviderResourceConfig("+15550100"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("aidbox_sms_provider.test", "id", "sms-provider"),
+					resource.TestCheckResourceAttr("aidbox_sms_provider.test", "twilio.from", "+15550100"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "aidbox_sms_provider.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"twilio.auth_token"},
+			},
+			// Import block testing, as with terraform plan -generate-config-out
+			{
+				// The Twilio auth token is not read back from the box
+				ResourceName:       "aidbox_sms_provider.test",
+				ImportState:        true,
+				ImportStateKind:    resource.ImportBlockWithID,
+				ExpectNonEmptyPlan: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccAidboxSMSProviderResourceConfig("+15550101"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("aidbox_sms_provider.test", "twilio.from", "+15550101"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccAidboxSMSProviderResource_writeOnly(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheckBox(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAidboxSMSProviderResourceConfigWriteOnly(1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("aidbox_sms_provider.test", "twilio.auth_token"),
+					resource.TestCheckNoResourceAttr("aidbox_sms_provider.test", "twilio.auth_token_wo"),
+					resource.TestCheckResourceAttr("aidbox_sms_provider.test", "twilio.auth_token_wo_version", "1"),
+				),
+			},
+			{
+				Config: testAccAidboxSMSProviderResourceConfigWriteOnly(2),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("aidbox_sms_provider.test", "twilio.auth_token_wo_version", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccAidboxSMSProviderResourceConfig(from string) string {
+	return fmt.Sprintf(`
+resource "aidbox_sms_provider" "test" {
+  twilio {
+    account_sid = "ACtfacc"
+    from        = %[1]q
+    auth_token  = "tf-acc-token"
+  }
+}
+`, from)
+}
+
+func testAccAidboxSMSProviderResourceConfigWriteOnly(version int) string {
+	return fmt.Sprintf(`
+resource "aidbox_sms_provider" "test" {
+  twilio {
+    account_sid           = "ACtfacc"
+    from                  = "+15550100"
+    auth_token_wo         = "tf-acc-token-%[1]d"
+    auth_token_wo_version = %[1]d
+  }
+}
+`, version)
+}
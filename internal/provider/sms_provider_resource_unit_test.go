@@ -0,0 +1,69 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox/fake"
+)
+
+func TestSMSProviderResource_writeOnlyAuthToken(t *testing.T) {
+	ctx := context.Background()
+	r := &SMSProviderResource{}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	model := SMSProviderResourceModel{
+		ID: types.StringUnknown(),
+		Twilio: &SMSProviderTwilioModel{
+			AccountSID:         types.StringValue("AC123"),
+			From:               types.StringValue("+15550100"),
+			AuthToken:          types.StringNull(),
+			AuthTokenWO:        types.StringValue("token"),
+			AuthTokenWOVersion: types.Int64Value(1),
+		},
+	}
+	plan := tfsdk.Plan{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	if diags := plan.Set(ctx, &model); diags.HasError() {
+		t.Fatalf("unable to build config: %v", diags)
+	}
+	config := tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw}
+
+	// Write-only values are null in the plan
+	model.Twilio.AuthTokenWO = types.StringNull()
+	authToken, diags := smsProviderAuthToken(ctx, config, model)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if authToken.ValueString() != "token" {
+		t.Fatalf("expected the write-only auth token, got: %s", authToken)
+	}
+
+	client := fake.NewBoxClient()
+	stored, err := client.PutResource(ctx, smsProviderToResource(model, authToken), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if jsonObject(stored, "sms")["auth-token"] != "token" {
+		t.Errorf("expected the auth token to be sent to the box, got: %v", stored)
+	}
+
+	mapSMSProviderFromResource(&model, stored)
+	if !model.Twilio.AuthToken.IsNull() || !model.Twilio.AuthTokenWO.IsNull() {
+		t.Errorf("expected no auth token in state, got: %+v", model.Twilio)
+	}
+	if model.Twilio.AuthTokenWOVersion.ValueInt64() != 1 || model.Twilio.AccountSID.ValueString() != "AC123" {
+		t.Errorf("unexpected model: %+v", model.Twilio)
+	}
+}
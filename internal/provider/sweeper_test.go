@@ -0,0 +1,88 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox/aidboxtest"
+)
+
+// testAccResourcePrefix prefixes the names of resources created by
+// acceptance tests, so that sweepers can find them.
+const testAccResourcePrefix = "tf-acc-"
+
+func TestMain(m *testing.M) {
+	resource.TestMain(m)
+}
+
+func init() {
+	resource.AddTestSweepers("aidbox_license", &resource.Sweeper{
+		Name: "aidbox_license",
+		F:    sweepLicenses,
+	})
+}
+
+// sweepLicenses deletes licenses left over by failed acceptance test runs.
+func sweepLicenses(_ string) error {
+	ctx := context.Background()
+	client := testAccClient()
+
+	licenses, err := client.ListLicenses(ctx)
+	if err != nil {
+		return fmt.Errorf("error listing licenses: %w", err)
+	}
+
+	var errs []string
+	for _, license := range licenses {
+		if !strings.HasPrefix(license.Name, testAccResourcePrefix) {
+			continue
+		}
+
+		if err := client.DeleteLicense(ctx, license.ID); err != nil {
+			errs = append(errs, fmt.Sprintf("error deleting license %s (%s): %s", license.Name, license.ID, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "\n"))
+	}
+
+	return nil
+}
+
+func TestSweepLicenses(t *testing.T) {
+	server := aidboxtest.NewPortalServer("token")
+	defer server.Close()
+
+	t.Setenv("AIDBOX_ENDPOINT", server.Endpoint())
+	t.Setenv("AIDBOX_API_TOKEN", server.Token)
+
+	ctx := context.Background()
+	client := testAccClient()
+
+	stray, err := client.CreateLicense(ctx, testAccResourcePrefix+"stray", "aidbox", "development")
+	if err != nil {
+		t.Fatal(err)
+	}
+	kept, err := client.CreateLicense(ctx, "production", "aidbox", "standard")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := sweepLicenses(""); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if _, ok := server.License(stray.License.ID); ok {
+		t.Error("expected the acceptance test license to be deleted")
+	}
+	if _, ok := server.License(kept.License.ID); !ok {
+		t.Error("expected other licenses to be kept")
+	}
+}
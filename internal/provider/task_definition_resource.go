@@ -0,0 +1,190 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox"
+	"regexp"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &TaskDefinitionResource{}
+var _ resource.ResourceWithImportState = &TaskDefinitionResource{}
+
+const taskDefinitionResourceType = "AidboxTaskDefinition"
+
+func NewTaskDefinitionResource() resource.Resource {
+	return &TaskDefinitionResource{}
+}
+
+// TaskDefinitionResource defines the resource implementation.
+type TaskDefinitionResource struct {
+	boxResource
+}
+
+// TaskDefinitionResourceModel describes the resource data model.
+type TaskDefinitionResourceModel struct {
+	ID           types.String         `tfsdk:"id"`
+	Name         types.String         `tfsdk:"name"`
+	ParamsSchema jsontypes.Normalized `tfsdk:"params_schema"`
+	Executor     types.String         `tfsdk:"executor"`
+	VersionID    types.String         `tfsdk:"version_id"`
+}
+
+func (r *TaskDefinitionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_task_definition"
+}
+
+func (r *TaskDefinitionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a task definition of the Aidbox workflow engine. Workflows reference tasks by name, and the box dispatches their runs to the executor the task is bound to.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the task definition. Changing it forces a new task definition to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validFHIRID(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Qualified name workflows reference the task by, such as `notifications/send-email`",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^[a-zA-Z][\w.-]*/[a-zA-Z][\w.-]*$`), "must be a qualified name, such as notifications/send-email"),
+				},
+			},
+			"params_schema": schema.StringAttribute{
+				MarkdownDescription: "JSON schema the parameters of the task runs are validated against, as a JSON document. Use the `jsonencode` function or the `edn_to_json` provider function to build it.",
+				CustomType:          jsontypes.NormalizedType{},
+				Optional:            true,
+			},
+			"executor": schema.StringAttribute{
+				MarkdownDescription: "Executor the task is bound to, such as the ID of the application polling the task runs",
+				Required:            true,
+			},
+			"version_id": schema.StringAttribute{
+				MarkdownDescription: "Version of the task definition, incremented by the box on every change",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *TaskDefinitionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var model TaskDefinitionResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stored, err := r.client.PutResource(ctx, taskDefinitionToResource(model), "")
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostic("Failed to Create Task Definition", "Unable to create task definition", err))
+		return
+	}
+
+	mapTaskDefinitionFromResource(&model, stored)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *TaskDefinitionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var model TaskDefinitionResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stored, err := r.client.GetResourceIfModified(ctx, taskDefinitionResourceType, model.ID.ValueString(), model.VersionID.ValueString())
+	if aidbox.IsNotModified(err) {
+		return
+	}
+	if aidbox.IsNotFound(err) {
+		tflog.Warn(ctx, "Task definition not found, removing from state", map[string]interface{}{"id": model.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostic("Failed to Fetch Task Definition", "Unable to fetch task definition", err))
+		return
+	}
+
+	mapTaskDefinitionFromResource(&model, stored)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *TaskDefinitionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var model TaskDefinitionResourceModel
+	var versionID types.String
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("version_id"), &versionID)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stored, err := r.client.PutResource(ctx, taskDefinitionToResource(model), versionID.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(updateErrorDiagnostic("task definition "+model.ID.ValueString(), "Failed to Update Task Definition", "Unable to update task definition", err))
+		return
+	}
+
+	mapTaskDefinitionFromResource(&model, stored)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *TaskDefinitionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var model TaskDefinitionResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteResource(ctx, taskDefinitionResourceType, model.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(deleteErrorDiagnostic(
+			"task definition "+model.ID.ValueString(),
+			"Failed to Delete Task Definition",
+			fmt.Sprintf("Error while trying to delete the task definition with ID %s", model.ID.ValueString()),
+			err,
+		))
+	}
+}
+
+func (r *TaskDefinitionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+func taskDefinitionToResource(model TaskDefinitionResourceModel) aidbox.Resource {
+	definition := aidbox.Resource{
+		"resourceType": taskDefinitionResourceType,
+		"id":           model.ID.ValueString(),
+	}
+	setJSONValue(definition, "name", model.Name)
+	setJSONValue(definition, "params-schema", model.ParamsSchema)
+	setJSONValue(definition, "executor", model.Executor)
+
+	return definition
+}
+
+func mapTaskDefinitionFromResource(model *TaskDefinitionResourceModel, stored aidbox.Resource) {
+	model.ID = types.StringValue(stored.ID())
+	model.Name = jsonStringValue(stored, "name")
+	model.ParamsSchema = jsonNormalizedValue(stored, "params-schema")
+	model.Executor = jsonStringValue(stored, "executor")
+	model.VersionID = stringValueOrNull(stored.VersionID())
+}
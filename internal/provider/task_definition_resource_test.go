@@ -0,0 +1,69 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccAidboxTaskDefinitionResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheckBox(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_5_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccAidboxTaskDefinitionResourceConfig("notification-worker"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("aidbox_task_definition.test", "id", "tf-acc-send-email"),
+					resource.TestCheckResourceAttr("aidbox_task_definition.test", "executor", "notification-worker"),
+					resource.TestCheckResourceAttrSet("aidbox_task_definition.test", "params_schema"),
+					resource.TestCheckResourceAttrSet("aidbox_task_definition.test", "version_id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "aidbox_task_definition.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Import block testing, as with terraform plan -generate-config-out
+			{
+				ResourceName:    "aidbox_task_definition.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithID,
+			},
+			// Update and Read testing
+			{
+				Config: testAccAidboxTaskDefinitionResourceConfig("email-worker"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("aidbox_task_definition.test", "executor", "email-worker"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccAidboxTaskDefinitionResourceConfig(executor string) string {
+	return fmt.Sprintf(`
+resource "aidbox_task_definition" "test" {
+  id       = "tf-acc-send-email"
+  name     = "tf-acc/send-email"
+  executor = %[1]q
+
+  params_schema = jsonencode({
+    type       = "object"
+    properties = { to = { type = "string" } }
+  })
+}
+`, executor)
+}
@@ -0,0 +1,51 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/petalmd/terraform-provider-aidbox/internal/fhir"
+	"strings"
+)
+
+var _ validator.String = uriValidator{}
+
+// uriValidator validates that a string attribute is an absolute URI,
+// optionally with one of the given schemes.
+type uriValidator struct {
+	schemes []string
+}
+
+// validURI returns a validator for URI and URL attributes, accepting any
+// scheme when none is given.
+func validURI(schemes ...string) validator.String {
+	return uriValidator{schemes: schemes}
+}
+
+func (v uriValidator) Description(ctx context.Context) string {
+	if len(v.schemes) == 0 {
+		return "value must be an absolute URI"
+	}
+	return fmt.Sprintf("value must be an absolute %s URI", strings.Join(v.schemes, " or "))
+}
+
+func (v uriValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v uriValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if err := fhir.ValidateURI(req.ConfigValue.ValueString(), v.schemes...); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid URI",
+			fmt.Sprintf("The value %q is not a valid URI: %s", req.ConfigValue.ValueString(), err),
+		)
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestURIValidator(t *testing.T) {
+	testCases := map[string]struct {
+		value       types.String
+		expectError bool
+	}{
+		"valid":      {value: types.StringValue("https://box.example.com/callback?x=1")},
+		"http":       {value: types.StringValue("http://localhost:8080")},
+		"null":       {value: types.StringNull()},
+		"unknown":    {value: types.StringUnknown()},
+		"relative":   {value: types.StringValue("/callback"), expectError: true},
+		"no host":    {value: types.StringValue("https:///callback"), expectError: true},
+		"scheme":     {value: types.StringValue("ftp://box.example.com"), expectError: true},
+		"whitespace": {value: types.StringValue("https://box.example.com/a b"), expectError: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resp := &validator.StringResponse{}
+			validURI("http", "https").ValidateString(context.Background(), validator.StringRequest{
+				Path:        path.Root("url"),
+				ConfigValue: testCase.value,
+			}, resp)
+
+			if resp.Diagnostics.HasError() != testCase.expectError {
+				t.Errorf("expected error %t, got: %v", testCase.expectError, resp.Diagnostics)
+			}
+		})
+	}
+}
@@ -0,0 +1,196 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox"
+	"regexp"
+	"sort"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &UsersDataSource{}
+var _ datasource.DataSourceWithConfigure = &UsersDataSource{}
+var _ datasource.DataSourceWithValidateConfig = &UsersDataSource{}
+
+const (
+	userResourceType = "User"
+	roleResourceType = "Role"
+)
+
+func NewUsersDataSource() datasource.DataSource {
+	return &UsersDataSource{}
+}
+
+// UsersDataSource defines the data source implementation.
+type UsersDataSource struct {
+	boxDataSource
+}
+
+// UsersDataSourceModel describes the data source data model.
+type UsersDataSourceModel struct {
+	EmailRegex types.String               `tfsdk:"email_regex"`
+	Role       types.String               `tfsdk:"role"`
+	Active     types.Bool                 `tfsdk:"active"`
+	PageSize   types.Int64                `tfsdk:"page_size"`
+	MaxItems   types.Int64                `tfsdk:"max_items"`
+	Users      []UsersDataSourceUserModel `tfsdk:"users"`
+}
+
+// UsersDataSourceUserModel describes a user of the users attribute.
+type UsersDataSourceUserModel struct {
+	ID     types.String `tfsdk:"id"`
+	Email  types.String `tfsdk:"email"`
+	Active types.Bool   `tfsdk:"active"`
+	Roles  types.Set    `tfsdk:"roles"`
+}
+
+func (d *UsersDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_users"
+}
+
+func (d *UsersDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the Users of the box matching all the given filters, such as to audit who has a role. Without filters, all the users are listed.",
+		Attributes: map[string]schema.Attribute{
+			"email_regex": schema.StringAttribute{
+				MarkdownDescription: "Regular expression, in the [RE2 syntax](https://github.com/google/re2/wiki/Syntax), the email of the users must match, such as `@example\\.com$`",
+				Optional:            true,
+			},
+			"role": schema.StringAttribute{
+				MarkdownDescription: "Name of a Role the users must have",
+				Optional:            true,
+			},
+			"active": schema.BoolAttribute{
+				MarkdownDescription: "Whether to list only the active, or only the inactive, users",
+				Optional:            true,
+			},
+			"page_size": pageSizeAttribute(),
+			"max_items": maxItemsAttribute("users"),
+			"users": schema.ListAttribute{
+				MarkdownDescription: "Users matching the filters, ordered by ID. Each user has an `id`, an `email`, whether it is `active` and the names of its `roles`.",
+				ElementType: types.ObjectType{
+					AttrTypes: map[string]attr.Type{
+						"id":     types.StringType,
+						"email":  types.StringType,
+						"active": types.BoolType,
+						"roles":  types.SetType{ElemType: types.StringType},
+					},
+				},
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (d *UsersDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var emailRegex types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("email_regex"), &emailRegex)...)
+	if resp.Diagnostics.HasError() || emailRegex.IsNull() || emailRegex.IsUnknown() {
+		return
+	}
+
+	if _, err := regexp.Compile(emailRegex.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("email_regex"), "Invalid Regular Expression", err.Error())
+	}
+}
+
+func (d *UsersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model UsersDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The users are filtered after the search, so max_items can only limit
+	// the search itself without filters.
+	limit := searchLimit(model.MaxItems)
+	if !model.EmailRegex.IsNull() || !model.Role.IsNull() || !model.Active.IsNull() {
+		limit = 0
+	}
+
+	users, err := d.client.SearchResources(ctx, userResourceType, searchParams(model.PageSize), limit)
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostic("Failed to List Users", "Unable to search users", err))
+		return
+	}
+
+	roles, err := d.client.SearchResources(ctx, roleResourceType, searchParams(model.PageSize), 0)
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostic("Failed to List Users", "Unable to search roles", err))
+		return
+	}
+
+	filtered, err := filterUsers(model, users, roles)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("email_regex"), "Invalid Regular Expression", err.Error())
+		return
+	}
+
+	model.Users = limitItems(filtered, model.MaxItems)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+// filterUsers returns the users matching the filters of model, ordered by
+// ID, with the names of the roles granted to them.
+func filterUsers(model UsersDataSourceModel, users, roles []aidbox.Resource) ([]UsersDataSourceUserModel, error) {
+	var emailRegex *regexp.Regexp
+	if !model.EmailRegex.IsNull() {
+		var err error
+		if emailRegex, err = regexp.Compile(model.EmailRegex.ValueString()); err != nil {
+			return nil, err
+		}
+	}
+
+	userRoles := map[string]map[string]bool{}
+	for _, role := range roles {
+		userID, _ := jsonObject(role, "user")["id"].(string)
+		name, _ := role["name"].(string)
+		if userID == "" || name == "" {
+			continue
+		}
+		if userRoles[userID] == nil {
+			userRoles[userID] = map[string]bool{}
+		}
+		userRoles[userID][name] = true
+	}
+
+	filtered := []UsersDataSourceUserModel{}
+	for _, user := range users {
+		email := jsonStringValue(user, "email")
+		active := !jsonBoolValue(user, "inactive").ValueBool()
+
+		if emailRegex != nil && !emailRegex.MatchString(email.ValueString()) {
+			continue
+		}
+		if !model.Active.IsNull() && model.Active.ValueBool() != active {
+			continue
+		}
+		if !model.Role.IsNull() && !userRoles[user.ID()][model.Role.ValueString()] {
+			continue
+		}
+
+		roles := make([]attr.Value, 0, len(userRoles[user.ID()]))
+		for name := range userRoles[user.ID()] {
+			roles = append(roles, types.StringValue(name))
+		}
+		filtered = append(filtered, UsersDataSourceUserModel{
+			ID:     types.StringValue(user.ID()),
+			Email:  email,
+			Active: types.BoolValue(active),
+			Roles:  types.SetValueMust(types.StringType, roles),
+		})
+	}
+
+	sort.Slice(filtered, func(i, j int) bool {
+		return filtered[i].ID.ValueString() < filtered[j].ID.ValueString()
+	})
+	return filtered, nil
+}
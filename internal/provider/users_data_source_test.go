@@ -0,0 +1,42 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccAidboxUsersDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckBox(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccAidboxUsersDataSourceConfig(`^tf-acc-nobody@`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.aidbox_users.test", "users.#", "0"),
+				),
+			},
+			// Validation testing
+			{
+				Config:      testAccAidboxUsersDataSourceConfig(`(`),
+				ExpectError: regexp.MustCompile(`Invalid Regular Expression`),
+			},
+		},
+	})
+}
+
+func testAccAidboxUsersDataSourceConfig(emailRegex string) string {
+	return fmt.Sprintf(`
+data "aidbox_users" "test" {
+  email_regex = %[1]q
+  active      = true
+}
+`, emailRegex)
+}
@@ -0,0 +1,90 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox/fake"
+)
+
+func TestFilterUsers(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewBoxClient()
+	for _, resource := range []aidbox.Resource{
+		{"resourceType": "User", "id": "carol", "email": "carol@example.com", "inactive": true},
+		{"resourceType": "User", "id": "alice", "email": "alice@example.com"},
+		{"resourceType": "User", "id": "bob", "email": "bob@partner.example.org"},
+		{"resourceType": "Role", "id": "alice-admin", "name": "admin", "user": map[string]interface{}{"id": "alice"}},
+		{"resourceType": "Role", "id": "carol-admin", "name": "admin", "user": map[string]interface{}{"id": "carol"}},
+		{"resourceType": "Role", "id": "bob-auditor", "name": "auditor", "user": map[string]interface{}{"id": "bob"}},
+	} {
+		if _, err := client.PutResource(ctx, resource, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+	users, _ := client.SearchResources(ctx, "User", nil, 0)
+	roles, _ := client.SearchResources(ctx, "Role", nil, 0)
+
+	testCases := map[string]struct {
+		model    UsersDataSourceModel
+		expected []string
+	}{
+		"all": {
+			model:    UsersDataSourceModel{},
+			expected: []string{"alice", "bob", "carol"},
+		},
+		"email": {
+			model:    UsersDataSourceModel{EmailRegex: types.StringValue(`@example\.com$`)},
+			expected: []string{"alice", "carol"},
+		},
+		"role": {
+			model:    UsersDataSourceModel{Role: types.StringValue("admin")},
+			expected: []string{"alice", "carol"},
+		},
+		"active-role": {
+			model:    UsersDataSourceModel{Role: types.StringValue("admin"), Active: types.BoolValue(true)},
+			expected: []string{"alice"},
+		},
+		"inactive": {
+			model:    UsersDataSourceModel{Active: types.BoolValue(false)},
+			expected: []string{"carol"},
+		},
+		"none": {
+			model:    UsersDataSourceModel{Role: types.StringValue("owner")},
+			expected: []string{},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			filtered, err := filterUsers(testCase.model, users, roles)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ids := []string{}
+			for _, user := range filtered {
+				ids = append(ids, user.ID.ValueString())
+			}
+			if len(ids) != len(testCase.expected) {
+				t.Fatalf("expected users %v, got: %v", testCase.expected, ids)
+			}
+			for i := range ids {
+				if ids[i] != testCase.expected[i] {
+					t.Fatalf("expected users %v, got: %v", testCase.expected, ids)
+				}
+			}
+		})
+	}
+
+	filtered, _ := filterUsers(UsersDataSourceModel{EmailRegex: types.StringValue("^bob@")}, users, roles)
+	if len(filtered) != 1 || filtered[0].Email.ValueString() != "bob@partner.example.org" || !filtered[0].Active.ValueBool() || !filtered[0].Roles.Equal(types.SetValueMust(types.StringType, []attr.Value{types.StringValue("auditor")})) {
+		t.Errorf("unexpected user: %+v", filtered)
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox"
+	"net/url"
+	"strconv"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ValueSetExpansionDataSource{}
+var _ datasource.DataSourceWithConfigure = &ValueSetExpansionDataSource{}
+
+const valueSetExpandOperation = "/ValueSet/$expand"
+
+func NewValueSetExpansionDataSource() datasource.DataSource {
+	return &ValueSetExpansionDataSource{}
+}
+
+// ValueSetExpansionDataSource defines the data source implementation.
+type ValueSetExpansionDataSource struct {
+	boxDataSource
+}
+
+// ValueSetExpansionDataSourceModel describes the data source data model.
+type ValueSetExpansionDataSourceModel struct {
+	URL      types.String                             `tfsdk:"url"`
+	Filter   types.String                             `tfsdk:"filter"`
+	MaxCodes types.Int64                              `tfsdk:"max_codes"`
+	Total    types.Int64                              `tfsdk:"total"`
+	Codes    []ValueSetExpansionDataSourceCodingModel `tfsdk:"codes"`
+}
+
+// ValueSetExpansionDataSourceCodingModel describes a code of the codes
+// attribute.
+type ValueSetExpansionDataSourceCodingModel struct {
+	System  types.String `tfsdk:"system"`
+	Code    types.String `tfsdk:"code"`
+	Display types.String `tfsdk:"display"`
+}
+
+func (d *ValueSetExpansionDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_valueset_expansion"
+}
+
+func (d *ValueSetExpansionDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Expands a ValueSet with the `$expand` operation of the box, such as to use the codes of a value set in other resources.",
+		Attributes: map[string]schema.Attribute{
+			"url": schema.StringAttribute{
+				MarkdownDescription: "Canonical URL of the ValueSet, such as `http://hl7.org/fhir/ValueSet/administrative-gender`",
+				Required:            true,
+			},
+			"filter": schema.StringAttribute{
+				MarkdownDescription: "Text the codes, or their display, must match, as interpreted by the terminology service of the box",
+				Optional:            true,
+			},
+			"max_codes": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of codes returned. Without it, the box applies its own limit.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"total": schema.Int64Attribute{
+				MarkdownDescription: "Total number of codes in the expansion, which is larger than the number of `codes` when the expansion is truncated",
+				Computed:            true,
+			},
+			"codes": schema.ListAttribute{
+				MarkdownDescription: "Codes of the expansion, in the order returned by the box. Each code has a `system`, a `code` and a `display`. Codes nested in the expansion are flattened, abstract codes are left out.",
+				ElementType: types.ObjectType{
+					AttrTypes: map[string]attr.Type{
+						"system":  types.StringType,
+						"code":    types.StringType,
+						"display": types.StringType,
+					},
+				},
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (d *ValueSetExpansionDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model ValueSetExpansionDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := url.Values{"url": {model.URL.ValueString()}}
+	if !model.Filter.IsNull() {
+		params.Set("filter", model.Filter.ValueString())
+	}
+	if !model.MaxCodes.IsNull() {
+		params.Set("count", strconv.FormatInt(model.MaxCodes.ValueInt64(), 10))
+	}
+
+	valueSet, err := d.client.RunOperation(ctx, valueSetExpandOperation, params)
+	if aidbox.IsNotFound(err) && d.operationNotSupported(ctx, "ValueSet", "expand") {
+		resp.Diagnostics.AddError(
+			"Operation Not Supported",
+			"The box doesn't support the ValueSet/$expand operation. Please check that its terminology service is enabled.",
+		)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostic(
+			"Failed to Expand Value Set",
+			fmt.Sprintf("Unable to expand the value set %s", model.URL.ValueString()),
+			err,
+		))
+		return
+	}
+
+	mapValueSetExpansion(&model, valueSet)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+// mapValueSetExpansion maps the expansion of valueSet to model, flattening
+// the nested codes. Abstract codes, which can't be used in data, are left
+// out.
+func mapValueSetExpansion(model *ValueSetExpansionDataSourceModel, valueSet aidbox.Resource) {
+	expansion := jsonObject(valueSet, "expansion")
+
+	model.Codes = []ValueSetExpansionDataSourceCodingModel{}
+	var flatten func(contains interface{})
+	flatten = func(contains interface{}) {
+		entries, _ := contains.([]interface{})
+		for _, entry := range entries {
+			coding, ok := entry.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if abstract, _ := coding["abstract"].(bool); !abstract && coding["code"] != nil {
+				model.Codes = append(model.Codes, ValueSetExpansionDataSourceCodingModel{
+					System:  jsonStringValue(coding, "system"),
+					Code:    jsonStringValue(coding, "code"),
+					Display: jsonStringValue(coding, "display"),
+				})
+			}
+			flatten(coding["contains"])
+		}
+	}
+	flatten(expansion["contains"])
+
+	model.Total = jsonInt64Value(expansion, "total")
+	if model.Total.IsNull() {
+		model.Total = types.Int64Value(int64(len(model.Codes)))
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccAidboxValueSetExpansionDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckBox(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccAidboxValueSetExpansionDataSourceConfig(""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.aidbox_valueset_expansion.test", "total", "4"),
+					resource.TestCheckResourceAttr("data.aidbox_valueset_expansion.test", "codes.#", "4"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.aidbox_valueset_expansion.test", "codes.*", map[string]string{
+						"system":  "http://hl7.org/fhir/administrative-gender",
+						"code":    "female",
+						"display": "Female",
+					}),
+				),
+			},
+			// Filter testing
+			{
+				Config: testAccAidboxValueSetExpansionDataSourceConfig("male"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs("data.aidbox_valueset_expansion.test", "codes.*", map[string]string{
+						"code": "male",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testAccAidboxValueSetExpansionDataSourceConfig(filter string) string {
+	if filter == "" {
+		return `
+data "aidbox_valueset_expansion" "test" {
+  url = "http://hl7.org/fhir/ValueSet/administrative-gender"
+}
+`
+	}
+	return fmt.Sprintf(`
+data "aidbox_valueset_expansion" "test" {
+  url    = "http://hl7.org/fhir/ValueSet/administrative-gender"
+  filter = %[1]q
+}
+`, filter)
+}
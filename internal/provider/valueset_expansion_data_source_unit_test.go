@@ -0,0 +1,69 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox/fake"
+)
+
+func TestMapValueSetExpansion(t *testing.T) {
+	client := fake.NewBoxClient()
+	client.HandleOperation(valueSetExpandOperation, func(params url.Values) (aidbox.Resource, error) {
+		return aidbox.Resource{
+			"resourceType": "ValueSet",
+			"url":          params.Get("url"),
+			"expansion": map[string]interface{}{
+				"total": 12,
+				"contains": []interface{}{
+					map[string]interface{}{"system": "http://example.org/colors", "code": "warm", "display": "Warm", "abstract": true, "contains": []interface{}{
+						map[string]interface{}{"system": "http://example.org/colors", "code": "red", "display": "Red"},
+						map[string]interface{}{"system": "http://example.org/colors", "code": "orange"},
+					}},
+					map[string]interface{}{"system": "http://example.org/colors", "code": "blue", "display": "Blue"},
+				},
+			},
+		}, nil
+	})
+
+	valueSet, err := client.RunOperation(context.Background(), valueSetExpandOperation, url.Values{"url": {"http://example.org/ValueSet/colors"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var model ValueSetExpansionDataSourceModel
+	mapValueSetExpansion(&model, valueSet)
+
+	expected := []string{"red", "orange", "blue"}
+	if len(model.Codes) != len(expected) {
+		t.Fatalf("expected codes %v, got: %+v", expected, model.Codes)
+	}
+	for i, coding := range model.Codes {
+		if coding.Code.ValueString() != expected[i] || coding.System.ValueString() != "http://example.org/colors" {
+			t.Errorf("expected code %s at %d, got: %+v", expected[i], i, coding)
+		}
+	}
+	if !model.Codes[1].Display.IsNull() {
+		t.Errorf("expected no display for orange, got: %s", model.Codes[1].Display)
+	}
+	if model.Total.ValueInt64() != 12 {
+		t.Errorf("expected the total of the box, got: %s", model.Total)
+	}
+}
+
+func TestMapValueSetExpansion_withoutTotal(t *testing.T) {
+	var model ValueSetExpansionDataSourceModel
+	mapValueSetExpansion(&model, aidbox.Resource{"resourceType": "ValueSet", "expansion": map[string]interface{}{}})
+
+	if model.Codes == nil || len(model.Codes) != 0 {
+		t.Errorf("expected no codes, got: %+v", model.Codes)
+	}
+	if model.Total.ValueInt64() != 0 {
+		t.Errorf("expected a total of 0, got: %s", model.Total)
+	}
+}
@@ -0,0 +1,250 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &WebhookResource{}
+var _ resource.ResourceWithImportState = &WebhookResource{}
+
+const webhookResourceType = "Hook"
+
+func NewWebhookResource() resource.Resource {
+	return &WebhookResource{}
+}
+
+// WebhookResource defines the resource implementation.
+type WebhookResource struct {
+	boxResource
+}
+
+// WebhookResourceModel describes the resource data model.
+type WebhookResourceModel struct {
+	ID        types.String       `tfsdk:"id"`
+	URL       types.String       `tfsdk:"url"`
+	Events    types.Set          `tfsdk:"events"`
+	Headers   types.Map          `tfsdk:"headers"`
+	Enabled   types.Bool         `tfsdk:"enabled"`
+	Retry     *WebhookRetryModel `tfsdk:"retry"`
+	VersionID types.String       `tfsdk:"version_id"`
+}
+
+// WebhookRetryModel describes the retry block.
+type WebhookRetryModel struct {
+	MaxAttempts types.Int64  `tfsdk:"max_attempts"`
+	Interval    types.String `tfsdk:"interval"`
+}
+
+func (r *WebhookResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_webhook"
+}
+
+func (r *WebhookResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a Hook, an outbound HTTP callback the box calls when the selected events occur. Unlike subscriptions, hooks are meant for operational callbacks, such as notifying a deployment pipeline.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the hook. Changing it forces a new hook to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validFHIRID(),
+				},
+			},
+			"url": schema.StringAttribute{
+				MarkdownDescription: "URL the box sends a POST request to when one of the events occurs",
+				Required:            true,
+				Validators: []validator.String{
+					validURI("http", "https"),
+				},
+			},
+			"events": schema.SetAttribute{
+				MarkdownDescription: "Events calling the hook, such as `User/create` or `Patient/delete`",
+				ElementType:         types.StringType,
+				Required:            true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+			"headers": schema.MapAttribute{
+				MarkdownDescription: "HTTP headers sent with every request, such as an authorization header",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether the box calls the hook. Defaults to `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"version_id": schema.StringAttribute{
+				MarkdownDescription: "Version of the hook, incremented by the box on every change",
+				Computed:            true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"retry": schema.SingleNestedBlock{
+				MarkdownDescription: "Retry policy for failed calls. Without it, failed calls are not retried.",
+				Attributes: map[string]schema.Attribute{
+					"max_attempts": schema.Int64Attribute{
+						MarkdownDescription: "Maximum number of attempts of a call, including the first one",
+						Optional:            true,
+						Validators: []validator.Int64{
+							int64validator.AtLeast(1),
+						},
+					},
+					"interval": schema.StringAttribute{
+						MarkdownDescription: "Duration to wait between attempts, such as `30s`",
+						Optional:            true,
+						Validators: []validator.String{
+							validDuration(),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *WebhookResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var model WebhookResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stored, err := r.client.PutResource(ctx, webhookToResource(model), "")
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostic("Failed to Create Webhook", "Unable to create webhook", err))
+		return
+	}
+
+	mapWebhookFromResource(&model, stored)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *WebhookResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var model WebhookResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stored, err := r.client.GetResourceIfModified(ctx, webhookResourceType, model.ID.ValueString(), model.VersionID.ValueString())
+	if aidbox.IsNotModified(err) {
+		return
+	}
+	if aidbox.IsNotFound(err) {
+		tflog.Warn(ctx, "Webhook not found, removing from state", map[string]interface{}{"id": model.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostic("Failed to Fetch Webhook", "Unable to fetch webhook", err))
+		return
+	}
+
+	mapWebhookFromResource(&model, stored)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *WebhookResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var model WebhookResourceModel
+	var versionID types.String
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("version_id"), &versionID)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stored, err := r.client.PutResource(ctx, webhookToResource(model), versionID.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(updateErrorDiagnostic("webhook "+model.ID.ValueString(), "Failed to Update Webhook", "Unable to update webhook", err))
+		return
+	}
+
+	mapWebhookFromResource(&model, stored)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *WebhookResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var model WebhookResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteResource(ctx, webhookResourceType, model.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(deleteErrorDiagnostic(
+			"webhook "+model.ID.ValueString(),
+			"Failed to Delete Webhook",
+			fmt.Sprintf("Error while trying to delete the webhook with ID %s", model.ID.ValueString()),
+			err,
+		))
+	}
+}
+
+func (r *WebhookResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+func webhookToResource(model WebhookResourceModel) aidbox.Resource {
+	hook := aidbox.Resource{
+		"resourceType": webhookResourceType,
+		"id":           model.ID.ValueString(),
+		"status":       "active",
+	}
+	setJSONValue(hook, "url", model.URL)
+	setJSONValue(hook, "events", model.Events)
+	setJSONValue(hook, "headers", model.Headers)
+	if !model.Enabled.IsNull() && !model.Enabled.ValueBool() {
+		hook["status"] = "off"
+	}
+
+	if model.Retry != nil {
+		retry := map[string]interface{}{}
+		setJSONValue(retry, "max-attempts", model.Retry.MaxAttempts)
+		setJSONValue(retry, "interval", model.Retry.Interval)
+		hook["retry"] = retry
+	}
+
+	return hook
+}
+
+func mapWebhookFromResource(model *WebhookResourceModel, stored aidbox.Resource) {
+	model.ID = types.StringValue(stored.ID())
+	model.URL = jsonStringValue(stored, "url")
+	model.Events = jsonStringSet(stored, "events")
+	model.Headers = jsonStringMap(stored, "headers")
+	model.Enabled = types.BoolValue(jsonStringValue(stored, "status").ValueString() != "off")
+	model.VersionID = stringValueOrNull(stored.VersionID())
+
+	model.Retry = nil
+	if retry := jsonObject(stored, "retry"); retry != nil {
+		model.Retry = &WebhookRetryModel{
+			MaxAttempts: jsonInt64Value(retry, "max-attempts"),
+			Interval:    jsonStringValue(retry, "interval"),
+		}
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccAidboxWebhookResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheckBox(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_5_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccAidboxWebhookResourceConfig(true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("aidbox_webhook.test", "id", "tf-acc-user-created"),
+					resource.TestCheckResourceAttr("aidbox_webhook.test", "enabled", "true"),
+					resource.TestCheckResourceAttr("aidbox_webhook.test", "events.#", "1"),
+					resource.TestCheckResourceAttr("aidbox_webhook.test", "retry.max_attempts", "3"),
+					resource.TestCheckResourceAttrSet("aidbox_webhook.test", "version_id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "aidbox_webhook.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Import block testing, as with terraform plan -generate-config-out
+			{
+				ResourceName:    "aidbox_webhook.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithID,
+			},
+			// Update and Read testing
+			{
+				Config: testAccAidboxWebhookResourceConfig(false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("aidbox_webhook.test", "enabled", "false"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccAidboxWebhookResourceConfig(enabled bool) string {
+	return fmt.Sprintf(`
+resource "aidbox_webhook" "test" {
+  id      = "tf-acc-user-created"
+  url     = "https://hooks.example.com/aidbox"
+  events  = ["User/create"]
+  enabled = %[1]t
+
+  headers = {
+    Authorization = "Bearer tf-acc"
+  }
+
+  retry {
+    max_attempts = 3
+    interval     = "10s"
+  }
+}
+`, enabled)
+}
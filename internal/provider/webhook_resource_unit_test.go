@@ -0,0 +1,204 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox/fake"
+)
+
+func TestWebhookResource_roundTrip(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewBoxClient()
+
+	model := WebhookResourceModel{
+		ID:      types.StringValue("user-created"),
+		URL:     types.StringValue("https://hooks.example.com/aidbox"),
+		Events:  types.SetValueMust(types.StringType, []attr.Value{types.StringValue("User/create")}),
+		Headers: types.MapNull(types.StringType),
+		Enabled: types.BoolValue(false),
+		Retry: &WebhookRetryModel{
+			MaxAttempts: types.Int64Value(5),
+			Interval:    types.StringValue("30s"),
+		},
+	}
+
+	stored, err := client.PutResource(ctx, webhookToResource(model), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored["status"] != "off" {
+		t.Errorf("expected a disabled hook to have the off status, got: %v", stored["status"])
+	}
+	if retry := jsonObject(stored, "retry"); retry["max-attempts"] != float64(5) {
+		t.Errorf("unexpected retry policy: %v", retry)
+	}
+
+	var got WebhookResourceModel
+	mapWebhookFromResource(&got, stored)
+	if got.Enabled.ValueBool() || !got.Events.Equal(model.Events) || !got.Headers.IsNull() {
+		t.Errorf("unexpected model: %+v", got)
+	}
+	if got.Retry == nil || got.Retry.MaxAttempts.ValueInt64() != 5 || got.Retry.Interval.ValueString() != "30s" {
+		t.Errorf("unexpected retry block: %+v", got.Retry)
+	}
+	if got.VersionID.ValueString() != "1" {
+		t.Errorf("expected version 1, got: %s", got.VersionID)
+	}
+}
+
+func TestWebhookResourceRead_notModified(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewBoxClient()
+
+	r := &WebhookResource{}
+	r.Configure(ctx, resource.ConfigureRequest{ProviderData: &ProviderData{BoxClient: client}}, &resource.ConfigureResponse{})
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	model := WebhookResourceModel{
+		ID:      types.StringValue("deploy"),
+		URL:     types.StringValue("https://ci.example.com/hooks/aidbox"),
+		Events:  types.SetValueMust(types.StringType, []attr.Value{types.StringValue("User/create")}),
+		Headers: types.MapNull(types.StringType),
+		Enabled: types.BoolValue(true),
+	}
+	stored, err := client.PutResource(ctx, webhookToResource(model), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	mapWebhookFromResource(&model, stored)
+
+	read := func(model WebhookResourceModel) WebhookResourceModel {
+		t.Helper()
+
+		state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+		if diags := state.Set(ctx, &model); diags.HasError() {
+			t.Fatalf("unable to build state: %v", diags)
+		}
+		resp := resource.ReadResponse{State: state}
+		r.Read(ctx, resource.ReadRequest{State: state}, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+
+		var got WebhookResourceModel
+		resp.Diagnostics.Append(resp.State.Get(ctx, &got)...)
+		return got
+	}
+
+	// The state of an unchanged hook is kept as is, even though it differs
+	// from the hook, as the box doesn't send the hook again
+	stale := model
+	stale.URL = types.StringValue("https://ci.example.com/hooks/stale")
+	if got := read(stale); got.URL != stale.URL {
+		t.Errorf("expected the state to be kept, got: %s", got.URL)
+	}
+
+	client.UpdateResource(webhookResourceType, "deploy", func(hook aidbox.Resource) {
+		hook["url"] = "https://ci.example.com/hooks/moved"
+	})
+	if got := read(model); got.URL.ValueString() != "https://ci.example.com/hooks/moved" || got.VersionID.ValueString() != "2" {
+		t.Errorf("expected the modified hook, got: %+v", got)
+	}
+}
+
+func TestWebhookResourceUpdate_conflict(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewBoxClient()
+
+	r := &WebhookResource{}
+	r.Configure(ctx, resource.ConfigureRequest{ProviderData: &ProviderData{BoxClient: client}}, &resource.ConfigureResponse{})
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	model := WebhookResourceModel{
+		ID:      types.StringValue("deploy"),
+		URL:     types.StringValue("https://ci.example.com/hooks/aidbox"),
+		Events:  types.SetValueMust(types.StringType, []attr.Value{types.StringValue("User/create")}),
+		Headers: types.MapNull(types.StringType),
+		Enabled: types.BoolValue(true),
+	}
+	stored, err := client.PutResource(ctx, webhookToResource(model), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	mapWebhookFromResource(&model, stored)
+
+	update := func() resource.UpdateResponse {
+		t.Helper()
+
+		state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+		if diags := state.Set(ctx, &model); diags.HasError() {
+			t.Fatalf("unable to build state: %v", diags)
+		}
+		planned := model
+		planned.URL = types.StringValue("https://ci.example.com/hooks/planned")
+		planned.VersionID = types.StringUnknown()
+		plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+		if diags := plan.Set(ctx, &planned); diags.HasError() {
+			t.Fatalf("unable to build plan: %v", diags)
+		}
+
+		resp := resource.UpdateResponse{State: state}
+		r.Update(ctx, resource.UpdateRequest{Plan: plan, State: state}, &resp)
+		return resp
+	}
+
+	// The hook is changed outside of Terraform after the plan was made
+	client.UpdateResource(webhookResourceType, "deploy", func(hook aidbox.Resource) {
+		hook["url"] = "https://ci.example.com/hooks/moved"
+	})
+	resp := update()
+	if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != "Resource Modified Concurrently" {
+		t.Errorf("expected a conflict, got: %v", resp.Diagnostics)
+	}
+	if hook, _ := client.Resource(webhookResourceType, "deploy"); hook["url"] != "https://ci.example.com/hooks/moved" {
+		t.Errorf("expected the hook not to be replaced, got: %v", hook["url"])
+	}
+
+	// Once refreshed, the update applies
+	model.VersionID = types.StringValue("2")
+	resp = update()
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	if hook, _ := client.Resource(webhookResourceType, "deploy"); hook["url"] != "https://ci.example.com/hooks/planned" || hook.VersionID() != "3" {
+		t.Errorf("expected the planned hook, got: %v", hook)
+	}
+}
+
+func TestWebhookResourceDelete_alreadyDeleted(t *testing.T) {
+	ctx := context.Background()
+	r := &WebhookResource{}
+	r.Configure(ctx, resource.ConfigureRequest{ProviderData: &ProviderData{BoxClient: fake.NewBoxClient()}}, &resource.ConfigureResponse{})
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	model := WebhookResourceModel{
+		ID:      types.StringValue("deploy"),
+		URL:     types.StringValue("https://ci.example.com/hooks/aidbox"),
+		Events:  types.SetValueMust(types.StringType, []attr.Value{types.StringValue("User/create")}),
+		Headers: types.MapNull(types.StringType),
+		Enabled: types.BoolValue(true),
+	}
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	if diags := state.Set(ctx, &model); diags.HasError() {
+		t.Fatalf("unable to build state: %v", diags)
+	}
+
+	resp := resource.DeleteResponse{State: state}
+	r.Delete(ctx, resource.DeleteRequest{State: state}, &resp)
+	if resp.Diagnostics.HasError() || resp.Diagnostics.WarningsCount() != 1 {
+		t.Errorf("expected a single warning, got: %v", resp.Diagnostics)
+	}
+}
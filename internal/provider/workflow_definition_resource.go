@@ -0,0 +1,193 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &WorkflowDefinitionResource{}
+var _ resource.ResourceWithImportState = &WorkflowDefinitionResource{}
+
+const workflowDefinitionResourceType = "AidboxWorkflowDefinition"
+
+func NewWorkflowDefinitionResource() resource.Resource {
+	return &WorkflowDefinitionResource{}
+}
+
+// WorkflowDefinitionResource defines the resource implementation.
+type WorkflowDefinitionResource struct {
+	boxResource
+}
+
+// WorkflowDefinitionResourceModel describes the resource data model.
+type WorkflowDefinitionResourceModel struct {
+	ID         types.String     `tfsdk:"id"`
+	Definition fhirResourceJSON `tfsdk:"definition"`
+	VersionID  types.String     `tfsdk:"version_id"`
+}
+
+func (r *WorkflowDefinitionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_workflow_definition"
+}
+
+func (r *WorkflowDefinitionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a workflow definition of the Aidbox workflow engine, with its steps, transitions and retry policy. Changes made to the definition outside of Terraform are detected as drift.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the workflow definition. Changing it forces a new workflow definition to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validFHIRID(),
+				},
+			},
+			"definition": schema.StringAttribute{
+				MarkdownDescription: "Body of the workflow definition, as a JSON object with the `steps`, `transitions` and `retry` attributes of the workflow, and optionally a `meta` attribute with its tags. " +
+					"Differences in formatting, in the order of object keys, or in the `meta` attributes managed by the box, such as `versionId`, are ignored. " +
+					"Load it from a JSON file with `file`, or from a zen/EDN file with the `edn_to_json` provider function.",
+				CustomType: fhirResourceJSONType{},
+				Required:   true,
+				Validators: []validator.String{
+					validWorkflowDefinition(),
+				},
+			},
+			"version_id": schema.StringAttribute{
+				MarkdownDescription: "Version of the workflow definition, incremented by the box on every change",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *WorkflowDefinitionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var model WorkflowDefinitionResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stored, err := r.client.PutResource(ctx, workflowDefinitionToResource(model), "")
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostic("Failed to Create Workflow Definition", "Unable to create workflow definition", err))
+		return
+	}
+
+	mapWorkflowDefinitionFromResource(&model, stored)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *WorkflowDefinitionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var model WorkflowDefinitionResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stored, err := r.client.GetResourceIfModified(ctx, workflowDefinitionResourceType, model.ID.ValueString(), model.VersionID.ValueString())
+	if aidbox.IsNotModified(err) {
+		return
+	}
+	if aidbox.IsNotFound(err) {
+		tflog.Warn(ctx, "Task definition not found, removing from state", map[string]interface{}{"id": model.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostic("Failed to Fetch Workflow Definition", "Unable to fetch workflow definition", err))
+		return
+	}
+
+	mapWorkflowDefinitionFromResource(&model, stored)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *WorkflowDefinitionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var model WorkflowDefinitionResourceModel
+	var versionID types.String
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("version_id"), &versionID)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stored, err := r.client.PutResource(ctx, workflowDefinitionToResource(model), versionID.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(updateErrorDiagnostic("workflow definition "+model.ID.ValueString(), "Failed to Update Workflow Definition", "Unable to update workflow definition", err))
+		return
+	}
+
+	mapWorkflowDefinitionFromResource(&model, stored)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *WorkflowDefinitionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var model WorkflowDefinitionResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteResource(ctx, workflowDefinitionResourceType, model.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(deleteErrorDiagnostic(
+			"workflow definition "+model.ID.ValueString(),
+			"Failed to Delete Workflow Definition",
+			fmt.Sprintf("Error while trying to delete the workflow definition with ID %s", model.ID.ValueString()),
+			err,
+		))
+	}
+}
+
+func (r *WorkflowDefinitionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// workflowDefinitionToResource returns the AidboxWorkflowDefinition resource
+// for model, with the attributes of the definition body at the top level.
+func workflowDefinitionToResource(model WorkflowDefinitionResourceModel) aidbox.Resource {
+	definition := aidbox.Resource{}
+	if body, ok := valueToJSON(model.Definition).(map[string]interface{}); ok {
+		for key, value := range body {
+			definition[key] = value
+		}
+	}
+	definition["resourceType"] = workflowDefinitionResourceType
+	definition["id"] = model.ID.ValueString()
+
+	return definition
+}
+
+// mapWorkflowDefinitionFromResource maps the resource stored by the box to
+// model. Every attribute but the resource type and the ID is part of the
+// definition body, so that changes made outside of Terraform show up as drift,
+// the meta attributes managed by the box being ignored by the definition type.
+func mapWorkflowDefinitionFromResource(model *WorkflowDefinitionResourceModel, stored aidbox.Resource) {
+	body := map[string]interface{}{}
+	for key, value := range stored {
+		switch key {
+		case "resourceType", "id":
+		default:
+			body[key] = value
+		}
+	}
+
+	model.ID = types.StringValue(stored.ID())
+	model.Definition = fhirResourceJSON{Normalized: jsonNormalizedValue(map[string]interface{}{"definition": body}, "definition")}
+	model.VersionID = stringValueOrNull(stored.VersionID())
+}
@@ -0,0 +1,70 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccAidboxWorkflowDefinitionResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheckBox(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_5_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccAidboxWorkflowDefinitionResourceConfig(3),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("aidbox_workflow_definition.test", "id", "tf-acc-onboarding"),
+					resource.TestCheckResourceAttrSet("aidbox_workflow_definition.test", "definition"),
+					resource.TestCheckResourceAttrSet("aidbox_workflow_definition.test", "version_id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "aidbox_workflow_definition.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Import block testing, as with terraform plan -generate-config-out
+			{
+				ResourceName:    "aidbox_workflow_definition.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithID,
+			},
+			// Update and Read testing
+			{
+				Config: testAccAidboxWorkflowDefinitionResourceConfig(5),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("aidbox_workflow_definition.test", "version_id"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccAidboxWorkflowDefinitionResourceConfig(maxAttempts int) string {
+	return fmt.Sprintf(`
+resource "aidbox_workflow_definition" "test" {
+  id = "tf-acc-onboarding"
+
+  definition = jsonencode({
+    steps = {
+      welcome = { task = "notifications/send-email" }
+    }
+    retry = {
+      max-attempts = %[1]d
+    }
+  })
+}
+`, maxAttempts)
+}
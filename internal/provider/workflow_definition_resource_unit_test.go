@@ -0,0 +1,68 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox/fake"
+)
+
+func TestWorkflowDefinitionResource_drift(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewBoxClient()
+
+	model := WorkflowDefinitionResourceModel{
+		ID: types.StringValue("onboarding"),
+		Definition: newFHIRResourceJSONValue(`{
+  "steps": {"welcome": {"task": "notifications/send-email"}},
+  "retry": {"max-attempts": 3}
+}`),
+	}
+
+	stored, err := client.PutResource(ctx, workflowDefinitionToResource(model), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if jsonObject(stored, "steps") == nil {
+		t.Fatalf("expected the definition body at the top level, got: %v", stored)
+	}
+
+	var got WorkflowDefinitionResourceModel
+	mapWorkflowDefinitionFromResource(&got, stored)
+	equal, diags := got.Definition.StringSemanticEquals(ctx, model.Definition)
+	if diags.HasError() || !equal {
+		t.Errorf("expected the stored definition to equal the configured one, got: %s", got.Definition)
+	}
+
+	// Tags set in the definition are kept, and the meta attributes the box
+	// manages are ignored.
+	model.Definition = newFHIRResourceJSONValue(`{"steps": {"welcome": {"task": "notifications/send-email"}}, "retry": {"max-attempts": 3}, "meta": {"tag": [{"code": "onboarding"}]}}`)
+	stored, err = client.PutResource(ctx, workflowDefinitionToResource(model), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	mapWorkflowDefinitionFromResource(&got, stored)
+	equal, diags = got.Definition.StringSemanticEquals(ctx, model.Definition)
+	if diags.HasError() || !equal {
+		t.Errorf("expected the stored definition with tags to equal the configured one, got: %s", got.Definition)
+	}
+
+	client.UpdateResource(workflowDefinitionResourceType, "onboarding", func(resource aidbox.Resource) {
+		resource["retry"] = map[string]interface{}{"max-attempts": 5}
+	})
+	stored, err = client.GetResource(ctx, workflowDefinitionResourceType, "onboarding")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mapWorkflowDefinitionFromResource(&got, stored)
+	equal, diags = got.Definition.StringSemanticEquals(ctx, model.Definition)
+	if diags.HasError() || equal {
+		t.Errorf("expected a change made outside of Terraform to be detected, got: %s", got.Definition)
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var _ validator.String = workflowDefinitionValidator{}
+
+// workflowDefinitionValidator validates that a JSON attribute is a workflow
+// definition body, an object with the steps of the workflow and without the
+// attributes the provider manages.
+type workflowDefinitionValidator struct{}
+
+// validWorkflowDefinition returns a validator for workflow definition bodies.
+func validWorkflowDefinition() validator.String {
+	return workflowDefinitionValidator{}
+}
+
+func (v workflowDefinitionValidator) Description(ctx context.Context) string {
+	return "value must be a JSON object with a `steps` attribute"
+}
+
+func (v workflowDefinitionValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v workflowDefinitionValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	var definition map[string]interface{}
+	if err := json.Unmarshal([]byte(req.ConfigValue.ValueString()), &definition); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Workflow Definition",
+			fmt.Sprintf("The workflow definition must be a JSON object: %s", err),
+		)
+		return
+	}
+
+	if _, ok := definition["steps"]; !ok {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Workflow Definition",
+			"The workflow definition must define the steps of the workflow in a `steps` attribute.",
+		)
+	}
+
+	for _, key := range []string{"resourceType", "id"} {
+		if _, ok := definition[key]; ok {
+			resp.Diagnostics.AddAttributeError(
+				req.Path,
+				"Invalid Workflow Definition",
+				fmt.Sprintf("The workflow definition must not set the %q attribute, which is managed by the provider.", key),
+			)
+		}
+	}
+}
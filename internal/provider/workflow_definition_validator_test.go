@@ -0,0 +1,42 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestWorkflowDefinitionValidator(t *testing.T) {
+	testCases := map[string]struct {
+		value       types.String
+		expectError bool
+	}{
+		"valid":       {value: types.StringValue(`{"steps": {"send": {"task": "notifications/send-email"}}}`)},
+		"null":        {value: types.StringNull()},
+		"unknown":     {value: types.StringUnknown()},
+		"array":       {value: types.StringValue(`[]`), expectError: true},
+		"no steps":    {value: types.StringValue(`{"transitions": {}}`), expectError: true},
+		"meta":        {value: types.StringValue(`{"meta": {"tag": [{"code": "onboarding"}]}, "steps": {}}`)},
+		"managed key": {value: types.StringValue(`{"id": "other", "steps": {}}`), expectError: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resp := &validator.StringResponse{}
+			validWorkflowDefinition().ValidateString(context.Background(), validator.StringRequest{
+				Path:        path.Root("definition"),
+				ConfigValue: testCase.value,
+			}, resp)
+
+			if resp.Diagnostics.HasError() != testCase.expectError {
+				t.Errorf("expected error %t, got: %v", testCase.expectError, resp.Diagnostics)
+			}
+		})
+	}
+}
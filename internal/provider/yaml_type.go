@@ -0,0 +1,123 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"gopkg.in/yaml.v3"
+	"reflect"
+)
+
+// Ensure the implementations satisfy the expected interfaces.
+var _ basetypes.StringTypable = yamlType{}
+var _ basetypes.StringValuableWithSemanticEquals = yamlValue{}
+var _ xattr.ValidateableAttribute = yamlValue{}
+
+// yamlType is the type of attributes holding a YAML document, such as a
+// matcho pattern. Documents are compared once decoded, so that differences in
+// formatting, comments or key order never show up as drift.
+type yamlType struct {
+	basetypes.StringType
+}
+
+func (t yamlType) String() string {
+	return "yamlType"
+}
+
+func (t yamlType) ValueType(ctx context.Context) attr.Value {
+	return yamlValue{}
+}
+
+func (t yamlType) Equal(o attr.Type) bool {
+	other, ok := o.(yamlType)
+	return ok && t.StringType.Equal(other.StringType)
+}
+
+func (t yamlType) ValueFromString(ctx context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return yamlValue{StringValue: in}, nil
+}
+
+func (t yamlType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	stringValuable, diags := t.ValueFromString(ctx, stringValue)
+	if diags.HasError() {
+		return nil, fmt.Errorf("unexpected error converting StringValue to StringValuable: %v", diags)
+	}
+	return stringValuable, nil
+}
+
+// yamlValue is a value of yamlType.
+type yamlValue struct {
+	basetypes.StringValue
+}
+
+func newYAMLValue(value string) yamlValue {
+	return yamlValue{StringValue: basetypes.NewStringValue(value)}
+}
+
+func (v yamlValue) Type(ctx context.Context) attr.Type {
+	return yamlType{}
+}
+
+func (v yamlValue) Equal(o attr.Value) bool {
+	other, ok := o.(yamlValue)
+	return ok && v.StringValue.Equal(other.StringValue)
+}
+
+// StringSemanticEquals reports whether both documents decode to the same
+// value.
+func (v yamlValue) StringSemanticEquals(ctx context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(yamlValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T, got: %T. Please report this to the provider developers.", v, newValuable),
+		)
+		return false, diags
+	}
+
+	var current, updated interface{}
+	if err := yaml.Unmarshal([]byte(v.ValueString()), &current); err != nil {
+		diags.AddError("Semantic Equality Check Error", "Unable to decode the YAML document: "+err.Error())
+		return false, diags
+	}
+	if err := yaml.Unmarshal([]byte(newValue.ValueString()), &updated); err != nil {
+		diags.AddError("Semantic Equality Check Error", "Unable to decode the YAML document: "+err.Error())
+		return false, diags
+	}
+
+	return reflect.DeepEqual(current, updated), diags
+}
+
+func (v yamlValue) ValidateAttribute(ctx context.Context, req xattr.ValidateAttributeRequest, resp *xattr.ValidateAttributeResponse) {
+	if v.IsNull() || v.IsUnknown() {
+		return
+	}
+
+	var decoded interface{}
+	if err := yaml.Unmarshal([]byte(v.ValueString()), &decoded); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid YAML Document",
+			fmt.Sprintf("The value must be a YAML document: %s", err),
+		)
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+func TestYAMLSemanticEquals(t *testing.T) {
+	configured := "# Allow the portal to read patients\nclient:\n  id: portal\nrequest-method:\n  $enum: [get]\n"
+
+	for name, testCase := range map[string]struct {
+		stored   string
+		expected bool
+	}{
+		"formatting": {
+			stored:   "request-method: {$enum: [\"get\"]}\nclient: {id: portal}\n",
+			expected: true,
+		},
+		"json": {
+			stored:   `{"client": {"id": "portal"}, "request-method": {"$enum": ["get"]}}`,
+			expected: true,
+		},
+		"changed value": {
+			stored:   "client:\n  id: portal\nrequest-method:\n  $enum: [get, post]\n",
+			expected: false,
+		},
+		"changed type": {
+			stored:   "client:\n  id: portal\nrequest-method:\n  $enum: get\n",
+			expected: false,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			equal, diags := newYAMLValue(configured).StringSemanticEquals(context.Background(), newYAMLValue(testCase.stored))
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			if equal != testCase.expected {
+				t.Errorf("expected semantic equality %t, got: %t", testCase.expected, equal)
+			}
+		})
+	}
+}
+
+func TestYAMLValidateAttribute(t *testing.T) {
+	for value, expectError := range map[string]bool{
+		"client:\n  id: portal\n": false,
+		"[get, post]":             false,
+		"client: [portal":         true,
+		"client:\n\tid: portal":   true,
+	} {
+		resp := &xattr.ValidateAttributeResponse{}
+		newYAMLValue(value).ValidateAttribute(context.Background(), xattr.ValidateAttributeRequest{Path: path.Root("matcho")}, resp)
+		if resp.Diagnostics.HasError() != expectError {
+			t.Errorf("expected error %t for %q, got: %v", expectError, value, resp.Diagnostics)
+		}
+	}
+}
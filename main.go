@@ -5,11 +5,20 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"flag"
+	"fmt"
+	"io"
 	"log"
+	"os"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
-	"terraform-provider-aidbox/internal/provider"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5/tf5server"
+	"github.com/hashicorp/terraform-plugin-mux/tf6to5server"
+	"github.com/petalmd/terraform-provider-aidbox/internal/provider"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox"
 )
 
 // Run "go generate" to format example terraform files and generate the docs for the registry/website
@@ -31,23 +40,126 @@ var (
 	// https://goreleaser.com/cookbooks/using-main.version/
 )
 
+// address is the name under which the provider is served.
+//
+// TODO: Update this string with the published name of your provider.
+// Also update the tfplugindocs generate command to either remove the
+// -provider-name flag or set its value to the updated provider name.
+const address = "registry.terraform.io/hashicorp/aidbox"
+
 func main() {
-	var debug bool
+	if len(os.Args) > 1 && os.Args[1] == "export" {
+		if err := export(os.Args[2:]); err != nil {
+			log.Fatal(err.Error())
+		}
+		return
+	}
+
+	var debug, check bool
 
 	flag.BoolVar(&debug, "debug", false, "set to true to run the provider with support for debuggers like delve")
+	flag.BoolVar(&check, "check", false, "check the credentials set in the environment against the portal and the box, print a JSON report and exit")
 	flag.Parse()
 
-	opts := providerserver.ServeOpts{
-		// TODO: Update this string with the published name of your provider.
-		// Also update the tfplugindocs generate command to either remove the
-		// -provider-name flag or set its value to the updated provider name.
-		Address: "registry.terraform.io/hashicorp/aidbox",
-		Debug:   debug,
+	if check {
+		report := provider.Check(context.Background(), version)
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetEscapeHTML(false)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			log.Fatal(err.Error())
+		}
+		if !report.OK() {
+			os.Exit(1)
+		}
+		return
 	}
 
-	err := providerserver.Serve(context.Background(), provider.New(version), opts)
+	var err error
+	if supportsProtocol6(os.Getenv("PLUGIN_PROTOCOL_VERSIONS")) {
+		err = providerserver.Serve(context.Background(), provider.New(version), providerserver.ServeOpts{
+			Address: address,
+			Debug:   debug,
+		})
+	} else {
+		err = serveProtocol5(debug)
+	}
 
 	if err != nil {
 		log.Fatal(err.Error())
 	}
 }
+
+// supportsProtocol6 reports whether the Terraform CLI launching the provider
+// negotiates plugin protocol 6. Terraform lists the protocol versions it
+// supports in PLUGIN_PROTOCOL_VERSIONS; when unset, e.g. in debug mode, the
+// provider serves protocol 6.
+func supportsProtocol6(versions string) bool {
+	if versions == "" {
+		return true
+	}
+	for _, v := range strings.Split(versions, ",") {
+		if strings.TrimSpace(v) == "6" {
+			return true
+		}
+	}
+	return false
+}
+
+// serveProtocol5 serves the provider downgraded to plugin protocol 5, for
+// Terraform CLI versions which do not support protocol 6.
+func serveProtocol5(debug bool) error {
+	ctx := context.Background()
+
+	downgraded, err := tf6to5server.DowngradeServer(ctx, providerserver.NewProtocol6(provider.New(version)()))
+	if err != nil {
+		return err
+	}
+
+	var serveOpts []tf5server.ServeOpt
+	if debug {
+		serveOpts = append(serveOpts, tf5server.WithManagedDebug())
+	}
+
+	return tf5server.Serve(address, func() tfprotov5.ProviderServer { return downgraded }, serveOpts...)
+}
+
+// export writes the configuration of the box set by the AIDBOX_BOX_URL,
+// AIDBOX_BOX_CLIENT_ID and AIDBOX_BOX_CLIENT_SECRET environment variables, as
+// import blocks and resources, so that an existing box can be brought under
+// Terraform management.
+func export(args []string) error {
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	resourceTypes := flags.String("types", "", "comma-separated resource types to export, such as aidbox_webhook; all by default")
+	out := flags.String("out", "", "file the configuration is written to; standard output by default")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	boxURL := os.Getenv("AIDBOX_BOX_URL")
+	clientID := os.Getenv("AIDBOX_BOX_CLIENT_ID")
+	clientSecret := os.Getenv("AIDBOX_BOX_CLIENT_SECRET")
+	if boxURL == "" || clientID == "" || clientSecret == "" {
+		return fmt.Errorf("the AIDBOX_BOX_URL, AIDBOX_BOX_CLIENT_ID and AIDBOX_BOX_CLIENT_SECRET environment variables are required")
+	}
+
+	var types []string
+	for _, resourceType := range strings.Split(*resourceTypes, ",") {
+		if resourceType = strings.TrimSpace(resourceType); resourceType != "" {
+			types = append(types, resourceType)
+		}
+	}
+
+	var w io.Writer = os.Stdout
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	client := aidbox.NewBoxClient(boxURL, clientID, clientSecret)
+	return provider.Export(context.Background(), client, types, w)
+}
@@ -0,0 +1,326 @@
+// Package aidboxtest provides an in-process fake of the Aidbox portal RPC API
+// for tests.
+package aidboxtest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// PortalServer is an httptest server speaking the portal RPC YAML protocol.
+// It keeps licenses, sessions and multibox boxes in memory.
+type PortalServer struct {
+	*httptest.Server
+
+	// Token is the API token accepted by the server.
+	Token string
+
+	mu       sync.Mutex
+	nextID   int
+	licenses map[string]map[string]interface{}
+	sessions map[string]bool
+	boxes    map[string]map[string]interface{}
+	// boxUsers maps box IDs to the roles of their users by email.
+	boxUsers map[string]map[string]string
+}
+
+// NewPortalServer starts a portal server accepting the given API token. The
+// caller should call Close when finished.
+func NewPortalServer(token string) *PortalServer {
+	s := &PortalServer{
+		Token:    token,
+		licenses: map[string]map[string]interface{}{},
+		sessions: map[string]bool{},
+		boxes:    map[string]map[string]interface{}{},
+		boxUsers: map[string]map[string]string{},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// Endpoint returns the RPC endpoint to configure the provider with.
+func (s *PortalServer) Endpoint() string {
+	return s.URL + "/rpc"
+}
+
+// License returns a copy of the stored license, and whether it exists.
+func (s *PortalServer) License(id string) (map[string]interface{}, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	license, ok := s.licenses[id]
+	if !ok {
+		return nil, false
+	}
+
+	copied := make(map[string]interface{}, len(license))
+	for key, value := range license {
+		copied[key] = value
+	}
+	return copied, true
+}
+
+// UpdateLicense changes attributes of a stored license, simulating an edit
+// made in the portal UI, and bumps its version.
+func (s *PortalServer) UpdateLicense(id string, attributes map[string]interface{}) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	license, ok := s.licenses[id]
+	if !ok {
+		return false
+	}
+
+	for key, value := range attributes {
+		license[key] = value
+	}
+	meta, ok := license["meta"].(map[string]interface{})
+	if !ok {
+		meta = map[string]interface{}{}
+		license["meta"] = meta
+	}
+	version, _ := strconv.Atoi(fmt.Sprint(meta["versionId"]))
+	meta["versionId"] = strconv.Itoa(version + 1)
+	meta["lastUpdated"] = time.Now().UTC().Format(time.RFC3339)
+	return true
+}
+
+type rpcRequest struct {
+	Method string                 `yaml:"method"`
+	Params map[string]interface{} `yaml:"params"`
+}
+
+func (s *PortalServer) handle(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req rpcRequest
+	if err := yaml.Unmarshal(body, &req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid request: %s", err))
+		return
+	}
+
+	if token, _ := req.Params["token"].(string); token != s.Token {
+		writeError(w, http.StatusUnauthorized, "Invalid token")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id, _ := req.Params["id"].(string)
+
+	switch req.Method {
+	case "portal.portal/issue-license":
+		license := s.issueLicense(req.Params)
+		writeResult(w, map[string]interface{}{"license": license, "jwt": licenseJWT(license)})
+	case "portal.portal/get-license":
+		license, ok := s.licenses[id]
+		if !ok {
+			writeError(w, http.StatusUnprocessableEntity, "You are not a member of the project")
+			return
+		}
+		writeResult(w, map[string]interface{}{"license": license, "jwt": licenseJWT(license)})
+	case "portal.portal/get-licenses":
+		licenses := make([]map[string]interface{}, 0, len(s.licenses))
+		for _, license := range s.licenses {
+			licenses = append(licenses, license)
+		}
+		sort.Slice(licenses, func(i, j int) bool {
+			return fmt.Sprint(licenses[i]["id"]) < fmt.Sprint(licenses[j]["id"])
+		})
+		writeResult(w, map[string]interface{}{"licenses": licenses})
+	case "portal.portal/remove-license":
+		if _, ok := s.licenses[id]; !ok {
+			writeError(w, http.StatusUnprocessableEntity, "You are not a member of the project")
+			return
+		}
+		delete(s.licenses, id)
+		writeResult(w, map[string]interface{}{})
+	case "portal.portal/open-session":
+		s.nextID++
+		sessionID := fmt.Sprintf("session-%d", s.nextID)
+		s.sessions[sessionID] = true
+		writeResult(w, map[string]interface{}{
+			"session": map[string]interface{}{
+				"id":      sessionID,
+				"token":   fmt.Sprintf("session-token-%d", s.nextID),
+				"expires": time.Now().Add(time.Hour).UTC().Format(time.RFC3339),
+			},
+		})
+	case "portal.portal/close-session":
+		if !s.sessions[id] {
+			writeError(w, http.StatusNotFound, "Session not found")
+			return
+		}
+		delete(s.sessions, id)
+		writeResult(w, map[string]interface{}{})
+	case "multibox/create-box":
+		if _, ok := s.boxes[id]; ok {
+			writeError(w, http.StatusConflict, fmt.Sprintf("Box %s already exists", id))
+			return
+		}
+		s.nextID++
+		box := map[string]interface{}{
+			"id":           id,
+			"fhir-version": req.Params["fhir-version"],
+			"description":  req.Params["description"],
+			"env":          req.Params["env"],
+			"box-url":      s.URL + "/boxes/" + id,
+		}
+		s.boxes[id] = box
+		writeResult(w, map[string]interface{}{
+			"box": box,
+			"credentials": map[string]interface{}{
+				"client-id":     "root",
+				"client-secret": fmt.Sprintf("box-secret-%d", s.nextID),
+			},
+		})
+	case "multibox/get-box":
+		box, ok := s.boxes[id]
+		if !ok {
+			writeError(w, http.StatusUnprocessableEntity, "Box not found")
+			return
+		}
+		writeResult(w, map[string]interface{}{"box": box})
+	case "multibox/update-box":
+		box, ok := s.boxes[id]
+		if !ok {
+			writeError(w, http.StatusUnprocessableEntity, "Box not found")
+			return
+		}
+		// Attributes left out of the request are kept
+		for _, key := range []string{"description", "env"} {
+			if value, ok := req.Params[key]; ok {
+				box[key] = value
+			}
+		}
+		writeResult(w, map[string]interface{}{"box": box})
+	case "multibox/delete-box":
+		if _, ok := s.boxes[id]; !ok {
+			writeError(w, http.StatusUnprocessableEntity, "Box not found")
+			return
+		}
+		delete(s.boxes, id)
+		delete(s.boxUsers, id)
+		writeResult(w, map[string]interface{}{})
+	case "multibox/grant-box-access", "multibox/get-box-user", "multibox/revoke-box-access":
+		s.handleBoxUser(w, req)
+	default:
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Unknown method %s", req.Method))
+	}
+}
+
+func (s *PortalServer) handleBoxUser(w http.ResponseWriter, req rpcRequest) {
+	boxID, _ := req.Params["box-id"].(string)
+	email, _ := req.Params["email"].(string)
+	if _, ok := s.boxes[boxID]; !ok {
+		writeError(w, http.StatusUnprocessableEntity, "Box not found")
+		return
+	}
+
+	users := s.boxUsers[boxID]
+	if req.Method == "multibox/grant-box-access" {
+		if users == nil {
+			users = map[string]string{}
+			s.boxUsers[boxID] = users
+		}
+		users[email], _ = req.Params["role"].(string)
+	}
+
+	role, ok := users[email]
+	if !ok {
+		writeError(w, http.StatusUnprocessableEntity, "User not found")
+		return
+	}
+
+	if req.Method == "multibox/revoke-box-access" {
+		delete(users, email)
+		writeResult(w, map[string]interface{}{})
+		return
+	}
+	writeResult(w, map[string]interface{}{
+		"user": map[string]interface{}{"box-id": boxID, "email": email, "role": role},
+	})
+}
+
+func (s *PortalServer) issueLicense(params map[string]interface{}) map[string]interface{} {
+	s.nextID++
+	now := time.Now().UTC()
+
+	product, _ := params["product"].(string)
+	if product == "" {
+		product = "aidbox"
+	}
+
+	id := fmt.Sprintf("license-%d", s.nextID)
+	license := map[string]interface{}{
+		"id":            id,
+		"name":          params["name"],
+		"product":       product,
+		"type":          params["type"],
+		"expiration":    now.AddDate(0, 0, 30).Format(time.RFC3339),
+		"status":        "active",
+		"max-instances": 1,
+		"creator":       map[string]interface{}{"id": "user-1", "resourceType": "User"},
+		"project":       map[string]interface{}{"id": "project-1", "resourceType": "Project"},
+		"offline":       false,
+		"created":       now.Format(time.RFC3339),
+		"meta": map[string]interface{}{
+			"lastUpdated": now.Format(time.RFC3339),
+			"createdAt":   now.Format(time.RFC3339),
+			"versionId":   "1",
+		},
+		"issuer": "https://aidbox.app",
+		"info":   map[string]interface{}{"hosting": "self-hosted"},
+	}
+	s.licenses[id] = license
+	return license
+}
+
+// licenseJWT returns an unsigned JWT with the claims of the license.
+func licenseJWT(license map[string]interface{}) string {
+	claims := map[string]interface{}{
+		"sub":     license["id"],
+		"product": license["product"],
+	}
+	if expiration, err := time.Parse(time.RFC3339, fmt.Sprint(license["expiration"])); err == nil {
+		claims["exp"] = expiration.Unix()
+	}
+
+	payload, _ := json.Marshal(claims)
+	encode := base64.RawURLEncoding.EncodeToString
+	return encode([]byte(`{"alg":"none"}`)) + "." + encode(payload) + "." + encode([]byte("signature"))
+}
+
+func writeResult(w http.ResponseWriter, result map[string]interface{}) {
+	writeYAML(w, http.StatusOK, map[string]interface{}{"result": result})
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeYAML(w, status, map[string]interface{}{"error": map[string]interface{}{"message": message}})
+}
+
+func writeYAML(w http.ResponseWriter, status int, body interface{}) {
+	data, err := yaml.Marshal(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/yaml")
+	w.WriteHeader(status)
+	_, _ = w.Write(data)
+}
@@ -0,0 +1,174 @@
+package aidboxtest
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox"
+)
+
+func TestPortalServerLicenses(t *testing.T) {
+	server := NewPortalServer("token")
+	defer server.Close()
+
+	ctx := context.Background()
+	client := aidbox.NewClient(server.Endpoint(), "token")
+
+	created, err := client.CreateLicense(ctx, "license-one", "aidbox", "development")
+	if err != nil {
+		t.Fatalf("unexpected error creating license: %s", err)
+	}
+	if created.License.ID == "" || created.License.Name != "license-one" || created.JWT == "" {
+		t.Fatalf("unexpected license: %+v", created)
+	}
+
+	fetched, err := client.GetLicense(ctx, created.License.ID)
+	if err != nil {
+		t.Fatalf("unexpected error fetching license: %s", err)
+	}
+	if fetched.License.Meta.VersionID != "1" || fetched.License.Status != "active" {
+		t.Errorf("unexpected license: %+v", fetched.License)
+	}
+
+	licenses, err := client.ListLicenses(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error listing licenses: %s", err)
+	}
+	if len(licenses) != 1 || licenses[0].ID != created.License.ID {
+		t.Errorf("unexpected licenses: %+v", licenses)
+	}
+
+	server.UpdateLicense(created.License.ID, map[string]interface{}{"status": "suspended"})
+	fetched, err = client.GetLicense(ctx, created.License.ID)
+	if err != nil {
+		t.Fatalf("unexpected error fetching license: %s", err)
+	}
+	if fetched.License.Meta.VersionID != "2" || fetched.License.Status != "suspended" {
+		t.Errorf("expected updated license, got: %+v", fetched.License)
+	}
+
+	// Updating a license without meta starts its versions over.
+	server.UpdateLicense(created.License.ID, map[string]interface{}{"meta": nil})
+	fetched, err = client.GetLicense(ctx, created.License.ID)
+	if err != nil {
+		t.Fatalf("unexpected error fetching license: %s", err)
+	}
+	if fetched.License.Meta.VersionID != "1" {
+		t.Errorf("expected the first version, got: %+v", fetched.License.Meta)
+	}
+
+	if err := client.DeleteLicense(ctx, created.License.ID); err != nil {
+		t.Fatalf("unexpected error deleting license: %s", err)
+	}
+	if _, err := client.GetLicense(ctx, created.License.ID); !aidbox.IsNotFound(err) {
+		t.Errorf("expected not found error, got: %v", err)
+	}
+}
+
+func TestPortalServerBoxes(t *testing.T) {
+	server := NewPortalServer("token")
+	defer server.Close()
+
+	ctx := context.Background()
+	client := aidbox.NewClient(server.Endpoint(), "token")
+
+	created, err := client.CreateBox(ctx, aidbox.Box{ID: "staging", FHIRVersion: "4.0.1", Env: map[string]string{"BOX_ID": "staging"}})
+	if err != nil {
+		t.Fatalf("unexpected error creating box: %s", err)
+	}
+	if created.Box.BoxURL == "" || created.Credentials.ClientSecret == "" {
+		t.Fatalf("unexpected box: %+v", created)
+	}
+
+	updated, err := client.UpdateBox(ctx, aidbox.Box{ID: "staging", FHIRVersion: "4.0.1", Description: "Staging"})
+	if err != nil {
+		t.Fatalf("unexpected error updating box: %s", err)
+	}
+	if updated.Box.Description != "Staging" || len(updated.Box.Env) != 0 {
+		t.Errorf("unexpected box: %+v", updated.Box)
+	}
+
+	fetched, err := client.GetBox(ctx, "staging")
+	if err != nil {
+		t.Fatalf("unexpected error fetching box: %s", err)
+	}
+	if fetched.Box.FHIRVersion != "4.0.1" || fetched.Credentials.ClientSecret != "" {
+		t.Errorf("unexpected box: %+v", fetched)
+	}
+
+	user, err := client.GrantBoxAccess(ctx, aidbox.BoxUser{BoxID: "staging", Email: "jane@example.com", Role: "viewer"})
+	if err != nil || user.Role != "viewer" {
+		t.Fatalf("unexpected access granted: %+v, %v", user, err)
+	}
+	if user, err = client.GetBoxUser(ctx, "staging", "jane@example.com"); err != nil || user.Email != "jane@example.com" {
+		t.Errorf("unexpected box user: %+v, %v", user, err)
+	}
+	if err := client.RevokeBoxAccess(ctx, "staging", "jane@example.com"); err != nil {
+		t.Fatalf("unexpected error revoking access: %s", err)
+	}
+	if _, err := client.GetBoxUser(ctx, "staging", "jane@example.com"); !aidbox.IsNotFound(err) {
+		t.Errorf("expected not found error, got: %v", err)
+	}
+
+	if err := client.DeleteBox(ctx, "staging"); err != nil {
+		t.Fatalf("unexpected error deleting box: %s", err)
+	}
+	if _, err := client.GetBox(ctx, "staging"); !aidbox.IsNotFound(err) {
+		t.Errorf("expected not found error, got: %v", err)
+	}
+}
+
+func TestPortalServerSessions(t *testing.T) {
+	server := NewPortalServer("token")
+	defer server.Close()
+
+	ctx := context.Background()
+	client := aidbox.NewClient(server.Endpoint(), "token")
+
+	session, err := client.OpenSession(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error opening session: %s", err)
+	}
+	if session.ID == "" || session.Token == "" {
+		t.Fatalf("unexpected session: %+v", session)
+	}
+
+	if err := client.CloseSession(ctx, session.ID); err != nil {
+		t.Fatalf("unexpected error closing session: %s", err)
+	}
+	if err := client.CloseSession(ctx, session.ID); !aidbox.IsNotFound(err) {
+		t.Errorf("expected not found error, got: %v", err)
+	}
+}
+
+func TestPortalServerInvalidToken(t *testing.T) {
+	server := NewPortalServer("token")
+	defer server.Close()
+
+	_, err := aidbox.NewClient(server.Endpoint(), "wrong").GetLicense(context.Background(), "license-1")
+	if !aidbox.IsAuthError(err) {
+		t.Errorf("expected auth error, got: %v", err)
+	}
+}
+
+func TestPortalServerCall(t *testing.T) {
+	server := NewPortalServer("token")
+	defer server.Close()
+
+	ctx := context.Background()
+	client := aidbox.NewClient(server.Endpoint(), "token")
+
+	body, err := client.Call(ctx, "portal.portal/get-licenses", nil)
+	if err != nil {
+		t.Fatalf("unexpected error calling RPC method: %s", err)
+	}
+	if !strings.Contains(string(body), "result:") {
+		t.Errorf("expected a result, got: %s", body)
+	}
+
+	_, err = aidbox.NewClient(server.Endpoint(), "invalid").Call(ctx, "portal.portal/get-licenses", nil)
+	if !aidbox.IsAuthError(err) {
+		t.Errorf("expected an authentication error, got: %v", err)
+	}
+}
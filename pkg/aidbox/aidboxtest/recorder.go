@@ -0,0 +1,197 @@
+package aidboxtest
+
+import (
+	"bytes"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+)
+
+// RecorderMode selects whether a Recorder captures or replays interactions.
+type RecorderMode int
+
+const (
+	// ModeReplay serves responses from the fixture file without any network
+	// access.
+	ModeReplay RecorderMode = iota
+	// ModeRecord forwards requests to the real portal and saves the
+	// sanitized interactions to the fixture file.
+	ModeRecord
+)
+
+// Redacted replaces secrets in recorded interactions.
+const Redacted = "REDACTED"
+
+// secretPatterns match the values removed from recorded interactions.
+var secretPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?m)^(\s*token:\s*).+$`),
+	regexp.MustCompile(`(?m)^(\s*jwt:\s*).+$`),
+}
+
+// Interaction is a recorded request and response pair.
+type Interaction struct {
+	Request  RecordedRequest  `yaml:"request"`
+	Response RecordedResponse `yaml:"response"`
+}
+
+// RecordedRequest is the sanitized request of an interaction.
+type RecordedRequest struct {
+	Method string `yaml:"method"`
+	Body   string `yaml:"body"`
+}
+
+// RecordedResponse is the sanitized response of an interaction.
+type RecordedResponse struct {
+	StatusCode int    `yaml:"status_code"`
+	Body       string `yaml:"body"`
+}
+
+// Recorder is an http.RoundTripper recording interactions with the portal to
+// a fixture file, or replaying them from it.
+type Recorder struct {
+	mode      RecorderMode
+	path      string
+	transport http.RoundTripper
+
+	mu           sync.Mutex
+	interactions []Interaction
+	position     int
+}
+
+// NewRecorder returns a recorder for the fixture at path. In replay mode the
+// fixture is loaded immediately.
+func NewRecorder(path string, mode RecorderMode) (*Recorder, error) {
+	r := &Recorder{
+		mode:      mode,
+		path:      path,
+		transport: http.DefaultTransport,
+	}
+
+	if mode == ModeReplay {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read fixture: %w", err)
+		}
+		if err := yaml.Unmarshal(data, &r.interactions); err != nil {
+			return nil, fmt.Errorf("failed to parse fixture %s: %w", path, err)
+		}
+	}
+
+	return r, nil
+}
+
+// Client returns an HTTP client using the recorder as transport.
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.mode == ModeReplay {
+		return r.replay(req, body)
+	}
+
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	r.interactions = append(r.interactions, Interaction{
+		Request:  RecordedRequest{Method: req.Method, Body: Sanitize(body)},
+		Response: RecordedResponse{StatusCode: resp.StatusCode, Body: Sanitize(respBody)},
+	})
+
+	return resp, nil
+}
+
+func (r *Recorder) replay(req *http.Request, body []byte) (*http.Response, error) {
+	if r.position >= len(r.interactions) {
+		return nil, fmt.Errorf("fixture %s has no interaction left for request:\n%s", r.path, body)
+	}
+
+	interaction := r.interactions[r.position]
+	if got := Sanitize(body); interaction.Request.Method != req.Method || interaction.Request.Body != got {
+		return nil, fmt.Errorf("request %d does not match fixture %s, expected:\n%s\ngot:\n%s", r.position, r.path, interaction.Request.Body, got)
+	}
+	r.position++
+
+	return &http.Response{
+		StatusCode: interaction.Response.StatusCode,
+		Status:     fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+		Header:     http.Header{"Content-Type": []string{"text/yaml"}},
+		Body:       io.NopCloser(bytes.NewReader([]byte(interaction.Response.Body))),
+		Request:    req,
+	}, nil
+}
+
+// Remaining returns the number of interactions of the fixture not replayed.
+func (r *Recorder) Remaining() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return len(r.interactions) - r.position
+}
+
+// Stop saves the recorded interactions to the fixture file in record mode.
+func (r *Recorder) Stop() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	data, err := yaml.Marshal(r.interactions)
+	if err != nil {
+		return fmt.Errorf("failed to encode fixture: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(r.path, data, 0o644)
+}
+
+// Sanitize removes tokens and JWTs from a YAML request or response body.
+func Sanitize(body []byte) string {
+	sanitized := body
+	for _, pattern := range secretPatterns {
+		sanitized = pattern.ReplaceAll(sanitized, []byte("${1}"+Redacted))
+	}
+	return string(sanitized)
+}
+
+func readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	return body, nil
+}
@@ -0,0 +1,21 @@
+package aidboxtest
+
+import (
+	"testing"
+)
+
+func TestSanitize(t *testing.T) {
+	body := "method: portal.portal/get-license\nparams:\n    id: license-1\n    token: secret\n"
+	expected := "method: portal.portal/get-license\nparams:\n    id: license-1\n    token: REDACTED\n"
+
+	if got := Sanitize([]byte(body)); got != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+
+	body = "result:\n    jwt: header.payload.signature\n    license:\n        id: license-1\n"
+	expected = "result:\n    jwt: REDACTED\n    license:\n        id: license-1\n"
+
+	if got := Sanitize([]byte(body)); got != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+}
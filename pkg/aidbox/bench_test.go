@@ -0,0 +1,160 @@
+package aidbox_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox/aidboxtest"
+)
+
+func BenchmarkHTTPClient_CreateLicense(b *testing.B) {
+	server := aidboxtest.NewPortalServer("token")
+	defer server.Close()
+
+	ctx := context.Background()
+	client := aidbox.NewClient(server.Endpoint(), "token")
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := client.CreateLicense(ctx, fmt.Sprintf("license-%d", i), "aidbox", "development"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkHTTPClient_GetLicense(b *testing.B) {
+	server := aidboxtest.NewPortalServer("token")
+	defer server.Close()
+
+	ctx := context.Background()
+	client := aidbox.NewClient(server.Endpoint(), "token")
+
+	created, err := client.CreateLicense(ctx, "license-one", "aidbox", "development")
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := client.GetLicense(ctx, created.License.ID); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkHTTPClient_GetLicenseParallel measures throughput when Terraform
+// refreshes many licenses concurrently.
+func BenchmarkHTTPClient_GetLicenseParallel(b *testing.B) {
+	server := aidboxtest.NewPortalServer("token")
+	defer server.Close()
+
+	ctx := context.Background()
+	client := aidbox.NewClient(server.Endpoint(), "token")
+
+	ids := make([]string, 100)
+	for i := range ids {
+		created, err := client.CreateLicense(ctx, fmt.Sprintf("license-%d", i), "aidbox", "development")
+		if err != nil {
+			b.Fatal(err)
+		}
+		ids[i] = created.License.ID
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			if _, err := client.GetLicense(ctx, ids[i%len(ids)]); err != nil {
+				b.Error(err)
+				return
+			}
+			i++
+		}
+	})
+}
+
+// TestHTTPClient_concurrentLicenses is a stress test creating, reading and
+// deleting hundreds of licenses concurrently, as in a large apply.
+func TestHTTPClient_concurrentLicenses(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping stress test in short mode")
+	}
+
+	server := aidboxtest.NewPortalServer("token")
+	defer server.Close()
+
+	ctx := context.Background()
+	client := aidbox.NewClient(server.Endpoint(), "token")
+
+	const count = 300
+	// Terraform applies up to 10 operations in parallel by default.
+	const parallelism = 10
+
+	work := make(chan int)
+	errs := make(chan error, count)
+	var wg sync.WaitGroup
+	for w := 0; w < parallelism; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range work {
+				created, err := client.CreateLicense(ctx, fmt.Sprintf("tf-acc-stress-%d", i), "aidbox", "development")
+				if err != nil {
+					errs <- err
+					continue
+				}
+				if _, err := client.GetLicense(ctx, created.License.ID); err != nil {
+					errs <- err
+					continue
+				}
+				if err := client.DeleteLicense(ctx, created.License.ID); err != nil {
+					errs <- err
+				}
+			}
+		}()
+	}
+
+	for i := 0; i < count; i++ {
+		work <- i
+	}
+	close(work)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+
+	licenses, err := client.ListLicenses(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(licenses) != 0 {
+		t.Errorf("expected all licenses to be deleted, %d left", len(licenses))
+	}
+}
+
+func BenchmarkHTTPClient_ListLicenses(b *testing.B) {
+	server := aidboxtest.NewPortalServer("token")
+	defer server.Close()
+
+	ctx := context.Background()
+	client := aidbox.NewClient(server.Endpoint(), "token")
+	if _, err := client.CreateLicense(ctx, "license-one", "aidbox", "development"); err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := client.ListLicenses(ctx); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
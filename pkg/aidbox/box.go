@@ -0,0 +1,387 @@
+package aidbox
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// Resource is a resource of an Aidbox box, such as an AidboxConfig or a
+// NotificationTemplate, in its JSON representation.
+type Resource map[string]interface{}
+
+// ResourceType returns the resourceType of the resource.
+func (r Resource) ResourceType() string {
+	resourceType, _ := r["resourceType"].(string)
+	return resourceType
+}
+
+// ID returns the id of the resource.
+func (r Resource) ID() string {
+	id, _ := r["id"].(string)
+	return id
+}
+
+// VersionID returns meta.versionId of the resource, or an empty string when
+// the box did not report it.
+func (r Resource) VersionID() string {
+	meta, _ := r["meta"].(map[string]interface{})
+	versionID, _ := meta["versionId"].(string)
+	return versionID
+}
+
+// BoxClient manages the resources of an Aidbox box through its REST API,
+// authenticating as an Aidbox Client with basic authentication, or with
+// access tokens when UseTokens is set.
+type BoxClient struct {
+	URL          string
+	ClientID     string
+	ClientSecret string
+	Client       *http.Client
+
+	// Compress gzips request bodies larger than compressMinSize. The box, or
+	// the proxy in front of it, must accept gzip encoded requests. Responses
+	// are decompressed by the HTTP transport either way.
+	Compress bool
+
+	// FHIR manages resources through the FHIR API of the box, under /fhir,
+	// exchanging them as application/fhir+json, instead of the Aidbox API.
+	// Other endpoints, such as settings and sequences, are not affected.
+	FHIR bool
+
+	// UseTokens authenticates with access tokens obtained with the OAuth
+	// client credentials grant, instead of sending the client secret with
+	// every request.
+	UseTokens bool
+	// TokenCacheDir is the directory where access tokens are cached, so that
+	// other clients of the same box and Client, such as in other provider
+	// instances, reuse them until they expire. Tokens are only cached in
+	// memory when empty.
+	TokenCacheDir string
+
+	tokenMu sync.Mutex
+	token   *accessToken
+}
+
+// fhirPath is the base path of the FHIR API of a box.
+const fhirPath = "/fhir"
+
+// compressMinSize is the size, in bytes, of the smallest request body
+// compressed, below which compression doesn't pay off.
+const compressMinSize = 1024
+
+func NewBoxClient(boxURL, clientID, clientSecret string) *BoxClient {
+	return &BoxClient{
+		URL:          strings.TrimRight(boxURL, "/"),
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		Client:       http.DefaultClient,
+	}
+}
+
+// GetResource reads the resource of the given type and id.
+func (c *BoxClient) GetResource(ctx context.Context, resourceType, id string) (Resource, error) {
+	var resource Resource
+	err := c.do(ctx, http.MethodGet, c.apiPath(resourcePath(resourceType, id)), nil, &resource)
+	return resource, err
+}
+
+// GetResourceIfModified reads the resource of the given type and id unless
+// its version is still versionID, in which case the box answers with a 304
+// status and the error satisfies IsNotModified. An empty versionID always
+// reads the resource.
+func (c *BoxClient) GetResourceIfModified(ctx context.Context, resourceType, id, versionID string) (Resource, error) {
+	var header http.Header
+	if versionID != "" {
+		header = http.Header{"If-None-Match": {fmt.Sprintf("W/%q", versionID)}}
+	}
+
+	var resource Resource
+	err := c.doWithHeader(ctx, http.MethodGet, c.apiPath(resourcePath(resourceType, id)), header, nil, &resource)
+	return resource, err
+}
+
+// PutResource creates or replaces the resource, identified by its
+// resourceType and id, and returns the resource stored by the box. Unless
+// versionID is empty, the resource is only replaced if its version is still
+// versionID; otherwise the box answers with a 412 status and the error
+// satisfies IsConflict.
+func (c *BoxClient) PutResource(ctx context.Context, resource Resource, versionID string) (Resource, error) {
+	if resource.ResourceType() == "" || resource.ID() == "" {
+		return nil, fmt.Errorf("resource must have a resourceType and an id")
+	}
+
+	var header http.Header
+	if versionID != "" {
+		header = http.Header{"If-Match": {fmt.Sprintf("W/%q", versionID)}}
+	}
+
+	var stored Resource
+	err := c.doWithHeader(ctx, http.MethodPut, c.apiPath(resourcePath(resource.ResourceType(), resource.ID())), header, resource, &stored)
+	return stored, err
+}
+
+// DeleteResource deletes the resource of the given type and id.
+func (c *BoxClient) DeleteResource(ctx context.Context, resourceType, id string) error {
+	return c.do(ctx, http.MethodDelete, c.apiPath(resourcePath(resourceType, id)), nil, nil)
+}
+
+// SearchResources returns the resources of the given type matching the
+// search parameters, following the pages of the search results until limit
+// resources are read. A limit of 0 reads all the pages.
+func (c *BoxClient) SearchResources(ctx context.Context, resourceType string, params url.Values, limit int) ([]Resource, error) {
+	path := c.apiPath("/" + url.PathEscape(resourceType))
+	if len(params) > 0 {
+		path += "?" + params.Encode()
+	}
+
+	var resources []Resource
+	for path != "" {
+		var bundle searchBundle
+		if err := c.do(ctx, http.MethodGet, path, nil, &bundle); err != nil {
+			return nil, err
+		}
+		for _, entry := range bundle.Entry {
+			if entry.Resource != nil {
+				resources = append(resources, entry.Resource)
+			}
+		}
+		if limit > 0 && len(resources) >= limit {
+			return resources[:limit], nil
+		}
+
+		next := bundle.nextPath(c.URL)
+		if next == path {
+			break
+		}
+		path = next
+	}
+
+	return resources, nil
+}
+
+// RunOperation calls the operation at path, such as /ValueSet/$expand, with
+// the given parameters and returns its result.
+func (c *BoxClient) RunOperation(ctx context.Context, path string, params url.Values) (Resource, error) {
+	path = c.apiPath(path)
+	if len(params) > 0 {
+		path += "?" + params.Encode()
+	}
+
+	var result Resource
+	err := c.do(ctx, http.MethodGet, path, nil, &result)
+	return result, err
+}
+
+// searchBundle is a page of search results.
+type searchBundle struct {
+	Entry []struct {
+		Resource Resource `json:"resource"`
+	} `json:"entry"`
+	Link []struct {
+		Relation string `json:"relation"`
+		URL      string `json:"url"`
+	} `json:"link"`
+}
+
+// nextPath returns the path of the next page of search results, relative to
+// the box URL, or an empty string on the last page.
+func (b searchBundle) nextPath(boxURL string) string {
+	for _, link := range b.Link {
+		if link.Relation != "next" || link.URL == "" {
+			continue
+		}
+		if strings.HasPrefix(link.URL, boxURL+"/") {
+			return strings.TrimPrefix(link.URL, boxURL)
+		}
+		next, err := url.Parse(link.URL)
+		if err != nil {
+			return ""
+		}
+		return next.RequestURI()
+	}
+	return ""
+}
+
+func resourcePath(resourceType, id string) string {
+	return "/" + url.PathEscape(resourceType) + "/" + url.PathEscape(id)
+}
+
+// apiPath returns the path of a resource, a search or an operation in the
+// API the client manages resources with. Paths already in the FHIR API, such
+// as /fhir/metadata, are left as is.
+func (c *BoxClient) apiPath(path string) string {
+	if c.FHIR && !strings.HasPrefix(path, fhirPath+"/") {
+		return fhirPath + path
+	}
+	return path
+}
+
+// mediaType returns the media type of the requests and responses at path.
+func (c *BoxClient) mediaType(path string) string {
+	if c.FHIR && strings.HasPrefix(path, fhirPath+"/") {
+		return "application/fhir+json"
+	}
+	return "application/json"
+}
+
+// do sends a request with body, when not nil, encoded as JSON, and decodes
+// the response into result, when not nil.
+func (c *BoxClient) do(ctx context.Context, method, path string, body, result interface{}) error {
+	return c.doWithHeader(ctx, method, path, nil, body, result)
+}
+
+// doWithHeader sends a request as do, adding header to the request headers,
+// or replacing the default ones.
+func (c *BoxClient) doWithHeader(ctx context.Context, method, path string, header http.Header, body, result interface{}) error {
+	resp, err := c.send(ctx, method, path, header, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// The response is decoded as it is read, rather than read in full first,
+	// as resources such as large ValueSets span several megabytes.
+	if result != nil {
+		err = json.NewDecoder(resp.Body).Decode(result)
+		if err != nil && err != io.EOF {
+			tflog.Error(ctx, "Failed to parse JSON response", map[string]interface{}{"error": err})
+			return fmt.Errorf("failed to parse JSON response: %w", err)
+		}
+	}
+
+	// Read what is left of the body, so that the connection can be reused.
+	if _, err := io.Copy(io.Discard, resp.Body); err != nil {
+		tflog.Error(ctx, "Failed to read response body", map[string]interface{}{"error": err})
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	return nil
+}
+
+// send sends a request with body, when not nil, encoded as JSON. An
+// unsuccessful response is returned as an error, a successful one with its
+// body left for the caller to read and close.
+func (c *BoxClient) send(ctx context.Context, method, path string, header http.Header, body interface{}) (*http.Response, error) {
+	request := method + " " + path
+
+	var jsonData []byte
+	var contentEncoding string
+	if body != nil {
+		var err error
+		jsonData, err = json.Marshal(body)
+		if err != nil {
+			tflog.Error(ctx, "Failed to create JSON request body", map[string]interface{}{"error": err})
+			return nil, fmt.Errorf("failed to create JSON request body: %w", err)
+		}
+		if c.Compress && len(jsonData) >= compressMinSize {
+			jsonData, err = gzipBytes(jsonData)
+			if err != nil {
+				tflog.Error(ctx, "Failed to compress request body", map[string]interface{}{"error": err})
+				return nil, fmt.Errorf("failed to compress request body: %w", err)
+			}
+			contentEncoding = "gzip"
+		}
+	}
+
+	for attempt := 1; ; attempt++ {
+		token := ""
+		if c.UseTokens {
+			var err error
+			if token, err = c.accessToken(ctx); err != nil {
+				return nil, err
+			}
+		}
+
+		// The body is read anew by every attempt
+		var reader io.Reader
+		if jsonData != nil {
+			reader = bytes.NewReader(jsonData)
+		}
+		req, err := http.NewRequestWithContext(ctx, method, c.URL+path, reader)
+		if err != nil {
+			tflog.Error(ctx, "Failed to create HTTP request", map[string]interface{}{"error": err})
+			return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+		}
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		} else {
+			req.SetBasicAuth(c.ClientID, c.ClientSecret)
+		}
+		req.Header.Set("Accept", c.mediaType(path))
+		if body != nil {
+			req.Header.Set("Content-Type", c.mediaType(path))
+		}
+		if contentEncoding != "" {
+			req.Header.Set("Content-Encoding", contentEncoding)
+		}
+		for name, values := range header {
+			req.Header[name] = values
+		}
+
+		resp, err := c.Client.Do(req)
+		if err != nil {
+			tflog.Error(ctx, "Box API call failed", map[string]interface{}{"error": err})
+			return nil, fmt.Errorf("box API call failed: %w", err)
+		}
+		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			return resp, nil
+		}
+
+		// A cached token may have been revoked before its expiry: it is
+		// exchanged again once.
+		if resp.StatusCode == http.StatusUnauthorized && token != "" && attempt == 1 {
+			tflog.Debug(ctx, "Box access token rejected, exchanging it again", map[string]interface{}{"request": request})
+			resp.Body.Close()
+			c.invalidateToken(ctx, token)
+			continue
+		}
+
+		return nil, boxResponseError(ctx, request, resp)
+	}
+}
+
+// boxResponseError returns the error of an unsuccessful response, closing
+// its body.
+func boxResponseError(ctx context.Context, request string, resp *http.Response) error {
+	defer resp.Body.Close()
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		tflog.Error(ctx, "Failed to read response body", map[string]interface{}{"error": err})
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	// A conditional read of an unchanged resource is not a failure
+	if resp.StatusCode == http.StatusNotModified {
+		tflog.Debug(ctx, "Box resource not modified", map[string]interface{}{"request": request})
+		return newBoxAPIError(request, resp.StatusCode, resp.Header, bodyBytes)
+	}
+
+	tflog.Error(ctx, "Box API response error", map[string]interface{}{
+		"request": request,
+		"status":  resp.Status,
+		"body":    string(bodyBytes),
+	})
+	return newBoxAPIError(request, resp.StatusCode, resp.Header, bodyBytes)
+}
+
+func gzipBytes(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write(data); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
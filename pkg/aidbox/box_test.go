@@ -0,0 +1,370 @@
+package aidbox
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestBoxClientResources(t *testing.T) {
+	resources := map[string]Resource{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, password, ok := r.BasicAuth(); !ok || user != "root" || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodPut:
+			var resource Resource
+			if err := json.NewDecoder(r.Body).Decode(&resource); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			resource["meta"] = map[string]interface{}{"versionId": "1"}
+			resources[r.URL.Path] = resource
+			_ = json.NewEncoder(w).Encode(resource)
+		case http.MethodGet:
+			resource, ok := resources[r.URL.Path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				fmt.Fprint(w, `{"resourceType": "OperationOutcome", "issue": [{"severity": "fatal", "code": "not-found"}]}`)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(resource)
+		case http.MethodDelete:
+			if _, ok := resources[r.URL.Path]; !ok {
+				w.WriteHeader(http.StatusGone)
+				return
+			}
+			delete(resources, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client := NewBoxClient(server.URL+"/", "root", "secret")
+
+	stored, err := client.PutResource(ctx, Resource{"resourceType": "NotificationTemplate", "id": "welcome", "subject": "Welcome"}, "")
+	if err != nil {
+		t.Fatalf("unexpected error putting resource: %s", err)
+	}
+	if stored.VersionID() != "1" {
+		t.Errorf("expected version 1, got: %v", stored)
+	}
+
+	fetched, err := client.GetResource(ctx, "NotificationTemplate", "welcome")
+	if err != nil {
+		t.Fatalf("unexpected error getting resource: %s", err)
+	}
+	if fetched.ID() != "welcome" || fetched.ResourceType() != "NotificationTemplate" || fetched["subject"] != "Welcome" {
+		t.Errorf("unexpected resource: %v", fetched)
+	}
+
+	if err := client.DeleteResource(ctx, "NotificationTemplate", "welcome"); err != nil {
+		t.Fatalf("unexpected error deleting resource: %s", err)
+	}
+
+	_, err = client.GetResource(ctx, "NotificationTemplate", "welcome")
+	if !IsNotFound(err) {
+		t.Fatalf("expected a not found error, got: %v", err)
+	}
+	var apiErr *APIError
+	if !strings.Contains(err.Error(), "404") || !errors.As(err, &apiErr) || apiErr.Request != "GET /NotificationTemplate/welcome" {
+		t.Errorf("unexpected error: %#v", err)
+	}
+
+	if err := client.DeleteResource(ctx, "NotificationTemplate", "welcome"); !IsNotFound(err) {
+		t.Errorf("expected a not found error for a deleted resource, got: %v", err)
+	}
+
+	_, err = NewBoxClient(server.URL, "root", "invalid").GetResource(ctx, "NotificationTemplate", "welcome")
+	if !IsAuthError(err) {
+		t.Errorf("expected an authentication error, got: %v", err)
+	}
+}
+
+func TestBoxClientValidationError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		fmt.Fprint(w, `{"resourceType": "OperationOutcome", "issue": [
+			{"severity": "error", "code": "invalid", "diagnostics": "Referenced resource not found", "expression": ["Client.auth"]},
+			{"severity": "error", "code": "required", "details": {"text": "secret is required"}}
+		]}`)
+	}))
+	defer server.Close()
+
+	_, err := NewBoxClient(server.URL, "root", "secret").PutResource(context.Background(), Resource{"resourceType": "Client", "id": "one"}, "")
+	if err == nil || IsNotFound(err) {
+		t.Errorf("expected a validation error not classified as not found, got: %v", err)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || len(apiErr.Issues) != 2 {
+		t.Fatalf("expected the issues of the OperationOutcome, got: %#v", err)
+	}
+	expected := OperationOutcomeIssue{Severity: "error", Code: "invalid", Diagnostics: "Referenced resource not found", Expression: []string{"Client.auth"}}
+	if issue := apiErr.Issues[0]; issue.Severity != expected.Severity || issue.Code != expected.Code || issue.Diagnostics != expected.Diagnostics || strings.Join(issue.Expression, ",") != "Client.auth" {
+		t.Errorf("unexpected issue: %#v", issue)
+	}
+	if issue := apiErr.Issues[1]; issue.Diagnostics != "secret is required" {
+		t.Errorf("expected the details text of an issue without diagnostics, got: %#v", issue)
+	}
+	if apiErr.Message != "- error (invalid) at Client.auth: Referenced resource not found\n- error (required): secret is required" {
+		t.Errorf("unexpected message: %q", apiErr.Message)
+	}
+	if strings.Contains(err.Error(), "OperationOutcome") || !strings.Contains(err.Error(), "422 Unprocessable Entity; error (invalid) at Client.auth") {
+		t.Errorf("expected the error to describe the issues instead of the body, got: %s", err)
+	}
+}
+
+func TestBoxClientFHIR(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path+" "+r.Header.Get("Accept")+" "+r.Header.Get("Content-Type"))
+		switch {
+		case r.URL.Path == "/health":
+			fmt.Fprint(w, `{"status": "pass"}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/fhir/Patient":
+			fmt.Fprint(w, `{"resourceType": "Bundle", "entry": [{"resource": {"resourceType": "Patient", "id": "one"}}]}`)
+		default:
+			_, _ = io.Copy(w, r.Body)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client := NewBoxClient(server.URL, "root", "secret")
+	client.FHIR = true
+
+	if _, err := client.PutResource(ctx, Resource{"resourceType": "Patient", "id": "one"}, ""); err != nil {
+		t.Fatalf("unexpected error putting resource: %s", err)
+	}
+	if _, err := client.SearchResources(ctx, "Patient", nil, 0); err != nil {
+		t.Fatalf("unexpected error searching resources: %s", err)
+	}
+	if _, err := client.RunOperation(ctx, "/fhir/metadata", nil); err != nil {
+		t.Fatalf("unexpected error running operation: %s", err)
+	}
+	if _, err := client.Health(ctx); err != nil {
+		t.Fatalf("unexpected error reading health: %s", err)
+	}
+
+	expected := []string{
+		"PUT /fhir/Patient/one application/fhir+json application/fhir+json",
+		"GET /fhir/Patient application/fhir+json ",
+		"GET /fhir/metadata application/fhir+json ",
+		"GET /health application/json ",
+	}
+	if strings.Join(requests, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("unexpected requests:\n%s", strings.Join(requests, "\n"))
+	}
+}
+
+func TestBoxClientLargeResource(t *testing.T) {
+	concepts := make([]string, 50000)
+	for i := range concepts {
+		concepts[i] = fmt.Sprintf(`{"code": "code-%d", "display": "Code %d"}`, i, i)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		fmt.Fprintf(w, `{"resourceType": "ValueSet", "id": "large", "compose": {"include": [{"concept": [%s]}]}}`, strings.Join(concepts, ","))
+	}))
+	defer server.Close()
+
+	client := NewBoxClient(server.URL, "root", "secret")
+	valueSet, err := client.GetResource(context.Background(), "ValueSet", "large")
+	if err != nil {
+		t.Fatalf("unexpected error getting resource: %s", err)
+	}
+	include := valueSet["compose"].(map[string]interface{})["include"].([]interface{})
+	if concept := include[0].(map[string]interface{})["concept"].([]interface{}); len(concept) != len(concepts) {
+		t.Errorf("expected %d concepts, got: %d", len(concepts), len(concept))
+	}
+
+	if err := client.DeleteResource(context.Background(), "ValueSet", "large"); err != nil {
+		t.Errorf("unexpected error deleting resource without response body: %s", err)
+	}
+}
+
+func TestBoxClientSearchResources(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/User" || r.URL.Query().Get("_count") != "1" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		switch r.URL.Query().Get("_page") {
+		case "":
+			fmt.Fprintf(w, `{"resourceType": "Bundle", "entry": [{"resource": {"resourceType": "User", "id": "alice"}}], "link": [{"relation": "next", "url": "%s/User?_count=1&_page=2"}]}`, server.URL)
+		case "2":
+			fmt.Fprint(w, `{"resourceType": "Bundle", "entry": [{"resource": {"resourceType": "User", "id": "bob"}}], "link": [{"relation": "self", "url": "/User?_count=1&_page=2"}]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewBoxClient(server.URL, "root", "secret")
+	users, err := client.SearchResources(context.Background(), "User", map[string][]string{"_count": {"1"}}, 0)
+	if err != nil {
+		t.Fatalf("unexpected error searching resources: %s", err)
+	}
+	if len(users) != 2 || users[0].ID() != "alice" || users[1].ID() != "bob" {
+		t.Errorf("expected the users of both pages, got: %v", users)
+	}
+
+	users, err = client.SearchResources(context.Background(), "User", map[string][]string{"_count": {"1"}}, 1)
+	if err != nil {
+		t.Fatalf("unexpected error searching resources: %s", err)
+	}
+	if len(users) != 1 || users[0].ID() != "alice" {
+		t.Errorf("expected the users of the first page only, got: %v", users)
+	}
+}
+
+func TestBoxClientRunOperation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/ValueSet/$expand" || r.URL.Query().Get("url") != "http://hl7.org/fhir/ValueSet/administrative-gender" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, `{"resourceType": "ValueSet", "expansion": {"total": 4}}`)
+	}))
+	defer server.Close()
+
+	result, err := NewBoxClient(server.URL, "root", "secret").RunOperation(context.Background(), "/ValueSet/$expand", map[string][]string{"url": {"http://hl7.org/fhir/ValueSet/administrative-gender"}})
+	if err != nil {
+		t.Fatalf("unexpected error running operation: %s", err)
+	}
+	if result.ResourceType() != "ValueSet" {
+		t.Errorf("unexpected result: %v", result)
+	}
+}
+
+func TestBoxClientCompression(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var reader io.Reader = r.Body
+		if r.Header.Get("Content-Encoding") == "gzip" {
+			gzipReader, err := gzip.NewReader(r.Body)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			reader = gzipReader
+		}
+
+		var resource Resource
+		if err := json.NewDecoder(reader).Decode(&resource); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		resource["compressed"] = r.Header.Get("Content-Encoding") == "gzip"
+
+		// Compress the response as the box does when the client accepts it
+		if !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+			_ = json.NewEncoder(w).Encode(resource)
+			return
+		}
+		w.Header().Set("Content-Encoding", "gzip")
+		gzipWriter := gzip.NewWriter(w)
+		_ = json.NewEncoder(gzipWriter).Encode(resource)
+		_ = gzipWriter.Close()
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client := NewBoxClient(server.URL, "root", "secret")
+	client.Compress = true
+
+	small, err := client.PutResource(ctx, Resource{"resourceType": "ValueSet", "id": "small"}, "")
+	if err != nil {
+		t.Fatalf("unexpected error putting resource: %s", err)
+	}
+	if small["compressed"] != false {
+		t.Errorf("expected a small body not to be compressed, got: %v", small)
+	}
+
+	large, err := client.PutResource(ctx, Resource{"resourceType": "ValueSet", "id": "large", "description": strings.Repeat("codes ", 1000)}, "")
+	if err != nil {
+		t.Fatalf("unexpected error putting resource: %s", err)
+	}
+	if large["compressed"] != true || large.ID() != "large" {
+		t.Errorf("expected a large body to be compressed, got: %v", large["compressed"])
+	}
+}
+
+func TestBoxClientGetResourceIfModified(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `W/"2"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		fmt.Fprint(w, `{"resourceType": "Hook", "id": "deploy", "meta": {"versionId": "2"}}`)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client := NewBoxClient(server.URL, "root", "secret")
+
+	fetched, err := client.GetResourceIfModified(ctx, "Hook", "deploy", "1")
+	if err != nil || fetched.VersionID() != "2" {
+		t.Fatalf("expected the new version of the resource, got: %v, %v", fetched, err)
+	}
+
+	_, err = client.GetResourceIfModified(ctx, "Hook", "deploy", "2")
+	if !IsNotModified(err) {
+		t.Errorf("expected a not modified error, got: %v", err)
+	}
+
+	fetched, err = client.GetResourceIfModified(ctx, "Hook", "deploy", "")
+	if err != nil || fetched.ID() != "deploy" {
+		t.Errorf("expected the resource without a version, got: %v, %v", fetched, err)
+	}
+}
+
+func TestBoxClientPutResourceIfMatch(t *testing.T) {
+	var ifMatch []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ifMatch = append(ifMatch, r.Header.Get("If-Match"))
+		if header := r.Header.Get("If-Match"); header != "" && header != `W/"2"` {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			fmt.Fprint(w, `{"resourceType": "OperationOutcome", "issue": [{"severity": "fatal", "code": "conflict", "diagnostics": "Version mismatch"}]}`)
+			return
+		}
+		fmt.Fprint(w, `{"resourceType": "Hook", "id": "deploy", "meta": {"versionId": "3"}}`)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client := NewBoxClient(server.URL, "root", "secret")
+	hook := Resource{"resourceType": "Hook", "id": "deploy"}
+
+	if _, err := client.PutResource(ctx, hook, "1"); !IsConflict(err) || IsNotFound(err) {
+		t.Errorf("expected a conflict error, got: %v", err)
+	}
+
+	stored, err := client.PutResource(ctx, hook, "2")
+	if err != nil || stored.VersionID() != "3" {
+		t.Errorf("expected the new version of the resource, got: %v, %v", stored, err)
+	}
+
+	if _, err := client.PutResource(ctx, hook, ""); err != nil {
+		t.Errorf("unexpected error without a version: %v", err)
+	}
+
+	if strings.Join(ifMatch, ",") != `W/"1",W/"2",` {
+		t.Errorf("unexpected If-Match headers: %q", ifMatch)
+	}
+}
@@ -0,0 +1,195 @@
+package aidbox
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// tokenPath is the OAuth token endpoint of the box.
+const tokenPath = "/auth/token"
+
+// tokenExpiryMargin is how long before their expiry access tokens are
+// exchanged again, so that a token does not expire during a request.
+const tokenExpiryMargin = time.Minute
+
+// accessToken is an access token of the box, in the format of the token
+// cache files.
+type accessToken struct {
+	AccessToken string `json:"access_token"`
+	// Expiry is zero when the box did not report the lifetime of the token.
+	Expiry time.Time `json:"expiry,omitempty"`
+}
+
+func (t *accessToken) valid(now time.Time) bool {
+	return t != nil && t.AccessToken != "" && (t.Expiry.IsZero() || now.Add(tokenExpiryMargin).Before(t.Expiry))
+}
+
+// tokenResponse maps the response of the token endpoint.
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+// accessToken returns an access token of the client, exchanging the client
+// credentials when no valid token is held in memory or in the token cache.
+func (c *BoxClient) accessToken(ctx context.Context) (string, error) {
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+
+	now := time.Now()
+	if c.token.valid(now) {
+		return c.token.AccessToken, nil
+	}
+
+	if c.TokenCacheDir != "" {
+		if token := readCachedToken(c.tokenCachePath()); token.valid(now) && !token.Expiry.IsZero() {
+			tflog.Debug(ctx, "Using cached box access token", map[string]interface{}{"expiry": token.Expiry})
+			c.token = token
+			return token.AccessToken, nil
+		}
+	}
+
+	token, err := c.exchangeToken(ctx)
+	if err != nil {
+		return "", err
+	}
+	c.token = token
+
+	// Tokens of unknown lifetime are not shared, as other clients could not
+	// tell when to stop using them.
+	if c.TokenCacheDir != "" && !token.Expiry.IsZero() {
+		if err := writeCachedToken(c.TokenCacheDir, c.tokenCachePath(), token); err != nil {
+			tflog.Warn(ctx, "Failed to cache box access token", map[string]interface{}{"error": err})
+		}
+	}
+	return token.AccessToken, nil
+}
+
+// exchangeToken obtains an access token with the client credentials grant.
+func (c *BoxClient) exchangeToken(ctx context.Context) (*accessToken, error) {
+	request := http.MethodPost + " " + tokenPath
+	body, err := json.Marshal(map[string]string{
+		"grant_type":    "client_credentials",
+		"client_id":     c.ClientID,
+		"client_secret": c.ClientSecret,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token request body: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.URL+tokenPath, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		tflog.Error(ctx, "Box token request failed", map[string]interface{}{"error": err})
+		return nil, fmt.Errorf("box token request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token response body: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		tflog.Error(ctx, "Box token response error", map[string]interface{}{"status": resp.Status})
+		return nil, newBoxAPIError(request, resp.StatusCode, resp.Header, bodyBytes)
+	}
+
+	var tokenResp tokenResponse
+	if err := json.Unmarshal(bodyBytes, &tokenResp); err != nil {
+		return nil, fmt.Errorf("failed to parse token response: %w", err)
+	}
+	if tokenResp.AccessToken == "" {
+		return nil, fmt.Errorf("no access token in the token response of the box")
+	}
+
+	token := &accessToken{AccessToken: tokenResp.AccessToken}
+	if tokenResp.ExpiresIn > 0 {
+		token.Expiry = time.Now().Add(time.Duration(tokenResp.ExpiresIn) * time.Second)
+	}
+	tflog.Debug(ctx, "Obtained box access token", map[string]interface{}{"expiry": token.Expiry})
+	return token, nil
+}
+
+// invalidateToken forgets the access token, such as after the box rejected
+// it, unless it was already replaced.
+func (c *BoxClient) invalidateToken(ctx context.Context, token string) {
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+
+	if c.token == nil || c.token.AccessToken != token {
+		return
+	}
+	c.token = nil
+
+	if c.TokenCacheDir != "" {
+		if err := os.Remove(c.tokenCachePath()); err != nil && !os.IsNotExist(err) {
+			tflog.Warn(ctx, "Failed to remove cached box access token", map[string]interface{}{"error": err})
+		}
+	}
+}
+
+// tokenCachePath returns the path of the token cache file of the client,
+// named after a hash of the box URL and the client ID.
+func (c *BoxClient) tokenCachePath() string {
+	sum := sha256.Sum256([]byte(c.URL + "\n" + c.ClientID))
+	return filepath.Join(c.TokenCacheDir, hex.EncodeToString(sum[:])+".json")
+}
+
+// readCachedToken returns the token of the cache file at path, or nil when
+// it can't be read.
+func readCachedToken(path string) *accessToken {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var token accessToken
+	if err := json.Unmarshal(data, &token); err != nil {
+		return nil
+	}
+	return &token
+}
+
+// writeCachedToken writes token to the cache file at path, readable by the
+// current user only. The file is replaced at once, so that other provider
+// instances never read a partial token.
+func writeCachedToken(dir, path string, token *accessToken) error {
+	data, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp(dir, ".token-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), path)
+}
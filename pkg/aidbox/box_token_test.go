@@ -0,0 +1,84 @@
+package aidbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+)
+
+func TestBoxClientAccessTokens(t *testing.T) {
+	var exchanges atomic.Int32
+	revoked := map[string]bool{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/auth/token" {
+			var body map[string]string
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body["grant_type"] != "client_credentials" || body["client_secret"] != "secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			fmt.Fprintf(w, `{"access_token": "token-%d", "token_type": "Bearer", "expires_in": 3600}`, exchanges.Add(1))
+			return
+		}
+
+		authorization := r.Header.Get("Authorization")
+		if len(authorization) < 7 || authorization[:7] != "Bearer " || revoked[authorization[7:]] {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `{"resourceType": "Client", "id": "one"}`)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	cacheDir := t.TempDir()
+	newClient := func() *BoxClient {
+		client := NewBoxClient(server.URL, "root", "secret")
+		client.UseTokens = true
+		client.TokenCacheDir = cacheDir
+		return client
+	}
+
+	client := newClient()
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetResource(ctx, "Client", "one"); err != nil {
+			t.Fatalf("unexpected error getting resource: %s", err)
+		}
+	}
+	if exchanges.Load() != 1 {
+		t.Errorf("expected the token to be reused by the client, got %d exchanges", exchanges.Load())
+	}
+
+	info, err := os.Stat(client.tokenCachePath())
+	if err != nil {
+		t.Fatalf("expected the token to be cached: %s", err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Errorf("expected the token cache to be private, got mode: %s", info.Mode())
+	}
+
+	if _, err := newClient().GetResource(ctx, "Client", "one"); err != nil {
+		t.Fatalf("unexpected error getting resource: %s", err)
+	}
+	if exchanges.Load() != 1 {
+		t.Errorf("expected the cached token to be reused by another client, got %d exchanges", exchanges.Load())
+	}
+
+	revoked["token-1"] = true
+	if _, err := newClient().GetResource(ctx, "Client", "one"); err != nil {
+		t.Fatalf("unexpected error getting resource with a revoked token: %s", err)
+	}
+	if exchanges.Load() != 2 {
+		t.Errorf("expected the revoked token to be exchanged again, got %d exchanges", exchanges.Load())
+	}
+
+	client = NewBoxClient(server.URL, "root", "wrong")
+	client.UseTokens = true
+	if _, err := client.GetResource(ctx, "Client", "one"); !IsAuthError(err) {
+		t.Errorf("expected an auth error with invalid credentials, got: %v", err)
+	}
+}
@@ -2,6 +2,7 @@ package aidbox
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"gopkg.in/yaml.v3"
@@ -14,8 +15,17 @@ type HTTPClient struct {
 	Endpoint string
 	Token    string
 	Client   *http.Client
+
+	// MethodNamespace is the namespace of the license and session RPC
+	// methods, DefaultMethodNamespace when empty. Self-hosted license
+	// servers may expose them under another namespace.
+	MethodNamespace string
 }
 
+// DefaultMethodNamespace is the namespace of the license and session RPC
+// methods of the Aidbox portal.
+const DefaultMethodNamespace = "portal.portal"
+
 type Creator struct {
 	ID           string `yaml:"id"`
 	ResourceType string `yaml:"resourceType"`
@@ -48,10 +58,10 @@ type License struct {
 	Type         string     `yaml:"type"`
 	Expiration   string     `yaml:"expiration"`
 	Status       string     `yaml:"status"`
-	MaxInstances int        `yaml:"max-instances"`
+	MaxInstances *int       `yaml:"max-instances"`
 	Creator      Creator    `yaml:"creator"`
 	Project      Project    `yaml:"project"`
-	Offline      bool       `yaml:"offline"`
+	Offline      *bool      `yaml:"offline"`
 	Created      string     `yaml:"created"`
 	Meta         Meta       `yaml:"meta"`
 	Issuer       string     `yaml:"issuer"`
@@ -73,6 +83,27 @@ type APIResponse struct {
 	}
 }
 
+// LicensesAPIResponse maps the YAML response listing the licenses of the project.
+type LicensesAPIResponse struct {
+	Result struct {
+		Licenses []License `yaml:"licenses"`
+	}
+}
+
+// Session is a temporary portal session.
+type Session struct {
+	ID      string `yaml:"id"`
+	Token   string `yaml:"token"`
+	Expires string `yaml:"expires"`
+}
+
+// SessionAPIResponse maps the YAML response of the session RPC methods.
+type SessionAPIResponse struct {
+	Result struct {
+		Session Session `yaml:"session"`
+	}
+}
+
 func NewClient(endpoint, token string) *HTTPClient {
 	return &HTTPClient{
 		Endpoint: endpoint,
@@ -89,7 +120,7 @@ func (c *HTTPClient) CreateLicense(ctx context.Context, name, product, licenseTy
 		"type":    licenseType,
 	}
 
-	bodyBytes, err := c.makeAPICall(ctx, "portal.portal/issue-license", params)
+	bodyBytes, err := c.makeAPICall(ctx, c.portalMethod("issue-license"), params)
 	if err != nil {
 		return LicenseResponse{}, err
 	}
@@ -110,13 +141,10 @@ func (c *HTTPClient) GetLicense(ctx context.Context, licenseID string) (LicenseR
 		"id":    licenseID,
 	}
 
-	bodyBytes, err := c.makeAPICall(ctx, "portal.portal/get-license", params)
+	bodyBytes, err := c.makeAPICall(ctx, c.portalMethod("get-license"), params)
 	if err != nil {
-		if strings.Contains(err.Error(), "You are not a member of the project") {
-			// Interpret as the license not existing; return empty response without error
-			return LicenseResponse{}, nil
-		}
-		return LicenseResponse{}, err
+		// Use IsNotFound to tell a missing license apart from other errors
+		return LicenseResponse{}, c.licenseError(ctx, licenseID, err)
 	}
 
 	// If the API call was successful, parse the response
@@ -130,14 +158,105 @@ func (c *HTTPClient) GetLicense(ctx context.Context, licenseID string) (LicenseR
 	return apiResp, nil
 }
 
+func (c *HTTPClient) ListLicenses(ctx context.Context) ([]License, error) {
+	bodyBytes, err := c.makeAPICall(ctx, c.portalMethod("get-licenses"), map[string]interface{}{
+		"token": c.Token,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp LicensesAPIResponse
+	if err := yaml.Unmarshal(bodyBytes, &apiResp); err != nil {
+		tflog.Error(ctx, "Failed to parse YAML response", map[string]interface{}{"error": err})
+		return nil, fmt.Errorf("failed to parse YAML response: %w", err)
+	}
+
+	return apiResp.Result.Licenses, nil
+}
+
 func (c *HTTPClient) DeleteLicense(ctx context.Context, licenseID string) error {
-	_, err := c.makeAPICall(ctx, "portal.portal/remove-license", map[string]interface{}{
+	_, err := c.makeAPICall(ctx, c.portalMethod("remove-license"), map[string]interface{}{
 		"token": c.Token,
 		"id":    licenseID,
 	})
+	if err != nil {
+		return c.licenseError(ctx, licenseID, err)
+	}
+	return nil
+}
+
+// licenseError returns err as a not found error when the portal answered
+// that the token is not a member of the project of the license, but the
+// license is not among the licenses of the token either: the portal answers
+// the same for deleted licenses and for licenses of other projects.
+func (c *HTTPClient) licenseError(ctx context.Context, licenseID string, err error) error {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Message != notMemberMessage {
+		return err
+	}
+
+	licenses, listErr := c.ListLicenses(ctx)
+	if listErr != nil {
+		tflog.Warn(ctx, "Unable to list licenses to check whether the license was deleted", map[string]interface{}{"error": listErr})
+		return err
+	}
+	for _, license := range licenses {
+		if license.ID == licenseID {
+			return err
+		}
+	}
+
+	notFound := *apiErr
+	notFound.Code = ErrorCodeNotFound
+	return &notFound
+}
+
+func (c *HTTPClient) OpenSession(ctx context.Context) (Session, error) {
+	bodyBytes, err := c.makeAPICall(ctx, c.portalMethod("open-session"), map[string]interface{}{
+		"token": c.Token,
+	})
+	if err != nil {
+		return Session{}, err
+	}
+
+	var apiResp SessionAPIResponse
+	if err := yaml.Unmarshal(bodyBytes, &apiResp); err != nil {
+		tflog.Error(ctx, "Failed to parse YAML response", map[string]interface{}{"error": err})
+		return Session{}, fmt.Errorf("failed to parse YAML response: %w", err)
+	}
+
+	return apiResp.Result.Session, nil
+}
+
+func (c *HTTPClient) CloseSession(ctx context.Context, sessionID string) error {
+	_, err := c.makeAPICall(ctx, c.portalMethod("close-session"), map[string]interface{}{
+		"token": c.Token,
+		"id":    sessionID,
+	})
 	return err
 }
 
+// Call invokes an arbitrary portal RPC method and returns the raw YAML response
+// body. The API token is added to params unless they already include one.
+func (c *HTTPClient) Call(ctx context.Context, method string, params map[string]interface{}) ([]byte, error) {
+	withToken := map[string]interface{}{"token": c.Token}
+	for key, value := range params {
+		withToken[key] = value
+	}
+	return c.makeAPICall(ctx, method, withToken)
+}
+
+// portalMethod returns the RPC method of the given name in the namespace of
+// the license and session methods.
+func (c *HTTPClient) portalMethod(name string) string {
+	namespace := c.MethodNamespace
+	if namespace == "" {
+		namespace = DefaultMethodNamespace
+	}
+	return namespace + "/" + name
+}
+
 func (c *HTTPClient) makeAPICall(ctx context.Context, method string, params map[string]interface{}) ([]byte, error) {
 	requestBody := map[string]interface{}{
 		"method": method,
@@ -150,7 +269,7 @@ func (c *HTTPClient) makeAPICall(ctx context.Context, method string, params map[
 		return nil, fmt.Errorf("failed to create YAML request body: %w", err)
 	}
 
-	req, err := http.NewRequest("POST", c.Endpoint, strings.NewReader(string(yamlData)))
+	req, err := http.NewRequestWithContext(ctx, "POST", c.Endpoint, strings.NewReader(string(yamlData)))
 	if err != nil {
 		tflog.Error(ctx, "Failed to create HTTP request", map[string]interface{}{"error": err})
 		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
@@ -176,7 +295,7 @@ func (c *HTTPClient) makeAPICall(ctx context.Context, method string, params map[
 			"status": resp.Status,
 			"body":   string(bodyBytes),
 		})
-		return nil, fmt.Errorf("API response error: %s; Body: %s", resp.Status, string(bodyBytes))
+		return nil, newAPIError(method, resp.StatusCode, resp.Header, bodyBytes)
 	}
 
 	return bodyBytes, nil
@@ -0,0 +1,174 @@
+package aidbox_test
+
+import (
+	"context"
+	"flag"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox"
+)
+
+var updateContracts = flag.Bool("update-contracts", false, "update the request contracts in testdata/contracts")
+
+// TestRequestContracts asserts the exact payload sent for each RPC method, so
+// that serialization changes, e.g. renamed or re-cased fields, are caught.
+func TestRequestContracts(t *testing.T) {
+	testCases := map[string]func(ctx context.Context, client *aidbox.HTTPClient) error{
+		"issue-license": func(ctx context.Context, client *aidbox.HTTPClient) error {
+			_, err := client.CreateLicense(ctx, "license-one", "aidbox", "development")
+			return err
+		},
+		"get-license": func(ctx context.Context, client *aidbox.HTTPClient) error {
+			_, err := client.GetLicense(ctx, "license-1")
+			return err
+		},
+		"get-licenses": func(ctx context.Context, client *aidbox.HTTPClient) error {
+			_, err := client.ListLicenses(ctx)
+			return err
+		},
+		"remove-license": func(ctx context.Context, client *aidbox.HTTPClient) error {
+			return client.DeleteLicense(ctx, "license-1")
+		},
+		"open-session": func(ctx context.Context, client *aidbox.HTTPClient) error {
+			_, err := client.OpenSession(ctx)
+			return err
+		},
+		"close-session": func(ctx context.Context, client *aidbox.HTTPClient) error {
+			return client.CloseSession(ctx, "session-1")
+		},
+		"create-box": func(ctx context.Context, client *aidbox.HTTPClient) error {
+			_, err := client.CreateBox(ctx, aidbox.Box{
+				ID:          "staging",
+				FHIRVersion: "4.0.1",
+				Description: "Staging box",
+				Env:         map[string]string{"BOX_SEARCH_FHIR__COMPARISONS": "true"},
+			})
+			return err
+		},
+		"get-box": func(ctx context.Context, client *aidbox.HTTPClient) error {
+			_, err := client.GetBox(ctx, "staging")
+			return err
+		},
+		"update-box": func(ctx context.Context, client *aidbox.HTTPClient) error {
+			_, err := client.UpdateBox(ctx, aidbox.Box{ID: "staging", FHIRVersion: "4.0.1"})
+			return err
+		},
+		"delete-box": func(ctx context.Context, client *aidbox.HTTPClient) error {
+			return client.DeleteBox(ctx, "staging")
+		},
+		"grant-box-access": func(ctx context.Context, client *aidbox.HTTPClient) error {
+			_, err := client.GrantBoxAccess(ctx, aidbox.BoxUser{BoxID: "staging", Email: "jane@example.com", Role: "developer"})
+			return err
+		},
+		"get-box-user": func(ctx context.Context, client *aidbox.HTTPClient) error {
+			_, err := client.GetBoxUser(ctx, "staging", "jane@example.com")
+			return err
+		},
+		"revoke-box-access": func(ctx context.Context, client *aidbox.HTTPClient) error {
+			return client.RevokeBoxAccess(ctx, "staging", "jane@example.com")
+		},
+	}
+
+	for name, call := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var body []byte
+			var contentType string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ = io.ReadAll(r.Body)
+				contentType = r.Header.Get("Content-Type")
+				_, _ = io.WriteString(w, "result: {}\n")
+			}))
+			defer server.Close()
+
+			if err := call(context.Background(), aidbox.NewClient(server.URL, "token")); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if contentType != "text/yaml" {
+				t.Errorf("expected text/yaml content type, got %q", contentType)
+			}
+
+			path := filepath.Join("testdata", "contracts", name+".yaml")
+			if *updateContracts {
+				if err := os.WriteFile(path, body, 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			expected, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("failed to read contract, run with -update-contracts to create it: %s", err)
+			}
+
+			if string(body) != string(expected) {
+				t.Errorf("request does not match contract %s, expected:\n%s\ngot:\n%s", path, expected, body)
+			}
+		})
+	}
+}
+
+// TestResponseContract asserts how a portal license response maps to the
+// client types.
+func TestResponseContract(t *testing.T) {
+	response, err := os.ReadFile(filepath.Join("testdata", "contracts", "license-response.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(response)
+	}))
+	defer server.Close()
+
+	resp, err := aidbox.NewClient(server.URL, "token").GetLicense(context.Background(), "license-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	license := resp.License
+	checks := map[string][2]string{
+		"id":                 {license.ID, "license-1"},
+		"name":               {license.Name, "license-one"},
+		"product":            {license.Product, "aidbox"},
+		"type":               {license.Type, "development"},
+		"expiration":         {license.Expiration, "2025-01-01T00:00:00Z"},
+		"status":             {license.Status, "active"},
+		"creator.id":         {license.Creator.ID, "user-1"},
+		"project.id":         {license.Project.ID, "project-1"},
+		"created":            {license.Created, "2024-01-01T00:00:00Z"},
+		"meta.lastUpdated":   {license.Meta.LastUpdated, "2024-01-02T00:00:00Z"},
+		"meta.createdAt":     {license.Meta.CreatedAt, "2024-01-01T00:00:00Z"},
+		"meta.versionId":     {license.Meta.VersionID, "7"},
+		"issuer":             {license.Issuer, "https://aidbox.app"},
+		"info.hosting":       {license.Info.Hosting, "self-hosted"},
+		"jwt":                {resp.JWT, "header.payload.signature"},
+		"additional.box-url": {stringValue(license.Additional.BoxURL), "https://box.example.com"},
+	}
+	for field, values := range checks {
+		if values[0] != values[1] {
+			t.Errorf("expected %s %q, got %q", field, values[1], values[0])
+		}
+	}
+
+	if license.MaxInstances == nil || *license.MaxInstances != 2 {
+		t.Errorf("expected max-instances 2, got %v", license.MaxInstances)
+	}
+	if license.Offline == nil || !*license.Offline {
+		t.Errorf("expected offline true, got %v", license.Offline)
+	}
+	if license.Additional.ExpirationDays != 30 {
+		t.Errorf("expected additional.expiration-days 30, got %d", license.Additional.ExpirationDays)
+	}
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
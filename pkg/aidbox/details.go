@@ -0,0 +1,62 @@
+package aidbox
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// hints suggest how to resolve an API error of a given code.
+var hints = map[ErrorCode]string{
+	ErrorCodeUnauthorized: "The API token is invalid or expired. Please check the provider 'token' or the 'AIDBOX_API_TOKEN' environment variable.",
+	ErrorCodeForbidden:    "The API token lacks the permissions required by this operation, e.g. the project admin role.",
+	ErrorCodeNotFound:     "The resource may have been deleted outside of Terraform.",
+	ErrorCodeConflict:     "The resource was modified concurrently. Please refresh and plan again.",
+}
+
+// Hint suggests how to resolve the error, or returns an empty string.
+func (e *APIError) Hint() string {
+	if hint, ok := hints[e.Code]; ok {
+		return hint
+	}
+	if e.StatusCode >= http.StatusInternalServerError {
+		return "The portal may be temporarily unavailable. Please try again later."
+	}
+	return ""
+}
+
+// Details describes the error for users: the message followed by the RPC
+// method or box request, the error codes, the request ID and a hint on how
+// to resolve it.
+func (e *APIError) Details() string {
+	var b strings.Builder
+
+	message := e.Message
+	if message == "" {
+		message = strings.TrimSpace(e.Body)
+	}
+	if message == "" {
+		message = http.StatusText(e.StatusCode)
+	}
+	fmt.Fprintf(&b, "%s\n\n", message)
+
+	if e.Method != "" {
+		fmt.Fprintf(&b, "RPC method: %s\n", e.Method)
+	}
+	if e.Request != "" {
+		fmt.Fprintf(&b, "Request: %s\n", e.Request)
+	}
+	fmt.Fprintf(&b, "HTTP status: %d %s\n", e.StatusCode, http.StatusText(e.StatusCode))
+	if e.PortalCode != "" {
+		fmt.Fprintf(&b, "Error code: %s\n", e.PortalCode)
+	}
+	if e.RequestID != "" {
+		fmt.Fprintf(&b, "Request ID: %s\n", e.RequestID)
+	}
+
+	if hint := e.Hint(); hint != "" {
+		fmt.Fprintf(&b, "\n%s", hint)
+	}
+
+	return strings.TrimRight(b.String(), "\n")
+}
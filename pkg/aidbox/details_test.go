@@ -0,0 +1,67 @@
+package aidbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAPIErrorDetails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "request-one")
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, "error:\n  message: Access denied\n  code: not-admin\n")
+	}))
+	defer server.Close()
+
+	_, err := NewClient(server.URL, "token").GetLicense(context.Background(), "license-one")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected an API error, got: %v", err)
+	}
+
+	for _, expected := range []string{
+		"Access denied",
+		"RPC method: portal.portal/get-license",
+		"HTTP status: 403 Forbidden",
+		"Error code: not-admin",
+		"Request ID: request-one",
+		hints[ErrorCodeForbidden],
+	} {
+		if !strings.Contains(apiErr.Details(), expected) {
+			t.Errorf("expected details to contain %q, got:\n%s", expected, apiErr.Details())
+		}
+	}
+}
+
+func TestAPIErrorHint(t *testing.T) {
+	testCases := map[string]struct {
+		err      *APIError
+		expected string
+	}{
+		"unauthorized": {
+			err:      &APIError{Code: ErrorCodeUnauthorized, StatusCode: http.StatusUnauthorized},
+			expected: hints[ErrorCodeUnauthorized],
+		},
+		"server-error": {
+			err:      &APIError{Code: ErrorCodeUnknown, StatusCode: http.StatusBadGateway},
+			expected: "The portal may be temporarily unavailable. Please try again later.",
+		},
+		"bad-request": {
+			err:      &APIError{Code: ErrorCodeUnknown, StatusCode: http.StatusBadRequest},
+			expected: "",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := testCase.err.Hint(); got != testCase.expected {
+				t.Errorf("expected hint %q, got %q", testCase.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,11 @@
+// Package aidbox is a Go client for the Aidbox portal RPC API, shared by the
+// Terraform provider and by tools complementing it, such as operators and CLIs.
+//
+// NewClient returns an HTTPClient managing licenses and sessions. Methods not
+// covered by the client can be invoked with HTTPClient.Call. Failed calls
+// return an *APIError, which can be classified with IsNotFound, IsAuthError
+// and IsConflict, and described to users with APIError.Details.
+//
+// The aidboxtest package provides an in-memory portal server and an HTTP
+// recorder for tests, and the fake package an in-memory client.
+package aidbox
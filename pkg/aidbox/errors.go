@@ -0,0 +1,259 @@
+package aidbox
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"net/http"
+	"strings"
+)
+
+// ErrorCode classifies errors returned by the Aidbox API.
+type ErrorCode string
+
+const (
+	ErrorCodeUnknown      ErrorCode = "unknown"
+	ErrorCodeNotFound     ErrorCode = "not-found"
+	ErrorCodeUnauthorized ErrorCode = "unauthorized"
+	ErrorCodeForbidden    ErrorCode = "forbidden"
+	ErrorCodeConflict     ErrorCode = "conflict"
+	ErrorCodeNotModified  ErrorCode = "not-modified"
+)
+
+// portalNotFoundCode is the error code the portal reports for a missing
+// resource.
+const portalNotFoundCode = "not-found"
+
+// notMemberMessage is the error message the portal responds with when the
+// token has no access to the project of the requested resource. The portal
+// answers the same for deleted licenses, which the client tells apart by
+// listing the licenses of the token.
+const notMemberMessage = "You are not a member of the project"
+
+// multiboxNotFoundMessages are the error messages the multibox methods
+// respond with when the box or the user does not exist.
+var multiboxNotFoundMessages = map[string]bool{
+	"Box not found":  true,
+	"User not found": true,
+}
+
+// APIError is returned when the Aidbox API responds with an error.
+type APIError struct {
+	Code       ErrorCode
+	StatusCode int
+	Message    string
+	Body       string
+
+	// Method is the RPC method of the failed call.
+	Method string
+	// Request is the HTTP method and path of a failed box API call.
+	Request string
+	// PortalCode is the error code reported by the portal, if any.
+	PortalCode string
+	// RequestID identifies the request in the portal logs, if reported.
+	RequestID string
+	// Issues are the issues of the OperationOutcome a box responded with.
+	Issues []OperationOutcomeIssue
+}
+
+// OperationOutcomeIssue is an issue of the OperationOutcome a box responds
+// with on errors.
+type OperationOutcomeIssue struct {
+	// Severity is fatal, error, warning or information.
+	Severity string
+	// Code is the FHIR issue type, such as invalid or required.
+	Code string
+	// Diagnostics describes the issue, from its details text when the box
+	// doesn't set its diagnostics.
+	Diagnostics string
+	// Expression lists the FHIRPath of the elements the issue is about, such
+	// as Client.auth.
+	Expression []string
+}
+
+// String describes the issue on a single line, such as
+// "error (invalid) at Client.auth: Referenced resource not found".
+func (i OperationOutcomeIssue) String() string {
+	var b strings.Builder
+	b.WriteString(i.Severity)
+	if i.Code != "" {
+		fmt.Fprintf(&b, " (%s)", i.Code)
+	}
+	if len(i.Expression) > 0 {
+		fmt.Fprintf(&b, " at %s", strings.Join(i.Expression, ", "))
+	}
+	if i.Diagnostics != "" {
+		fmt.Fprintf(&b, ": %s", i.Diagnostics)
+	}
+	return strings.TrimSpace(b.String())
+}
+
+func (e *APIError) Error() string {
+	if len(e.Issues) > 0 {
+		issues := make([]string, len(e.Issues))
+		for i, issue := range e.Issues {
+			issues[i] = issue.String()
+		}
+		return fmt.Sprintf("API response error: %d %s; %s", e.StatusCode, http.StatusText(e.StatusCode), strings.Join(issues, "; "))
+	}
+	return fmt.Sprintf("API response error: %d %s; Body: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Body)
+}
+
+// IsNotFound reports whether err is an API error for a missing resource.
+func IsNotFound(err error) bool {
+	return errorCode(err) == ErrorCodeNotFound
+}
+
+// IsAuthError reports whether err is an API error caused by an invalid
+// token or missing permissions.
+func IsAuthError(err error) bool {
+	code := errorCode(err)
+	return code == ErrorCodeUnauthorized || code == ErrorCodeForbidden
+}
+
+// IsConflict reports whether err is an API error caused by a concurrent
+// modification of the resource.
+func IsConflict(err error) bool {
+	return errorCode(err) == ErrorCodeConflict
+}
+
+// IsNotModified reports whether err is the response to a conditional read
+// of a resource that did not change.
+func IsNotModified(err error) bool {
+	return errorCode(err) == ErrorCodeNotModified
+}
+
+func errorCode(err error) ErrorCode {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Code
+	}
+	return ""
+}
+
+// newAPIError builds an APIError from an unsuccessful response, classifying
+// it from the status code and the error code and message in the body. Only a
+// 404, the portal not-found code or the not found messages of the multibox
+// mean the resource is missing.
+func newAPIError(method string, statusCode int, header http.Header, body []byte) *APIError {
+	message, portalCode := parseErrorBody(body)
+	apiErr := &APIError{
+		Code:       ErrorCodeUnknown,
+		StatusCode: statusCode,
+		Message:    message,
+		Body:       string(body),
+		Method:     method,
+		PortalCode: portalCode,
+		RequestID:  header.Get("X-Request-Id"),
+	}
+
+	switch statusCode {
+	case http.StatusUnauthorized:
+		apiErr.Code = ErrorCodeUnauthorized
+	case http.StatusForbidden:
+		apiErr.Code = ErrorCodeForbidden
+	case http.StatusNotFound:
+		apiErr.Code = ErrorCodeNotFound
+	case http.StatusConflict, http.StatusPreconditionFailed:
+		apiErr.Code = ErrorCodeConflict
+	default:
+		switch {
+		case portalCode == portalNotFoundCode:
+			apiErr.Code = ErrorCodeNotFound
+		case strings.HasPrefix(method, "multibox/") && multiboxNotFoundMessages[message]:
+			apiErr.Code = ErrorCodeNotFound
+		case message == notMemberMessage:
+			apiErr.Code = ErrorCodeForbidden
+		}
+	}
+
+	return apiErr
+}
+
+// newBoxAPIError builds an APIError from an unsuccessful box API response.
+// Unlike portal errors, box errors are classified from the status code only,
+// as validation messages may mention missing references.
+func newBoxAPIError(request string, statusCode int, header http.Header, body []byte) *APIError {
+	apiErr := newAPIError("", statusCode, header, body)
+	apiErr.Request = request
+	apiErr.Issues = parseOperationOutcome(body)
+	if apiErr.Message == "" {
+		apiErr.Message = issuesMessage(apiErr.Issues)
+	}
+
+	switch statusCode {
+	case http.StatusNotFound, http.StatusGone:
+		apiErr.Code = ErrorCodeNotFound
+	case http.StatusNotModified:
+		apiErr.Code = ErrorCodeNotModified
+	default:
+		if apiErr.Code == ErrorCodeNotFound {
+			apiErr.Code = ErrorCodeUnknown
+		}
+	}
+
+	return apiErr
+}
+
+// parseOperationOutcome returns the issues of the OperationOutcome the box
+// responds with on errors, or nil when body is not an OperationOutcome.
+func parseOperationOutcome(body []byte) []OperationOutcomeIssue {
+	var outcome struct {
+		ResourceType string `json:"resourceType"`
+		Issue        []struct {
+			Severity    string `json:"severity"`
+			Code        string `json:"code"`
+			Diagnostics string `json:"diagnostics"`
+			Details     struct {
+				Text string `json:"text"`
+			} `json:"details"`
+			Expression []string `json:"expression"`
+		} `json:"issue"`
+	}
+	if err := json.Unmarshal(body, &outcome); err != nil || outcome.ResourceType != "OperationOutcome" {
+		return nil
+	}
+
+	issues := make([]OperationOutcomeIssue, 0, len(outcome.Issue))
+	for _, issue := range outcome.Issue {
+		diagnostics := issue.Diagnostics
+		if diagnostics == "" {
+			diagnostics = issue.Details.Text
+		}
+		issues = append(issues, OperationOutcomeIssue{
+			Severity:    issue.Severity,
+			Code:        issue.Code,
+			Diagnostics: diagnostics,
+			Expression:  issue.Expression,
+		})
+	}
+	return issues
+}
+
+// issuesMessage describes issues, one per line when there are several.
+func issuesMessage(issues []OperationOutcomeIssue) string {
+	if len(issues) == 1 {
+		return issues[0].String()
+	}
+
+	lines := make([]string, len(issues))
+	for i, issue := range issues {
+		lines[i] = "- " + issue.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// parseErrorBody extracts the error message and code from an RPC error body.
+func parseErrorBody(body []byte) (string, string) {
+	var errResp struct {
+		Error struct {
+			Message string `yaml:"message"`
+			Code    string `yaml:"code"`
+		} `yaml:"error"`
+	}
+	if err := yaml.Unmarshal(body, &errResp); err != nil {
+		return "", ""
+	}
+	return errResp.Error.Message, errResp.Error.Code
+}
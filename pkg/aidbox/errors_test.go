@@ -0,0 +1,101 @@
+package aidbox
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestGetLicenseErrors(t *testing.T) {
+	testCases := map[string]struct {
+		status         int
+		body           string
+		licenses       string
+		expectNotFound bool
+		expectAuth     bool
+	}{
+		"not-member": {
+			status:     http.StatusUnprocessableEntity,
+			body:       "error:\n  message: You are not a member of the project\n",
+			expectAuth: true,
+		},
+		"not-member-deleted": {
+			status:         http.StatusUnprocessableEntity,
+			body:           "error:\n  message: You are not a member of the project\n",
+			licenses:       "result:\n  licenses: []\n",
+			expectNotFound: true,
+		},
+		"not-member-listed": {
+			status:     http.StatusUnprocessableEntity,
+			body:       "error:\n  message: You are not a member of the project\n",
+			licenses:   "result:\n  licenses:\n  - id: license-one\n",
+			expectAuth: true,
+		},
+		"not-found": {
+			status:         http.StatusNotFound,
+			body:           "error:\n  message: License not found\n",
+			expectNotFound: true,
+		},
+		"not-found-code": {
+			status:         http.StatusUnprocessableEntity,
+			body:           "error:\n  message: License license-one does not exist\n  code: not-found\n",
+			expectNotFound: true,
+		},
+		"not-found-message": {
+			status: http.StatusUnprocessableEntity,
+			body:   "error:\n  message: Product not found\n",
+		},
+		"unauthorized": {
+			status:     http.StatusUnauthorized,
+			body:       "error:\n  message: Invalid token\n",
+			expectAuth: true,
+		},
+		"forbidden": {
+			status:     http.StatusForbidden,
+			body:       "error:\n  message: Access denied\n",
+			expectAuth: true,
+		},
+		"conflict": {
+			status: http.StatusConflict,
+			body:   "error:\n  message: Version mismatch\n",
+		},
+		"server-error": {
+			status: http.StatusInternalServerError,
+			body:   "boom",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				if testCase.licenses != "" && strings.Contains(string(body), "get-licenses") {
+					fmt.Fprint(w, testCase.licenses)
+					return
+				}
+				w.WriteHeader(testCase.status)
+				fmt.Fprint(w, testCase.body)
+			}))
+			defer server.Close()
+
+			_, err := NewClient(server.URL, "token").GetLicense(context.Background(), "license-one")
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+
+			if IsNotFound(err) != testCase.expectNotFound {
+				t.Errorf("expected IsNotFound to be %t for %s", testCase.expectNotFound, err)
+			}
+			if IsConflict(err) != (testCase.status == http.StatusConflict) {
+				t.Errorf("expected IsConflict to be %t for %s", testCase.status == http.StatusConflict, err)
+			}
+			if IsAuthError(err) != testCase.expectAuth {
+				t.Errorf("expected IsAuthError to be %t for %s", testCase.expectAuth, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,493 @@
+package fake
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// BoxClient is an in-memory implementation of the box client used by the
+// provider. The zero value is ready to use.
+type BoxClient struct {
+	mu         sync.Mutex
+	resources  map[string]aidbox.Resource
+	sequences  map[string]aidbox.Sequence
+	settings   map[string]aidbox.Setting
+	defaults   map[string]string
+	operations map[string]func(url.Values) (aidbox.Resource, error)
+	health     *aidbox.Health
+
+	// Err, when set, is returned by every call, e.g. to simulate invalid
+	// client credentials.
+	Err error
+}
+
+// NewBoxClient returns an empty fake box client.
+func NewBoxClient() *BoxClient {
+	return &BoxClient{}
+}
+
+// BoxNotFoundError returns the error the box responds with for a missing
+// resource.
+func BoxNotFoundError(request string) error {
+	return &aidbox.APIError{
+		Code:       aidbox.ErrorCodeNotFound,
+		StatusCode: http.StatusNotFound,
+		Body:       `{"resourceType":"OperationOutcome","issue":[{"severity":"fatal","code":"not-found"}]}`,
+		Request:    request,
+	}
+}
+
+func (c *BoxClient) GetResource(ctx context.Context, resourceType, id string) (aidbox.Resource, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.Err != nil {
+		return nil, c.Err
+	}
+
+	resource, ok := c.resources[resourceKey(resourceType, id)]
+	if !ok {
+		return nil, BoxNotFoundError(fmt.Sprintf("GET /%s/%s", resourceType, id))
+	}
+
+	return copyResource(resource), nil
+}
+
+// GetResourceIfModified returns the stored resource unless its version is
+// still versionID, as the box does for conditional reads.
+func (c *BoxClient) GetResourceIfModified(ctx context.Context, resourceType, id, versionID string) (aidbox.Resource, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.Err != nil {
+		return nil, c.Err
+	}
+
+	resource, ok := c.resources[resourceKey(resourceType, id)]
+	if !ok {
+		return nil, BoxNotFoundError(fmt.Sprintf("GET /%s/%s", resourceType, id))
+	}
+	if versionID != "" && resource.VersionID() == versionID {
+		return nil, &aidbox.APIError{
+			Code:       aidbox.ErrorCodeNotModified,
+			StatusCode: http.StatusNotModified,
+			Request:    fmt.Sprintf("GET /%s/%s", resourceType, id),
+		}
+	}
+
+	return copyResource(resource), nil
+}
+
+// PutResource stores resource, unless versionID is set and the stored
+// resource has another version, as the box does for conditional updates.
+func (c *BoxClient) PutResource(ctx context.Context, resource aidbox.Resource, versionID string) (aidbox.Resource, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.Err != nil {
+		return nil, c.Err
+	}
+
+	if previous, ok := c.resources[resourceKey(resource.ResourceType(), resource.ID())]; ok && versionID != "" && previous.VersionID() != versionID {
+		return nil, &aidbox.APIError{
+			Code:       aidbox.ErrorCodeConflict,
+			StatusCode: http.StatusPreconditionFailed,
+			Request:    fmt.Sprintf("PUT /%s/%s", resource.ResourceType(), resource.ID()),
+		}
+	}
+
+	c.putResource(copyResource(resource))
+
+	return copyResource(c.resources[resourceKey(resource.ResourceType(), resource.ID())]), nil
+}
+
+func (c *BoxClient) DeleteResource(ctx context.Context, resourceType, id string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.Err != nil {
+		return c.Err
+	}
+
+	key := resourceKey(resourceType, id)
+	if _, ok := c.resources[key]; !ok {
+		return BoxNotFoundError(fmt.Sprintf("DELETE /%s/%s", resourceType, id))
+	}
+	delete(c.resources, key)
+
+	return nil
+}
+
+// SearchResources returns the stored resources of the given type, sorted by
+// ID, up to limit resources when limit is not 0. Search parameters match
+// top-level fields of the resources by equality, parameters starting with an
+// underscore, such as _count, are ignored.
+func (c *BoxClient) SearchResources(ctx context.Context, resourceType string, params url.Values, limit int) ([]aidbox.Resource, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.Err != nil {
+		return nil, c.Err
+	}
+
+	keys := make([]string, 0, len(c.resources))
+	for key := range c.resources {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var resources []aidbox.Resource
+	for _, key := range keys {
+		resource := c.resources[key]
+		if limit > 0 && len(resources) == limit {
+			break
+		}
+		if resource.ResourceType() == resourceType && matchesParams(resource, params) {
+			resources = append(resources, copyResource(resource))
+		}
+	}
+
+	return resources, nil
+}
+
+func matchesParams(resource aidbox.Resource, params url.Values) bool {
+	for name, values := range params {
+		if strings.HasPrefix(name, "_") {
+			continue
+		}
+		if value := fmt.Sprint(resource[name]); len(values) > 0 && value != values[0] {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *BoxClient) RunOperation(ctx context.Context, path string, params url.Values) (aidbox.Resource, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.Err != nil {
+		return nil, c.Err
+	}
+
+	operation, ok := c.operations[path]
+	if !ok {
+		return nil, BoxNotFoundError("GET " + path)
+	}
+	resource, err := operation(params)
+	return copyResource(resource), err
+}
+
+// HandleOperation declares an operation the box supports, such as
+// /ValueSet/$expand, returning the result of handler. Operations not declared
+// are reported as not found.
+func (c *BoxClient) HandleOperation(path string, handler func(params url.Values) (aidbox.Resource, error)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.operations == nil {
+		c.operations = map[string]func(url.Values) (aidbox.Resource, error){}
+	}
+	c.operations[path] = handler
+}
+
+func (c *BoxClient) CreateSequence(ctx context.Context, sequence aidbox.Sequence) (aidbox.Sequence, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.Err != nil {
+		return aidbox.Sequence{}, c.Err
+	}
+
+	if _, ok := c.sequences[sequence.ID]; ok {
+		return aidbox.Sequence{}, &aidbox.APIError{
+			Code:       aidbox.ErrorCodeConflict,
+			StatusCode: http.StatusConflict,
+			Body:       fmt.Sprintf(`{"message":"sequence %s already exists"}`, sequence.ID),
+			Request:    "POST /db/sequences",
+		}
+	}
+
+	if c.sequences == nil {
+		c.sequences = map[string]aidbox.Sequence{}
+	}
+	c.sequences[sequence.ID] = sequence
+
+	return sequence, nil
+}
+
+func (c *BoxClient) GetSequence(ctx context.Context, id string) (aidbox.Sequence, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.Err != nil {
+		return aidbox.Sequence{}, c.Err
+	}
+
+	sequence, ok := c.sequences[id]
+	if !ok {
+		return aidbox.Sequence{}, BoxNotFoundError("GET /db/sequences/" + id)
+	}
+
+	return sequence, nil
+}
+
+func (c *BoxClient) DeleteSequence(ctx context.Context, id string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.Err != nil {
+		return c.Err
+	}
+
+	if _, ok := c.sequences[id]; !ok {
+		return BoxNotFoundError("DELETE /db/sequences/" + id)
+	}
+	delete(c.sequences, id)
+
+	return nil
+}
+
+func (c *BoxClient) GetSetting(ctx context.Context, name, scope string) (aidbox.Setting, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.Err != nil {
+		return aidbox.Setting{}, c.Err
+	}
+
+	return c.setting(name, scope)
+}
+
+// ListSettings returns the declared settings, sorted by name, with their
+// effective values in the given scope.
+func (c *BoxClient) ListSettings(ctx context.Context, scope string) ([]aidbox.Setting, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.Err != nil {
+		return nil, c.Err
+	}
+
+	names := make([]string, 0, len(c.defaults))
+	for name := range c.defaults {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	settings := make([]aidbox.Setting, 0, len(names))
+	for _, name := range names {
+		setting, err := c.setting(name, scope)
+		if err != nil {
+			return nil, err
+		}
+		settings = append(settings, setting)
+	}
+	return settings, nil
+}
+
+func (c *BoxClient) PutSetting(ctx context.Context, setting aidbox.Setting) (aidbox.Setting, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.Err != nil {
+		return aidbox.Setting{}, c.Err
+	}
+
+	if _, ok := c.defaults[setting.Name]; !ok {
+		return aidbox.Setting{}, BoxNotFoundError("PUT /api/v1/settings/" + setting.Name)
+	}
+	if c.settings == nil {
+		c.settings = map[string]aidbox.Setting{}
+	}
+	c.settings[settingKey(setting.Name, setting.Scope)] = aidbox.Setting{Name: setting.Name, Value: setting.Value, Scope: setting.Scope}
+
+	return c.setting(setting.Name, setting.Scope)
+}
+
+func (c *BoxClient) DeleteSetting(ctx context.Context, name, scope string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.Err != nil {
+		return c.Err
+	}
+
+	if _, ok := c.defaults[name]; !ok {
+		return BoxNotFoundError("DELETE /api/v1/settings/" + name)
+	}
+	delete(c.settings, settingKey(name, scope))
+
+	return nil
+}
+
+// SetSettingDefault declares a setting the box knows of, with its default
+// value. Settings not declared are reported as not found.
+func (c *BoxClient) SetSettingDefault(name, value string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.defaults == nil {
+		c.defaults = map[string]string{}
+	}
+	c.defaults[name] = value
+}
+
+// setting returns the effective value of a setting, as the box reports it.
+func (c *BoxClient) setting(name, scope string) (aidbox.Setting, error) {
+	defaultValue, ok := c.defaults[name]
+	if !ok {
+		return aidbox.Setting{}, BoxNotFoundError("GET /api/v1/settings/" + name)
+	}
+
+	setting := aidbox.Setting{Name: name, Value: defaultValue, Scope: scope, Default: defaultValue, Source: aidbox.SettingSourceDefault}
+	if stored, ok := c.settings[settingKey(name, scope)]; ok {
+		setting.Value, setting.Source = stored.Value, "api"
+	}
+	return setting, nil
+}
+
+func settingKey(name, scope string) string {
+	return scope + "/" + name
+}
+
+// ListTableStats returns the number of stored resources of each type, sorted
+// by type. The table size is the size of the resources in JSON, indexes are
+// not simulated.
+func (c *BoxClient) ListTableStats(ctx context.Context) ([]aidbox.TableStats, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.Err != nil {
+		return nil, c.Err
+	}
+
+	byType := map[string]*aidbox.TableStats{}
+	for _, resource := range c.resources {
+		stats, ok := byType[resource.ResourceType()]
+		if !ok {
+			stats = &aidbox.TableStats{ResourceType: resource.ResourceType()}
+			byType[resource.ResourceType()] = stats
+		}
+		data, _ := json.Marshal(resource)
+		stats.Count++
+		stats.TableSize += int64(len(data))
+	}
+
+	stats := make([]aidbox.TableStats, 0, len(byType))
+	for _, typeStats := range byType {
+		stats = append(stats, *typeStats)
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].ResourceType < stats[j].ResourceType })
+	return stats, nil
+}
+
+// Health returns the health set with SetHealth, a passing health by default.
+func (c *BoxClient) Health(ctx context.Context) (aidbox.Health, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.Err != nil {
+		return aidbox.Health{}, c.Err
+	}
+
+	if c.health == nil {
+		return aidbox.Health{Status: aidbox.HealthStatusPass}, nil
+	}
+	return *c.health, nil
+}
+
+// SetHealth sets the health the box reports, e.g. to simulate a failing
+// database.
+func (c *BoxClient) SetHealth(health aidbox.Health) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.health = &health
+}
+
+// Resource returns a stored resource, e.g. to assert what the provider sent.
+func (c *BoxClient) Resource(resourceType, id string) (aidbox.Resource, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	resource, ok := c.resources[resourceKey(resourceType, id)]
+	return copyResource(resource), ok
+}
+
+// UpdateResource changes a stored resource, simulating an edit made outside
+// of Terraform, and bumps its version. It reports whether the resource
+// exists.
+func (c *BoxClient) UpdateResource(resourceType, id string, update func(aidbox.Resource)) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	resource, ok := c.resources[resourceKey(resourceType, id)]
+	if !ok {
+		return false
+	}
+
+	resource = copyResource(resource)
+	update(resource)
+	c.putResource(resource)
+
+	return true
+}
+
+// putResource stores resource with the next version, as the box does on
+// every write.
+func (c *BoxClient) putResource(resource aidbox.Resource) {
+	key := resourceKey(resource.ResourceType(), resource.ID())
+	version := 0
+	if previous, ok := c.resources[key]; ok {
+		version, _ = strconv.Atoi(previous.VersionID())
+	}
+
+	// The box keeps the tags and the other attributes of meta set by clients.
+	meta := map[string]interface{}{}
+	if previous, ok := resource["meta"].(map[string]interface{}); ok {
+		for key, value := range previous {
+			meta[key] = value
+		}
+	}
+	meta["versionId"] = strconv.Itoa(version + 1)
+	meta["lastUpdated"] = time.Now().UTC().Format(time.RFC3339)
+	resource["meta"] = meta
+
+	if c.resources == nil {
+		c.resources = map[string]aidbox.Resource{}
+	}
+	c.resources[key] = resource
+}
+
+func resourceKey(resourceType, id string) string {
+	return resourceType + "/" + id
+}
+
+// copyResource returns a deep copy of resource, as the box would return it
+// over JSON, so callers can't change stored resources.
+func copyResource(resource aidbox.Resource) aidbox.Resource {
+	if resource == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(resource)
+	if err != nil {
+		panic(fmt.Sprintf("fake: resource is not JSON serializable: %s", err))
+	}
+
+	var copied aidbox.Resource
+	if err := json.Unmarshal(data, &copied); err != nil {
+		panic(fmt.Sprintf("fake: unable to copy resource: %s", err))
+	}
+	return copied
+}
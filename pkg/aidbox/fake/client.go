@@ -0,0 +1,360 @@
+// Package fake provides an in-memory implementation of the Aidbox client for
+// unit testing resource logic without HTTP.
+package fake
+
+import (
+	"context"
+	"fmt"
+	"github.com/petalmd/terraform-provider-aidbox/pkg/aidbox"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Client is an in-memory implementation of the client used by the provider.
+// The zero value is ready to use.
+type Client struct {
+	mu       sync.Mutex
+	nextID   int
+	licenses map[string]aidbox.License
+	sessions map[string]aidbox.Session
+	boxes    map[string]aidbox.Box
+	boxUsers map[string]aidbox.BoxUser
+
+	// Err, when set, is returned by every call, e.g. to simulate an invalid
+	// token.
+	Err error
+}
+
+// NewClient returns an empty fake client.
+func NewClient() *Client {
+	return &Client{}
+}
+
+// NotFoundError returns the error the portal responds with for a missing
+// resource, as classified by the HTTP client once it checked the resource is
+// gone.
+func NotFoundError(method string) error {
+	return &aidbox.APIError{
+		Code:       aidbox.ErrorCodeNotFound,
+		StatusCode: http.StatusUnprocessableEntity,
+		Message:    "You are not a member of the project",
+		Method:     method,
+	}
+}
+
+func (c *Client) CreateLicense(ctx context.Context, name, product, licenseType string) (aidbox.LicenseResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.Err != nil {
+		return aidbox.LicenseResponse{}, c.Err
+	}
+
+	c.nextID++
+	now := time.Now().UTC().Format(time.RFC3339)
+	maxInstances, offline := 1, false
+	license := aidbox.License{
+		ID:           fmt.Sprintf("license-%d", c.nextID),
+		Name:         name,
+		Product:      product,
+		Type:         licenseType,
+		Expiration:   time.Now().UTC().AddDate(0, 0, 30).Format(time.RFC3339),
+		Status:       "active",
+		MaxInstances: &maxInstances,
+		Creator:      aidbox.Creator{ID: "user-1", ResourceType: "User"},
+		Project:      aidbox.Project{ID: "project-1", ResourceType: "Project"},
+		Offline:      &offline,
+		Created:      now,
+		Meta:         aidbox.Meta{LastUpdated: now, CreatedAt: now, VersionID: "1"},
+		Issuer:       "https://aidbox.app",
+		Info:         aidbox.Info{Hosting: "self-hosted"},
+	}
+	c.putLicense(license)
+
+	return aidbox.LicenseResponse{License: license, JWT: licenseJWT(license)}, nil
+}
+
+func (c *Client) GetLicense(ctx context.Context, licenseID string) (aidbox.LicenseResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.Err != nil {
+		return aidbox.LicenseResponse{}, c.Err
+	}
+
+	license, ok := c.licenses[licenseID]
+	if !ok {
+		return aidbox.LicenseResponse{}, NotFoundError("portal.portal/get-license")
+	}
+
+	return aidbox.LicenseResponse{License: license, JWT: licenseJWT(license)}, nil
+}
+
+func (c *Client) ListLicenses(ctx context.Context) ([]aidbox.License, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.Err != nil {
+		return nil, c.Err
+	}
+
+	licenses := make([]aidbox.License, 0, len(c.licenses))
+	for _, license := range c.licenses {
+		licenses = append(licenses, license)
+	}
+	sort.Slice(licenses, func(i, j int) bool { return licenses[i].ID < licenses[j].ID })
+
+	return licenses, nil
+}
+
+func (c *Client) DeleteLicense(ctx context.Context, licenseID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.Err != nil {
+		return c.Err
+	}
+
+	if _, ok := c.licenses[licenseID]; !ok {
+		return NotFoundError("portal.portal/remove-license")
+	}
+	delete(c.licenses, licenseID)
+
+	return nil
+}
+
+func (c *Client) OpenSession(ctx context.Context) (aidbox.Session, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.Err != nil {
+		return aidbox.Session{}, c.Err
+	}
+
+	c.nextID++
+	session := aidbox.Session{
+		ID:      fmt.Sprintf("session-%d", c.nextID),
+		Token:   fmt.Sprintf("session-token-%d", c.nextID),
+		Expires: time.Now().UTC().Add(time.Hour).Format(time.RFC3339),
+	}
+	if c.sessions == nil {
+		c.sessions = map[string]aidbox.Session{}
+	}
+	c.sessions[session.ID] = session
+
+	return session, nil
+}
+
+func (c *Client) CloseSession(ctx context.Context, sessionID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.Err != nil {
+		return c.Err
+	}
+
+	if _, ok := c.sessions[sessionID]; !ok {
+		return NotFoundError("portal.portal/close-session")
+	}
+	delete(c.sessions, sessionID)
+
+	return nil
+}
+
+func (c *Client) CreateBox(ctx context.Context, box aidbox.Box) (aidbox.BoxResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.Err != nil {
+		return aidbox.BoxResponse{}, c.Err
+	}
+
+	if _, ok := c.boxes[box.ID]; ok {
+		return aidbox.BoxResponse{}, &aidbox.APIError{
+			Code:       aidbox.ErrorCodeConflict,
+			StatusCode: http.StatusConflict,
+			Message:    fmt.Sprintf("Box %s already exists", box.ID),
+			Method:     "multibox/create-box",
+		}
+	}
+
+	c.nextID++
+	now := time.Now().UTC().Format(time.RFC3339)
+	box.BoxURL = "https://" + box.ID + ".aidbox.app"
+	box.Meta = aidbox.Meta{LastUpdated: now, CreatedAt: now, VersionID: "1"}
+	if c.boxes == nil {
+		c.boxes = map[string]aidbox.Box{}
+	}
+	c.boxes[box.ID] = box
+
+	return aidbox.BoxResponse{
+		Box: box,
+		Credentials: aidbox.BoxCredentials{
+			ClientID:     "root",
+			ClientSecret: fmt.Sprintf("box-secret-%d", c.nextID),
+		},
+	}, nil
+}
+
+func (c *Client) GetBox(ctx context.Context, boxID string) (aidbox.BoxResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.Err != nil {
+		return aidbox.BoxResponse{}, c.Err
+	}
+
+	box, ok := c.boxes[boxID]
+	if !ok {
+		return aidbox.BoxResponse{}, NotFoundError("multibox/get-box")
+	}
+
+	return aidbox.BoxResponse{Box: box}, nil
+}
+
+func (c *Client) UpdateBox(ctx context.Context, box aidbox.Box) (aidbox.BoxResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.Err != nil {
+		return aidbox.BoxResponse{}, c.Err
+	}
+
+	stored, ok := c.boxes[box.ID]
+	if !ok {
+		return aidbox.BoxResponse{}, NotFoundError("multibox/update-box")
+	}
+
+	stored.Description = box.Description
+	stored.Env = box.Env
+	version, _ := strconv.Atoi(stored.Meta.VersionID)
+	stored.Meta.VersionID = strconv.Itoa(version + 1)
+	stored.Meta.LastUpdated = time.Now().UTC().Format(time.RFC3339)
+	c.boxes[box.ID] = stored
+
+	return aidbox.BoxResponse{Box: stored}, nil
+}
+
+func (c *Client) DeleteBox(ctx context.Context, boxID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.Err != nil {
+		return c.Err
+	}
+
+	if _, ok := c.boxes[boxID]; !ok {
+		return NotFoundError("multibox/delete-box")
+	}
+	delete(c.boxes, boxID)
+	for key, user := range c.boxUsers {
+		if user.BoxID == boxID {
+			delete(c.boxUsers, key)
+		}
+	}
+
+	return nil
+}
+
+func (c *Client) GrantBoxAccess(ctx context.Context, user aidbox.BoxUser) (aidbox.BoxUser, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.Err != nil {
+		return aidbox.BoxUser{}, c.Err
+	}
+
+	if _, ok := c.boxes[user.BoxID]; !ok {
+		return aidbox.BoxUser{}, NotFoundError("multibox/grant-box-access")
+	}
+	if c.boxUsers == nil {
+		c.boxUsers = map[string]aidbox.BoxUser{}
+	}
+	c.boxUsers[user.BoxID+"/"+user.Email] = user
+
+	return user, nil
+}
+
+func (c *Client) GetBoxUser(ctx context.Context, boxID, email string) (aidbox.BoxUser, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.Err != nil {
+		return aidbox.BoxUser{}, c.Err
+	}
+
+	user, ok := c.boxUsers[boxID+"/"+email]
+	if !ok {
+		return aidbox.BoxUser{}, NotFoundError("multibox/get-box-user")
+	}
+
+	return user, nil
+}
+
+func (c *Client) RevokeBoxAccess(ctx context.Context, boxID, email string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.Err != nil {
+		return c.Err
+	}
+
+	if _, ok := c.boxUsers[boxID+"/"+email]; !ok {
+		return NotFoundError("multibox/revoke-box-access")
+	}
+	delete(c.boxUsers, boxID+"/"+email)
+
+	return nil
+}
+
+// PutLicense stores a license as is, e.g. to seed the fake with a license
+// created outside of Terraform.
+func (c *Client) PutLicense(license aidbox.License) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.putLicense(license)
+}
+
+// UpdateLicense changes a stored license, simulating an edit made in the
+// portal UI, and bumps its version. It reports whether the license exists.
+func (c *Client) UpdateLicense(licenseID string, update func(*aidbox.License)) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	license, ok := c.licenses[licenseID]
+	if !ok {
+		return false
+	}
+
+	update(&license)
+	version, _ := strconv.Atoi(license.Meta.VersionID)
+	license.Meta.VersionID = strconv.Itoa(version + 1)
+	license.Meta.LastUpdated = time.Now().UTC().Format(time.RFC3339)
+	c.licenses[licenseID] = license
+
+	return true
+}
+
+// Sessions returns the number of open sessions.
+func (c *Client) Sessions() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.sessions)
+}
+
+func (c *Client) putLicense(license aidbox.License) {
+	if c.licenses == nil {
+		c.licenses = map[string]aidbox.License{}
+	}
+	c.licenses[license.ID] = license
+}
+
+// licenseJWT returns a placeholder JWT for the license.
+func licenseJWT(license aidbox.License) string {
+	return "fake." + license.ID + "." + license.Meta.VersionID
+}
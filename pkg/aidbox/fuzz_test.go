@@ -0,0 +1,36 @@
+package aidbox
+
+import (
+	"net/http"
+	"testing"
+)
+
+func FuzzParseYAMLResponse(f *testing.F) {
+	f.Add([]byte("result:\n  jwt: header.payload.signature\n  license:\n    id: license-1\n    max-instances: 2\n    offline: true\n"))
+	f.Add([]byte("result: {}\n"))
+	f.Add([]byte("result:\n  license:\n    max-instances: many\n"))
+	f.Add([]byte("- not\n- a\n- map\n"))
+	f.Add([]byte("&a [*a]"))
+	f.Add([]byte{})
+
+	f.Fuzz(func(t *testing.T, body []byte) {
+		// Malformed responses must produce an error rather than panic.
+		_, _ = parseYAMLResponse(body)
+	})
+}
+
+func FuzzNewAPIError(f *testing.F) {
+	f.Add(http.StatusUnprocessableEntity, []byte("error:\n  message: You are not a member of the project\n"))
+	f.Add(http.StatusForbidden, []byte("error:\n  message: Access denied\n  code: not-admin\n"))
+	f.Add(http.StatusInternalServerError, []byte("<html>Bad Gateway</html>"))
+	f.Add(0, []byte("error: [1, 2]"))
+
+	f.Fuzz(func(t *testing.T, statusCode int, body []byte) {
+		apiErr := newAPIError("portal.portal/get-license", statusCode, http.Header{}, body)
+		if apiErr.Code == "" {
+			t.Errorf("expected an error code for status %d", statusCode)
+		}
+		_ = apiErr.Error()
+		_ = apiErr.Details()
+	})
+}
@@ -0,0 +1,51 @@
+package aidbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+// Statuses of the health of a box and of its checks.
+const (
+	HealthStatusPass = "pass"
+	HealthStatusWarn = "warn"
+	HealthStatusFail = "fail"
+)
+
+// Health is the health of a box, as reported by its health endpoint.
+type Health struct {
+	Status string `json:"status"`
+	// Checks are the statuses of the subsystems of the box, such as its
+	// database, by name.
+	Checks map[string]HealthCheck `json:"checks,omitempty"`
+}
+
+// HealthCheck is the status of a subsystem of a box.
+type HealthCheck struct {
+	Status string `json:"status"`
+	// Output describes the failure of the check, if any.
+	Output string `json:"output,omitempty"`
+}
+
+// Healthy reports whether the box is ready to serve requests.
+func (h Health) Healthy() bool {
+	return h.Status == HealthStatusPass || h.Status == HealthStatusWarn
+}
+
+// Health reads the health of the box. An unhealthy box answers with a 503
+// status, which is not reported as an error as long as the box describes its
+// health.
+func (c *BoxClient) Health(ctx context.Context) (Health, error) {
+	var health Health
+	err := c.do(ctx, http.MethodGet, "/health", nil, &health)
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusServiceUnavailable {
+		if json.Unmarshal([]byte(apiErr.Body), &health) == nil && health.Status != "" {
+			return health, nil
+		}
+	}
+	return health, err
+}
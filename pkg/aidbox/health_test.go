@@ -0,0 +1,61 @@
+package aidbox
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestBoxClientHealth(t *testing.T) {
+	testCases := map[string]struct {
+		statusCode int
+		body       string
+		healthy    bool
+		err        bool
+	}{
+		"pass": {
+			statusCode: http.StatusOK,
+			body:       `{"status": "pass", "checks": {"db": {"status": "pass"}}}`,
+			healthy:    true,
+		},
+		"fail": {
+			statusCode: http.StatusServiceUnavailable,
+			body:       `{"status": "fail", "checks": {"db": {"status": "fail", "output": "connection refused"}}}`,
+		},
+		"unavailable": {
+			statusCode: http.StatusServiceUnavailable,
+			body:       `upstream connect error`,
+			err:        true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/health" {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				w.WriteHeader(testCase.statusCode)
+				fmt.Fprint(w, testCase.body)
+			}))
+			defer server.Close()
+
+			health, err := NewBoxClient(server.URL, "root", "secret").Health(context.Background())
+			if testCase.err {
+				if err == nil {
+					t.Fatalf("expected an error, got: %+v", health)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if health.Healthy() != testCase.healthy || health.Checks["db"].Status != health.Status {
+				t.Errorf("unexpected health: %+v", health)
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package aidbox
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"gopkg.in/yaml.v3"
+)
+
+// Box is a box of a multibox deployment.
+type Box struct {
+	ID          string            `yaml:"id"`
+	FHIRVersion string            `yaml:"fhir-version"`
+	Description string            `yaml:"description,omitempty"`
+	Env         map[string]string `yaml:"env,omitempty"`
+	BoxURL      string            `yaml:"box-url,omitempty"`
+	Meta        Meta              `yaml:"meta,omitempty"`
+}
+
+// BoxCredentials are the credentials of the root client of a box. The
+// multibox only returns them when the box is created.
+type BoxCredentials struct {
+	ClientID     string `yaml:"client-id"`
+	ClientSecret string `yaml:"client-secret"`
+}
+
+// BoxResponse includes the Box and, on creation, its credentials.
+type BoxResponse struct {
+	Box         Box
+	Credentials BoxCredentials
+}
+
+// BoxAPIResponse maps the YAML response of the multibox RPC methods.
+type BoxAPIResponse struct {
+	Result struct {
+		Box         Box            `yaml:"box"`
+		Credentials BoxCredentials `yaml:"credentials"`
+	}
+}
+
+// BoxUser is the access of a portal user to a box of a multibox deployment.
+type BoxUser struct {
+	BoxID string `yaml:"box-id"`
+	Email string `yaml:"email"`
+	Role  string `yaml:"role"`
+}
+
+// BoxUserAPIResponse maps the YAML response of the box access RPC methods.
+type BoxUserAPIResponse struct {
+	Result struct {
+		User BoxUser `yaml:"user"`
+	}
+}
+
+// CreateBox creates a box with the ID, FHIR version, description and
+// environment variables of box.
+func (c *HTTPClient) CreateBox(ctx context.Context, box Box) (BoxResponse, error) {
+	bodyBytes, err := c.makeAPICall(ctx, "multibox/create-box", boxParams(c.Token, box))
+	if err != nil {
+		return BoxResponse{}, err
+	}
+	return parseBoxResponse(ctx, bodyBytes)
+}
+
+// GetBox reads the box with the given id. Credentials are not returned.
+func (c *HTTPClient) GetBox(ctx context.Context, boxID string) (BoxResponse, error) {
+	bodyBytes, err := c.makeAPICall(ctx, "multibox/get-box", map[string]interface{}{
+		"token": c.Token,
+		"id":    boxID,
+	})
+	if err != nil {
+		return BoxResponse{}, err
+	}
+	return parseBoxResponse(ctx, bodyBytes)
+}
+
+// UpdateBox changes the description and environment variables of a box. The
+// box restarts to apply new environment variables. An empty description or
+// env clears it.
+func (c *HTTPClient) UpdateBox(ctx context.Context, box Box) (BoxResponse, error) {
+	params := boxParams(c.Token, box)
+	// The multibox keeps the attributes left out of an update
+	params["description"] = box.Description
+	if box.Env == nil {
+		params["env"] = map[string]string{}
+	}
+	bodyBytes, err := c.makeAPICall(ctx, "multibox/update-box", params)
+	if err != nil {
+		return BoxResponse{}, err
+	}
+	return parseBoxResponse(ctx, bodyBytes)
+}
+
+// DeleteBox deletes the box with the given id, with all of its data.
+func (c *HTTPClient) DeleteBox(ctx context.Context, boxID string) error {
+	_, err := c.makeAPICall(ctx, "multibox/delete-box", map[string]interface{}{
+		"token": c.Token,
+		"id":    boxID,
+	})
+	return err
+}
+
+// GrantBoxAccess grants the user access to the box with the role, replacing
+// the role the user had on the box, if any.
+func (c *HTTPClient) GrantBoxAccess(ctx context.Context, user BoxUser) (BoxUser, error) {
+	bodyBytes, err := c.makeAPICall(ctx, "multibox/grant-box-access", map[string]interface{}{
+		"token":  c.Token,
+		"box-id": user.BoxID,
+		"email":  user.Email,
+		"role":   user.Role,
+	})
+	if err != nil {
+		return BoxUser{}, err
+	}
+	return parseBoxUserResponse(ctx, bodyBytes)
+}
+
+// GetBoxUser reads the access of the user with the given email to a box.
+func (c *HTTPClient) GetBoxUser(ctx context.Context, boxID, email string) (BoxUser, error) {
+	bodyBytes, err := c.makeAPICall(ctx, "multibox/get-box-user", map[string]interface{}{
+		"token":  c.Token,
+		"box-id": boxID,
+		"email":  email,
+	})
+	if err != nil {
+		return BoxUser{}, err
+	}
+	return parseBoxUserResponse(ctx, bodyBytes)
+}
+
+// RevokeBoxAccess revokes the access of the user with the given email to a
+// box.
+func (c *HTTPClient) RevokeBoxAccess(ctx context.Context, boxID, email string) error {
+	_, err := c.makeAPICall(ctx, "multibox/revoke-box-access", map[string]interface{}{
+		"token":  c.Token,
+		"box-id": boxID,
+		"email":  email,
+	})
+	return err
+}
+
+func boxParams(token string, box Box) map[string]interface{} {
+	params := map[string]interface{}{
+		"token":        token,
+		"id":           box.ID,
+		"fhir-version": box.FHIRVersion,
+	}
+	if box.Description != "" {
+		params["description"] = box.Description
+	}
+	if len(box.Env) > 0 {
+		params["env"] = box.Env
+	}
+	return params
+}
+
+func parseBoxResponse(ctx context.Context, bodyBytes []byte) (BoxResponse, error) {
+	var apiResp BoxAPIResponse
+	if err := yaml.Unmarshal(bodyBytes, &apiResp); err != nil {
+		tflog.Error(ctx, "Failed to parse YAML response", map[string]interface{}{"error": err})
+		return BoxResponse{}, fmt.Errorf("failed to parse YAML response: %w", err)
+	}
+	return BoxResponse{Box: apiResp.Result.Box, Credentials: apiResp.Result.Credentials}, nil
+}
+
+func parseBoxUserResponse(ctx context.Context, bodyBytes []byte) (BoxUser, error) {
+	var apiResp BoxUserAPIResponse
+	if err := yaml.Unmarshal(bodyBytes, &apiResp); err != nil {
+		tflog.Error(ctx, "Failed to parse YAML response", map[string]interface{}{"error": err})
+		return BoxUser{}, fmt.Errorf("failed to parse YAML response: %w", err)
+	}
+	return apiResp.Result.User, nil
+}
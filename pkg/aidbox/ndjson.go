@@ -0,0 +1,38 @@
+package aidbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// ReadNDJSON reads the NDJSON file at path, such as an output file of a bulk
+// export, and calls fn with each resource as it is decoded, so that files
+// listing thousands of resources are never held in memory. Reading stops at
+// the first error returned by fn.
+func (c *BoxClient) ReadNDJSON(ctx context.Context, path string, fn func(Resource) error) error {
+	resp, err := c.send(ctx, http.MethodGet, path, http.Header{"Accept": {"application/fhir+ndjson"}}, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	decoder := json.NewDecoder(resp.Body)
+	for line := 1; ; line++ {
+		var resource Resource
+		err := decoder.Decode(&resource)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to parse NDJSON resource %d: %w", line, err)
+		}
+
+		if err := fn(resource); err != nil {
+			return err
+		}
+	}
+}
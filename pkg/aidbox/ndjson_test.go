@@ -0,0 +1,66 @@
+package aidbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestBoxClientReadNDJSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept") != "application/fhir+ndjson" {
+			w.WriteHeader(http.StatusNotAcceptable)
+			return
+		}
+		switch r.URL.Path {
+		case "/export/Patient.ndjson":
+			for i := 1; i <= 3; i++ {
+				fmt.Fprintf(w, "{\"resourceType\": \"Patient\", \"id\": \"pt-%d\"}\n", i)
+			}
+		case "/export/invalid.ndjson":
+			fmt.Fprint(w, "{\"resourceType\": \"Patient\", \"id\": \"pt-1\"}\n{\"resourceType\": \n")
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client := NewBoxClient(server.URL, "root", "secret")
+
+	var ids []string
+	err := client.ReadNDJSON(ctx, "/export/Patient.ndjson", func(resource Resource) error {
+		ids = append(ids, resource.ID())
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error reading NDJSON: %s", err)
+	}
+	if strings.Join(ids, ",") != "pt-1,pt-2,pt-3" {
+		t.Errorf("unexpected resources: %v", ids)
+	}
+
+	stop := errors.New("stop")
+	read := 0
+	err = client.ReadNDJSON(ctx, "/export/Patient.ndjson", func(resource Resource) error {
+		read++
+		return stop
+	})
+	if !errors.Is(err, stop) || read != 1 {
+		t.Errorf("expected reading to stop at the first error, got: %v after %d resources", err, read)
+	}
+
+	err = client.ReadNDJSON(ctx, "/export/invalid.ndjson", func(resource Resource) error { return nil })
+	if err == nil || !strings.Contains(err.Error(), "resource 2") {
+		t.Errorf("expected a parse error on the second resource, got: %v", err)
+	}
+
+	err = client.ReadNDJSON(ctx, "/export/missing.ndjson", func(resource Resource) error { return nil })
+	if !IsNotFound(err) {
+		t.Errorf("expected a not found error, got: %v", err)
+	}
+}
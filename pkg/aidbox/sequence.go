@@ -0,0 +1,39 @@
+package aidbox
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// Sequence is a PostgreSQL sequence managed through the sequences API of a
+// box, e.g. to generate medical record or accession numbers.
+type Sequence struct {
+	ID        string `json:"id"`
+	Start     int64  `json:"start"`
+	Increment int64  `json:"increment"`
+	Cycle     bool   `json:"cycle"`
+}
+
+// CreateSequence creates the sequence and returns it as created by the box.
+func (c *BoxClient) CreateSequence(ctx context.Context, sequence Sequence) (Sequence, error) {
+	var created Sequence
+	err := c.do(ctx, http.MethodPost, "/db/sequences", sequence, &created)
+	return created, err
+}
+
+// GetSequence reads the sequence with the given id.
+func (c *BoxClient) GetSequence(ctx context.Context, id string) (Sequence, error) {
+	var sequence Sequence
+	err := c.do(ctx, http.MethodGet, sequencePath(id), nil, &sequence)
+	return sequence, err
+}
+
+// DeleteSequence drops the sequence with the given id.
+func (c *BoxClient) DeleteSequence(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodDelete, sequencePath(id), nil, nil)
+}
+
+func sequencePath(id string) string {
+	return "/db/sequences/" + url.PathEscape(id)
+}
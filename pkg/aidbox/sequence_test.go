@@ -0,0 +1,65 @@
+package aidbox
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestBoxClientSequences(t *testing.T) {
+	sequences := map[string]Sequence{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/db/sequences":
+			var sequence Sequence
+			if err := json.NewDecoder(r.Body).Decode(&sequence); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			sequences[sequence.ID] = sequence
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(sequence)
+		case r.Method == http.MethodGet:
+			sequence, ok := sequences[r.URL.Path[len("/db/sequences/"):]]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(sequence)
+		case r.Method == http.MethodDelete:
+			delete(sequences, r.URL.Path[len("/db/sequences/"):])
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client := NewBoxClient(server.URL, "root", "secret")
+
+	created, err := client.CreateSequence(ctx, Sequence{ID: "mrn", Start: 1000, Increment: 1})
+	if err != nil {
+		t.Fatalf("unexpected error creating sequence: %s", err)
+	}
+	if created.ID != "mrn" || created.Start != 1000 {
+		t.Errorf("unexpected sequence: %+v", created)
+	}
+
+	fetched, err := client.GetSequence(ctx, "mrn")
+	if err != nil {
+		t.Fatalf("unexpected error getting sequence: %s", err)
+	}
+	if fetched != created {
+		t.Errorf("expected %+v, got: %+v", created, fetched)
+	}
+
+	if err := client.DeleteSequence(ctx, "mrn"); err != nil {
+		t.Fatalf("unexpected error deleting sequence: %s", err)
+	}
+	if _, err := client.GetSequence(ctx, "mrn"); !IsNotFound(err) {
+		t.Errorf("expected a not found error, got: %v", err)
+	}
+}
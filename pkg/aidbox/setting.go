@@ -0,0 +1,65 @@
+package aidbox
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// SettingSourceDefault is the source of settings left to the default of the
+// box.
+const SettingSourceDefault = "default"
+
+// Setting is a setting of a box managed through its settings API.
+type Setting struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+	// Scope restricts the setting, e.g. to an organization of a multitenant
+	// box. Settings without a scope apply to the whole box.
+	Scope string `json:"scope,omitempty"`
+	// Default is the value of the setting when it is not set.
+	Default string `json:"default,omitempty"`
+	// Source tells where the value comes from, SettingSourceDefault when the
+	// setting is not set.
+	Source string `json:"source,omitempty"`
+}
+
+// GetSetting reads the setting with the given name and scope.
+func (c *BoxClient) GetSetting(ctx context.Context, name, scope string) (Setting, error) {
+	var setting Setting
+	err := c.do(ctx, http.MethodGet, settingPath(name, scope), nil, &setting)
+	return setting, err
+}
+
+// ListSettings reads the settings of the given scope, with their effective
+// values.
+func (c *BoxClient) ListSettings(ctx context.Context, scope string) ([]Setting, error) {
+	var settings []Setting
+	err := c.do(ctx, http.MethodGet, settingPath("", scope), nil, &settings)
+	return settings, err
+}
+
+// PutSetting sets the value of the setting and returns it as stored by the
+// box.
+func (c *BoxClient) PutSetting(ctx context.Context, setting Setting) (Setting, error) {
+	var stored Setting
+	err := c.do(ctx, http.MethodPut, settingPath(setting.Name, setting.Scope), map[string]interface{}{"value": setting.Value}, &stored)
+	return stored, err
+}
+
+// DeleteSetting resets the setting with the given name and scope to its
+// default.
+func (c *BoxClient) DeleteSetting(ctx context.Context, name, scope string) error {
+	return c.do(ctx, http.MethodDelete, settingPath(name, scope), nil, nil)
+}
+
+func settingPath(name, scope string) string {
+	path := "/api/v1/settings"
+	if name != "" {
+		path += "/" + url.PathEscape(name)
+	}
+	if scope != "" {
+		path += "?" + url.Values{"scope": {scope}}.Encode()
+	}
+	return path
+}
@@ -0,0 +1,82 @@
+package aidbox
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestBoxClientSettings(t *testing.T) {
+	values := map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.URL.Query().Get("scope") + "/" + r.URL.Path
+		if r.URL.Path == "/api/v1/settings" {
+			setting := Setting{Name: "search.default-count", Value: "100", Default: "100", Source: SettingSourceDefault, Scope: r.URL.Query().Get("scope")}
+			if value, ok := values[key+"/search.default-count"]; ok {
+				setting.Value, setting.Source = value, "api"
+			}
+			_ = json.NewEncoder(w).Encode([]Setting{setting})
+			return
+		}
+
+		switch r.Method {
+		case http.MethodPut:
+			var body struct {
+				Value string `json:"value"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			values[key] = body.Value
+		case http.MethodDelete:
+			delete(values, key)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		setting := Setting{Name: "search.default-count", Value: "100", Default: "100", Source: SettingSourceDefault, Scope: r.URL.Query().Get("scope")}
+		if value, ok := values[key]; ok {
+			setting.Value, setting.Source = value, "api"
+		}
+		_ = json.NewEncoder(w).Encode(setting)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client := NewBoxClient(server.URL, "root", "secret")
+
+	stored, err := client.PutSetting(ctx, Setting{Name: "search.default-count", Value: "50", Scope: "org-1"})
+	if err != nil {
+		t.Fatalf("unexpected error putting setting: %s", err)
+	}
+	if stored.Value != "50" || stored.Source != "api" || stored.Scope != "org-1" {
+		t.Errorf("unexpected setting: %+v", stored)
+	}
+
+	listed, err := client.ListSettings(ctx, "org-1")
+	if err != nil {
+		t.Fatalf("unexpected error listing settings: %s", err)
+	}
+	if len(listed) != 1 || listed[0].Value != "50" || listed[0].Source != "api" {
+		t.Errorf("unexpected settings: %+v", listed)
+	}
+
+	fetched, err := client.GetSetting(ctx, "search.default-count", "")
+	if err != nil {
+		t.Fatalf("unexpected error getting setting: %s", err)
+	}
+	if fetched.Source != SettingSourceDefault {
+		t.Errorf("expected the setting of another scope to be unaffected, got: %+v", fetched)
+	}
+
+	if err := client.DeleteSetting(ctx, "search.default-count", "org-1"); err != nil {
+		t.Fatalf("unexpected error deleting setting: %s", err)
+	}
+	fetched, err = client.GetSetting(ctx, "search.default-count", "org-1")
+	if err != nil || fetched.Value != "100" || fetched.Source != SettingSourceDefault {
+		t.Errorf("expected the default value, got: %+v, %v", fetched, err)
+	}
+}
@@ -0,0 +1,25 @@
+package aidbox
+
+import (
+	"context"
+	"net/http"
+)
+
+// TableStats are the statistics of the table storing a resource type in the
+// database of a box.
+type TableStats struct {
+	ResourceType string `json:"resourceType"`
+	// Count is the number of resources, as estimated by PostgreSQL.
+	Count int64 `json:"count"`
+	// TableSize and IndexSize are in bytes.
+	TableSize int64 `json:"tableSize"`
+	IndexSize int64 `json:"indexSize"`
+}
+
+// ListTableStats reads the statistics of the tables of every resource type
+// of the box.
+func (c *BoxClient) ListTableStats(ctx context.Context) ([]TableStats, error) {
+	var stats []TableStats
+	err := c.do(ctx, http.MethodGet, "/db/stats/tables", nil, &stats)
+	return stats, err
+}
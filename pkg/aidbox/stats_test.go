@@ -0,0 +1,29 @@
+package aidbox
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestBoxClientListTableStats(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/db/stats/tables" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, `[{"resourceType": "Patient", "count": 1200, "tableSize": 1048576, "indexSize": 262144}]`)
+	}))
+	defer server.Close()
+
+	stats, err := NewBoxClient(server.URL, "root", "secret").ListTableStats(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error listing table stats: %s", err)
+	}
+	expected := TableStats{ResourceType: "Patient", Count: 1200, TableSize: 1048576, IndexSize: 262144}
+	if len(stats) != 1 || stats[0] != expected {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+}